@@ -1,6 +1,7 @@
 package main
 
 import (
+	"errors"
 	"fmt"
 	"os"
 
@@ -8,10 +9,14 @@ import (
 )
 
 // main is the entry point for the Govman CLI.
-// It runs cli.Execute and exits with a non-zero status code if an error occurs.
+// It runs cli.Execute and exits with the code cli.ExitCode assigns to any error,
+// passing through the exit status of a command run by 'govman exec' without printing anything.
 func main() {
 	if err := _cli.Execute(); err != nil {
-		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-		os.Exit(1)
+		var exitCode *_cli.ExitCodeError
+		if !errors.As(err, &exitCode) {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		}
+		os.Exit(_cli.ExitCode(err))
 	}
 }
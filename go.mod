@@ -5,6 +5,7 @@ go 1.25
 require (
 	github.com/spf13/cobra v1.10.1
 	github.com/spf13/viper v1.21.0
+	golang.org/x/crypto v0.43.0
 )
 
 require (
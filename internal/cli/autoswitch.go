@@ -0,0 +1,80 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	cobra "github.com/spf13/cobra"
+
+	_logger "github.com/justjundana/govman/internal/logger"
+	_manager "github.com/justjundana/govman/internal/manager"
+	_shell "github.com/justjundana/govman/internal/shell"
+)
+
+// newAutoSwitchCmd creates the hidden '_autoswitch' command called by the shell hook on every directory change.
+// It prints a PATH command for the version chosen with 'govman use --temp' in this terminal or else the nearest
+// project version, or nothing when there is neither. Returns a *cobra.Command.
+func newAutoSwitchCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:          "_autoswitch <shell>",
+		Short:        "Print the PATH for the nearest project Go version (used by 'govman hook')",
+		Hidden:       true,
+		Args:         cobra.ExactArgs(1),
+		SilenceUsage: true,
+		// Runs on every cd, so skip the root pre-run housekeeping and only load the config
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			return initConfig()
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			sh := getShellByName(args[0])
+			if sh == nil {
+				return fmt.Errorf("unsupported shell: %s", args[0])
+			}
+
+			cfg := getConfig()
+			// A 'govman shell' subshell keeps its version until it exits
+			if os.Getenv(_shell.SubshellVersionEnv) != "" {
+				return nil
+			}
+
+			mgr := _manager.New(cfg)
+			// So does a version chosen with 'govman use --temp', even where auto-switching is off
+			version := mgr.SessionVersion()
+			if version == "" {
+				if !cfg.AutoSwitch.Enabled {
+					return nil
+				}
+
+				var err error
+				_, version, err = mgr.AutoSwitchVersion()
+				if err != nil {
+					// Report the problem but keep the prompt usable
+					_logger.Warning("%v", err)
+					return nil
+				}
+				if version == "" {
+					return nil
+				}
+			}
+
+			binDir := filepath.Join(cfg.GetVersionDir(version), "bin")
+			entries, changed := _shell.SwitchPath(os.Getenv("PATH"), binDir, cfg.InstallDir)
+			if !changed {
+				return nil
+			}
+
+			pathCmd, err := _shell.SetPathCommand(sh, entries)
+			if err != nil {
+				return err
+			}
+			// Nushell's hook only understands the JSON PATH list; its profile sets GOTOOLCHAIN itself
+			if toolchainCmd := _shell.ToolchainCommand(sh); toolchainCmd != "" && sh.Name() != "nushell" {
+				fmt.Println(toolchainCmd)
+			}
+			fmt.Println(pathCmd)
+
+			return nil
+		},
+	}
+}
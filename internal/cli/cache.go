@@ -0,0 +1,84 @@
+package cli
+
+import (
+	"fmt"
+	"time"
+
+	cobra "github.com/spf13/cobra"
+
+	_logger "github.com/justjundana/govman/internal/logger"
+	_manager "github.com/justjundana/govman/internal/manager"
+	_util "github.com/justjundana/govman/internal/util"
+)
+
+// newCacheCmd creates the 'cache' Cobra command grouping cache inspection subcommands.
+// Returns a *cobra.Command.
+func newCacheCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "cache",
+		Short: "Inspect the download cache",
+		Long: `Inspect downloaded archives and metadata kept in the govman cache.
+
+Use 'govman clean' to remove cache entries.
+
+Examples:
+  govman cache info                  # List cached files with sizes and ages`,
+	}
+
+	cmd.AddCommand(newCacheInfoCmd())
+
+	return cmd
+}
+
+// newCacheInfoCmd creates the 'cache info' subcommand that lists cache entries with their sizes and ages.
+// Returns a *cobra.Command.
+func newCacheInfoCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "info",
+		Short: "List cached archives with sizes and ages",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg := getConfig()
+			mgr := _manager.New(cfg)
+
+			entries, err := mgr.CacheEntries()
+			if err != nil {
+				_logger.ErrorWithHelp("Unable to read the cache directory", "Verify that %s exists and you have permission to read it.", cfg.CacheDir)
+				return err
+			}
+
+			_logger.Info("Cache directory: %s", cfg.CacheDir)
+			if len(entries) == 0 {
+				_logger.Info("Cache is empty")
+				return nil
+			}
+
+			var total int64
+			now := time.Now()
+			for _, entry := range entries {
+				fmt.Printf("  %-40s %10s  %s old\n", entry.Name, _util.FormatBytes(entry.Size), formatAge(now.Sub(entry.ModTime)))
+				total += entry.Size
+			}
+
+			_logger.Info("%d entries, %s total", len(entries), _util.FormatBytes(total))
+			_logger.Info("Run 'govman clean --older-than 30d' to remove stale entries")
+
+			return nil
+		},
+	}
+}
+
+// formatAge renders an age with its largest whole unit (e.g. 3d, 5h, 12m, 40s) for cache listings.
+// Returns the formatted age.
+func formatAge(d time.Duration) string {
+	switch {
+	case d >= 24*time.Hour:
+		return fmt.Sprintf("%dd", int(d.Hours()/24))
+	case d >= time.Hour:
+		return fmt.Sprintf("%dh", int(d.Hours()))
+	case d >= time.Minute:
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	default:
+		return fmt.Sprintf("%ds", max(int(d.Seconds()), 0))
+	}
+}
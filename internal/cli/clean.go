@@ -1,15 +1,24 @@
 package cli
 
 import (
+	"time"
+
 	cobra "github.com/spf13/cobra"
 
 	_logger "github.com/justjundana/govman/internal/logger"
 	_manager "github.com/justjundana/govman/internal/manager"
+	_util "github.com/justjundana/govman/internal/util"
 )
 
 // newCleanCmd creates the 'clean' Cobra command to remove cached downloads and temporary data.
-// It returns a *cobra.Command that calls Manager.Clean, preserving installed Go versions.
+// It returns a *cobra.Command that calls Manager.Clean, or Manager.CleanSelective when --older-than or --dry-run is given,
+// preserving installed Go versions.
 func newCleanCmd() *cobra.Command {
+	var (
+		olderThan string
+		dryRun    bool
+	)
+
 	cmd := &cobra.Command{
 		Use:   "clean",
 		Short: "Clean download cache and optimize disk usage",
@@ -26,26 +35,90 @@ Safety guaranteed:
   • Your project files and configurations are preserved
   • Only temporary cache files are removed
 
-Run periodically to keep your system clean and optimized.`,
-		RunE: func(cmd *cobra.Command, args []string) error {
-			_logger.Info("Cleaning download cache and temporary files...")
-			_logger.Progress("Scanning cache directories for removable files")
+Use --older-than to keep recent archives and only remove stale entries.
+Durations accept s, m, h, d, and w units (e.g. 12h, 30d, 2w).
 
+Examples:
+  govman clean                             # Remove everything in the cache
+  govman clean --older-than 30d            # Remove entries untouched for 30 days
+  govman clean --older-than 2w --dry-run   # Preview what would be removed
+  govman cache info                        # Inspect the cache first`,
+		RunE: func(cmd *cobra.Command, args []string) error {
 			mgr := _manager.New(getConfig())
-			err := mgr.Clean()
+
+			if !dryRun {
+				unlock, err := lockHome(cmd)
+				if err != nil {
+					return err
+				}
+				defer unlock()
+			}
+
+			if olderThan == "" && !dryRun {
+				_logger.Info("Cleaning download cache and temporary files...")
+				_logger.Progress("Scanning cache directories for removable files")
+
+				if err := mgr.Clean(); err != nil {
+					_logger.ErrorWithHelp("Unable to clean cache directories", "Verify that ~/.govman/cache exists and you have sufficient permissions to modify it.")
+					return err
+				}
+
+				_logger.Success("Cache cleanup completed successfully")
+				_logger.Info("Disk space has been optimized")
+				_logger.Info("Your installed Go versions remain untouched and ready to use")
+				_logger.Info("Future downloads will rebuild cache as needed")
+				return nil
+			}
+
+			var age time.Duration
+			if olderThan != "" {
+				var err error
+				age, err = _util.ParseDuration(olderThan)
+				if err != nil {
+					_logger.ErrorWithHelp("Invalid --older-than value '%s'", "Use a duration such as 12h, 30d, or 2w.", olderThan)
+					return err
+				}
+			}
+
+			entries, freed, err := mgr.CleanSelective(age, dryRun)
+
+			if len(entries) == 0 && err == nil {
+				if age > 0 {
+					_logger.Info("No cache entries older than %s", olderThan)
+				} else {
+					_logger.Info("Cache is empty")
+				}
+				return nil
+			}
+
+			if dryRun {
+				_logger.Info("The following %d cache entries would be removed:", len(entries))
+			} else {
+				_logger.Success("Removed %d cache entries:", len(entries))
+			}
+			now := time.Now()
+			for _, entry := range entries {
+				_logger.Info("  • %s (%s, %s old)", entry.Name, _util.FormatBytes(entry.Size), formatAge(now.Sub(entry.ModTime)))
+			}
+
+			if dryRun {
+				_logger.Info("Disk space to be freed: %s", _util.FormatBytes(freed))
+				_logger.Info("Dry run: no cache entries were removed.")
+				return nil
+			}
+			_logger.Info("Total disk space freed: %s", _util.FormatBytes(freed))
+
 			if err != nil {
-				_logger.ErrorWithHelp("Unable to clean cache directories", "Verify that ~/.govman/cache exists and you have sufficient permissions to modify it.", "")
+				_logger.ErrorWithHelp("Unable to remove some cache entries", "Verify that you have sufficient permissions to modify the cache directory.")
 				return err
 			}
 
-			_logger.Success("Cache cleanup completed successfully")
-			_logger.Info("Disk space has been optimized")
-			_logger.Info("Your installed Go versions remain untouched and ready to use")
-			_logger.Info("Future downloads will rebuild cache as needed")
-
 			return nil
 		},
 	}
 
+	cmd.Flags().StringVar(&olderThan, "older-than", "", "Only remove cache entries last modified longer ago than this duration (e.g. 30d)")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Show what would be removed without removing anything")
+
 	return cmd
 }
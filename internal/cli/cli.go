@@ -1,18 +1,35 @@
 package cli
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"os"
+	"os/signal"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"sync"
+	"syscall"
 
 	cobra "github.com/spf13/cobra"
+	viper "github.com/spf13/viper"
 
 	_config "github.com/justjundana/govman/internal/config"
+	_golang "github.com/justjundana/govman/internal/golang"
+	_lock "github.com/justjundana/govman/internal/lock"
+	_logger "github.com/justjundana/govman/internal/logger"
+	_shell "github.com/justjundana/govman/internal/shell"
+	_theme "github.com/justjundana/govman/internal/theme"
 	_version "github.com/justjundana/govman/internal/version"
 )
 
+// lockFile is the name of the lock file lockHome creates in the govman home.
+const lockFile = ".lock"
+
+// offlineEnv turns on offline mode like --offline when set to a true value such as 1 or true.
+const offlineEnv = "GOVMAN_OFFLINE"
+
 var (
 	cfgFile string
 	cfg     *_config.Config
@@ -25,11 +42,34 @@ var rootCmd = &cobra.Command{
 	Long:    createLongDescription(),
 	Version: _version.BuildVersion(),
 	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
-		cleanupOldBackups()
-		return initConfig()
+		return setupCommand(cmd, nil)
 	},
 }
 
+// setupCommand applies the global flags and loads the configuration before a command runs; commands that override
+// PersistentPreRunE call it too. onConfigError, when set, reports a config that fails to load.
+// Returns an error for an invalid log format or config file.
+func setupCommand(cmd *cobra.Command, onConfigError func(error)) error {
+	_theme.SetColor(!viper.GetBool("no-color"))
+	if err := validateLogFormat(); err != nil {
+		return err
+	}
+	cleanupOldBackups()
+	if err := initConfig(); err != nil {
+		if onConfigError != nil {
+			onConfigError(err)
+		}
+		// A broken config file is not a usage mistake, so don't bury the error under the flag list
+		cmd.SilenceUsage = true
+		return err
+	}
+	// Not bound through viper, so offline mode is never written back to the config file
+	if cfg.Offline = offlineRequested(cmd); cfg.Offline {
+		_logger.Verbose("Offline mode: the network will not be used")
+	}
+	return nil
+}
+
 // createLongDescription returns a formatted long description string for the root CLI command.
 // It assembles key features into a multi-line string and returns it.
 func createLongDescription() string {
@@ -59,10 +99,47 @@ func Execute() error {
 	if len(os.Args) <= 1 {
 		showBanner()
 	}
-	return rootCmd.Execute()
+	return rootCmd.ExecuteContext(context.Background())
+}
+
+// lockHome takes the lock on the govman home that keeps commands changing installed versions, the global symlink,
+// or the cache from running at the same time, unless --no-lock is given. Returns a function that releases the lock,
+// or an error, with cmd's usage silenced, if another govman operation holds it or the lock file cannot be created.
+func lockHome(cmd *cobra.Command) (func(), error) {
+	if viper.GetBool("no-lock") {
+		return func() {}, nil
+	}
+
+	home, err := _config.GovmanHome()
+	if err != nil {
+		return nil, err
+	}
+
+	lock, err := _lock.Acquire(filepath.Join(home, lockFile))
+	if err != nil {
+		// Contention is not a usage mistake, so don't bury the error under the flag list
+		cmd.SilenceUsage = true
+		if errors.Is(err, _lock.ErrLocked) {
+			_logger.ErrorWithHelp("Another govman operation is in progress",
+				"Wait for it to finish and try again. If no other govman is running, rerun with --no-lock.")
+		}
+		return nil, err
+	}
+
+	return func() {
+		if err := lock.Release(); err != nil {
+			_logger.Warning("%v", err)
+		}
+	}, nil
 }
 
-// showBanner prints a colored ASCII banner to stdout.
+// interruptContext derives a context from the command's context that is canceled on SIGINT or SIGTERM.
+// Callers must invoke the returned stop function to restore default signal handling.
+func interruptContext(cmd *cobra.Command) (context.Context, context.CancelFunc) {
+	return signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+}
+
+// showBanner prints an ASCII banner to stdout, colored when stdout is a terminal and NO_COLOR is unset.
 // It has no parameters and no return value.
 func showBanner() {
 	fmt.Println()
@@ -82,9 +159,15 @@ func showBanner() {
 		reset = "\033[0m"
 	)
 
+	colored := _theme.ColorEnabled(os.Stdout)
 	for _, line := range lines {
-		if strings.TrimSpace(line) != "" {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		if colored {
 			fmt.Printf("%s%s%s%s\n", color, bold, line, reset)
+		} else {
+			fmt.Println(line)
 		}
 	}
 	fmt.Println()
@@ -98,12 +181,61 @@ func initConfig() error {
 		var err error
 		cfg, err = _config.Load(cfgFile)
 		if err != nil {
+			var validationErr *_config.ValidationError
+			if errors.As(err, &validationErr) {
+				_logger.ErrorWithHelp("Invalid configuration: %s is set to %q: %s", fmt.Sprintf("Correct '%s' in %s, or remove the line to fall back to the default.", validationErr.Key, configFileForHelp()), validationErr.Key, validationErr.Value, validationErr.Reason)
+			}
 			initErr = fmt.Errorf("failed to load config: %w", err)
+			return
 		}
+		_shell.SetPinToolchain(cfg.Shell.PinToolchain)
+		if theme, err := _theme.Lookup(cfg.UI.Theme); err == nil {
+			_theme.Set(theme)
+		}
+		_shell.SetConfigFile(cfg.ConfigPath())
 	})
 	return initErr
 }
 
+// offlineRequested reports whether offline mode is on: --offline when given, otherwise a true GOVMAN_OFFLINE.
+func offlineRequested(cmd *cobra.Command) bool {
+	if flag := cmd.Flags().Lookup("offline"); flag != nil && flag.Changed {
+		return flag.Value.String() == "true"
+	}
+	offline, _ := strconv.ParseBool(os.Getenv(offlineEnv))
+	return offline
+}
+
+// requireOnline fails fast, with cmd's usage silenced, when offline mode is on and what needs the network.
+// Returns an error wrapping _golang.ErrOffline, or nil when online.
+func requireOnline(cmd *cobra.Command, what string) error {
+	if !getConfig().Offline {
+		return nil
+	}
+	cmd.SilenceUsage = true
+	_logger.ErrorWithHelp("%s needs the network, but offline mode is on", fmt.Sprintf("Run without --offline and unset %s to go online.", offlineEnv), what)
+	return fmt.Errorf("%s: %w", strings.ToLower(what[:1])+what[1:], _golang.ErrOffline)
+}
+
+// configFileForHelp returns the config file path to mention in error help: --config when given,
+// otherwise the default location for this platform and environment.
+func configFileForHelp() string {
+	if cfgFile != "" {
+		return cfgFile
+	}
+	if configDir, err := _config.ConfigHome(); err == nil {
+		return filepath.Join(configDir, "config.yaml")
+	}
+	return "$HOME/.govman/config.yaml"
+}
+
+// validateLogFormat checks the --log-format flag before any command runs.
+// Returns an error if the value is neither "text" nor "json".
+func validateLogFormat() error {
+	_, err := _logger.ParseFormat(viper.GetString("log-format"))
+	return err
+}
+
 // getConfig returns the loaded configuration instance.
 // No parameters; returns a pointer to Config.
 // Thread-safe after initConfig completes via sync.Once.
@@ -5,15 +5,21 @@ import (
 	"os"
 
 	viper "github.com/spf13/viper"
+
+	_version "github.com/justjundana/govman/internal/version"
 )
 
 // init configures root-level persistent flags, binds them to viper,
-// registers subcommands, and disables the default completion command.
+// registers subcommands, and replaces Cobra's default completion command with our own.
 // It runs automatically before main execution.
 func init() {
-	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default is $HOME/.govman/config.yaml)")
+	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default is ~/.config/govman/config.yaml on Linux, ~/.govman/config.yaml elsewhere, or $GOVMAN_HOME/config.yaml)")
 	rootCmd.PersistentFlags().Bool("verbose", false, "verbose output")
-	rootCmd.PersistentFlags().Bool("quiet", false, "quiet output (errors only)")
+	rootCmd.PersistentFlags().BoolP("quiet", "q", false, "quiet output (warnings and errors only)")
+	rootCmd.PersistentFlags().String("log-format", "text", "log output format: text or json")
+	rootCmd.PersistentFlags().Bool("no-color", false, "disable colored output (also honors the NO_COLOR environment variable)")
+	rootCmd.PersistentFlags().Bool("no-lock", false, "do not take the lock that keeps concurrent govman commands from changing installations at once")
+	rootCmd.PersistentFlags().Bool("offline", false, "never use the network: install only from the download cache or --from-file (also honors "+offlineEnv+")")
 
 	if err := viper.BindPFlag("verbose", rootCmd.PersistentFlags().Lookup("verbose")); err != nil {
 		fmt.Fprintf(os.Stderr, "Error: failed to bind verbose flag: %v\n", err)
@@ -25,8 +31,26 @@ func init() {
 		os.Exit(1)
 	}
 
+	if err := viper.BindPFlag("log-format", rootCmd.PersistentFlags().Lookup("log-format")); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: failed to bind log-format flag: %v\n", err)
+		os.Exit(1)
+	}
+
+	if err := viper.BindPFlag("no-color", rootCmd.PersistentFlags().Lookup("no-color")); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: failed to bind no-color flag: %v\n", err)
+		os.Exit(1)
+	}
+
+	if err := viper.BindPFlag("no-lock", rootCmd.PersistentFlags().Lookup("no-lock")); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: failed to bind no-lock flag: %v\n", err)
+		os.Exit(1)
+	}
+
 	addCommands()
 
+	// Make --version print the same build report as 'govman version'
+	rootCmd.SetVersionTemplate(_version.Get().String() + "\n")
+
 	rootCmd.CompletionOptions.DisableDefaultCmd = true
 }
 
@@ -34,15 +58,33 @@ func init() {
 func addCommands() {
 	rootCmd.AddCommand(
 		newInitCmd(),
+		newShellInitCmd(),
 		newInstallCmd(),
 		newUninstallCmd(),
 		newUseCmd(),
+		newExecCmd(),
+		newShellCmd(),
 		newCurrentCmd(),
 		newListCmd(),
 		newInfoCmd(),
 		newCleanCmd(),
+		newCacheCmd(),
 		newPruneCmd(),
 		newSelfUpdateCmd(),
 		newRefreshCmd(),
+		newDoctorCmd(),
+		newVerifyCmd(),
+		newStatusCmd(),
+		newExportCmd(),
+		newImportCmd(),
+		newConfigCmd(),
+		newLocalCmd(),
+		newPinCmd(),
+		newTagCmd(),
+		newShimCmd(),
+		newCompletionCmd(),
+		newVersionCmd(),
+		newHookCmd(),
+		newAutoSwitchCmd(),
 	)
 }
@@ -0,0 +1,107 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	cobra "github.com/spf13/cobra"
+
+	_manager "github.com/justjundana/govman/internal/manager"
+)
+
+// newCompletionCmd creates the 'completion' Cobra command that prints a shell completion script.
+// Supports bash, zsh, fish, and powershell via Cobra's built-in generators. Returns a *cobra.Command.
+func newCompletionCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "completion <bash|zsh|fish|powershell>",
+		Short: "Generate shell completion scripts for commands and versions",
+		Long: `Print a completion script that enables tab completion for govman.
+
+What gets completed:
+  • Subcommands and flags
+  • Installed versions for use, uninstall, info, and local
+  • Remote versions for install, from the last 'govman list --remote' (no network access)
+
+Remote version completion only uses the release list cached on disk, so it never
+waits on the network. Run 'govman list --remote' to refresh it once it goes stale.
+
+Examples:
+  source <(govman completion bash)                              # Bash, current session
+  govman completion bash > ~/.local/share/bash-completion/completions/govman
+  govman completion zsh > "${fpath[1]}/_govman"                 # Zsh
+  govman completion fish > ~/.config/fish/completions/govman.fish
+  govman completion powershell | Out-String | Invoke-Expression # PowerShell`,
+		Args:                  cobra.ExactArgs(1),
+		ValidArgs:             []string{"bash", "zsh", "fish", "powershell"},
+		DisableFlagsInUseLine: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			root := cmd.Root()
+
+			switch args[0] {
+			case "bash":
+				return root.GenBashCompletionV2(os.Stdout, true)
+			case "zsh":
+				return root.GenZshCompletion(os.Stdout)
+			case "fish":
+				return root.GenFishCompletion(os.Stdout, true)
+			case "powershell", "pwsh":
+				return root.GenPowerShellCompletionWithDesc(os.Stdout)
+			default:
+				return fmt.Errorf("unsupported shell %q (supported: bash, zsh, fish, powershell)", args[0])
+			}
+		},
+	}
+
+	return cmd
+}
+
+// completeInstalledVersions is a Cobra ValidArgsFunction offering installed Go versions.
+// Versions already given as arguments are skipped; file completion is disabled.
+func completeInstalledVersions(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if err := initConfig(); err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	versions, err := _manager.New(getConfig()).ListInstalled()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	return filterCompletions(versions, args, toComplete), cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeRemoteVersions is a Cobra ValidArgsFunction offering remote Go versions from the on-disk cache.
+// It never fetches from the network, so nothing is offered when the cache is missing or stale.
+func completeRemoteVersions(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if err := initConfig(); err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	versions := append([]string{"latest"}, _manager.New(getConfig()).CachedRemoteVersions()...)
+
+	return filterCompletions(versions, args, toComplete), cobra.ShellCompDirectiveNoFileComp
+}
+
+// singleArgCompletion limits a ValidArgsFunction to the first positional argument.
+// Returns a ValidArgsFunction that offers nothing once an argument is present.
+func singleArgCompletion(complete cobra.CompletionFunc) cobra.CompletionFunc {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) > 0 {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		return complete(cmd, args, toComplete)
+	}
+}
+
+// filterCompletions returns the candidates starting with toComplete that are not already in args.
+func filterCompletions(candidates, args []string, toComplete string) []string {
+	var result []string
+	for _, candidate := range candidates {
+		if strings.HasPrefix(candidate, toComplete) && !slices.Contains(args, candidate) {
+			result = append(result, candidate)
+		}
+	}
+	return result
+}
@@ -0,0 +1,233 @@
+package cli
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	cobra "github.com/spf13/cobra"
+
+	_config "github.com/justjundana/govman/internal/config"
+	_logger "github.com/justjundana/govman/internal/logger"
+	_manager "github.com/justjundana/govman/internal/manager"
+)
+
+// newConfigCmd creates the 'config' Cobra command with get, set, and list subcommands.
+// Keys are dotted paths matching the YAML structure, e.g. go_releases.api_url. Returns a *cobra.Command.
+func newConfigCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "config",
+		Short: "View and change govman configuration",
+		Long: `Read and update govman settings without editing config.yaml by hand.
+
+Keys use dotted paths matching the YAML structure of the config file.
+Values are validated before being saved.
+
+Examples:
+  govman config list                                   # Show all settings
+  govman config get install_dir                        # Show a single setting
+  govman config set go_releases.cache_expiry 30m       # Change a setting
+  govman config set go_releases.mirrors https://a/,https://b/  # Lists are comma-separated
+  govman config set install_dir ~/go-versions --migrate # Move installed versions along
+  govman config migrate                                # Move ~/.govman to the XDG directories (Linux)`,
+	}
+
+	cmd.AddCommand(
+		newConfigGetCmd(),
+		newConfigSetCmd(),
+		newConfigListCmd(),
+		newConfigMigrateCmd(),
+	)
+
+	return cmd
+}
+
+// newConfigGetCmd creates the 'config get' subcommand that prints the value of a single key.
+// Returns a *cobra.Command.
+func newConfigGetCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "get <key>",
+		Short: "Print the value of a configuration key",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			value, err := getConfig().Get(args[0])
+			if err != nil {
+				_logger.ErrorWithHelp("Unknown configuration key '%s'", "Run 'govman config list' to see all available keys.", args[0])
+				return err
+			}
+
+			fmt.Println(value)
+			return nil
+		},
+	}
+}
+
+// newConfigSetCmd creates the 'config set' subcommand that validates and saves a new value for a key.
+// With --migrate, changing install_dir also moves the installed versions. Returns a *cobra.Command.
+func newConfigSetCmd() *cobra.Command {
+	var migrate bool
+
+	cmd := &cobra.Command{
+		Use:   "set <key> <value>",
+		Short: "Change the value of a configuration key",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			key, value := args[0], args[1]
+			cfg := getConfig()
+
+			if migrate {
+				if key != "install_dir" {
+					return fmt.Errorf("--migrate only applies to install_dir")
+				}
+				return moveInstallDir(cmd, cfg, value)
+			}
+
+			previous, err := cfg.Get(key)
+			if err != nil {
+				_logger.ErrorWithHelp("Unknown configuration key '%s'", "Run 'govman config list' to see all available keys.", key)
+				return err
+			}
+
+			if err := cfg.Set(key, value); err != nil {
+				return err
+			}
+
+			if err := cfg.Save(); err != nil {
+				_logger.ErrorWithHelp("Unable to save configuration", "Verify that the config file and its directory are writable.", "")
+				return fmt.Errorf("failed to save config: %w", err)
+			}
+
+			current, _ := cfg.Get(key)
+			_logger.Success("Set %s = %s", key, displayValue(key, current))
+
+			if key == "install_dir" && current != previous {
+				_logger.Warning("Existing Go versions in %s were not moved", previous)
+				_logger.Info("Rerun with --migrate to move them, or reinstall them with 'govman install <version>'")
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&migrate, "migrate", false, "With install_dir, move the installed Go versions to the new directory")
+
+	return cmd
+}
+
+// moveInstallDir moves every installed version to newDir and saves it as install_dir, holding the govman lock.
+// Returns an error if the move fails; rerunning the same command resumes it.
+func moveInstallDir(cmd *cobra.Command, cfg *_config.Config, newDir string) error {
+	cmd.SilenceUsage = true
+
+	unlock, err := lockHome(cmd)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	previous := cfg.InstallDir
+	if err := _manager.New(cfg).MoveInstallDir(newDir); err != nil {
+		_logger.ErrorWithHelp("Unable to move installed versions from %s", "Fix the problem above and rerun the same command to finish the move; install_dir was not changed.", previous)
+		return err
+	}
+
+	if cfg.InstallDir == previous {
+		_logger.Info("install_dir is already %s", previous)
+		return nil
+	}
+
+	_logger.Success("Moved installed Go versions from %s to %s", previous, cfg.InstallDir)
+	_logger.Info("Set install_dir = %s", cfg.InstallDir)
+	return nil
+}
+
+// newConfigListCmd creates the 'config list' subcommand that prints every key and its value.
+// Returns a *cobra.Command.
+func newConfigListCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:     "list",
+		Short:   "List all configuration keys and values",
+		Aliases: []string{"ls"},
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg := getConfig()
+
+			_logger.Verbose("Configuration file: %s", cfg.ConfigPath())
+			for _, key := range cfg.Keys() {
+				value, _ := cfg.Get(key)
+				fmt.Printf("%s = %s\n", key, displayValue(key, strings.TrimSpace(value)))
+			}
+
+			return nil
+		},
+	}
+}
+
+// newConfigMigrateCmd creates the 'config migrate' subcommand that moves a legacy ~/.govman to the XDG layout on Linux.
+// Returns a *cobra.Command that asks for confirmation unless --yes is given.
+func newConfigMigrateCmd() *cobra.Command {
+	var skipConfirm bool
+
+	cmd := &cobra.Command{
+		Use:   "migrate",
+		Short: "Move ~/.govman to the XDG base directories (Linux)",
+		Long: `Move an existing ~/.govman to the XDG base directories used by new Linux installs.
+
+What moves where:
+  • config.yaml to ${XDG_CONFIG_HOME:-~/.config}/govman
+  • Installed versions, cache, and bin to ${XDG_DATA_HOME:-~/.local/share}/govman
+  • install_dir and cache_dir settings inside ~/.govman are rewritten to match
+
+Afterwards, rerun 'govman init --force' so your shell uses the new bin directory.
+To keep ~/.govman instead, leave it in place, or set GOVMAN_HOME=~/.govman.
+
+Examples:
+  govman config migrate       # Show what moves and ask before moving
+  govman config migrate -y    # Move without asking`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			legacyDir, inUse := _config.LegacyHome()
+			if !inUse {
+				_logger.Info("Nothing to migrate: govman is already using its standard directories")
+				return nil
+			}
+
+			_logger.Info("This moves %s to the XDG base directories.", legacyDir)
+			_logger.Warning("Installed Go versions are moved, not copied; 'go' will be unavailable until you rerun 'govman init --force'")
+			if !skipConfirm && !confirmAction("Proceed with migration?") {
+				_logger.Info("Migration cancelled.")
+				return nil
+			}
+
+			configDir, dataDir, err := _config.MigrateLegacyHome()
+			if err != nil {
+				_logger.ErrorWithHelp("Unable to migrate %s", "Nothing was moved. Fix the problem above and retry, or keep using ~/.govman.", legacyDir)
+				return err
+			}
+
+			_logger.Success("Migrated %s", legacyDir)
+			_logger.Info("  Config: %s", configDir)
+			_logger.Info("  Data:   %s", dataDir)
+			_logger.Info(strings.Repeat("─", 50))
+			_logger.Info("Next Steps:")
+			_logger.Info("  1. Run: %s init --force", filepath.Join(dataDir, "bin", "govman"))
+			_logger.Info("  2. Restart your terminal")
+
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVarP(&skipConfirm, "yes", "y", false, "Skip confirmation prompt")
+
+	return cmd
+}
+
+// displayValue masks the value of secret keys such as go_releases.github_token for display.
+// Returns value unchanged for other keys and for unset secrets.
+func displayValue(key, value string) string {
+	if value != "" && _config.IsSecretKey(key) {
+		return "********"
+	}
+
+	return value
+}
@@ -2,62 +2,59 @@ package cli
 
 import (
 	"fmt"
-	"strings"
 
 	cobra "github.com/spf13/cobra"
+	viper "github.com/spf13/viper"
 
 	_logger "github.com/justjundana/govman/internal/logger"
 	_manager "github.com/justjundana/govman/internal/manager"
-	_util "github.com/justjundana/govman/internal/util"
 )
 
-// newCurrentCmd creates the 'current' Cobra command to display details of the active Go version.
-// It returns a *cobra.Command whose RunE queries the Manager for the current version and prints environment info.
+// newCurrentCmd creates the 'current' Cobra command that prints the active Go version and nothing else.
+// With --verbose it also prints the activation method and install path. Returns a *cobra.Command that
+// fails with a one-line error when no version is active.
 func newCurrentCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "current",
-		Short: "Display comprehensive current Go version information",
-		Long: `Show detailed information about the currently active Go version.
+		Short: "Print the active Go version",
+		Long: `Print the active Go version, with no decoration, for shell prompts and scripts.
 
-Information displayed:
-  • Version number and release status
-  • Installation path and size
-  • Platform architecture details
-  • Installation date and source
-  • Activation method (system, project, or session)
+Output:
+  • The version number alone, e.g. 1.25.1
+  • With --verbose, also the activation method (session-only, project-local, or system-default) and install path
+  • Exits non-zero with a one-line error when no version is active
 
-Use this to verify your environment and troubleshoot version issues.`,
+For a full overview of your environment, use 'govman status'.
+For details about the version itself, use 'govman info <version>'.
+
+Examples:
+  govman current               # 1.25.1
+  govman current --verbose     # Version, activation method, and path
+  PS1='[go $(govman current 2>/dev/null)] $ '  # Show the version in a bash prompt`,
+		Args: cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			mgr := _manager.New(getConfig())
 
-			_logger.Verbose("Detecting currently active Go version")
-			current, err := mgr.Current()
-			if err != nil {
-				_logger.ErrorWithHelp("No Go version is currently active in your environment", "Install a Go version with 'govman install latest', then activate it with 'govman use <version>'.", "")
-				_logger.Info("Quick setup: govman install latest && govman use latest --default")
-				return fmt.Errorf("no Go version is currently active")
-			}
-
-			info, err := mgr.Info(current)
+			state, err := mgr.CurrentDetailed()
 			if err != nil {
-				_logger.Warning("Version %s is active but installation details are unavailable", current)
-				_logger.Info("Current Go version: %s", current)
-				return nil
+				// Keep failures to a single line so prompts and scripts stay readable
+				_logger.Verbose("Could not determine active version: %v", err)
+				cmd.SilenceUsage = true
+				cmd.SilenceErrors = true
+				// The cause still decides the exit code, e.g. a project version that is not installed
+				return &summaryError{msg: "no Go version is active", causes: []error{err}}
 			}
 
-			_logger.Info("Current Go Environment:")
-			_logger.Info(strings.Repeat("─", 50))
-			_logger.Info("Version:         Go %s", info.Version)
-			_logger.Info("Install Path:    %s", info.Path)
-			_logger.Info("Platform:        %s/%s", info.OS, info.Arch)
-			_logger.Info("Installed:       %s", info.InstallDate.Format("2006-01-02 15:04:05 MST"))
-			_logger.Info("Disk Usage:      %s", _util.FormatBytes(info.Size))
-
-			activationMethod := mgr.CurrentActivationMethod()
+			fmt.Println(state.Version)
 
-			_logger.Info("Activation:      %s", activationMethod)
-			_logger.Info(strings.Repeat("─", 50))
-			_logger.Info("Run 'go version' to verify your Go installation")
+			if viper.GetBool("verbose") {
+				fmt.Printf("activation: %s\n", state.Method)
+				if mgr.IsInstalled(state.Version) {
+					fmt.Printf("path: %s\n", getConfig().GetVersionDir(state.Version))
+				} else {
+					fmt.Println("path: not managed by govman")
+				}
+			}
 
 			return nil
 		},
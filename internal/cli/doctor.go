@@ -0,0 +1,464 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	cobra "github.com/spf13/cobra"
+	viper "github.com/spf13/viper"
+
+	_config "github.com/justjundana/govman/internal/config"
+	_logger "github.com/justjundana/govman/internal/logger"
+	_manager "github.com/justjundana/govman/internal/manager"
+	_shell "github.com/justjundana/govman/internal/shell"
+	_util "github.com/justjundana/govman/internal/util"
+)
+
+// staleCacheAge is how old a cache entry must be before doctor reports it as stale.
+const staleCacheAge = 30 * 24 * time.Hour
+
+// doctorCheck is the outcome of a single diagnostic performed by 'govman doctor'.
+// Failed critical checks make the command exit non-zero; failed non-critical checks are reported as warnings.
+type doctorCheck struct {
+	name     string
+	passed   bool
+	critical bool
+	detail   string
+	help     string
+	fix      *doctorFix // nil when the problem cannot be repaired automatically
+}
+
+// doctorFix is a repair that 'govman doctor --fix' can apply to a failed check.
+// Destructive repairs delete data, so they are only applied after confirmation or with --yes.
+type doctorFix struct {
+	description string
+	destructive bool
+	apply       func() error
+}
+
+// newDoctorCmd creates the 'doctor' Cobra command to diagnose the govman installation and, with --fix, repair
+// what it safely can. Returns a *cobra.Command that fails if any critical check is still failing.
+func newDoctorCmd() *cobra.Command {
+	var (
+		fix         bool
+		skipConfirm bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "doctor",
+		Short: "Diagnose common problems with your govman installation",
+		Long: `Run a series of health checks and report what is broken and how to fix it.
+
+Checks performed:
+  • Configuration file can be read and parsed
+  • govman directories follow the platform layout (XDG on Linux)
+  • govman bin directory is on your PATH
+  • The 'go' found on PATH is govman's, not another installation earlier on PATH
+  • Global 'go' symlink exists and points to an installed version
+  • Every installed version has a working bin/go
+  • Shell integration is present in your shell configuration file
+  • The download cache has no entries older than 30 days
+
+Repairs applied with --fix:
+  • Recreate a missing or broken 'go' symlink for the configured default version
+  • Add shell integration to your shell configuration file if it is absent
+  • Remove cache entries older than 30 days (asks first, or use --yes)
+
+Problems without a safe repair, such as a broken installed version, are still
+reported with instructions for fixing them by hand.
+
+The command exits with a non-zero status if any critical check fails
+and is not repaired.
+
+Examples:
+  govman doctor                     # Run all checks
+  govman doctor --fix               # Run all checks and repair what can be fixed
+  govman doctor --fix --yes         # Also apply repairs that delete data without asking`,
+		SilenceUsage: true,
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			return setupCommand(cmd, func(err error) {
+				printDoctorCheck(doctorCheck{
+					name:     "Configuration file",
+					critical: true,
+					detail:   err.Error(),
+					help:     fmt.Sprintf("Fix the YAML syntax in %s, or move it aside to regenerate defaults.", configFileForHelp()),
+				})
+			})
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			// Repairs change state, so check and fix under the lock
+			if fix {
+				unlock, err := lockHome(cmd)
+				if err != nil {
+					return err
+				}
+				defer unlock()
+			}
+
+			cfg := getConfig()
+			mgr := _manager.New(cfg)
+
+			_logger.Info("Running govman diagnostics...")
+			_logger.Info(strings.Repeat("─", 50))
+
+			state, _ := mgr.CurrentDetailed()
+			checks := []doctorCheck{
+				checkConfigFile(cfg),
+				checkDirectoryLayout(cfg),
+				checkBinOnPath(cfg),
+				checkGoOnPath(mgr, cfg),
+				checkGlobalSymlink(mgr, state),
+			}
+			checks = append(checks, checkInstalledVersions(mgr, cfg)...)
+			checks = append(checks, checkShellIntegration(cfg))
+			checks = append(checks, checkCache(mgr))
+
+			fixable := 0
+			for _, check := range checks {
+				printDoctorCheck(check)
+				if !check.passed && check.fix != nil {
+					fixable++
+				}
+			}
+
+			_logger.Info(strings.Repeat("─", 50))
+
+			if fix && fixable > 0 {
+				for i := range checks {
+					if !checks[i].passed && checks[i].fix != nil {
+						checks[i].passed = applyDoctorFix(checks[i], skipConfirm)
+					}
+				}
+				_logger.Info(strings.Repeat("─", 50))
+			}
+
+			failed := 0
+			warnings := 0
+			for _, check := range checks {
+				if !check.passed {
+					if check.critical {
+						failed++
+					} else {
+						warnings++
+					}
+				}
+			}
+
+			if !fix && fixable > 0 {
+				_logger.Info("Run 'govman doctor --fix' to repair %d issue(s) automatically", fixable)
+			}
+
+			if failed > 0 {
+				return fmt.Errorf("%d critical check(s) failed", failed)
+			}
+
+			if warnings > 0 {
+				_logger.Warning("All critical checks passed with %d warning(s)", warnings)
+				return nil
+			}
+
+			_logger.Success("All checks passed - govman is healthy")
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&fix, "fix", false, "Repair the problems that can be fixed automatically")
+	cmd.Flags().BoolVarP(&skipConfirm, "yes", "y", false, "Apply repairs that delete data without asking")
+
+	return cmd
+}
+
+// applyDoctorFix applies the repair for a failed check and reports the outcome.
+// Destructive repairs ask first; without a terminal or in quiet mode they are skipped unless skipConfirm is set.
+// Returns true if the problem was repaired.
+func applyDoctorFix(check doctorCheck, skipConfirm bool) bool {
+	if check.fix.destructive && !skipConfirm {
+		if viper.GetBool("quiet") || !isTerminal(os.Stdin) {
+			_logger.Warning("Skipped fix for %s, which deletes data (rerun with --yes to apply it)", check.name)
+			return false
+		}
+		if !confirmAction(check.fix.description + "?") {
+			_logger.Info("Skipped fix for %s", check.name)
+			return false
+		}
+	}
+
+	_logger.Progress("%s", check.fix.description)
+	if err := check.fix.apply(); err != nil {
+		_logger.ErrorWithHelp("Could not fix %s: %v", check.help, check.name, err)
+		return false
+	}
+
+	_logger.Success("Fixed %s", check.name)
+	return true
+}
+
+// printDoctorCheck renders a single checklist line, using ErrorWithHelp for critical failures.
+func printDoctorCheck(check doctorCheck) {
+	switch {
+	case check.passed:
+		_logger.Info("  ✓ %s: %s", check.name, check.detail)
+	case check.critical:
+		_logger.ErrorWithHelp("✗ %s: %s", check.help, check.name, check.detail)
+	default:
+		_logger.Warning("%s: %s", check.name, check.detail)
+		if check.help != "" {
+			_logger.Info("  Help: %s", check.help)
+		}
+	}
+}
+
+// checkConfigFile verifies that the configuration file on disk can be parsed.
+func checkConfigFile(cfg *_config.Config) doctorCheck {
+	check := doctorCheck{name: "Configuration file", critical: true}
+
+	if err := _config.CheckFile(cfg.ConfigPath()); err != nil {
+		check.detail = err.Error()
+		check.help = "Fix the YAML syntax in the config file, or move it aside to regenerate defaults."
+		return check
+	}
+
+	check.passed = true
+	check.detail = fmt.Sprintf("%s parses correctly", cfg.ConfigPath())
+	return check
+}
+
+// checkDirectoryLayout reports where govman keeps its files and warns when Linux still uses a legacy ~/.govman
+// that 'govman config migrate' can move to the XDG base directories.
+func checkDirectoryLayout(cfg *_config.Config) doctorCheck {
+	check := doctorCheck{name: "Directory layout"}
+
+	if legacyDir, inUse := _config.LegacyHome(); inUse {
+		check.detail = fmt.Sprintf("using legacy %s instead of the XDG base directories", legacyDir)
+		check.help = "Run 'govman config migrate' to move it, or set GOVMAN_HOME=~/.govman to keep it."
+		return check
+	}
+
+	dataDir, err := _config.GovmanHome()
+	if err != nil {
+		check.detail = err.Error()
+		return check
+	}
+
+	check.passed = true
+	check.detail = fmt.Sprintf("config in %s, data in %s", filepath.Dir(cfg.ConfigPath()), dataDir)
+	return check
+}
+
+// checkBinOnPath verifies that the govman bin directory appears in PATH.
+func checkBinOnPath(cfg *_config.Config) doctorCheck {
+	binPath := cfg.GetBinPath()
+	check := doctorCheck{name: "PATH", critical: true}
+
+	if isOnPath(binPath) {
+		check.passed = true
+		check.detail = fmt.Sprintf("%s is on PATH", binPath)
+		return check
+	}
+
+	check.detail = fmt.Sprintf("%s is not on PATH", binPath)
+	check.help = "Run 'govman init' to set up shell integration, then restart your terminal."
+	return check
+}
+
+// checkGoOnPath warns when the go that PATH resolves to is not govman's, so switching versions appears to do nothing.
+func checkGoOnPath(mgr *_manager.Manager, cfg *_config.Config) doctorCheck {
+	check := doctorCheck{name: "go on PATH"}
+
+	goPath, managed, err := mgr.GoOnPath()
+	switch {
+	case err != nil:
+		check.detail = fmt.Sprintf("no %s command found on PATH", cfg.GetBinaryName())
+		check.help = "Run 'govman use <version> --default', and make sure the govman bin directory is on PATH."
+	case !managed:
+		check.detail = fmt.Sprintf("%s comes before govman on PATH, so 'govman use' has no effect", goPath)
+		check.help = shadowingGoHelp(goPath, cfg)
+	default:
+		check.passed = true
+		check.detail = fmt.Sprintf("%s is managed by govman", goPath)
+	}
+	return check
+}
+
+// shadowingGoHelp advises how to stop the go at goPath from shadowing govman's.
+func shadowingGoHelp(goPath string, cfg *_config.Config) string {
+	return fmt.Sprintf("Put %s before %s on PATH: in your shell configuration, move the govman lines below any line that adds %s, or uninstall that Go.",
+		cfg.GetBinPath(), filepath.Dir(goPath), filepath.Dir(goPath))
+}
+
+// checkGlobalSymlink verifies the global 'go' symlink from the Manager.CurrentGlobal validation in state.
+// A missing symlink is only a warning when no default version is configured.
+func checkGlobalSymlink(mgr *_manager.Manager, state _manager.CurrentState) doctorCheck {
+	check := doctorCheck{name: "Global symlink", critical: true}
+
+	if err := state.GlobalErr; err == nil {
+		// A working link can still disagree with the configured default
+		if err := mgr.VerifyDefault(); err != nil {
+			check.critical = false
+			check.detail = err.Error()
+			check.help = "Run 'govman use default' to relink it, or 'govman use <version> --default' to change the default."
+			check.fix = reconcileFix(mgr, state.Default)
+			return check
+		}
+		check.passed = true
+		check.detail = fmt.Sprintf("points to Go %s", state.Global)
+		return check
+	}
+
+	check.detail = state.GlobalErr.Error()
+	check.help = "Run 'govman use <version> --default' to recreate the symlink."
+
+	switch {
+	case state.Default == "":
+		check.critical = false
+	case mgr.IsInstalled(state.Default):
+		check.fix = reconcileFix(mgr, state.Default)
+	}
+	return check
+}
+
+// reconcileFix returns the repair that relinks 'go' to the configured default version, defaultVersion.
+func reconcileFix(mgr *_manager.Manager, defaultVersion string) *doctorFix {
+	return &doctorFix{
+		description: fmt.Sprintf("Relink 'go' to the default version %s", defaultVersion),
+		apply: func() error {
+			_, err := mgr.Reconcile()
+			return err
+		},
+	}
+}
+
+// checkInstalledVersions runs 'go version' for each installed version to confirm the toolchain works.
+func checkInstalledVersions(mgr *_manager.Manager, cfg *_config.Config) []doctorCheck {
+	versions, err := mgr.ListInstalled()
+	if err != nil {
+		return []doctorCheck{{
+			name:     "Installed versions",
+			critical: true,
+			detail:   err.Error(),
+			help:     fmt.Sprintf("Verify that %s exists and is readable.", cfg.InstallDir),
+		}}
+	}
+
+	if len(versions) == 0 {
+		return []doctorCheck{{
+			name:   "Installed versions",
+			detail: "no Go versions are installed",
+			help:   "Install one with 'govman install latest'.",
+		}}
+	}
+
+	var checks []doctorCheck
+	for _, version := range versions {
+		check := doctorCheck{name: fmt.Sprintf("Go %s", version), critical: true}
+
+		goBinary := filepath.Join(cfg.GetVersionDir(version), "bin", "go")
+		if runtime.GOOS == "windows" {
+			goBinary += ".exe"
+		}
+
+		output, err := exec.Command(goBinary, "version").Output()
+		if err != nil {
+			check.detail = fmt.Sprintf("%s does not run: %v", goBinary, err)
+			check.help = fmt.Sprintf("Reinstall with 'govman uninstall %s && govman install %s'.", version, version)
+		} else {
+			check.passed = true
+			check.detail = strings.TrimSpace(string(output))
+		}
+
+		checks = append(checks, check)
+	}
+
+	return checks
+}
+
+// checkShellIntegration verifies that 'govman init' has written its block to the shell config file.
+// A missing block can be added by --fix, as 'govman init' would.
+func checkShellIntegration(cfg *_config.Config) doctorCheck {
+	sh := _shell.Detect()
+	check := doctorCheck{name: "Shell integration"}
+
+	if sh.Name() == "cmd" {
+		check.passed = true
+		check.detail = "Command Prompt does not use a configuration file"
+		return check
+	}
+
+	initialized, err := _shell.IsInitialized(sh)
+	if err != nil {
+		check.detail = err.Error()
+		check.help = fmt.Sprintf("Check the permissions of %s.", sh.ConfigFile())
+		return check
+	}
+
+	if !initialized {
+		check.detail = fmt.Sprintf("govman is not configured in %s", sh.ConfigFile())
+		check.help = fmt.Sprintf("Run 'govman init --shell %s' to enable auto-switching.", sh.Name())
+		check.fix = &doctorFix{
+			description: fmt.Sprintf("Add govman integration to %s", sh.ConfigFile()),
+			apply:       func() error { return _shell.InitializeShell(sh, cfg.GetBinPath(), false) },
+		}
+		return check
+	}
+
+	check.passed = true
+	check.detail = fmt.Sprintf("%s integration found in %s", sh.DisplayName(), sh.ConfigFile())
+	return check
+}
+
+// checkCache reports cache entries older than staleCacheAge, which --fix can remove after confirmation.
+func checkCache(mgr *_manager.Manager) doctorCheck {
+	check := doctorCheck{name: "Download cache"}
+
+	entries, err := mgr.CacheEntries()
+	if err != nil {
+		check.detail = err.Error()
+		check.help = "Verify that the cache directory exists and is readable."
+		return check
+	}
+
+	cutoff := time.Now().Add(-staleCacheAge)
+	var stale int
+	var staleSize, total int64
+	for _, entry := range entries {
+		total += entry.Size
+		if entry.ModTime.Before(cutoff) {
+			stale++
+			staleSize += entry.Size
+		}
+	}
+
+	if stale == 0 {
+		check.passed = true
+		check.detail = fmt.Sprintf("%d entries, %s total, none older than 30 days", len(entries), _util.FormatBytes(total))
+		return check
+	}
+
+	check.detail = fmt.Sprintf("%d entries (%s) are older than 30 days", stale, _util.FormatBytes(staleSize))
+	check.help = "Run 'govman clean --older-than 30d' to remove them."
+	check.fix = &doctorFix{
+		description: fmt.Sprintf("Remove %d cache entries older than 30 days", stale),
+		destructive: true,
+		apply: func() error {
+			_, _, err := mgr.CleanSelective(staleCacheAge, false)
+			return err
+		},
+	}
+	return check
+}
+
+// isOnPath reports whether dir is one of the entries in the PATH environment variable.
+func isOnPath(dir string) bool {
+	target := filepath.Clean(dir)
+	for _, entry := range filepath.SplitList(os.Getenv("PATH")) {
+		if entry != "" && filepath.Clean(entry) == target {
+			return true
+		}
+	}
+	return false
+}
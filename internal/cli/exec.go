@@ -0,0 +1,232 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"os/signal"
+	"strings"
+	"syscall"
+
+	cobra "github.com/spf13/cobra"
+	viper "github.com/spf13/viper"
+
+	_golang "github.com/justjundana/govman/internal/golang"
+	_logger "github.com/justjundana/govman/internal/logger"
+	_manager "github.com/justjundana/govman/internal/manager"
+	_util "github.com/justjundana/govman/internal/util"
+)
+
+// ExitCodeError reports the non-zero exit status of a command run by 'govman exec'.
+// main exits with Code without printing anything, so govman is transparent to scripts.
+type ExitCodeError struct {
+	Code int
+}
+
+// Error returns a short description of the exit status.
+func (e *ExitCodeError) Error() string {
+	return fmt.Sprintf("command exited with status %d", e.Code)
+}
+
+// newExecCmd creates the 'exec' Cobra command that runs a command with a specific Go version without activating it.
+// Returns a *cobra.Command that resolves the version, installs it when missing (after confirmation), and runs the command.
+func newExecCmd() *cobra.Command {
+	var skipConfirm bool
+
+	cmd := &cobra.Command{
+		Use:   "exec <version> [--] <command> [args...]",
+		Short: "Run a command with a specific Go version without switching to it",
+		Long: `Run a single command with a specific Go version, leaving your active version untouched.
+
+Behavior:
+  • The version's bin directory comes first on PATH, with GOROOT set and GOTOOLCHAIN=local
+    (unless shell.pin_toolchain is off)
+  • latest and stable always resolve to the newest release online
+  • Partial versions like 1.24 prefer the newest installed patch
+  • Missing versions are installed after confirmation and kept afterwards
+  • The symlink, default version, and session version are never changed
+  • govman exits with the command's exit status
+
+Without a terminal, or with --quiet, missing versions are only installed with --yes.
+
+Examples:
+  govman exec latest -- go version        # Try the newest Go release
+  govman exec 1.22 -- go test ./...       # Run tests against Go 1.22
+  govman exec -y stable -- go build ./... # Install without asking if needed
+  govman exec 1.25.1 gofmt -l .           # '--' is optional`,
+		Args: cobra.MinimumNArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			command := args[1:]
+			if command[0] == "--" {
+				command = command[1:]
+			}
+			if len(command) == 0 {
+				return fmt.Errorf("no command given after '--'")
+			}
+			// Past argument checks, failures are not usage mistakes
+			cmd.SilenceUsage = true
+
+			mgr := _manager.New(getConfig())
+
+			version, err := resolveExecVersion(mgr, args[0])
+			if err != nil {
+				_logger.ErrorWithHelp("Unable to resolve Go version '%s'", "Check the version with 'govman list --remote', or pass an exact version like 1.25.1.", args[0])
+				return err
+			}
+
+			if !mgr.IsInstalled(version) {
+				if err := installForExec(cmd, mgr, version, skipConfirm); err != nil {
+					return err
+				}
+			}
+
+			child, err := mgr.Command(cmd.Context(), version, command)
+			if err != nil {
+				return err
+			}
+			child.Stdin, child.Stdout, child.Stderr = os.Stdin, os.Stdout, os.Stderr
+
+			_logger.Verbose("Running %s with Go %s", strings.Join(command, " "), version)
+
+			err = runForeground(child)
+			var exitCode *ExitCodeError
+			if errors.As(err, &exitCode) {
+				// The command has already reported its own failure
+				cmd.SilenceErrors = true
+			}
+			return err
+		},
+	}
+
+	// Everything after the version belongs to the command, including flags like -v
+	cmd.Flags().SetInterspersed(false)
+	cmd.Flags().BoolVarP(&skipConfirm, "yes", "y", false, "Install the version without asking if it is missing")
+
+	return cmd
+}
+
+// resolveExecVersion resolves the version argument of 'govman exec' to a concrete version.
+// latest and stable always resolve online; partial versions prefer an installed patch; "default" uses the
+// configured default. Returns an error if the version is malformed or cannot be resolved.
+func resolveExecVersion(mgr *_manager.Manager, version string) (string, error) {
+	if version == "default" {
+		if defaultVersion := mgr.DefaultVersion(); defaultVersion != "" {
+			return defaultVersion, nil
+		}
+		return "", fmt.Errorf("no default version is configured")
+	}
+
+	if !_manager.VersionFormatRegex.MatchString(version) && !_util.IsVersionConstraint(version) {
+		return "", fmt.Errorf("invalid version format: %s", version)
+	}
+
+	if version != "latest" && version != "stable" {
+		if mgr.IsInstalled(version) {
+			return version, nil
+		}
+		if strings.Count(version, ".") == 1 {
+			installed, err := mgr.ListInstalled()
+			if err != nil {
+				_logger.Verbose("Failed to list installed versions: %v", err)
+			}
+			if matched, err := _util.FindBestMatchingVersion(version, installed); err == nil {
+				_logger.Verbose("Resolved %s to installed version %s", version, matched)
+				return matched, nil
+			}
+		}
+	}
+
+	resolved, err := mgr.ResolveVersion(version)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve version %s: %w", version, err)
+	}
+	return resolved, nil
+}
+
+// installForExec installs a missing version for 'govman exec', asking first unless skipConfirm is set.
+// Without a terminal or in quiet mode there is nobody to ask, so --yes is required.
+// Returns an error if the user declines, confirmation is impossible, or the install fails.
+func installForExec(cmd *cobra.Command, mgr *_manager.Manager, version string, skipConfirm bool) error {
+	// Catch a nonexistent version before asking to install it
+	if _, err := mgr.PlanInstall(version); err != nil {
+		if errors.Is(err, _golang.ErrVersionNotFound) {
+			_logger.ErrorWithHelp("Go %s is not a known release", "Run 'govman list --remote' to see available versions.", version)
+		}
+		return err
+	}
+
+	if !skipConfirm {
+		if viper.GetBool("quiet") || !isTerminal(os.Stdin) {
+			_logger.ErrorWithHelp("Go %s is not installed", fmt.Sprintf("Rerun with --yes to install it automatically, or run 'govman install %s' first.", version), version)
+			return fmt.Errorf("go version %s is not installed", version)
+		}
+
+		_logger.Info("Go %s is not installed. It will be installed and kept, without changing your active version.", version)
+		if !confirmAction(fmt.Sprintf("Install Go %s?", version)) {
+			_logger.Info("Installation cancelled.")
+			return fmt.Errorf("go version %s is not installed", version)
+		}
+	}
+
+	ctx, stop := interruptContext(cmd)
+	defer stop()
+
+	if err := mgr.InstallContext(ctx, version); err != nil {
+		if ctx.Err() != nil {
+			_logger.Warning("Installation interrupted")
+		}
+		return err
+	}
+
+	return nil
+}
+
+// runForeground runs child to completion with the terminal's stdio. Ctrl-C reaches the child directly
+// through the terminal, so govman ignores it instead of exiting first; SIGTERM is forwarded.
+// Returns an *ExitCodeError if the child exits non-zero, or an error if it cannot be started.
+func runForeground(child *exec.Cmd) error {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+
+	if err := child.Start(); err != nil {
+		signal.Stop(signals)
+		return fmt.Errorf("failed to run %s: %w", child.Args[0], err)
+	}
+
+	go func() {
+		for sig := range signals {
+			if sig != os.Interrupt {
+				_ = child.Process.Signal(sig)
+			}
+		}
+	}()
+
+	err := child.Wait()
+	signal.Stop(signals)
+	close(signals)
+
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		code := exitErr.ExitCode()
+		if code < 0 {
+			// Killed by a signal; follow the shell convention of 128+signal where it is known
+			code = 1
+			if status, ok := exitErr.Sys().(syscall.WaitStatus); ok && status.Signaled() {
+				code = 128 + int(status.Signal())
+			}
+		}
+		return &ExitCodeError{Code: code}
+	}
+
+	return err
+}
+
+// isTerminal reports whether f is connected to a terminal rather than a pipe or file.
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
@@ -0,0 +1,97 @@
+package cli
+
+import (
+	"errors"
+	"io/fs"
+	"net"
+	"net/http"
+	"net/url"
+
+	_downloader "github.com/justjundana/govman/internal/downloader"
+	_golang "github.com/justjundana/govman/internal/golang"
+	_manager "github.com/justjundana/govman/internal/manager"
+)
+
+// Exit codes govman returns, so scripts can tell kinds of failure apart.
+// 'govman exec' and 'govman shell' return the exit status of what they ran instead.
+const (
+	ExitOK           = 0 // Success
+	ExitError        = 1 // Any failure without a more specific code, including usage errors
+	ExitNotInstalled = 2 // The version is not installed
+	ExitNetwork      = 3 // A server could not be reached or refused the request, or offline mode forbade the request
+	ExitNotFound     = 4 // The version does not exist upstream
+	ExitPermission   = 5 // A file or directory could not be accessed
+)
+
+// summaryError reports a failure with a one-line summary while unwrapping to its causes, so ExitCode
+// can still tell what kind of failure it was.
+type summaryError struct {
+	msg    string
+	causes []error
+}
+
+// Error returns the summary.
+func (e *summaryError) Error() string {
+	return e.msg
+}
+
+// Unwrap returns the causes.
+func (e *summaryError) Unwrap() []error {
+	return e.causes
+}
+
+// ExitCode returns the exit code for an error returned by Execute: the status of a command run by
+// 'govman exec' or 'govman shell', the code for the kind of failure err wraps, or ExitError.
+// A summary of several failures gets a specific code only when all of them are of the same kind.
+func ExitCode(err error) int {
+	if err == nil {
+		return ExitOK
+	}
+
+	var exitCode *ExitCodeError
+	if errors.As(err, &exitCode) {
+		return exitCode.Code
+	}
+
+	var summary *summaryError
+	if errors.As(err, &summary) && len(summary.causes) > 0 {
+		code := exitCodeOf(summary.causes[0])
+		for _, cause := range summary.causes[1:] {
+			if exitCodeOf(cause) != code {
+				return ExitError
+			}
+		}
+		return code
+	}
+
+	return exitCodeOf(err)
+}
+
+// exitCodeOf maps a single failure to its exit code.
+func exitCodeOf(err error) int {
+	var statusErr *_downloader.StatusError
+	var urlErr *url.Error
+	var opErr *net.OpError
+
+	switch {
+	case errors.Is(err, _manager.ErrNotInstalled):
+		return ExitNotInstalled
+	case errors.Is(err, _golang.ErrOffline):
+		return ExitNetwork
+	case errors.Is(err, _manager.ErrVersionNotFound),
+		errors.Is(err, _golang.ErrVersionNotFound),
+		errors.Is(err, _golang.ErrNoDownload):
+		return ExitNotFound
+	case errors.As(err, &statusErr):
+		if statusErr.StatusCode == http.StatusNotFound {
+			return ExitNotFound
+		}
+		return ExitNetwork
+	case errors.Is(err, fs.ErrPermission):
+		return ExitPermission
+	case errors.Is(err, _golang.ErrNetwork), errors.As(err, &urlErr), errors.As(err, &opErr):
+		return ExitNetwork
+	default:
+		return ExitError
+	}
+}
@@ -0,0 +1,56 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"testing"
+
+	_downloader "github.com/justjundana/govman/internal/downloader"
+	_golang "github.com/justjundana/govman/internal/golang"
+	_manager "github.com/justjundana/govman/internal/manager"
+)
+
+func TestExitCode(t *testing.T) {
+	notInstalled := fmt.Errorf("Go 1.22.0: %w", _manager.ErrNotInstalled)
+	network := &url.Error{Op: "Get", URL: "https://go.dev/dl/", Err: errors.New("connection refused")}
+
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{"nil", nil, ExitOK},
+		{"plain error", errors.New("boom"), ExitError},
+		{"exit status", &ExitCodeError{Code: 7}, 7},
+		{"wrapped exit status", fmt.Errorf("exec: %w", &ExitCodeError{Code: 42}), 42},
+		{"not installed", notInstalled, ExitNotInstalled},
+		{"offline", fmt.Errorf("list: %w", _golang.ErrOffline), ExitNetwork},
+		{"manager version not found", _manager.ErrVersionNotFound, ExitNotFound},
+		{"golang version not found", _golang.ErrVersionNotFound, ExitNotFound},
+		{"no download", _golang.ErrNoDownload, ExitNotFound},
+		{"status 404", &_downloader.StatusError{StatusCode: http.StatusNotFound, Status: "404 Not Found"}, ExitNotFound},
+		{"status 503", &_downloader.StatusError{StatusCode: http.StatusServiceUnavailable, Status: "503 Service Unavailable"}, ExitNetwork},
+		{"permission", &fs.PathError{Op: "open", Path: "/root", Err: fs.ErrPermission}, ExitPermission},
+		{"os permission", os.ErrPermission, ExitPermission},
+		{"golang network", _golang.ErrNetwork, ExitNetwork},
+		{"url error", network, ExitNetwork},
+		{"op error", &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("no route to host")}, ExitNetwork},
+		{"summary of one kind", &summaryError{msg: "failed", causes: []error{notInstalled, _manager.ErrNotInstalled}}, ExitNotInstalled},
+		{"summary of mixed kinds", &summaryError{msg: "failed", causes: []error{notInstalled, network}}, ExitError},
+		{"summary without causes", &summaryError{msg: "failed"}, ExitError},
+		{"wrapped summary", fmt.Errorf("uninstall: %w", &summaryError{msg: "failed", causes: []error{network}}), ExitNetwork},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ExitCode(tt.err); got != tt.want {
+				t.Errorf("ExitCode(%v) = %d, want %d", tt.err, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	cobra "github.com/spf13/cobra"
+
+	_logger "github.com/justjundana/govman/internal/logger"
+	_manager "github.com/justjundana/govman/internal/manager"
+)
+
+// newExportCmd creates the 'export' Cobra command to write a manifest of installed Go versions.
+// An optional file argument selects the output path; otherwise the manifest is written to stdout. Returns a *cobra.Command.
+func newExportCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "export [file]",
+		Short: "Export installed Go versions to a portable manifest",
+		Long: `Write a JSON manifest describing your installed Go versions.
+
+The manifest includes:
+  • Every installed Go version
+  • The system default version
+  • Tags attached with 'govman tag'
+  • A schema version so future formats can be migrated
+
+Use 'govman import' on another machine to recreate the same toolchains.
+
+Examples:
+  govman export                      # Print manifest to stdout
+  govman export govman.json          # Write manifest to a file`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			mgr := _manager.New(getConfig())
+
+			manifest, err := mgr.Export()
+			if err != nil {
+				_logger.ErrorWithHelp("Unable to read installed Go versions", "Verify that ~/.govman/versions exists and is accessible.", "")
+				return err
+			}
+
+			data, err := json.MarshalIndent(manifest, "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to encode manifest: %w", err)
+			}
+			data = append(data, '\n')
+
+			if len(args) == 0 || args[0] == "-" {
+				_, err := os.Stdout.Write(data)
+				return err
+			}
+
+			if err := os.WriteFile(args[0], data, 0644); err != nil {
+				return fmt.Errorf("failed to write manifest: %w", err)
+			}
+
+			_logger.Success("Exported %d Go version(s) to %s", len(manifest.Versions), args[0])
+			return nil
+		},
+	}
+
+	return cmd
+}
@@ -0,0 +1,129 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"text/template"
+	"time"
+
+	_golang "github.com/justjundana/govman/internal/golang"
+	_logger "github.com/justjundana/govman/internal/logger"
+	_manager "github.com/justjundana/govman/internal/manager"
+	_util "github.com/justjundana/govman/internal/util"
+)
+
+// formatPresets are the named templates accepted by --format in place of a template.
+var formatPresets = map[string]string{
+	"short": `{{.Version}}`,
+	"wide":  `{{printf "%-12s" .Version}} {{.OS}}/{{.Arch}} {{printf "%9s" (bytes .Size)}} {{date .InstallDate}}{{if .Active}} active{{end}}{{if .Default}} default{{end}}{{if .Local}} local{{end}}`,
+}
+
+// formatFuncs are the functions available to --format templates besides the text/template built-ins.
+var formatFuncs = template.FuncMap{
+	"bytes": _util.FormatBytes,
+	"date": func(t time.Time) string {
+		if t.IsZero() {
+			return ""
+		}
+		return t.Format("2006-01-02")
+	},
+}
+
+// versionRecord is what a --format template renders for one installed version.
+type versionRecord struct {
+	Version     string
+	Path        string
+	OS          string
+	Arch        string
+	Tag         string
+	InstallDate time.Time
+	Active      bool
+	Default     bool
+	Local       bool
+
+	size func() int64
+}
+
+// Size returns the version's disk usage in bytes, measured only when a template asks for it.
+func (r versionRecord) Size() int64 {
+	if r.size == nil {
+		return 0
+	}
+	return r.size()
+}
+
+// versionState is the active, default, and project version a versionRecord is compared against.
+type versionState struct {
+	current        string
+	defaultVersion string
+	local          string
+}
+
+// currentVersionState reads the active, default, and project version once for a run of records.
+func currentVersionState(mgr *_manager.Manager) versionState {
+	state, _ := mgr.CurrentDetailed()
+	return versionState{current: state.Version, defaultVersion: state.Default, local: state.Local}
+}
+
+// parseFormat returns the template for a --format value, which is a preset name or a text/template.
+// Returns an error carrying the template's message if it does not parse or refers to an unknown field.
+func parseFormat(format string) (*template.Template, error) {
+	if preset, ok := formatPresets[format]; ok {
+		format = preset
+	}
+
+	tmpl, err := template.New("format").Funcs(formatFuncs).Parse(format)
+	if err != nil {
+		return nil, fmt.Errorf("invalid --format template: %w", err)
+	}
+	// Unknown fields only surface on execution, so catch them before any output is written
+	if err := tmpl.Execute(io.Discard, versionRecord{}); err != nil {
+		return nil, fmt.Errorf("invalid --format template: %w", err)
+	}
+	return tmpl, nil
+}
+
+// newVersionRecord describes an installed version for a --format template; info may be nil, in which case the
+// version's metadata is read with Manager.Stat and its size measured on demand.
+func newVersionRecord(mgr *_manager.Manager, version string, info *_golang.VersionInfo, state versionState) versionRecord {
+	record := versionRecord{
+		Version: version,
+		Path:    getConfig().GetVersionDir(version),
+		Tag:     mgr.Tag(version),
+		Active:  version == state.current,
+		Default: version == state.defaultVersion && state.defaultVersion != "",
+		Local:   version == state.local && state.local != "",
+	}
+
+	if info == nil {
+		var err error
+		if info, err = mgr.Stat(version); err != nil {
+			_logger.Verbose("Could not read installation info for Go %s: %v", version, err)
+		}
+		record.size = func() int64 {
+			return mgr.VersionSizes([]string{version})[version]
+		}
+	} else {
+		size := info.Size
+		record.size = func() int64 { return size }
+	}
+
+	if info != nil {
+		record.OS = info.OS
+		record.Arch = info.Arch
+		record.InstallDate = info.InstallDate
+	}
+
+	return record
+}
+
+// renderRecord writes record through tmpl to stdout, followed by a newline.
+// Returns an error naming the version if the template fails to execute.
+func renderRecord(tmpl *template.Template, record versionRecord) error {
+	if err := tmpl.Execute(os.Stdout, record); err != nil {
+		return fmt.Errorf("failed to format Go %s: %w", record.Version, err)
+	}
+	fmt.Println()
+	return nil
+}
@@ -0,0 +1,64 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	cobra "github.com/spf13/cobra"
+
+	_logger "github.com/justjundana/govman/internal/logger"
+	_shell "github.com/justjundana/govman/internal/shell"
+)
+
+// newHookCmd creates the 'hook' Cobra command that prints an auto-switch hook for the given shell.
+// The hook calls 'govman _autoswitch' on every directory change. Returns a *cobra.Command.
+func newHookCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "hook <shell>",
+		Short: "Print a shell hook that auto-switches Go versions on cd",
+		Long: `Print shell code that switches Go versions automatically when you change directory.
+
+On every directory change the hook runs a lightweight 'govman _autoswitch' that finds
+the nearest project version file (.govman-goversion, .go-version, or go.mod) and puts
+the matching installed Go version first on PATH for the current session.
+Nothing happens, and nothing is printed, outside a project.
+
+Supported shells: bash, zsh, fish, nushell, powershell
+
+Examples:
+  eval "$(govman hook bash)"                  # Add to ~/.bashrc
+  eval "$(govman hook zsh)"                   # Add to ~/.zshrc
+  govman hook fish | source                   # Add to ~/.config/fish/config.fish
+  govman hook powershell | Out-String | Invoke-Expression   # Add to $PROFILE
+  govman hook nushell | save -f ~/.config/nushell/govman-hook.nu   # Then 'source' it from config.nu`,
+		Args:      cobra.ExactArgs(1),
+		ValidArgs: []string{"bash", "zsh", "fish", "nushell", "powershell"},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			sh := getShellByName(args[0])
+			if sh == nil {
+				_logger.ErrorWithHelp("Unsupported shell: %s", "Supported shells: bash, zsh, fish, nushell, powershell.", args[0])
+				return fmt.Errorf("unsupported shell: %s", args[0])
+			}
+
+			exePath, err := os.Executable()
+			if err != nil {
+				return fmt.Errorf("failed to locate govman executable: %w", err)
+			}
+			if resolved, err := filepath.EvalSymlinks(exePath); err == nil {
+				exePath = resolved
+			}
+
+			lines, err := _shell.HookCommands(sh, exePath)
+			if err != nil {
+				return err
+			}
+
+			for _, line := range lines {
+				fmt.Println(line)
+			}
+
+			return nil
+		},
+	}
+}
@@ -0,0 +1,96 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	cobra "github.com/spf13/cobra"
+
+	_logger "github.com/justjundana/govman/internal/logger"
+	_manager "github.com/justjundana/govman/internal/manager"
+)
+
+// newImportCmd creates the 'import' Cobra command to install the Go versions listed in a manifest.
+// An optional file argument selects the manifest; otherwise it is read from stdin. Returns a *cobra.Command.
+func newImportCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "import [file]",
+		Short: "Install Go versions from an exported manifest",
+		Long: `Recreate a set of Go toolchains from a manifest written by 'govman export'.
+
+What it does:
+  • Installs every listed version that is not already installed
+  • Skips versions that are already present
+  • Restores the system default version from the manifest
+
+Examples:
+  govman import govman.json          # Import from a file
+  govman export | ssh host govman import   # Replicate to another machine`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var data []byte
+			var err error
+			if len(args) == 0 || args[0] == "-" {
+				data, err = io.ReadAll(os.Stdin)
+			} else {
+				data, err = os.ReadFile(args[0])
+			}
+			if err != nil {
+				return fmt.Errorf("failed to read manifest: %w", err)
+			}
+
+			manifest, err := _manager.ParseManifest(data)
+			if err != nil {
+				_logger.ErrorWithHelp("Unable to read manifest", "Make sure the file was created with 'govman export'.", "")
+				return err
+			}
+
+			unlock, err := lockHome(cmd)
+			if err != nil {
+				return err
+			}
+			defer unlock()
+
+			mgr := _manager.New(getConfig())
+
+			_logger.Info("Importing %d Go version(s)...", len(manifest.Versions))
+			result := mgr.Import(manifest)
+
+			_logger.Info(strings.Repeat("─", 50))
+
+			if len(result.Added) > 0 {
+				_logger.Success("Added %d version(s):", len(result.Added))
+				for _, version := range result.Added {
+					_logger.Info("  • Go %s", version)
+				}
+			}
+
+			if len(result.Skipped) > 0 {
+				_logger.Info("Skipped %d already installed version(s):", len(result.Skipped))
+				for _, version := range result.Skipped {
+					_logger.Info("  • Go %s", version)
+				}
+			}
+
+			if result.DefaultSet {
+				_logger.Success("Default version set to Go %s", manifest.Default)
+			}
+
+			if len(result.Failures) > 0 {
+				_logger.ErrorWithHelp("Failed to install %d version(s):", "Review the errors below and try installing problematic versions individually for more details.", len(result.Failures))
+				for _, failure := range result.Failures {
+					printInstallFailure(failure)
+				}
+				printInstallHints(result.Failures)
+				return fmt.Errorf("failed to import %d version(s)", len(result.Failures))
+			}
+
+			_logger.Success("Import completed successfully")
+			return nil
+		},
+	}
+
+	return cmd
+}
@@ -2,7 +2,9 @@ package cli
 
 import (
 	"fmt"
+	"runtime"
 	"strings"
+	"text/template"
 	"time"
 
 	cobra "github.com/spf13/cobra"
@@ -12,28 +14,65 @@ import (
 	_util "github.com/justjundana/govman/internal/util"
 )
 
-// newInfoCmd creates the 'info' Cobra command to display details for a specific installed Go version.
-// It returns a *cobra.Command whose RunE reads the version from args, fetches metadata via Manager, and prints platform, path, install date, size, and active status.
+// newInfoCmd creates the 'info' Cobra command to display details for an installed Go version, or with --remote a
+// downloadable release; --format renders it through a template. Returns a *cobra.Command.
 func newInfoCmd() *cobra.Command {
+	var (
+		remote bool
+		format string
+	)
+
 	cmd := &cobra.Command{
 		Use:   "info <version>",
 		Short: "Display comprehensive Go version information",
 		Long: `Show detailed information about any installed Go version.
 
 Information includes:
-  • Version number and release details
+  • Version number, release details, and tag
   • Complete installation path and directory structure
   • Platform architecture and OS compatibility
   • Installation date, size, and disk usage
+  • GOROOT, release date, and cgo default read from the install tree
   • Binary locations and environment details
   • Release notes and changelog links (when available)
 
-Perfect for debugging installation issues and verifying setups.`,
-		Args: cobra.ExactArgs(1),
+With --remote, describe a release before downloading it instead:
+  • Archive name and the download URL govman would use
+  • Archive size from the server (or the release API) and SHA256 checksum
+  • Publish date when the server reports one
+
+Perfect for debugging installation issues and verifying setups.
+
+Examples:
+  govman info 1.25.1                 # Installed version details
+  govman info --remote 1.26          # Newest 1.26.x archive, before installing
+  govman info --remote latest        # Size and checksum of the latest release
+  govman info 1.25.1 --format '{{.Path}}'
+  govman info 1.25.1 --format wide   # Presets: short, wide (see 'govman list --help')`,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: singleArgCompletion(completeInstalledVersions),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			version := args[0]
 			mgr := _manager.New(getConfig())
 
+			if remote {
+				if format != "" {
+					return fmt.Errorf("--format describes installed versions and cannot be combined with --remote")
+				}
+				if err := requireOnline(cmd, "Looking up a remote version"); err != nil {
+					return err
+				}
+				return showRemoteInfo(mgr, version)
+			}
+
+			var tmpl *template.Template
+			if format != "" {
+				var err error
+				if tmpl, err = parseFormat(format); err != nil {
+					return err
+				}
+			}
+
 			// Resolve alias to concrete version if needed
 			originalVersion := version
 			if version == "latest" || version == "stable" {
@@ -61,6 +100,10 @@ Perfect for debugging installation issues and verifying setups.`,
 				return err
 			}
 
+			if tmpl != nil {
+				return renderRecord(tmpl, newVersionRecord(mgr, info.Version, info, currentVersionState(mgr)))
+			}
+
 			current, _ := mgr.Current()
 			isActive := current == info.Version
 
@@ -72,10 +115,23 @@ Perfect for debugging installation issues and verifying setups.`,
 				activeStatus = "Currently Active"
 			}
 			_logger.Info("Version:            Go %s (%s)", info.Version, activeStatus)
+			if tag := mgr.Tag(info.Version); tag != "" {
+				_logger.Info("Tag:                %s", tag)
+			}
 			_logger.Info("Platform:           %s/%s", info.OS, info.Arch)
 			_logger.Info("Installation Path:  %s", info.Path)
 			_logger.Info("Installed On:       %s", info.InstallDate.Format("Monday, January 2, 2006 at 15:04:05 MST"))
 			_logger.Info("Disk Usage:         %s", _util.FormatBytes(info.Size))
+			_logger.Info("GOROOT:             %s", info.GOROOT)
+			if !info.ReleaseDate.IsZero() {
+				_logger.Info("Released:           %s", info.ReleaseDate.Format("2006-01-02"))
+			}
+			switch info.CGODefault {
+			case "enabled", "disabled":
+				_logger.Info("CGO Default:        %s", info.CGODefault)
+			case "auto":
+				_logger.Info("CGO Default:        auto (enabled for native builds when a C compiler is available)")
+			}
 
 			daysInstalled := int(time.Since(info.InstallDate).Hours() / 24)
 			if daysInstalled > 0 {
@@ -102,5 +158,54 @@ Perfect for debugging installation issues and verifying setups.`,
 		},
 	}
 
+	cmd.Flags().BoolVar(&remote, "remote", false, "Describe the downloadable archive for a version without installing it")
+	cmd.Flags().StringVar(&format, "format", "", "Render the version with a Go template, or a preset: short, wide")
+
 	return cmd
 }
+
+// showRemoteInfo prints the archive URL, size, checksum, and publish date of a release without downloading it.
+// Parameters: mgr (Manager), version (alias, partial, or exact). Returns an error if the release cannot be found.
+func showRemoteInfo(mgr *_manager.Manager, version string) error {
+	_logger.Verbose("Looking up release details for Go %s", version)
+	info, err := mgr.RemoteInfo(version)
+	if err != nil {
+		_logger.ErrorWithHelp("Unable to find a downloadable release for Go %s", "Check available versions with 'govman list --remote'.", version)
+		return fmt.Errorf("failed to get remote info: %w", err)
+	}
+
+	stability := "stable"
+	if !info.Stable {
+		stability = "pre-release"
+	}
+
+	size := "unknown (server did not report Content-Length)"
+	if info.ContentLength >= 0 {
+		size = _util.FormatBytes(info.ContentLength)
+	} else if info.Size > 0 {
+		size = _util.FormatBytes(info.Size) + " (from release API)"
+	}
+
+	_logger.Info("Go Release Information:")
+	_logger.Info(strings.Repeat("═", 60))
+	_logger.Info("Version:            Go %s (%s)", info.Version, stability)
+	_logger.Info("Platform:           %s/%s", runtime.GOOS, runtime.GOARCH)
+	_logger.Info("Archive:            %s", info.Filename)
+	_logger.Info("Download URL:       %s", info.URL)
+	_logger.Info("Download Size:      %s", size)
+	if info.Sha256 != "" {
+		_logger.Info("SHA256:             %s", info.Sha256)
+	}
+	if !info.LastModified.IsZero() {
+		_logger.Info("Published:          %s", info.LastModified.Format("2006-01-02"))
+	}
+	_logger.Info(strings.Repeat("═", 60))
+
+	if mgr.IsInstalled(info.Version) {
+		_logger.Info("Already installed - see 'govman info %s' for local details", info.Version)
+	} else {
+		_logger.Info("Install this version with: govman install %s", info.Version)
+	}
+
+	return nil
+}
@@ -46,7 +46,7 @@ Go version when you navigate to different projects.`,
 			if shellName != "" {
 				sh = getShellByName(shellName)
 				if sh == nil {
-					_logger.ErrorWithHelp("Unsupported shell: %s", "Supported shells: bash, zsh, fish, powershell. Use --shell flag to specify.", shellName)
+					_logger.ErrorWithHelp("Unsupported shell: %s", "Supported shells: bash, zsh, fish, nushell, powershell. Use --shell flag to specify.", shellName)
 					return fmt.Errorf("unsupported shell: %s", shellName)
 				}
 				_logger.Info("Using manually specified shell: %s", sh.Name())
@@ -83,13 +83,13 @@ Go version when you navigate to different projects.`,
 	}
 
 	cmd.Flags().BoolVarP(&force, "force", "f", false, "Force re-initialization (overwrite existing configuration)")
-	cmd.Flags().StringVar(&shellName, "shell", "", "Target specific shell (bash, zsh, fish, powershell)")
+	cmd.Flags().StringVar(&shellName, "shell", "", "Target specific shell (bash, zsh, fish, nushell, powershell)")
 
 	return cmd
 }
 
 // getShellByName maps a shell name to its Shell implementation.
-// Supported values: bash, zsh, fish, powershell/pwsh. Returns nil if unsupported.
+// Supported values: bash, zsh, fish, nushell/nu, powershell/pwsh. Returns nil if unsupported.
 func getShellByName(name string) _shell.Shell {
 	switch name {
 	case "bash":
@@ -98,6 +98,8 @@ func getShellByName(name string) _shell.Shell {
 		return &_shell.ZshShell{}
 	case "fish":
 		return &_shell.FishShell{}
+	case "nushell", "nu":
+		return &_shell.NushellShell{}
 	case "powershell", "pwsh":
 		return &_shell.PowerShell{}
 	default:
@@ -2,9 +2,12 @@ package cli
 
 import (
 	"bufio"
+	"errors"
 	"fmt"
 	"os"
+	"slices"
 	"strings"
+	"time"
 
 	cobra "github.com/spf13/cobra"
 
@@ -19,6 +22,17 @@ import (
 func newInstallCmd() *cobra.Command {
 	var includeUnstable bool
 	var skipConfirm bool
+	var retries int
+	var timeout time.Duration
+	var limitRate string
+	var mirror string
+	var noCache bool
+	var verifySignature bool
+	var dryRun bool
+	var skipHooks bool
+	var setDefault bool
+	var setLocal bool
+	var fromFile string
 
 	cmd := &cobra.Command{
 		Use:   "install [version...]",
@@ -28,22 +42,112 @@ func newInstallCmd() *cobra.Command {
 Features:
   • Lightning-fast parallel downloads with resume capability
   • Automatic integrity verification and checksum validation
-  • Smart caching to avoid re-downloading existing archives
+  • Optional OpenPGP or minisign signature verification against download.signature_key
+  • Smart caching: verified archives in the cache are reused instead of re-downloaded
   • Support for latest, stable, and pre-release versions
   • Batch installation with detailed progress tracking
   • Automatic cleanup of temporary files on completion
   • Wildcard pattern support for batch installation (e.g., 1.14.*)
+  • Version constraints like ^1.22, ~1.22.3, or '>=1.21 <1.23'
+  • Post-install hooks from hooks.post_install, e.g. to install gopls for each new version
+  • Activate a single new version right away with --default or --local, as 'govman use' does
+  • Install from a local archive with --from-file, e.g. one copied from another machine
+  • Offline mode (--offline or GOVMAN_OFFLINE=1) installs verified archives from the download cache
 
 Examples:
   govman install latest              # Latest stable release
+  govman install 1                   # Latest stable 1.x release
   govman install 1.25.1              # Specific version
   govman install 1.25.1 1.20.12      # Multiple versions
   govman install 1.22rc1             # Pre-release version
   govman install '1.14.*'            # All 1.14.x stable versions (quote the pattern!)
-  govman install '1.14.*' --unstable # All 1.14.x versions including beta/rc`,
-		Args: cobra.MinimumNArgs(1),
+  govman install '1.14.*' --unstable # All 1.14.x versions including beta/rc
+  govman install '^1.22'             # Highest stable 1.x release >= 1.22
+  govman install '>=1.21 <1.23'      # Highest stable release in a range
+  govman install 1.25.1 --retries 5  # Retry flaky downloads up to 5 times
+  govman install 1.25.1 --timeout 1m # Allow slow connections more time to respond
+  govman install 1.25.1 --limit-rate 500k  # Cap the download at 500 KB/s
+  govman install 1.25.1 --mirror https://golang.google.cn/dl/  # Download from a mirror first
+  govman install 1.25.1 --no-cache   # Ignore any cached archive and download a fresh copy
+  govman install 1.25.1 --verify-signature  # Also check the release signature
+  govman install 1.25 --dry-run      # Show what would be downloaded, and where, without downloading
+  govman install 1.25 --skip-hooks   # Install without running post-install hooks
+  govman install latest --default    # Install and make it the system default
+  govman install 1.24 --local        # Install and pin it for the current project
+  govman install --from-file ~/Downloads/go1.25.1.linux-amd64.tar.gz  # Install a downloaded archive
+  govman install 1.25.1 --from-file ./go.tar.gz  # Name the version of a renamed archive
+  govman --offline install 1.25.1    # Install from the download cache without the network`,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if fromFile != "" {
+				return cobra.MaximumNArgs(1)(cmd, args)
+			}
+			return cobra.MinimumNArgs(1)(cmd, args)
+		},
+		ValidArgsFunction: completeRemoteVersions,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			mgr := _manager.New(getConfig())
+			if (setDefault || setLocal) && fromFile == "" && (len(args) != 1 || hasWildcardPattern(args)) {
+				return fmt.Errorf("--default and --local need exactly one version to activate")
+			}
+			if fromFile != "" && (dryRun || hasWildcardPattern(args)) {
+				return fmt.Errorf("--from-file installs one archive and cannot be combined with --dry-run or a version pattern")
+			}
+			if getConfig().Offline && noCache {
+				return fmt.Errorf("--no-cache cannot be used in offline mode, which installs only from the download cache")
+			}
+
+			// One-run flags change a copy, so a config saved while activating never persists them
+			installCfg := *getConfig()
+
+			if cmd.Flags().Changed("retries") {
+				if retries < 1 {
+					return fmt.Errorf("--retries must be at least 1, got %d", retries)
+				}
+				installCfg.Download.RetryCount = retries
+			}
+
+			if cmd.Flags().Changed("timeout") {
+				if timeout <= 0 {
+					return fmt.Errorf("--timeout must be positive, got %v", timeout)
+				}
+				installCfg.Network.Timeout = timeout
+			}
+
+			if limitRate != "" {
+				rate, err := _util.ParseBytes(limitRate)
+				if err != nil {
+					return fmt.Errorf("--limit-rate: %w", err)
+				}
+				if rate <= 0 {
+					return fmt.Errorf("--limit-rate must be positive, got %q", limitRate)
+				}
+				installCfg.Download.RateLimit = rate
+			}
+
+			if mirror != "" {
+				installCfg.Mirror.Enabled = true
+				installCfg.Mirror.URL = mirror
+			}
+
+			if noCache {
+				installCfg.Download.UseCache = false
+			}
+
+			if verifySignature {
+				installCfg.Download.VerifySignature = true
+			}
+
+			mgr := _manager.New(&installCfg)
+			mgr.SkipHooks = skipHooks
+
+			if fromFile != "" {
+				return installFromFile(cmd, mgr, fromFile, args, setDefault, setLocal)
+			}
+
+			if hasWildcardPattern(args) {
+				if err := requireOnline(cmd, "Expanding a version pattern"); err != nil {
+					return err
+				}
+			}
 
 			// Expand wildcard patterns in args
 			expandedVersions, err := expandInstallPatterns(args, mgr, includeUnstable)
@@ -56,6 +160,10 @@ Examples:
 				return fmt.Errorf("no versions to install")
 			}
 
+			if dryRun {
+				return printInstallPlan(mgr, expandedVersions)
+			}
+
 			// Show confirmation for pattern-based installation
 			if hasWildcardPattern(args) && !skipConfirm {
 				versionType := "stable"
@@ -75,25 +183,33 @@ Examples:
 				}
 			}
 
+			unlock, err := lockHome(cmd)
+			if err != nil {
+				return err
+			}
+			defer unlock()
+
 			_logger.Info("Starting installation of %d Go version(s)...", len(expandedVersions))
 			_logger.Progress("Preparing downloads and verifying version availability")
 
-			var errors []string
-			var successful []string
-			for i, version := range expandedVersions {
-				_logger.Info("[%d/%d] Installing Go %s...", i+1, len(expandedVersions), version)
-				if err := mgr.Install(version); err != nil {
-					errors = append(errors, fmt.Sprintf("Go %s: %v", version, err))
-					_logger.Warning("Failed to install Go %s: %v", version, err)
-					continue
-				}
+			// Ctrl-C or SIGTERM cancels the in-flight download instead of killing the process mid-write
+			ctx, stop := interruptContext(cmd)
+			defer stop()
 
-				successful = append(successful, version)
-				_logger.Success("Successfully installed Go %s", version)
-			}
+			successful, failures := mgr.InstallManyContext(ctx, expandedVersions)
 
 			_logger.Info(strings.Repeat("─", 50))
 
+			if ctx.Err() != nil {
+				stop()
+				_logger.Warning("Installation interrupted")
+				if len(successful) > 0 {
+					_logger.Info("Completed before the interrupt: Go %s", strings.Join(successful, ", Go "))
+				}
+				_logger.Info("No partial version directory was left behind; a partial download stays in the cache and resumes on the next 'govman install'")
+				return fmt.Errorf("installation interrupted")
+			}
+
 			if len(successful) > 0 {
 				_logger.Success("Successfully installed %d version(s):", len(successful))
 				for _, version := range successful {
@@ -101,20 +217,20 @@ Examples:
 				}
 			}
 
-			if len(errors) > 0 {
-				_logger.ErrorWithHelp("Failed to install %d version(s):", "Review the errors below and try installing problematic versions individually for more details.", len(errors))
-				for _, err := range errors {
-					_logger.Info("  %s", err)
+			if len(failures) > 0 {
+				_logger.ErrorWithHelp("Failed to install %d version(s):", "Review the errors below and try installing problematic versions individually for more details.", len(failures))
+				for _, failure := range failures {
+					printInstallFailure(failure)
 				}
-				_logger.Info("Common solutions:")
-				_logger.Info("  • Check your internet connection")
-				_logger.Info("  • Verify version exists with 'govman list --remote'")
-				_logger.Info("  • Try again with verbose mode: govman install <version> --verbose")
-				return fmt.Errorf("failed to install %d version(s)", len(errors))
+				printInstallHints(failures)
+				return installFailures(fmt.Sprintf("failed to install %d version(s)", len(failures)), failures)
 			}
 
 			if len(successful) > 0 {
 				_logger.Success("All installations completed successfully!")
+				if setDefault || setLocal {
+					return activateInstalled(successful[0], setDefault, setLocal)
+				}
 				if len(successful) == 1 {
 					_logger.Info("Activate it with: govman use %s", successful[0])
 				} else {
@@ -129,14 +245,202 @@ Examples:
 
 	cmd.Flags().BoolVar(&includeUnstable, "unstable", false, "Show only unstable versions (beta, rc) when using wildcard patterns")
 	cmd.Flags().BoolVarP(&skipConfirm, "yes", "y", false, "Skip confirmation prompt for batch operations")
+	cmd.Flags().IntVar(&retries, "retries", 0, "Maximum download attempts for transient network failures (overrides config)")
+	cmd.Flags().DurationVar(&timeout, "timeout", 0, "Per-request connection and response timeout, e.g. 30s or 2m (overrides config)")
+	cmd.Flags().StringVar(&limitRate, "limit-rate", "", "Cap download speed in bytes per second, e.g. 500k or 2m")
+	cmd.Flags().StringVar(&mirror, "mirror", "", "Download mirror base URL to try first, falling back to configured URLs")
+	cmd.Flags().BoolVar(&noCache, "no-cache", false, "Ignore cached archives and download a fresh copy (overrides config)")
+	cmd.Flags().BoolVar(&verifySignature, "verify-signature", false, "Check each archive's detached signature against download.signature_key (overrides config)")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Resolve versions and show download URLs and target directories without downloading")
+	cmd.Flags().BoolVar(&skipHooks, "skip-hooks", false, "Do not run the post-install hooks configured in hooks.post_install")
+	cmd.Flags().BoolVarP(&setDefault, "default", "d", false, "Set the installed version as the system-wide default")
+	cmd.Flags().BoolVarP(&setLocal, "local", "l", false, "Set the installed version for the current project (creates .govman-goversion file)")
+	cmd.Flags().StringVar(&fromFile, "from-file", "", "Install from a local Go archive instead of downloading it")
+	cmd.MarkFlagsMutuallyExclusive("default", "local")
 
 	return cmd
 }
 
+// activateInstalled makes the installed version the default or the project version, as 'govman use --default' or
+// 'govman use --local' would, with the saved config. Returns an error if activation fails; the install itself stands.
+func activateInstalled(version string, setDefault, setLocal bool) error {
+	mgr := _manager.New(getConfig())
+
+	_logger.Verbose("Activating Go %s with mode: %s", version, getActivationMode(setDefault, setLocal, false))
+	if err := mgr.Use(version, setDefault, setLocal, false); err != nil {
+		flag := "--local"
+		if setDefault {
+			flag = "--default"
+		}
+		helpMsg := fmt.Sprintf("The install succeeded; activate it with 'govman use %s %s' once the problem is fixed.", version, flag)
+		_logger.ErrorWithHelp("Installed Go %s but failed to activate it", helpMsg, version)
+		return err
+	}
+
+	// As with 'govman use', a new activation replaces the terminal's recorded version
+	if err := mgr.ClearSessionVersion(); err != nil {
+		_logger.Verbose("Could not clear the session version: %v", err)
+	}
+
+	if setDefault {
+		_logger.Success("Set Go %s as system default version", version)
+		_logger.Info("All new terminal sessions will use this version")
+	} else {
+		_logger.Info("This version will be used automatically when working in this project")
+	}
+	return nil
+}
+
+// printInstallPlan reports what install would do for each version without downloading anything.
+// Returns an error if any version cannot be resolved, mirroring a real run's exit status.
+func printInstallPlan(mgr *_manager.Manager, versions []string) error {
+	_logger.Info("Dry run: planning installation of %d Go version(s)...", len(versions))
+	plans, failures := mgr.PlanInstallMany(versions)
+
+	_logger.Info(strings.Repeat("─", 50))
+
+	var toInstall, installed []*_manager.InstallPlan
+	for _, plan := range plans {
+		if plan.Installed {
+			installed = append(installed, plan)
+		} else {
+			toInstall = append(toInstall, plan)
+		}
+	}
+
+	// The plan is the command's data and goes to stdout; headers stay on stderr
+	if len(toInstall) > 0 {
+		_logger.Info("Would install %d version(s):", len(toInstall))
+		for _, plan := range toInstall {
+			fmt.Printf("  • Go %s\n", describePlannedVersion(plan))
+			fmt.Printf("      download: %s\n", plan.URL)
+			fmt.Printf("      target:   %s\n", plan.InstallDir)
+		}
+		if !mgr.SkipHooks {
+			for _, hook := range getConfig().Hooks.PostInstall {
+				_logger.Info("Would run post-install hook: %s", hook)
+			}
+		}
+	}
+
+	if len(installed) > 0 {
+		_logger.Info("Already installed, would be skipped (%d):", len(installed))
+		for _, plan := range installed {
+			fmt.Printf("  • Go %s (%s)\n", describePlannedVersion(plan), plan.InstallDir)
+		}
+	}
+
+	if len(failures) > 0 {
+		_logger.ErrorWithHelp("Would fail to install %d version(s):", "Review the errors below before running the installation.", len(failures))
+		for _, failure := range failures {
+			printInstallFailure(failure)
+		}
+		printInstallHints(failures)
+		return installFailures(fmt.Sprintf("failed to plan %d version(s)", len(failures)), failures)
+	}
+
+	_logger.Info("Dry run: nothing was downloaded or installed.")
+	return nil
+}
+
+// installFromFile installs the archive at path as the version in args, or the version its file name names,
+// then activates it as --default or --local ask. Returns an error, with cmd's usage silenced, if installation fails.
+func installFromFile(cmd *cobra.Command, mgr *_manager.Manager, path string, args []string, setDefault, setLocal bool) error {
+	version := ""
+	if len(args) == 1 {
+		version = args[0]
+	}
+
+	unlock, err := lockHome(cmd)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	ctx, stop := interruptContext(cmd)
+	defer stop()
+
+	installed, err := mgr.InstallFromFile(ctx, path, version)
+	if err != nil {
+		cmd.SilenceUsage = true
+		if installed != "" {
+			// Only a strict post-install hook fails after the version is in place
+			return err
+		}
+		_logger.ErrorWithHelp("Failed to install from %s", "Check that the file is an official Go archive for this platform, e.g. go1.25.1.linux-amd64.tar.gz.", path)
+		return err
+	}
+
+	if setDefault || setLocal {
+		return activateInstalled(installed, setDefault, setLocal)
+	}
+	_logger.Info("Activate it with: govman use %s", installed)
+	return nil
+}
+
+// describePlannedVersion returns the resolved version, noting the requested form when it differs.
+func describePlannedVersion(plan *_manager.InstallPlan) string {
+	if plan.Requested == plan.Version {
+		return plan.Version
+	}
+	return fmt.Sprintf("%s (from %s)", plan.Version, plan.Requested)
+}
+
+// printInstallFailure prints one failed version with its kind. A version missing from the release list gets
+// a plain explanation instead of the raw error, so it is not mistaken for a network problem.
+func printInstallFailure(failure _manager.InstallError) {
+	if errors.Is(failure, _golang.ErrVersionNotFound) {
+		_logger.Info("  Go %s is not a known release; run 'govman list --remote' to see available versions [%s]", failure.Version, failure.Kind)
+		return
+	}
+	_logger.Info("  %s [%s]", failure.Error(), failure.Kind)
+}
+
+// installFailures returns an error with summary msg that unwraps to failures, so the exit code reflects their kind.
+func installFailures(msg string, failures []_manager.InstallError) error {
+	causes := make([]error, len(failures))
+	for i, failure := range failures {
+		causes[i] = failure
+	}
+	return &summaryError{msg: msg, causes: causes}
+}
+
+// printInstallHints prints remediation hints for the kinds of failures reported by InstallMany.
+func printInstallHints(failures []_manager.InstallError) {
+	kinds := make(map[_manager.InstallErrorKind]bool)
+	for _, failure := range failures {
+		kinds[failure.Kind] = true
+	}
+
+	_logger.Info("Common solutions:")
+	if kinds[_manager.InstallErrorNetwork] {
+		_logger.Info("  • Check your internet connection or proxy settings")
+	}
+	if kinds[_manager.InstallErrorOffline] {
+		_logger.Info("  • Offline mode installs only archives already in the download cache; use --from-file or go online")
+	}
+	if kinds[_manager.InstallErrorNotFound] {
+		_logger.Info("  • Verify version exists with 'govman list --remote'")
+	}
+	if kinds[_manager.InstallErrorAlreadyInstalled] {
+		_logger.Info("  • See installed versions with 'govman list'")
+	}
+	if kinds[_manager.InstallErrorDisk] {
+		_logger.Info("  • Free up disk space with 'govman clean' or 'govman prune'")
+	}
+	if kinds[_manager.InstallErrorHook] {
+		_logger.Info("  • The version is installed; fix the hook in hooks.post_install or set hooks.strict to false")
+	}
+	_logger.Info("  • Try again with verbose mode: govman install <version> --verbose")
+}
+
 // newUninstallCmd creates the 'uninstall' Cobra command to remove one or more installed Go versions.
 // Versions are provided as positional args. Returns a *cobra.Command that uninstalls each version and reports results.
 func newUninstallCmd() *cobra.Command {
 	var skipConfirm bool
+	var allExcept []string
+	var force bool
+	var switchTo string
 
 	cmd := &cobra.Command{
 		Use:   "uninstall [version...]",
@@ -144,13 +448,15 @@ func newUninstallCmd() *cobra.Command {
 		Long: `Completely remove one or more installed Go versions from your system.
 
 Safety features:
-  • Prevents removal of currently active versions
+  • Prevents removal of currently active versions (override with --force)
+  • Removes the active version last, after switching to another with --switch-to
   • Confirms version exists before attempting removal
   • Complete cleanup of binaries and associated files
   • Automatic recalculation of disk space
   • Preserves other installed versions safely
   • Batch uninstallation with detailed progress tracking
   • Wildcard pattern support for batch uninstallation (e.g., 1.14.*)
+  • Keep-list mode with --all-except to remove everything but the listed versions
 
 The uninstalled versions will no longer appear in 'govman list'.
 
@@ -158,29 +464,77 @@ Examples:
   govman uninstall 1.24.1              # Single version
   govman uninstall 1.24.1 1.24.2       # Multiple versions
   govman rm 1.21.1 1.22.0 1.23.0       # Using alias
-  govman uninstall '1.14.*'            # All 1.14.x versions (quote the pattern!)`,
+  govman uninstall '1.14.*'            # All 1.14.x versions (quote the pattern!)
+  govman uninstall --all-except 1.25   # Everything except the newest installed 1.25.x
+  govman uninstall --all-except 1.25.1,1.24.7 -y  # Keep two versions, no prompt
+  govman uninstall 1.25.1 --force      # Remove even if it is the active version
+  govman uninstall --all-except 1.25 --switch-to 1.25 -y  # Move to 1.25 and remove everything else`,
 		Aliases: []string{"remove", "rm"},
-		Args:    cobra.MinimumNArgs(1),
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(allExcept) > 0 {
+				if len(args) > 0 {
+					return fmt.Errorf("--all-except cannot be combined with version arguments")
+				}
+				return nil
+			}
+			return cobra.MinimumNArgs(1)(cmd, args)
+		},
+		ValidArgsFunction: completeInstalledVersions,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			mgr := _manager.New(getConfig())
 
-			// Expand wildcard patterns for installed versions
-			expandedVersions, err := expandUninstallPatterns(args, mgr)
+			if switchTo != "" {
+				resolved, err := resolveInstalledVersion(mgr, switchTo)
+				if err != nil {
+					return err
+				}
+				switchTo = resolved
+			}
+
+			var expandedVersions []string
+			var err error
+			if len(allExcept) > 0 {
+				// With --switch-to the active version can be removed, so it is not kept implicitly
+				expandedVersions, err = expandAllExcept(allExcept, mgr, force || switchTo != "")
+			} else {
+				// Expand wildcard patterns for installed versions
+				expandedVersions, err = expandUninstallPatterns(args, mgr)
+			}
 			if err != nil {
 				return err
 			}
 
 			if len(expandedVersions) == 0 {
+				if len(allExcept) > 0 {
+					_logger.Success("No versions to remove: only kept versions are installed")
+					return nil
+				}
 				_logger.Warning("No installed versions matched the specified pattern(s)")
-				return fmt.Errorf("no versions to uninstall")
+				return &summaryError{msg: "no versions to uninstall", causes: []error{_manager.ErrNotInstalled}}
 			}
 
-			// Show confirmation for pattern-based uninstallation
-			if hasWildcardPattern(args) && !skipConfirm {
+			if slices.Contains(expandedVersions, switchTo) {
+				return fmt.Errorf("cannot switch to Go %s: it is one of the versions being uninstalled", switchTo)
+			}
+
+			// Remove the active version last, so a failure switching away from it leaves the others done
+			state, _ := mgr.CurrentDetailed()
+			current := state.Version
+			switching := switchTo != "" && slices.Contains(expandedVersions, current)
+			projectSelectsCurrent := switching && state.Local == current
+			if i := slices.Index(expandedVersions, current); i >= 0 {
+				expandedVersions = append(slices.Delete(expandedVersions, i, i+1), current)
+			}
+
+			// Show confirmation for pattern-based and keep-list uninstallation
+			if (hasWildcardPattern(args) || len(allExcept) > 0) && !skipConfirm {
 				_logger.Info("The following %d version(s) will be uninstalled:", len(expandedVersions))
 				for _, v := range expandedVersions {
 					_logger.Info("  • Go %s", v)
 				}
+				if switching {
+					_logger.Info("Go %s is active and will be removed last, after switching to Go %s", current, switchTo)
+				}
 				_logger.Info("")
 
 				if !confirmAction("Proceed with uninstallation?") {
@@ -189,21 +543,55 @@ Examples:
 				}
 			}
 
+			unlock, err := lockHome(cmd)
+			if err != nil {
+				return err
+			}
+			defer unlock()
+
 			_logger.Info("Starting uninstallation of %d Go version(s)...", len(expandedVersions))
 			_logger.Progress("Validating versions and checking installation status")
 
-			current, _ := mgr.Current()
 			var errors []string
+			var causes []error
 			var successful []string
 			var totalFreedSpace int64
+			var switchedFrom string
 
 			for i, version := range expandedVersions {
 				_logger.Info("[%d/%d] Uninstalling Go %s...", i+1, len(expandedVersions), version)
 
+				if current == version && switching {
+					if len(errors) > 0 {
+						// Stay on the active version rather than move away from it after a partial failure
+						_logger.Warning("Keeping Go %s active because other versions failed to uninstall", version)
+						errors = append(errors, fmt.Sprintf("Go %s: not switched to Go %s because other versions failed", version, switchTo))
+						causes = append(causes, fmt.Errorf("go version %s is active", version))
+						continue
+					}
+
+					moved, err := mgr.ReplaceActive(version, switchTo)
+					if err != nil {
+						_logger.Warning("Failed to switch from Go %s to Go %s: %v", version, switchTo, err)
+						errors = append(errors, fmt.Sprintf("Go %s: %v", version, err))
+						causes = append(causes, err)
+						continue
+					}
+					for _, scope := range moved {
+						if scope == "default" {
+							_logger.Success("Switched the system default to Go %s", switchTo)
+						} else {
+							_logger.Success("Switched this terminal to Go %s", switchTo)
+						}
+					}
+					switchedFrom = version
+				}
+
 				// Check if version is currently active
-				if current == version {
+				if current == version && !force && !switching {
 					_logger.Warning("Cannot uninstall currently active Go version %s", version)
-					errors = append(errors, fmt.Sprintf("Go %s: cannot uninstall active version", version))
+					errors = append(errors, fmt.Sprintf("Go %s: cannot uninstall active version (use --force to remove it anyway)", version))
+					causes = append(causes, fmt.Errorf("go version %s is active", version))
 					continue
 				}
 
@@ -212,21 +600,35 @@ Examples:
 				if err != nil {
 					_logger.Warning("Go version %s is not installed or information is unavailable", version)
 					errors = append(errors, fmt.Sprintf("Go %s: %v", version, err))
+					causes = append(causes, err)
 					continue
 				}
 
 				// Perform uninstallation
 				_logger.Progress("Removing installation directory and associated files")
-				err = mgr.Uninstall(version)
+				// A version just switched away from may still be first on this process's PATH, so skip the active check
+				if force || version == switchedFrom {
+					err = mgr.ForceUninstall(version)
+				} else {
+					err = mgr.Uninstall(version)
+				}
 				if err != nil {
 					_logger.Warning("Failed to uninstall Go %s: %v", version, err)
 					errors = append(errors, fmt.Sprintf("Go %s: %v", version, err))
+					causes = append(causes, err)
 					continue
 				}
 
 				successful = append(successful, version)
 				totalFreedSpace += info.Size
 				_logger.Success("Successfully uninstalled Go %s", version)
+				if version == switchedFrom {
+					if projectSelectsCurrent && mgr.LocalVersion() == "" {
+						_logger.Warning("This project's version file asks for Go %s, which is no longer installed; update it with 'govman use %s --local'", mgr.GetLocalVersionRaw(), switchTo)
+					}
+				} else if current == version {
+					_logger.Warning("Go %s was the active version; activate another with 'govman use <version>'", version)
+				}
 			}
 
 			_logger.Info(strings.Repeat("─", 50))
@@ -237,6 +639,9 @@ Examples:
 					_logger.Info("  • Go %s", version)
 				}
 				_logger.Info("Total disk space freed: %s", _util.FormatBytes(totalFreedSpace))
+				if switchedFrom != "" {
+					_logger.Info("Switched from Go %s to Go %s; run 'govman use %s' in terminals still using Go %s", switchedFrom, switchTo, switchTo, switchedFrom)
+				}
 			}
 
 			if len(errors) > 0 {
@@ -245,10 +650,10 @@ Examples:
 					_logger.Info("  %s", err)
 				}
 				_logger.Info("Common solutions:")
-				_logger.Info("  • Switch to a different version if trying to uninstall active version")
+				_logger.Info("  • Switch to a different version if trying to uninstall active version, or pass --switch-to <version>")
 				_logger.Info("  • Verify version is installed with 'govman list'")
 				_logger.Info("  • Ensure no processes are using the Go installation")
-				return fmt.Errorf("failed to uninstall %d version(s)", len(errors))
+				return &summaryError{msg: fmt.Sprintf("failed to uninstall %d version(s)", len(errors)), causes: causes}
 			}
 
 			if len(successful) > 0 {
@@ -261,10 +666,55 @@ Examples:
 	}
 
 	cmd.Flags().BoolVarP(&skipConfirm, "yes", "y", false, "Skip confirmation prompt for batch operations")
+	cmd.Flags().StringSliceVar(&allExcept, "all-except", nil, "Uninstall every installed version except these (aliases, partial versions, and patterns allowed)")
+	cmd.RegisterFlagCompletionFunc("all-except", completeInstalledVersions)
+	cmd.Flags().BoolVar(&force, "force", false, "Allow removing the currently active version, clearing the default and global symlink if they refer to it")
+	cmd.Flags().StringVar(&switchTo, "switch-to", "", "If the active version is being removed, switch to this installed version first, then remove it last")
+	cmd.RegisterFlagCompletionFunc("switch-to", completeInstalledVersions)
 
 	return cmd
 }
 
+// expandAllExcept resolves the keep-list given to --all-except and returns every other installed version.
+// The currently active version is kept unless force is set. Returns an error if a kept version is not installed,
+// so a typo cannot turn into removing everything.
+func expandAllExcept(keep []string, mgr *_manager.Manager, force bool) ([]string, error) {
+	installedVersions, err := mgr.ListInstalled()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list installed versions: %w", err)
+	}
+
+	keptVersions, err := expandUninstallPatterns(keep, mgr)
+	if err != nil {
+		return nil, err
+	}
+
+	kept := make(map[string]bool)
+	for _, version := range keptVersions {
+		if !mgr.IsInstalled(version) {
+			return nil, fmt.Errorf("cannot keep Go %s: it is not installed", version)
+		}
+		kept[version] = true
+	}
+	if len(kept) == 0 {
+		return nil, fmt.Errorf("--all-except did not match any installed version")
+	}
+
+	if current, err := mgr.Current(); !force && err == nil && current != "" && !kept[current] && mgr.IsInstalled(current) {
+		_logger.Info("Keeping Go %s (currently active)", current)
+		kept[current] = true
+	}
+
+	var toRemove []string
+	for _, version := range installedVersions {
+		if !kept[version] {
+			toRemove = append(toRemove, version)
+		}
+	}
+
+	return toRemove, nil
+}
+
 // hasWildcardPattern checks if any of the provided args contains a wildcard pattern.
 func hasWildcardPattern(args []string) bool {
 	for _, arg := range args {
@@ -288,7 +738,7 @@ func expandInstallPatterns(args []string, mgr *_manager.Manager, unstableOnly bo
 			_logger.Progress("Fetching available versions for pattern '%s'...", arg)
 			// Always fetch all versions when unstableOnly is true (we'll filter later)
 			// Otherwise, fetch stable versions only
-			remoteVersions, err := _golang.GetAvailableVersions(unstableOnly)
+			remoteVersions, err := mgr.ListRemote(unstableOnly)
 			if err != nil {
 				return nil, fmt.Errorf("failed to fetch remote versions: %w", err)
 			}
@@ -397,7 +847,7 @@ func expandUninstallPatterns(args []string, mgr *_manager.Manager) ([]string, er
 // confirmAction prompts the user for confirmation and returns true if they respond with 'y' or 'yes'.
 func confirmAction(prompt string) bool {
 	reader := bufio.NewReader(os.Stdin)
-	fmt.Printf("%s [y/N]: ", prompt)
+	fmt.Fprintf(os.Stderr, "%s [y/N]: ", prompt)
 	response, err := reader.ReadString('\n')
 	if err != nil {
 		return false
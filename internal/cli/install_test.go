@@ -0,0 +1,97 @@
+package cli
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+	"testing"
+
+	_config "github.com/justjundana/govman/internal/config"
+	_golang "github.com/justjundana/govman/internal/golang"
+)
+
+// writeToolchainArchive writes a minimal Go release archive for version that passes toolchain validation.
+func writeToolchainArchive(t *testing.T, path, version string) {
+	t.Helper()
+
+	file, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	gzWriter := gzip.NewWriter(file)
+	tarWriter := tar.NewWriter(gzWriter)
+	for name, body := range map[string]string{"go/bin/go": "binary", "go/pkg/": "", "go/VERSION": "go" + version + "\n"} {
+		header := &tar.Header{Name: name, Size: int64(len(body)), Mode: 0755, Typeflag: tar.TypeReg}
+		if strings.HasSuffix(name, "/") {
+			header.Typeflag = tar.TypeDir
+		}
+		if err := tarWriter.WriteHeader(header); err != nil {
+			t.Fatal(err)
+		}
+		tarWriter.Write([]byte(body))
+	}
+	tarWriter.Close()
+	gzWriter.Close()
+}
+
+func TestInstallCmd_DefaultKeepsOneRunFlagsOutOfConfig(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("official Windows archives are zip files")
+	}
+
+	dir := t.TempDir()
+	home := filepath.Join(dir, "govman")
+	t.Setenv("HOME", dir)
+	t.Setenv(_config.HomeEnvVar, home)
+	if err := os.MkdirAll(home, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	configFile := filepath.Join(home, "config.yaml")
+	saved := "install_dir: " + filepath.Join(home, "versions") + "\n" +
+		"cache_dir: " + filepath.Join(home, "cache") + "\n" +
+		"download:\n  retry_count: 3\n  use_cache: true\n" +
+		"hooks:\n  post_install:\n    - go version\n"
+	if err := os.WriteFile(configFile, []byte(saved), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, err := _config.Load(configFile)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	previous := cfg
+	cfg = loaded
+	t.Cleanup(func() { cfg = previous })
+
+	archive := filepath.Join(dir, _golang.ArchiveName("1.20"))
+	writeToolchainArchive(t, archive, "1.20")
+
+	cmd := newInstallCmd()
+	cmd.SetArgs([]string{"--from-file", archive, "--default", "--skip-hooks", "--retries", "9", "--no-cache", "--mirror", "https://example.com/dl/"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("install --from-file --default error = %v", err)
+	}
+
+	reloaded, err := _config.Load(configFile)
+	if err != nil {
+		t.Fatalf("Load() after install error = %v", err)
+	}
+	if reloaded.DefaultVersion != "1.20" {
+		t.Errorf("default_version = %q, want 1.20, the installed release", reloaded.DefaultVersion)
+	}
+	if !slices.Equal(reloaded.Hooks.PostInstall, []string{"go version"}) {
+		t.Errorf("hooks.post_install = %v, want [go version]", reloaded.Hooks.PostInstall)
+	}
+	if reloaded.Download.RetryCount != 3 || !reloaded.Download.UseCache {
+		t.Errorf("download = retry_count %d, use_cache %v; want 3, true", reloaded.Download.RetryCount, reloaded.Download.UseCache)
+	}
+	if reloaded.Mirror.Enabled || reloaded.Mirror.URL == "https://example.com/dl/" {
+		t.Errorf("mirror = enabled %v, url %q; want the saved mirror settings", reloaded.Mirror.Enabled, reloaded.Mirror.URL)
+	}
+}
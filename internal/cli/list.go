@@ -1,25 +1,63 @@
 package cli
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
+	"os"
 	"path/filepath"
 	"strings"
+	"text/template"
+	"time"
 
 	cobra "github.com/spf13/cobra"
 
+	_golang "github.com/justjundana/govman/internal/golang"
 	_logger "github.com/justjundana/govman/internal/logger"
 	_manager "github.com/justjundana/govman/internal/manager"
 	_util "github.com/justjundana/govman/internal/util"
 )
 
-// newListCmd creates the 'list' Cobra command to display installed or remote Go versions.
-// Flags: --remote, --stable-only, --beta, and --pattern control the output. Returns a *cobra.Command.
+// remoteListOptions holds the filters applied to 'list --remote' output.
+type remoteListOptions struct {
+	includeUnstable bool
+	pattern         string
+	latestOnly      bool
+	major           int
+	limit           int
+	allPages        bool
+}
+
+// installedVersionRecord is one line of 'list --json-lines' output describing an installed version.
+type installedVersionRecord struct {
+	Version     string     `json:"version"`
+	Path        string     `json:"path"`
+	OS          string     `json:"os,omitempty"`
+	Arch        string     `json:"arch,omitempty"`
+	InstallDate *time.Time `json:"install_date,omitempty"`
+	Active      bool       `json:"active"`
+	Default     bool       `json:"default"`
+	Tag         string     `json:"tag,omitempty"`
+	SizeBytes   *int64     `json:"size_bytes,omitempty"`
+	Error       string     `json:"error,omitempty"`
+}
+
+// newListCmd creates the 'list' Cobra command to display installed or remote Go versions, shaped by its filter
+// and output flags. Returns a *cobra.Command.
 func newListCmd() *cobra.Command {
 	var (
 		remote     bool
 		stableOnly bool
 		beta       bool
 		pattern    string
+		latestOnly bool
+		major      int
+		limit      int
+		all        bool
+		showSize   bool
+		tree       bool
+		jsonLines  bool
+		format     string
 	)
 
 	cmd := &cobra.Command{
@@ -28,7 +66,9 @@ func newListCmd() *cobra.Command {
 		Long: `Display comprehensive information about Go versions on your system.
 
 Features:
-  • View all installed Go versions with size information
+  • View all installed Go versions with install dates and tags
+  • Show per-version disk usage and a grand total with --size
+  • Group installed patches under their minor line with --tree
   • Browse available remote versions for installation
   • Filter versions by patterns and stability level
   • See which version is currently active
@@ -37,16 +77,89 @@ Features:
 Pro Tips:
   • Use --remote to explore available versions before installing
   • Combine --pattern with --remote to find specific version ranges
-  • The * marker indicates your currently active version`,
+  • Use --latest-only, --major, and --limit with --remote to trim long lists
+  • Use --all with --remote to follow a paginated release API to the end
+  • Use --size to see which versions take the most space before pruning
+  • Combine --tree with --size to see how much each minor line takes
+  • Use --json-lines to stream one JSON object per installed version, e.g. into jq
+  • Use --format with a Go template, or the short and wide presets, for custom output
+  • The * marker indicates your currently active version
+
+Examples:
+  govman list                                # Installed versions
+  govman list --tree --size                  # Installed versions grouped by minor line, with totals
+  govman list --remote                       # Available releases (first page of the API)
+  govman list --remote --all                 # Complete release history across all pages
+  govman list --remote --latest-only         # Newest patch of each minor line
+  govman list --remote --major 1 --limit 5   # Five newest 1.x releases
+  govman list --remote --beta --latest-only  # Include lines that only have pre-releases
+  govman list --json-lines --size | jq -r 'select(.size_bytes > 500000000) | .version'
+  govman list --format wide                  # Version, platform, size, date, and markers
+  govman list --format '{{.Version}}{{if .Default}} (default){{end}}'
+
+Template fields: .Version .Path .OS .Arch .Tag .Size .InstallDate .Active .Default .Local
+Template functions: bytes (human-readable size), date (YYYY-MM-DD)`,
 		Aliases: []string{"ls"},
 		RunE: func(cmd *cobra.Command, args []string) error {
+			remoteOnly := []string{"all", "latest-only", "major", "limit"}
+			for _, name := range remoteOnly {
+				if cmd.Flags().Changed(name) && !remote {
+					return fmt.Errorf("--%s can only be used with --remote", name)
+				}
+			}
+			if jsonLines && remote {
+				return fmt.Errorf("--json-lines lists installed versions and cannot be combined with --remote")
+			}
+			if format != "" && (remote || jsonLines) {
+				return fmt.Errorf("--format lists installed versions and cannot be combined with --remote or --json-lines")
+			}
+			if tree && (remote || jsonLines || format != "") {
+				return fmt.Errorf("--tree lists installed versions and cannot be combined with --remote, --json-lines, or --format")
+			}
+			if major < 0 {
+				return fmt.Errorf("--major must not be negative")
+			}
+			if limit < 0 {
+				return fmt.Errorf("--limit must not be negative")
+			}
+
 			mgr := _manager.New(getConfig())
 
 			if remote {
-				return listRemoteVersions(mgr, !stableOnly || beta, pattern)
+				opts := remoteListOptions{
+					includeUnstable: !stableOnly || beta,
+					pattern:         pattern,
+					latestOnly:      latestOnly,
+					major:           -1,
+					limit:           limit,
+					allPages:        all,
+				}
+				if cmd.Flags().Changed("major") {
+					opts.major = major
+				}
+				if err := requireOnline(cmd, "Listing remote versions"); err != nil {
+					return err
+				}
+				return listRemoteVersions(mgr, opts)
+			}
+
+			if jsonLines {
+				return streamInstalledVersions(mgr, showSize)
+			}
+
+			if format != "" {
+				tmpl, err := parseFormat(format)
+				if err != nil {
+					return err
+				}
+				return formatInstalledVersions(mgr, tmpl)
 			}
 
-			return listInstalledVersions(mgr)
+			if tree {
+				return listInstalledTree(mgr, showSize)
+			}
+
+			return listInstalledVersions(mgr, showSize)
 		},
 	}
 
@@ -54,20 +167,33 @@ Pro Tips:
 	cmd.Flags().BoolVar(&stableOnly, "stable-only", false, "Show only stable, production-ready versions (remote only)")
 	cmd.Flags().BoolVar(&beta, "beta", false, "Include beta/rc versions for early testing (remote only)")
 	cmd.Flags().StringVar(&pattern, "pattern", "", "Filter versions using glob patterns like '1.25*' or '1.2?' (remote only)")
+	cmd.Flags().BoolVar(&latestOnly, "latest-only", false, "Show only the newest release of each minor line (remote only)")
+	cmd.Flags().IntVar(&major, "major", 0, "Show only releases with this major version (remote only)")
+	cmd.Flags().IntVar(&limit, "limit", 0, "Show at most this many versions, newest first (remote only)")
+	cmd.Flags().BoolVar(&all, "all", false, "Follow release API pagination to list the complete history (remote only)")
+	cmd.Flags().BoolVar(&showSize, "size", false, "Show on-disk size of each installed version and the total")
+	cmd.Flags().BoolVar(&tree, "tree", false, "Group installed versions under their minor line, with per-line totals when combined with --size")
+	cmd.Flags().BoolVar(&jsonLines, "json-lines", false, "Write one JSON object per installed version per line, for streaming tools like jq")
+	cmd.Flags().StringVar(&format, "format", "", "Render each installed version with a Go template, or a preset: short, wide")
 
 	return cmd
 }
 
-// listInstalledVersions lists installed Go versions with size, install date, and active/default markers.
-// Parameter mgr is the Manager used to query versions and metadata. Returns an error if listing fails.
-func listInstalledVersions(mgr *_manager.Manager) error {
+// listInstalledVersions lists installed Go versions with install date and active/default markers.
+// Parameters: mgr (Manager), showSize (include per-version size and total). Returns an error if listing fails.
+func listInstalledVersions(mgr *_manager.Manager, showSize bool) error {
 	_logger.Verbose("Scanning installation directory for Go versions")
-	versions, err := mgr.ListInstalled()
+	installed, err := mgr.ListInstalledWithMeta()
 	if err != nil {
 		_logger.ErrorWithHelp("Unable to scan for installed Go versions", "Verify that ~/.govman/versions exists and is accessible.", "")
 		return fmt.Errorf("failed to list installed versions: %w", err)
 	}
 
+	versions := make([]string, 0, len(installed))
+	for _, entry := range installed {
+		versions = append(versions, entry.Version)
+	}
+
 	if len(versions) == 0 {
 		_logger.Info("No Go versions are currently installed")
 		_logger.Info("Quick start: Run 'govman install latest' to get the newest stable version")
@@ -78,11 +204,24 @@ func listInstalledVersions(mgr *_manager.Manager) error {
 	current, _ := mgr.Current()
 	defaultVersion := mgr.DefaultVersion()
 
+	var sizes map[string]int64
+	if showSize {
+		_logger.Verbose("Calculating disk usage for %d versions", len(versions))
+		sizes = mgr.VersionSizes(versions)
+	}
+
 	_logger.Info("Installed Go Versions (%d total):", len(versions))
 	_logger.Info(strings.Repeat("─", 60))
 
+	// Version rows are the command's data and go to stdout; headers and hints stay on stderr
 	totalSize := int64(0)
-	for _, version := range versions {
+	for _, entry := range installed {
+		version := entry.Version
+		tag := ""
+		if entry.Tag != "" {
+			tag = fmt.Sprintf("   # %s", entry.Tag)
+		}
+
 		marker := "  "
 		statusIcon := "Installed"
 		if version == current {
@@ -90,9 +229,9 @@ func listInstalledVersions(mgr *_manager.Manager) error {
 			statusIcon = "Active"
 		}
 
-		info, err := mgr.Info(version)
+		info, err := mgr.Stat(version)
 		if err != nil {
-			_logger.Info("%s%s %s (unable to read installation info)", marker, statusIcon, version)
+			fmt.Printf("%s%s %s (unable to read installation info)%s\n", marker, statusIcon, version, tag)
 			continue
 		}
 
@@ -101,14 +240,24 @@ func listInstalledVersions(mgr *_manager.Manager) error {
 			versionDisplay = version + " [default]"
 		}
 
-		size := _util.FormatBytes(info.Size)
-		totalSize += info.Size
 		installDate := info.InstallDate.Format("2006-01-02")
-		_logger.Info("%s%s %-25s %8s   installed: %s", marker, statusIcon, versionDisplay, size, installDate)
+		if !showSize {
+			fmt.Printf("%s%s %-25s installed: %s%s\n", marker, statusIcon, versionDisplay, installDate, tag)
+			continue
+		}
+
+		size := "unknown"
+		if versionSize, ok := sizes[version]; ok {
+			size = _util.FormatBytes(versionSize)
+			totalSize += versionSize
+		}
+		fmt.Printf("%s%s %-25s %8s   installed: %s%s\n", marker, statusIcon, versionDisplay, size, installDate, tag)
 	}
 
 	_logger.Info(strings.Repeat("─", 60))
-	_logger.Info("Total disk usage: %s across %d versions", _util.FormatBytes(totalSize), len(versions))
+	if showSize {
+		_logger.Info("Total disk usage: %s across %d versions", _util.FormatBytes(totalSize), len(versions))
+	}
 
 	if current != "" {
 		_logger.Info("Currently active: Go %s", current)
@@ -120,12 +269,197 @@ func listInstalledVersions(mgr *_manager.Manager) error {
 	return nil
 }
 
-// listRemoteVersions fetches and displays available remote Go versions.
-// Parameters: mgr (Manager), includeUnstable (include beta/rc), pattern (glob filter). Returns an error on fetch failures.
-func listRemoteVersions(mgr *_manager.Manager, includeUnstable bool, pattern string) error {
+// listInstalledTree lists installed Go versions grouped by minor line, newest first, with each line as a header and
+// its patches and prereleases nested beneath it. The active version is marked with → and the default with [default].
+// With showSize, each version shows its size and each line header its total. Returns an error if listing fails.
+func listInstalledTree(mgr *_manager.Manager, showSize bool) error {
+	installed, err := mgr.ListInstalledWithMeta()
+	if err != nil {
+		_logger.ErrorWithHelp("Unable to scan for installed Go versions", "Verify that ~/.govman/versions exists and is accessible.", "")
+		return fmt.Errorf("failed to list installed versions: %w", err)
+	}
+
+	if len(installed) == 0 {
+		_logger.Info("No Go versions are currently installed")
+		_logger.Info("Quick start: Run 'govman install latest' to get the newest stable version")
+		return nil
+	}
+
+	versions := make([]string, 0, len(installed))
+	tags := make(map[string]string, len(installed))
+	for _, entry := range installed {
+		versions = append(versions, entry.Version)
+		tags[entry.Version] = entry.Tag
+	}
+
+	current, _ := mgr.Current()
+	defaultVersion := mgr.DefaultVersion()
+
+	var sizes map[string]int64
+	if showSize {
+		_logger.Verbose("Calculating disk usage for %d versions", len(versions))
+		sizes = mgr.VersionSizes(versions)
+	}
+
+	groups := _util.GroupByReleaseLine(versions)
+	_logger.Info("Installed Go Versions (%d total in %d minor lines):", len(versions), len(groups))
+	_logger.Info(strings.Repeat("─", 60))
+
+	// Lines and version rows are the command's data and go to stdout; headers and hints stay on stderr
+	totalSize := int64(0)
+	for _, group := range groups {
+		header := fmt.Sprintf("Go %s (%d installed)", group.Line, len(group.Versions))
+		if showSize {
+			lineSize := int64(0)
+			for _, version := range group.Versions {
+				lineSize += sizes[version]
+			}
+			totalSize += lineSize
+			header = fmt.Sprintf("Go %s (%d installed, %s)", group.Line, len(group.Versions), _util.FormatBytes(lineSize))
+		}
+		fmt.Println(header)
+
+		for i, version := range group.Versions {
+			branch := "├─"
+			if i == len(group.Versions)-1 {
+				branch = "└─"
+			}
+
+			marker := "  "
+			if version == current {
+				marker = "→ "
+			}
+
+			versionDisplay := version
+			if version == defaultVersion && defaultVersion != "" {
+				versionDisplay = version + " [default]"
+			}
+
+			details := ""
+			if showSize {
+				size := "unknown"
+				if versionSize, ok := sizes[version]; ok {
+					size = _util.FormatBytes(versionSize)
+				}
+				details = fmt.Sprintf(" %8s  ", size)
+			}
+			if info, err := mgr.Stat(version); err == nil {
+				details += " installed: " + info.InstallDate.Format("2006-01-02")
+			} else {
+				details += " (unable to read installation info)"
+			}
+			if tags[version] != "" {
+				details += "   # " + tags[version]
+			}
+
+			fmt.Printf("  %s %s%-20s%s\n", branch, marker, versionDisplay, details)
+		}
+	}
+
+	_logger.Info(strings.Repeat("─", 60))
+	if showSize {
+		_logger.Info("Total disk usage: %s across %d versions", _util.FormatBytes(totalSize), len(versions))
+	}
+	if current != "" {
+		_logger.Info("Currently active: Go %s", current)
+	}
+
+	return nil
+}
+
+// streamInstalledVersions writes one installedVersionRecord per line to stdout, newest version first.
+// Each line is written as soon as it is ready; with showSize, sizes are computed one version at a time
+// so output starts immediately. Returns an error if listing fails or stdout cannot be written.
+func streamInstalledVersions(mgr *_manager.Manager, showSize bool) error {
+	installed, err := mgr.ListInstalledWithMeta()
+	if err != nil {
+		return fmt.Errorf("failed to list installed versions: %w", err)
+	}
+
+	current, _ := mgr.Current()
+	defaultVersion := mgr.DefaultVersion()
+
+	var sizer *_manager.VersionSizer
+	if showSize {
+		sizer = mgr.NewVersionSizer()
+		defer sizer.Save()
+	}
+
+	// Encode writes each record with a single unbuffered write to stdout
+	encoder := json.NewEncoder(os.Stdout)
+	for _, entry := range installed {
+		record := installedVersionRecord{
+			Version: entry.Version,
+			Path:    getConfig().GetVersionDir(entry.Version),
+			Active:  entry.Version == current,
+			Default: entry.Version == defaultVersion && defaultVersion != "",
+			Tag:     entry.Tag,
+		}
+
+		if info, err := mgr.Stat(entry.Version); err != nil {
+			record.Error = err.Error()
+		} else {
+			record.OS = info.OS
+			record.Arch = info.Arch
+			record.InstallDate = &info.InstallDate
+		}
+
+		if sizer != nil {
+			if size, ok := sizer.Size(entry.Version); ok {
+				record.SizeBytes = &size
+			}
+		}
+
+		if err := encoder.Encode(record); err != nil {
+			return fmt.Errorf("failed to write version %s: %w", entry.Version, err)
+		}
+	}
+
+	return nil
+}
+
+// formatInstalledVersions renders each installed version, newest first, through tmpl on its own line.
+// Returns an error if listing fails or the template fails to execute.
+func formatInstalledVersions(mgr *_manager.Manager, tmpl *template.Template) error {
+	installed, err := mgr.ListInstalled()
+	if err != nil {
+		return fmt.Errorf("failed to list installed versions: %w", err)
+	}
+
+	state := currentVersionState(mgr)
+	for _, version := range installed {
+		if err := renderRecord(tmpl, newVersionRecord(mgr, version, nil, state)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// listRemoteVersions fetches, filters, and displays available remote Go versions.
+// Parameters: mgr (Manager), opts (stability, pattern, and count filters). Returns an error on fetch failures.
+func listRemoteVersions(mgr *_manager.Manager, opts remoteListOptions) error {
+	includeUnstable := opts.includeUnstable
+	pattern := opts.pattern
+
 	_logger.Verbose("Fetching available versions from Go's official release API")
-	versions, err := mgr.ListRemote(includeUnstable)
+	var versions []string
+	var err error
+	if opts.allPages {
+		versions, err = mgr.ListRemoteAll(includeUnstable)
+	} else {
+		versions, err = mgr.ListRemote(includeUnstable)
+	}
 	if err != nil {
+		var rateErr *_golang.RateLimitError
+		if errors.As(err, &rateErr) {
+			help := "Wait until the limit resets, then try again."
+			if !rateErr.Authenticated {
+				help = "Set GITHUB_TOKEN (or GH_TOKEN) or run 'govman config set go_releases.github_token <token>' to raise the limit."
+			}
+			_logger.ErrorWithHelp("The release API rate limit has been reached", help)
+			return fmt.Errorf("failed to list remote versions: %w", err)
+		}
 		_logger.ErrorWithHelp("Unable to fetch remote Go versions", "Check your internet connection and verify that golang.org is accessible.", "")
 		return fmt.Errorf("failed to list remote versions: %w", err)
 	}
@@ -148,10 +482,15 @@ func listRemoteVersions(mgr *_manager.Manager, includeUnstable bool, pattern str
 		_logger.Verbose("Pattern '%s' matched %d of %d available versions", pattern, len(versions), originalCount)
 	}
 
+	versions = filterRemoteVersions(versions, opts)
+
 	if len(versions) == 0 {
 		if pattern != "" {
 			_logger.Info("No versions found matching pattern '%s'", pattern)
 			_logger.Info("Try a broader pattern like '%s*' or remove the pattern filter", pattern[:min(len(pattern), 4)])
+		} else if opts.major >= 0 {
+			_logger.Info("No Go %d.x versions found", opts.major)
+			_logger.Info("Remove --major to see all available versions")
 		} else {
 			_logger.Info("No versions found")
 			_logger.Info("This might be a temporary issue - try again in a moment")
@@ -181,6 +520,10 @@ func listRemoteVersions(mgr *_manager.Manager, includeUnstable bool, pattern str
 		versionTypeDesc = "stable versions"
 	}
 
+	if opts.major >= 0 || opts.latestOnly || opts.limit > 0 {
+		versionTypeDesc += " after filtering"
+	}
+
 	_logger.Info("Available Go %s (%d total, %d already installed):", versionTypeDesc, len(versions), installedCount)
 	_logger.Info(strings.Repeat("─", 60))
 
@@ -202,7 +545,7 @@ func listRemoteVersions(mgr *_manager.Manager, includeUnstable bool, pattern str
 			versionType = " [beta]"
 		}
 
-		_logger.Info("%s%s %-15s %s%s", marker, statusIcon, version, statusText, versionType)
+		fmt.Printf("%s%s %-15s %s%s\n", marker, statusIcon, version, statusText, versionType)
 	}
 
 	_logger.Info(strings.Repeat("─", 60))
@@ -216,3 +559,24 @@ func listRemoteVersions(mgr *_manager.Manager, includeUnstable bool, pattern str
 
 	return nil
 }
+
+// filterRemoteVersions applies the --major, --latest-only, and --limit filters, in that order.
+// Parameters: versions (sorted newest first), opts. Returns the filtered versions, newest first.
+func filterRemoteVersions(versions []string, opts remoteListOptions) []string {
+	if opts.major >= 0 {
+		versions = _util.FilterByMajor(versions, opts.major)
+		_logger.Verbose("Major version %d matched %d versions", opts.major, len(versions))
+	}
+
+	if opts.latestOnly {
+		versions = _util.LatestPerMinor(versions)
+		_logger.Verbose("Reduced to %d versions (newest per minor line)", len(versions))
+	}
+
+	if opts.limit > 0 && len(versions) > opts.limit {
+		versions = versions[:opts.limit]
+		_logger.Verbose("Limited output to %d versions", opts.limit)
+	}
+
+	return versions
+}
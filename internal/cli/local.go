@@ -0,0 +1,159 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	cobra "github.com/spf13/cobra"
+
+	_logger "github.com/justjundana/govman/internal/logger"
+	_manager "github.com/justjundana/govman/internal/manager"
+)
+
+// newLocalCmd creates the 'local' Cobra command to set, show (--show), remove (--unset), or migrate (--migrate)
+// the project-local Go version. Returns a *cobra.Command.
+func newLocalCmd() *cobra.Command {
+	var (
+		show    bool
+		unset   bool
+		migrate bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "local [version]",
+		Short: "Set, show, or remove the project-local Go version",
+		Long: `Manage the Go version pinned to the current project directory.
+
+Usage:
+  • With a version: write .govman-goversion and switch to that version
+  • --show: print the version requested by the project version file
+  • --unset: delete .govman-goversion from the current directory
+  • --migrate: rename a deprecated .govman-version to .govman-goversion
+
+After --unset the directory falls back to any other project version file
+(.go-version, go.mod, or a parent directory) or to your default version.
+
+Examples:
+  govman local 1.25.1               # Pin this project to Go 1.25.1
+  govman local 1.25                 # Pin to the newest installed 1.25.x
+  govman local --show               # Show the pinned version
+  govman local --unset              # Remove the pin
+  govman local --migrate            # Rename a deprecated .govman-version`,
+		Args:              cobra.MaximumNArgs(1),
+		ValidArgsFunction: singleArgCompletion(completeInstalledVersions),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) > 0 && (show || unset || migrate) {
+				return fmt.Errorf("a version cannot be combined with --show, --unset, or --migrate")
+			}
+
+			mgr := _manager.New(getConfig())
+
+			switch {
+			case migrate:
+				return migrateLegacyProjectFile(cmd, mgr)
+			case unset:
+				return unsetLocalVersion(mgr)
+			case len(args) == 0:
+				return showLocalVersion(mgr)
+			}
+
+			version, err := resolveInstalledVersion(mgr, args[0])
+			if err != nil {
+				return err
+			}
+
+			if err := mgr.Use(version, false, true, false); err != nil {
+				_logger.ErrorWithHelp("Failed to set local Go %s", "Ensure you have permission to write to the current directory.", version)
+				return err
+			}
+
+			_logger.Info("Created/updated %s in current directory", getConfig().AutoSwitch.ProjectFile)
+			_logger.Info("This version will be used automatically when working in this project")
+			hintLegacyMigration(mgr)
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&show, "show", false, "Print the project-local version")
+	cmd.Flags().BoolVar(&unset, "unset", false, "Remove the project version file from the current directory")
+	cmd.Flags().BoolVar(&migrate, "migrate", false, "Rename a deprecated .govman-version to the project version file, or remove it if superseded")
+	cmd.MarkFlagsMutuallyExclusive("show", "unset", "migrate")
+
+	return cmd
+}
+
+// showLocalVersion prints the raw project-local version and the file it comes from.
+// Returns nil even when no local version is set.
+func showLocalVersion(mgr *_manager.Manager) error {
+	filename, version := mgr.FindProjectVersionFile()
+	if version == "" {
+		_logger.Info("No project-local version is set")
+		_logger.Info("Set one with: govman local <version>")
+		return nil
+	}
+
+	fmt.Println(version)
+	_logger.Verbose("Read from %s", filename)
+	return nil
+}
+
+// unsetLocalVersion removes the project version file and reports what the directory falls back to.
+// Returns an error only if the file exists but cannot be removed.
+func unsetLocalVersion(mgr *_manager.Manager) error {
+	removed, err := mgr.UnsetLocal()
+	if err != nil {
+		_logger.ErrorWithHelp("Unable to remove the project version file", "Check the file permissions in the current directory.", "")
+		return err
+	}
+
+	if removed == "" {
+		_logger.Info("No %s in the current directory - nothing to remove", getConfig().AutoSwitch.ProjectFile)
+	} else {
+		_logger.Success("Removed %s", removed)
+	}
+
+	if filename, version := mgr.FindProjectVersionFile(); version != "" {
+		_logger.Info("This directory still uses Go %s from %s", version, filename)
+		return nil
+	}
+
+	if defaultVersion := mgr.DefaultVersion(); defaultVersion != "" {
+		_logger.Info("This directory will now fall back to the default version (Go %s)", defaultVersion)
+	} else {
+		_logger.Info("This directory will now fall back to the default version (none set)")
+	}
+
+	return nil
+}
+
+// hintLegacyMigration points to 'govman local --migrate' when a legacy .govman-version sits next to the project file.
+// It never prompts, since the shell wrapper may be reading the output.
+func hintLegacyMigration(mgr *_manager.Manager) {
+	if legacy := mgr.LegacyProjectFile(); legacy != "" {
+		_logger.Info("Run 'govman local --migrate' to migrate the deprecated %s", filepath.Base(legacy))
+	}
+}
+
+// migrateLegacyProjectFile renames a legacy .govman-version to the project file, or removes it once the project file
+// exists and takes precedence. Returns an error if the file cannot be migrated.
+func migrateLegacyProjectFile(cmd *cobra.Command, mgr *_manager.Manager) error {
+	projectFile := filepath.Base(getConfig().AutoSwitch.ProjectFile)
+	_, err := os.Stat(getConfig().AutoSwitch.ProjectFile)
+	superseded := err == nil
+
+	legacy, err := mgr.MigrateLegacyProjectFile()
+	if err != nil {
+		cmd.SilenceUsage = true
+		return err
+	}
+	switch {
+	case legacy == "":
+		_logger.Info("No deprecated .govman-version to migrate")
+	case superseded:
+		_logger.Success("Removed %s; %s sets the version", filepath.Base(legacy), projectFile)
+	default:
+		_logger.Success("Renamed %s to %s", filepath.Base(legacy), projectFile)
+	}
+	return nil
+}
@@ -0,0 +1,61 @@
+package cli
+
+import (
+	"errors"
+
+	cobra "github.com/spf13/cobra"
+
+	_logger "github.com/justjundana/govman/internal/logger"
+	_manager "github.com/justjundana/govman/internal/manager"
+)
+
+// newPinCmd creates the 'pin' Cobra command that writes the exact active version to the project version file.
+// With --minor it writes only the major.minor line. Returns a *cobra.Command.
+func newPinCmd() *cobra.Command {
+	var minor bool
+
+	cmd := &cobra.Command{
+		Use:   "pin",
+		Short: "Pin the project to the exact Go version in use",
+		Long: `Write the Go version you are using right now to .govman-goversion, so every machine uses the same one.
+
+Unlike 'govman use --local <version>', which writes the version as typed (possibly
+a partial like 1.22), pin resolves the active version to its exact patch first.
+
+Behavior:
+  • The active version is resolved as 'govman current' does, including the project file itself
+  • --minor writes only major.minor, which matches the newest installed patch of that line
+  • The file is written in the current directory; PATH is not changed
+
+Examples:
+  govman pin                         # e.g. writes 1.22.5
+  govman pin --minor                 # e.g. writes 1.22`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+			mgr := _manager.New(getConfig())
+
+			version, err := mgr.Pin(minor)
+			if err != nil {
+				if errors.Is(err, _manager.ErrNotInstalled) {
+					_logger.ErrorWithHelp("The Go version this project asks for is not installed", "Install it first, or pick another version with 'govman use <version>'.")
+				} else {
+					_logger.ErrorWithHelp("Unable to pin the active Go version", "Check 'govman current', and that you can write to the current directory.")
+				}
+				return err
+			}
+
+			_logger.Success("Pinned Go %s in %s", version, getConfig().AutoSwitch.ProjectFile)
+			if minor {
+				_logger.Info("The newest installed Go %s.x patch will be used in this project", version)
+			} else {
+				_logger.Info("Commit the file so every machine uses exactly Go %s", version)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&minor, "minor", false, "Write only the major.minor version for flexible patch matching")
+
+	return cmd
+}
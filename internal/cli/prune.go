@@ -2,20 +2,26 @@ package cli
 
 import (
 	"fmt"
+	"slices"
 	"strings"
 
 	cobra "github.com/spf13/cobra"
 
+	_golang "github.com/justjundana/govman/internal/golang"
 	_logger "github.com/justjundana/govman/internal/logger"
 	_manager "github.com/justjundana/govman/internal/manager"
 	_util "github.com/justjundana/govman/internal/util"
 )
 
 // newPruneCmd creates the 'prune' Cobra command to remove all unused Go versions.
-// It keeps the currently active version, the system default, and the local project version.
+// It keeps the currently active version, the system default, the local project version, and those --keep retains.
 // Returns a *cobra.Command that prunes unused versions and reports freed disk space.
 func newPruneCmd() *cobra.Command {
-	var skipConfirm bool
+	var (
+		skipConfirm bool
+		dryRun      bool
+		policy      _manager.PrunePolicy
+	)
 
 	cmd := &cobra.Command{
 		Use:   "prune",
@@ -26,88 +32,91 @@ Protected versions (will NOT be removed):
   • Currently active version (session or global)
   • System default version (from config)
   • Project-local version (from .govman-goversion)
+  • With --keep <n>, the n highest versions, or the n highest of each
+    minor line with --keep-per-minor
 
 This is a convenient way to reclaim disk space by removing
 versions you no longer need, without manually identifying them.
 
 Examples:
-  govman prune              # Interactive confirmation
-  govman prune --yes        # Skip confirmation prompt`,
+  govman prune                           # Interactive confirmation
+  govman prune --yes                     # Skip confirmation prompt
+  govman prune --dry-run                 # Preview without removing anything
+  govman prune --keep 3                  # Also keep the 3 highest versions
+  govman prune --keep 2 --keep-per-minor # Keep the 2 newest patches of each minor line`,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			mgr := _manager.New(getConfig())
-
-			// Get all installed versions
-			installed, err := mgr.ListInstalled()
-			if err != nil {
-				_logger.ErrorWithHelp("Unable to list installed versions", "Verify that ~/.govman/versions exists and you have sufficient permissions.", "")
-				return fmt.Errorf("failed to list installed versions: %w", err)
+			if policy.Keep < 0 {
+				return fmt.Errorf("--keep must not be negative, got %d", policy.Keep)
 			}
-
-			if len(installed) == 0 {
-				_logger.Info("No Go versions are installed")
-				return nil
+			if policy.PerMinor && policy.Keep == 0 {
+				return fmt.Errorf("--keep-per-minor requires --keep")
 			}
 
-			// Determine which versions are protected
-			protected := make(map[string]string) // version -> reason
-
-			// Currently active version
-			if current, err := mgr.Current(); err == nil && current != "" {
-				protected[current] = "currently active"
-			}
-
-			// System default version
-			if defaultVersion := mgr.DefaultVersion(); defaultVersion != "" {
-				if _, exists := protected[defaultVersion]; !exists {
-					protected[defaultVersion] = "system default"
+			// Lock before planning, so the plan still holds when it is carried out
+			if !dryRun {
+				unlock, err := lockHome(cmd)
+				if err != nil {
+					return err
 				}
+				defer unlock()
 			}
 
-			// Local project version (from .govman-goversion)
-			cfg := getConfig()
-			if cfg != nil && cfg.AutoSwitch.ProjectFile != "" {
-				localVersion := mgr.GetLocalVersionRaw()
-				if localVersion != "" {
-					// Find the best matching installed version for partial versions
-					for _, v := range installed {
-						if v == localVersion || strings.HasPrefix(v, localVersion) {
-							if _, exists := protected[v]; !exists {
-								protected[v] = "project-local (.govman-goversion)"
-							}
-						}
-					}
-				}
+			mgr := _manager.New(getConfig())
+
+			// Work out what would be removed without touching anything
+			plan, err := mgr.PlanPrune(policy)
+			if err != nil {
+				_logger.ErrorWithHelp("Unable to list installed versions", "Verify that ~/.govman/versions exists and you have sufficient permissions.")
+				return err
 			}
+			toRemove, protected := plan.Remove, plan.Protected
 
-			// Determine which versions to remove
-			var toRemove []string
-			for _, version := range installed {
-				if _, isProtected := protected[version]; !isProtected {
-					toRemove = append(toRemove, version)
-				}
+			if len(plan.Installed) == 0 {
+				_logger.Info("No Go versions are installed")
+				return nil
 			}
 
 			if len(toRemove) == 0 {
 				_logger.Success("No unused versions to prune")
-				_logger.Info("All %d installed version(s) are currently in use:", len(installed))
-				for version, reason := range protected {
-					_logger.Info("  • Go %s (%s)", version, reason)
+				_logger.Info("All %d installed version(s) are protected:", len(plan.Installed))
+				for _, version := range newestFirst(protected) {
+					_logger.Info("  • Go %s (%s)", version, protected[version])
 				}
 				return nil
 			}
 
-			// Show what will be removed
-			_logger.Info("Protected versions (will be kept):")
-			for version, reason := range protected {
-				_logger.Info("  ✓ Go %s (%s)", version, reason)
+			// Show what will be kept, separating versions in use from those only the policy keeps
+			inUse, retained := splitProtected(protected)
+			_logger.Info("Protected versions in use (will be kept):")
+			if len(inUse) == 0 {
+				_logger.Info("  (none)")
+			}
+			for _, version := range inUse {
+				_logger.Info("  ✓ Go %s (%s)", version, protected[version])
+			}
+			if policy.Keep > 0 {
+				_logger.Info("")
+				_logger.Info("Kept by the retention policy (%s):", describePolicy(policy))
+				if len(retained) == 0 {
+					_logger.Info("  (none beyond the versions in use)")
+				}
+				for _, version := range retained {
+					_logger.Info("  ✓ Go %s", version)
+				}
 			}
 			_logger.Info("")
 			_logger.Info("The following %d version(s) will be removed:", len(toRemove))
 			for _, version := range toRemove {
 				_logger.Info("  ✗ Go %s", version)
 			}
+			_logger.Info("Disk space to be freed: %s", _util.FormatBytes(plan.Reclaimable))
 			_logger.Info("")
 
+			if dryRun {
+				_logger.Info("Dry run: no versions were removed.")
+				return nil
+			}
+
 			// Ask for confirmation
 			if !skipConfirm {
 				if !confirmAction("Proceed with pruning?") {
@@ -120,49 +129,21 @@ Examples:
 			_logger.Info("Pruning %d unused Go version(s)...", len(toRemove))
 			_logger.Progress("Removing unused installations")
 
-			var errors []string
-			var successful []string
-			var totalFreedSpace int64
-
-			for i, version := range toRemove {
-				_logger.Info("[%d/%d] Removing Go %s...", i+1, len(toRemove), version)
-
-				// Get version info before uninstalling to track disk space
-				info, err := mgr.Info(version)
-				if err != nil {
-					_logger.Warning("Failed to get info for Go %s: %v", version, err)
-					errors = append(errors, fmt.Sprintf("Go %s: %v", version, err))
-					continue
-				}
-
-				// Perform uninstallation
-				if err := mgr.Uninstall(version); err != nil {
-					_logger.Warning("Failed to remove Go %s: %v", version, err)
-					errors = append(errors, fmt.Sprintf("Go %s: %v", version, err))
-					continue
-				}
-
-				successful = append(successful, version)
-				totalFreedSpace += info.Size
-				_logger.Success("Removed Go %s", version)
-			}
+			removed, freed, pruneErr := mgr.ApplyPrune(plan)
 
 			_logger.Info(strings.Repeat("─", 50))
 
-			if len(successful) > 0 {
-				_logger.Success("Successfully pruned %d version(s):", len(successful))
-				for _, version := range successful {
+			if len(removed) > 0 {
+				_logger.Success("Successfully pruned %d version(s):", len(removed))
+				for _, version := range removed {
 					_logger.Info("  • Go %s", version)
 				}
-				_logger.Info("Total disk space freed: %s", _util.FormatBytes(totalFreedSpace))
+				_logger.Info("Total disk space freed: %s", _util.FormatBytes(freed))
 			}
 
-			if len(errors) > 0 {
-				_logger.ErrorWithHelp("Failed to remove %d version(s):", "Review the errors below and address any issues.", len(errors))
-				for _, err := range errors {
-					_logger.Info("  %s", err)
-				}
-				return fmt.Errorf("failed to prune %d version(s)", len(errors))
+			if pruneErr != nil {
+				_logger.ErrorWithHelp("Failed to remove %d version(s)", "Review the warnings above and address any issues.", len(toRemove)-len(removed))
+				return pruneErr
 			}
 
 			_logger.Success("Pruning completed successfully!")
@@ -178,6 +159,42 @@ Examples:
 	}
 
 	cmd.Flags().BoolVarP(&skipConfirm, "yes", "y", false, "Skip confirmation prompt")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Show what would be removed without removing anything")
+	cmd.Flags().IntVar(&policy.Keep, "keep", 0, "Also keep the n highest installed versions")
+	cmd.Flags().BoolVar(&policy.PerMinor, "keep-per-minor", false, "Apply --keep to each major.minor line instead of to all versions")
 
 	return cmd
 }
+
+// newestFirst returns the versions in protected sorted from newest to oldest.
+func newestFirst(protected map[string]string) []string {
+	versions := make([]string, 0, len(protected))
+	for version := range protected {
+		versions = append(versions, version)
+	}
+	slices.SortFunc(versions, func(a, b string) int {
+		return _golang.CompareVersions(b, a)
+	})
+	return versions
+}
+
+// splitProtected splits the versions in protected, newest first, into those kept because they are in use
+// and those kept only by the retention policy.
+func splitProtected(protected map[string]string) (inUse, retained []string) {
+	for _, version := range newestFirst(protected) {
+		if strings.HasPrefix(protected[version], _manager.RetainedReason) {
+			retained = append(retained, version)
+		} else {
+			inUse = append(inUse, version)
+		}
+	}
+	return inUse, retained
+}
+
+// describePolicy returns a short description of a retention policy for the prune preview.
+func describePolicy(policy _manager.PrunePolicy) string {
+	if policy.PerMinor {
+		return fmt.Sprintf("the %d highest of each minor line", policy.Keep)
+	}
+	return fmt.Sprintf("the %d highest versions", policy.Keep)
+}
@@ -2,13 +2,12 @@ package cli
 
 import (
 	"fmt"
-	"os"
-	"strings"
 
 	cobra "github.com/spf13/cobra"
 
 	_logger "github.com/justjundana/govman/internal/logger"
 	_manager "github.com/justjundana/govman/internal/manager"
+	_util "github.com/justjundana/govman/internal/util"
 )
 
 // newRefreshCmd creates the 'refresh' Cobra command to re-evaluate the current directory for a .govman-goversion file.
@@ -23,29 +22,21 @@ Purpose:
   • Re-evaluate the current directory for .govman-goversion files
   • Switch to the appropriate version (local or default)
   • Useful after adding/removing .govman-goversion files
+  • Offers to rename a legacy .govman-version file to .govman-goversion
 
 Examples:
   govman refresh                    # Re-evaluate current directory
 
 Behavior:
-  • If .govman-goversion exists: switch to that version
-  • If no .govman-goversion: switch to default version
+  • If a project version file exists: switch to that version
+    (.govman-goversion, then .go-version, then the go directive in go.mod)
+  • If no project version file: switch to default version
   • Equivalent to the auto-switch that happens on 'cd'`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			mgr := _manager.New(getConfig())
 
-			cfg := getConfig()
-			filename := cfg.AutoSwitch.ProjectFile
-			if data, err := os.ReadFile(filename); err == nil {
-				version := strings.TrimSpace(string(data))
-
+			if filename, version := mgr.FindProjectVersionFile(); filename != "" {
 				// Validate version format
-				if version == "" {
-					_logger.Warning("Empty version file: %s", filename)
-					_logger.Info("Switching to default Go version")
-					return mgr.Use("default", false, false)
-				}
-
 				if !_manager.VersionFormatRegex.MatchString(version) {
 					_logger.ErrorWithHelp("Invalid version format in %s: %s", "Version should be like '1.25', '1.25.4', or 'latest'", filename, version)
 					return fmt.Errorf("invalid version format: %s", version)
@@ -54,19 +45,33 @@ Behavior:
 				_logger.Info("Found local version file: %s", filename)
 				_logger.Info("Switching to Go %s", version)
 
+				if !mgr.IsInstalled(version) {
+					// Partial versions such as a go.mod directive match the newest installed patch
+					if installed, err := mgr.ListInstalled(); err == nil {
+						if matched, err := _util.FindBestMatchingVersion(version, installed); err == nil {
+							_logger.Verbose("Resolved %s to installed version %s", version, matched)
+							version = matched
+						}
+					}
+				}
+
 				if !mgr.IsInstalled(version) {
 					helpMsg := fmt.Sprintf("Install it first with 'govman install %s'", version)
 					_logger.ErrorWithHelp("Go version %s is not installed", helpMsg, version)
 					return fmt.Errorf("version %s not installed", version)
 				}
 
-				return mgr.Use(version, false, false)
+				if err := mgr.Use(version, false, false, false); err != nil {
+					return err
+				}
+				hintLegacyMigration(mgr)
+				return nil
 			}
 
 			_logger.Info("No local version file found")
 			_logger.Info("Switching to default Go version")
 
-			return mgr.Use("default", false, false)
+			return mgr.Use("default", false, false, false)
 		},
 	}
 
@@ -1,6 +1,8 @@
 package cli
 
 import (
+	"cmp"
+	"crypto/sha256"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -8,6 +10,7 @@ import (
 	"os"
 	"path/filepath"
 	"runtime"
+	"strconv"
 	"strings"
 	"time"
 
@@ -29,12 +32,7 @@ type GitHubRelease struct {
 	Prerelease  bool      `json:"prerelease"`
 }
 
-// selfUpdateHTTPClient is a shared HTTP client for connection reuse
-var selfUpdateHTTPClient = &http.Client{
-	Timeout: 30 * time.Second,
-}
-
-// newSelfUpdateCmd creates the 'selfupdate' Cobra command.
+// newSelfUpdateCmd creates the 'selfupdate' Cobra command (also available as 'self-update').
 // It defines flags: checkOnly (only check for updates), force (reinstall even if on latest),
 // and prerelease (include pre-release versions). Returns the configured *cobra.Command that runs runSelfUpdate.
 func newSelfUpdateCmd() *cobra.Command {
@@ -45,24 +43,28 @@ func newSelfUpdateCmd() *cobra.Command {
 	)
 
 	cmd := &cobra.Command{
-		Use:   "selfupdate",
-		Short: "Update govman to the latest version with smart management",
+		Use:     "selfupdate",
+		Aliases: []string{"self-update"},
+		Short:   "Update govman to the latest version with smart management",
 		Long: `Automatically check for and install the latest version of govman.
 
 Smart Update Features:
   • Automatic platform detection and binary selection
-  • Safe backup and rollback on failure
-  • Integrity verification and secure downloads
+  • SHA-256 verification against the release checksums
+  • Atomic replacement of the running binary
+  • Uses the configured network proxy and timeouts
   • Support for stable and pre-release versions
-  • Non-disruptive updates with permission handling
   • Detailed release notes and changelog display
 
 Examples:
   govman selfupdate                    # Update to latest stable
-  govman selfupdate --check            # Check without installing
+  govman self-update --check           # Only report whether an update exists
   govman selfupdate --prerelease       # Include pre-releases
   govman selfupdate --force            # Force update even if latest`,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := requireOnline(cmd, "Self-update"); err != nil {
+				return err
+			}
 			return runSelfUpdate(checkOnly, force, prerelease)
 		},
 	}
@@ -74,22 +76,31 @@ Examples:
 	return cmd
 }
 
+// newSelfUpdateClient builds the HTTP client for self-update requests, honoring the same proxy
+// and timeout settings as Go version downloads.
+func newSelfUpdateClient() *http.Client {
+	cfg := getConfig()
+	return cfg.HTTPClient(cfg.Download.Timeout)
+}
+
 // runSelfUpdate orchestrates the self-update workflow.
 // Parameters: checkOnly (perform a dry run and do not install), force (reinstall even if already on latest),
 // prerelease (include pre-release versions when checking). Returns nil on success or an error if any step fails.
 func runSelfUpdate(checkOnly, force, prerelease bool) error {
+	client := newSelfUpdateClient()
+
 	_logger.Info("Checking for govman updates...")
 	_logger.Progress("Contacting GitHub API for latest release information")
 
 	_logger.Verbose("Retrieving latest release information from GitHub")
-	latest, err := getLatestRelease(prerelease)
+	latest, err := getLatestRelease(client, prerelease)
 	if err != nil {
-		_logger.ErrorWithHelp("Unable to fetch update information", "Verify your internet connection and that GitHub API is accessible.", "")
+		_logger.ErrorWithHelp("Unable to fetch update information", "Verify your internet connection and that GitHub API is accessible.")
 		return fmt.Errorf("failed to check for updates: %w", err)
 	}
 
 	current := _version.BuildVersion()
-	if current == "dev" {
+	if _version.Version == "dev" {
 		_logger.Warning("Development version detected - updates are not available")
 		_logger.Info("You're using a development build. Update manually from source.")
 		return nil
@@ -103,14 +114,10 @@ func runSelfUpdate(checkOnly, force, prerelease bool) error {
 		_logger.Info("  Released: %s", latest.PublishedAt.Format("January 2, 2006"))
 	}
 
-	if !force && latest.TagName == current {
-		_logger.Success("You are already using the latest version!")
-		_logger.Info("Use --force to reinstall the current version")
-		return nil
-	}
+	updateAvailable := compareReleaseVersions(latest.TagName, current) > 0
 
 	if checkOnly {
-		if latest.TagName != current {
+		if updateAvailable {
 			_logger.Info("A new version is available: %s → %s", current, latest.TagName)
 			if latest.Body != "" {
 				_logger.Info("Release Notes:")
@@ -125,34 +132,50 @@ func runSelfUpdate(checkOnly, force, prerelease bool) error {
 		return nil
 	}
 
+	if !force && !updateAvailable {
+		_logger.Success("You are already using the latest version!")
+		_logger.Info("Use --force to reinstall the current version")
+		return nil
+	}
+
+	_logger.Verbose("Getting current binary path")
+	currentBinary, err := os.Executable()
+	if err != nil {
+		_logger.ErrorWithHelp("Failed to get current binary path", "Check if the binary has proper permissions.")
+		return fmt.Errorf("failed to get current binary path: %w", err)
+	}
+	if resolved, err := filepath.EvalSymlinks(currentBinary); err == nil {
+		currentBinary = resolved
+	}
+
+	// Refuse early, before downloading anything, if the binary cannot be replaced
+	binaryDir := filepath.Dir(currentBinary)
+	if err := checkWritable(binaryDir); err != nil {
+		_logger.ErrorWithHelp("Cannot replace %s", elevationHint(), currentBinary)
+		return fmt.Errorf("binary directory %s is not writable: %w", binaryDir, err)
+	}
+
 	assetName := fmt.Sprintf("govman-%s-%s", runtime.GOOS, runtime.GOARCH)
 	if runtime.GOOS == "windows" {
 		assetName += ".exe"
 	}
 
-	var downloadURL string
-	for _, asset := range latest.Assets {
-		if strings.Contains(asset.Name, assetName) {
-			downloadURL = asset.DownloadURL
-			break
-		}
-	}
-
+	downloadURL := findAssetURL(latest, assetName)
 	if downloadURL == "" {
 		return fmt.Errorf("no binary found for %s/%s", runtime.GOOS, runtime.GOARCH)
 	}
 
-	_logger.Download("Downloading %s...", latest.TagName)
-
-	_logger.Verbose("Downloading binary")
-	resp, err := selfUpdateHTTPClient.Get(downloadURL)
+	_logger.Verbose("Retrieving release checksums")
+	expectedSHA256, err := getReleaseChecksum(client, latest, assetName)
 	if err != nil {
-		_logger.ErrorWithHelp("Failed to download binary", "Check your internet connection and try again.", "")
-		return fmt.Errorf("failed to download binary: %w", err)
+		_logger.ErrorWithHelp("Unable to verify the release", "The release does not publish a usable checksum for this platform; update manually instead.")
+		return fmt.Errorf("failed to get checksum for %s: %w", assetName, err)
 	}
-	defer resp.Body.Close()
 
-	tempFile, err := os.CreateTemp("", "govman-update-*.bin")
+	_logger.Download("Downloading %s...", latest.TagName)
+
+	// Download next to the current binary so the final rename stays on one filesystem and is atomic
+	tempFile, err := os.CreateTemp(binaryDir, ".govman-update-*")
 	if err != nil {
 		return fmt.Errorf("failed to create temporary file: %w", err)
 	}
@@ -164,84 +187,265 @@ func runSelfUpdate(checkOnly, force, prerelease bool) error {
 		}
 	}()
 
-	_, err = io.Copy(tempFile, resp.Body)
+	actualSHA256, err := downloadAsset(client, downloadURL, tempFile)
+	if closeErr := tempFile.Close(); err == nil && closeErr != nil {
+		err = fmt.Errorf("failed to close temporary file: %w", closeErr)
+	}
 	if err != nil {
-		return fmt.Errorf("failed to write binary to temporary file: %w", err)
+		_logger.ErrorWithHelp("Failed to download binary", "Check your internet connection and try again.")
+		return err
 	}
 
-	if err := tempFile.Close(); err != nil {
-		return fmt.Errorf("failed to close temporary file: %w", err)
+	_logger.Verify("Verifying checksum...")
+	if actualSHA256 != expectedSHA256 {
+		return fmt.Errorf("checksum mismatch for %s: expected %s, got %s", assetName, expectedSHA256, actualSHA256)
 	}
+	_logger.Success("Checksum verified")
 
-	_logger.Verbose("Getting current binary path")
-	currentBinary, err := os.Executable()
-	if err != nil {
-		_logger.ErrorWithHelp("Failed to get current binary path", "Check if the binary has proper permissions.", "")
-		return fmt.Errorf("failed to get current binary path: %w", err)
+	_logger.Verbose("Setting executable permissions")
+	if err := os.Chmod(tempFilePath, 0755); err != nil {
+		return fmt.Errorf("failed to set executable permission for new binary: %w", err)
+	}
+
+	_logger.Verbose("Installing new binary")
+	if err := replaceBinary(tempFilePath, currentBinary); err != nil {
+		return err
+	}
+
+	_logger.Success("Update completed successfully!")
+	return nil
+}
+
+// replaceBinary moves newBinary over currentBinary.
+// On Unix this is a single atomic rename; on Windows the running binary is locked, so it is first moved
+// to a backup that the next startup cleans up. Returns an error if the binary could not be replaced.
+func replaceBinary(newBinary, currentBinary string) error {
+	if runtime.GOOS != "windows" {
+		if err := os.Rename(newBinary, currentBinary); err != nil {
+			return fmt.Errorf("failed to move downloaded binary to current binary path: %w", err)
+		}
+		return nil
 	}
 
 	_logger.Verbose("Creating backup of current binary")
 	backupBinary := currentBinary + ".bak." + fmt.Sprintf("%d", time.Now().Unix())
 	if err := os.Rename(currentBinary, backupBinary); err != nil {
-		_logger.ErrorWithHelp("Failed to create backup of current binary", "Check if you have permission to modify the binary directory.", "")
+		_logger.ErrorWithHelp("Failed to create backup of current binary", "Check if you have permission to modify the binary directory.")
 		return fmt.Errorf("failed to rename current binary to backup: %w", err)
 	}
 
-	_logger.Verbose("Installing new binary")
-	if err := os.Rename(tempFile.Name(), currentBinary); err != nil {
+	if err := os.Rename(newBinary, currentBinary); err != nil {
 		// Failed to install new binary, restore backup
 		_logger.Warning("Failed to install new binary, restoring backup")
 		if restoreErr := os.Rename(backupBinary, currentBinary); restoreErr != nil {
-			_logger.ErrorWithHelp("Failed to restore backup binary", "You may need to manually restore the binary from the backup file.", "")
+			_logger.ErrorWithHelp("Failed to restore backup binary", "You may need to manually restore the binary from the backup file.")
 			return fmt.Errorf("failed to restore backup binary: %w", restoreErr)
 		}
 		return fmt.Errorf("failed to move downloaded binary to current binary path: %w", err)
 	}
 
-	_logger.Verbose("Setting executable permissions")
-	if err := os.Chmod(currentBinary, 0755); err != nil {
-		_logger.ErrorWithHelp("Failed to set executable permissions", "You may need to manually set executable permissions on the binary.", "")
-		return fmt.Errorf("failed to set executable permission for new binary: %w", err)
+	// The running process locks the backup file, so cleanup happens on next startup
+	_logger.Verbose("Skipping backup cleanup on Windows - will clean up on next startup")
+	return nil
+}
+
+// checkWritable reports whether files can be created in dir by creating and removing a probe file.
+func checkWritable(dir string) error {
+	probe, err := os.CreateTemp(dir, ".govman-write-test-*")
+	if err != nil {
+		return err
 	}
+	probe.Close()
+	return os.Remove(probe.Name())
+}
 
-	// Clean up backup files after successful update
-	// On Windows, the running process locks the backup file, so we skip
-	// cleanup here and let the startup routine handle it on next run.
+// elevationHint returns platform-specific guidance for updating a binary installed in a protected directory.
+func elevationHint() string {
 	if runtime.GOOS == "windows" {
-		_logger.Verbose("Skipping backup cleanup on Windows - will clean up on next startup")
-	} else {
-		_logger.Verbose("Cleaning up backup files")
-		os.Remove(backupBinary)
-
-		// Clean up any old backup files
-		dir := filepath.Dir(currentBinary)
-		baseName := filepath.Base(currentBinary)
-		entries, _ := os.ReadDir(dir)
-		for _, entry := range entries {
-			if strings.HasPrefix(entry.Name(), baseName+".bak.") {
-				oldBackup := filepath.Join(dir, entry.Name())
-				os.Remove(oldBackup)
-				_logger.Verbose("Removed old backup: %s", entry.Name())
+		return "Re-run 'govman selfupdate' from a terminal started with 'Run as administrator', or reinstall govman to a user-writable directory."
+	}
+	return "Re-run with elevated permissions: 'sudo govman selfupdate', or reinstall govman to a user-writable directory such as ~/.govman/bin."
+}
+
+// findAssetURL returns the download URL of the release asset named exactly name, or an empty string.
+func findAssetURL(release *GitHubRelease, name string) string {
+	for _, asset := range release.Assets {
+		if asset.Name == name {
+			return asset.DownloadURL
+		}
+	}
+	return ""
+}
+
+// getReleaseChecksum returns the expected SHA-256 of assetName, read from either an "<asset>.sha256" file
+// or a checksums.txt manifest attached to the release. Returns an error if neither lists the asset.
+func getReleaseChecksum(client *http.Client, release *GitHubRelease, assetName string) (string, error) {
+	var sources []string
+	if url := findAssetURL(release, assetName+".sha256"); url != "" {
+		sources = append(sources, url)
+	}
+	for _, asset := range release.Assets {
+		if strings.HasSuffix(asset.Name, "checksums.txt") {
+			sources = append(sources, asset.DownloadURL)
+		}
+	}
+	if len(sources) == 0 {
+		return "", fmt.Errorf("release %s has no checksum file", release.TagName)
+	}
+
+	for _, url := range sources {
+		data, err := fetch(client, url)
+		if err != nil {
+			return "", err
+		}
+		if sum := parseChecksum(string(data), assetName); sum != "" {
+			return sum, nil
+		}
+	}
+
+	return "", fmt.Errorf("no checksum listed for %s", assetName)
+}
+
+// parseChecksum finds the SHA-256 for assetName in sha256sum-style content ("<hash>  <name>" per line).
+// A single bare hash is accepted for per-asset checksum files. Returns the lowercase hash or an empty string.
+func parseChecksum(content, assetName string) string {
+	lines := strings.Split(strings.TrimSpace(content), "\n")
+	for _, line := range lines {
+		fields := strings.Fields(line)
+		switch {
+		case len(fields) == 1 && len(lines) == 1:
+			return strings.ToLower(fields[0])
+		case len(fields) >= 2 && strings.TrimPrefix(fields[1], "*") == assetName:
+			return strings.ToLower(fields[0])
+		}
+	}
+	return ""
+}
+
+// fetch performs a GET request and returns the response body, or an error for non-200 responses.
+func fetch(client *http.Client, url string) ([]byte, error) {
+	resp, err := client.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("GET %s returned status %d: %s", url, resp.StatusCode, resp.Status)
+	}
+
+	return io.ReadAll(resp.Body)
+}
+
+// downloadAsset streams url into dst while hashing it. Returns the hex SHA-256 of the downloaded bytes.
+func downloadAsset(client *http.Client, url string, dst io.Writer) (string, error) {
+	resp, err := client.Get(url)
+	if err != nil {
+		return "", fmt.Errorf("failed to download binary: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("failed to download binary: server returned status %d: %s", resp.StatusCode, resp.Status)
+	}
+
+	hasher := sha256.New()
+	if _, err := io.Copy(io.MultiWriter(dst, hasher), resp.Body); err != nil {
+		return "", fmt.Errorf("failed to write binary to temporary file: %w", err)
+	}
+
+	return fmt.Sprintf("%x", hasher.Sum(nil)), nil
+}
+
+// compareReleaseVersions compares two semantic version tags such as "v1.4.0" or "1.5.0-rc.1".
+// Build metadata and git-describe suffixes after a "+" are ignored, and a prerelease sorts before its release.
+// Returns 1 if a is newer, -1 if b is newer, and 0 if they are equal.
+func compareReleaseVersions(a, b string) int {
+	aCore, aPre := splitReleaseVersion(a)
+	bCore, bPre := splitReleaseVersion(b)
+
+	for i := 0; i < max(len(aCore), len(bCore)); i++ {
+		var x, y int
+		if i < len(aCore) {
+			x = aCore[i]
+		}
+		if i < len(bCore) {
+			y = bCore[i]
+		}
+		if x != y {
+			if x > y {
+				return 1
 			}
+			return -1
 		}
 	}
 
-	_logger.Success("Update completed successfully!")
-	return nil
+	switch {
+	case aPre == bPre:
+		return 0
+	case aPre == "":
+		return 1
+	case bPre == "":
+		return -1
+	default:
+		return comparePrerelease(aPre, bPre)
+	}
+}
+
+// comparePrerelease compares two prerelease suffixes by their dot-separated identifiers as semantic versioning does:
+// numeric identifiers numerically ("rc.10" is after "rc.9") and before alphanumeric ones, others lexically, and a
+// shorter suffix before a longer one it prefixes. Returns 1 if a is newer, -1 if b is newer, and 0 if they are equal.
+func comparePrerelease(a, b string) int {
+	aIDs, bIDs := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < min(len(aIDs), len(bIDs)); i++ {
+		x, xErr := strconv.Atoi(aIDs[i])
+		y, yErr := strconv.Atoi(bIDs[i])
+		switch {
+		case xErr == nil && yErr == nil:
+			if x != y {
+				return cmp.Compare(x, y)
+			}
+		case xErr == nil:
+			return -1
+		case yErr == nil:
+			return 1
+		default:
+			if c := strings.Compare(aIDs[i], bIDs[i]); c != 0 {
+				return c
+			}
+		}
+	}
+	return cmp.Compare(len(aIDs), len(bIDs))
+}
+
+// splitReleaseVersion splits a version tag into its numeric components and prerelease suffix.
+func splitReleaseVersion(version string) ([]int, string) {
+	version = strings.TrimPrefix(strings.TrimSpace(version), "v")
+	version, _, _ = strings.Cut(version, "+")
+	core, pre, _ := strings.Cut(version, "-")
+
+	var parts []int
+	for _, field := range strings.Split(core, ".") {
+		n, err := strconv.Atoi(field)
+		if err != nil {
+			break
+		}
+		parts = append(parts, n)
+	}
+
+	return parts, pre
 }
 
-// getLatestRelease queries GitHub for release information.
-// Parameter includePrerelease: when true, it reads the releases list (including prereleases) and returns
-// the first eligible release; otherwise it fetches the latest stable release endpoint.
-// Returns a *GitHubRelease on success or an error if the request or JSON parsing fails.
-func getLatestRelease(includePrerelease bool) (*GitHubRelease, error) {
+// getLatestRelease queries GitHub for the latest stable release, or with includePrerelease the first eligible one
+// in the releases list. Returns a *GitHubRelease or an error if the request or JSON parsing fails.
+func getLatestRelease(client *http.Client, includePrerelease bool) (*GitHubRelease, error) {
 	cfg := getConfig()
 	url := cfg.SelfUpdate.GitHubAPIURL
 	if includePrerelease {
 		url = cfg.SelfUpdate.GitHubReleasesURL
 	}
 
-	resp, err := selfUpdateHTTPClient.Get(url)
+	resp, err := client.Get(url)
 	if err != nil {
 		return nil, err
 	}
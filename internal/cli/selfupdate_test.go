@@ -0,0 +1,57 @@
+package cli
+
+import "testing"
+
+func TestCompareReleaseVersions(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"v1.4.0", "1.4.0", 0},
+		{"v1.5.0", "v1.4.9", 1},
+		{"v1.4", "v1.4.0", 0},
+		{"v1.10.0", "v1.9.0", 1},
+		{"v1.5.0", "v1.5.0-rc.1", 1},
+		{"v1.5.0-rc.1", "v1.5.0", -1},
+		{"v1.5.0-rc.10", "v1.5.0-rc.9", 1},
+		{"v1.5.0-rc.2", "v1.5.0-rc.10", -1},
+		{"v1.5.0-beta.1", "v1.5.0-rc.1", -1},
+		{"v1.5.0-rc.1", "v1.5.0-rc.1.1", -1},
+		{"v1.5.0-1", "v1.5.0-rc", -1},
+		{"v1.5.0-alpha", "v1.5.0-1", 1},
+		{"v1.5.0+build.7", "v1.5.0", 0},
+		{"v1.5.0-rc.1+abc", "v1.5.0-rc.1+def", 0},
+	}
+
+	for _, tt := range tests {
+		if got := compareReleaseVersions(tt.a, tt.b); got != tt.want {
+			t.Errorf("compareReleaseVersions(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestParseChecksum(t *testing.T) {
+	const sum = "3f786850e387550fdab836ed7e6dc881de23001b0c44a1f3a4b9e0e4d0e6ad1f"
+	tests := []struct {
+		name    string
+		content string
+		asset   string
+		want    string
+	}{
+		{name: "bare checksum", content: sum + "\n", asset: "govman-linux-amd64", want: sum},
+		{name: "upper case", content: "3F786850E387550FDAB836ED7E6DC881DE23001B0C44A1F3A4B9E0E4D0E6AD1F", asset: "govman-linux-amd64", want: sum},
+		{name: "sha256sum listing", content: "0000  govman-darwin-arm64\n" + sum + "  govman-linux-amd64\n", asset: "govman-linux-amd64", want: sum},
+		{name: "binary mode marker", content: sum + " *govman-windows-amd64.exe\n", asset: "govman-windows-amd64.exe", want: sum},
+		{name: "asset missing from listing", content: sum + "  govman-linux-amd64\n" + sum + "  govman-linux-arm64\n", asset: "govman-darwin-amd64", want: ""},
+		{name: "bare checksum among several lines", content: sum + "\n" + sum + "\n", asset: "govman-linux-amd64", want: ""},
+		{name: "empty", content: "", asset: "govman-linux-amd64", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parseChecksum(tt.content, tt.asset); got != tt.want {
+				t.Errorf("parseChecksum() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
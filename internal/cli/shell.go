@@ -0,0 +1,97 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	cobra "github.com/spf13/cobra"
+
+	_logger "github.com/justjundana/govman/internal/logger"
+	_manager "github.com/justjundana/govman/internal/manager"
+	_shell "github.com/justjundana/govman/internal/shell"
+)
+
+// newShellCmd creates the 'shell' Cobra command that starts an interactive subshell with a Go version activated.
+// Returns a *cobra.Command that resolves the version like 'use', runs the detected shell, and returns its exit status.
+func newShellCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "shell <version>",
+		Short: "Start a subshell that uses a specific Go version",
+		Long: `Start a new interactive shell with a Go version activated, leaving the current shell untouched.
+
+Behavior:
+  • The version's bin directory comes first on PATH, with GOROOT set and GOTOOLCHAIN=local
+    (unless shell.pin_toolchain is off)
+  • Versions resolve like 'govman use': aliases, partial versions like 1.24, and default
+  • GOVMAN_SHELL_VERSION is set to the version, so a prompt can show the override
+  • Auto-switching is paused inside the subshell
+  • Type 'exit' to leave; the original shell is exactly as before
+  • The symlink, default version, and session version are never changed
+
+The shell is your current one ($SHELL when it matches). Shell integration written before
+'govman shell' existed lets the default version win again; rerun 'govman init --force'.
+
+Examples:
+  govman shell 1.22                # Work with Go 1.22 until you exit
+  govman shell latest              # Newest installed version
+  govman shell default             # The system default, even inside a project`,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: singleArgCompletion(completeInstalledVersions),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			// Past argument checks, failures are not usage mistakes
+			cmd.SilenceUsage = true
+
+			mgr := _manager.New(getConfig())
+
+			version := args[0]
+			if version == "default" {
+				version = mgr.DefaultVersion()
+				if version == "" {
+					_logger.ErrorWithHelp("No default Go version is set", "Set one with 'govman use <version> --default', or pass a version.")
+					return fmt.Errorf("no default version is configured")
+				}
+			} else {
+				resolved, err := resolveInstalledVersion(mgr, version)
+				if err != nil {
+					return err
+				}
+				version = resolved
+			}
+
+			sh := _shell.Detect()
+			shellPath, err := _shell.Executable(sh)
+			if err != nil {
+				_logger.ErrorWithHelp("Unable to start %s", "Set SHELL to the shell you want to use.", sh.DisplayName())
+				return err
+			}
+
+			child, err := mgr.ShellCommand(cmd.Context(), version, shellPath)
+			if err != nil {
+				return err
+			}
+			child.Stdin, child.Stdout, child.Stderr = os.Stdin, os.Stdout, os.Stderr
+
+			if outer := os.Getenv(_shell.SubshellVersionEnv); outer != "" {
+				_logger.Info("Already in a Go %s subshell; exiting this one returns to it", outer)
+			}
+			_logger.Info("Starting %s with Go %s. Type 'exit' to return.", sh.DisplayName(), version)
+
+			err = runForeground(child)
+			var exitCode *ExitCodeError
+			if errors.As(err, &exitCode) {
+				// The shell's own status, usually of the last command run in it
+				cmd.SilenceErrors = true
+				return err
+			}
+			if err != nil {
+				return err
+			}
+
+			_logger.Info("Left the Go %s subshell", version)
+			return nil
+		},
+	}
+
+	return cmd
+}
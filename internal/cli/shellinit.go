@@ -0,0 +1,149 @@
+package cli
+
+import (
+	"fmt"
+
+	cobra "github.com/spf13/cobra"
+
+	_logger "github.com/justjundana/govman/internal/logger"
+	_shell "github.com/justjundana/govman/internal/shell"
+)
+
+// newShellInitCmd creates the 'shell-init' Cobra command that prints the shell integration lines for the
+// detected or given shell, and with --write adds them to the shell's configuration file unless already present.
+// With --remove it strips the govman block from that file instead. Returns a *cobra.Command.
+func newShellInitCmd() *cobra.Command {
+	var (
+		write     bool
+		remove    bool
+		shellName string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "shell-init",
+		Short: "Print or install the lines that put govman on PATH and enable auto-switching",
+		Long: `Print the exact lines your shell needs to put the govman bin directory on PATH
+and switch Go versions automatically, or add them for you with --write.
+
+Behavior:
+  • Without --write, the lines are printed to stdout, ready to paste or append
+  • With --write, they are appended to the shell's configuration file
+  • The lines are wrapped in "# >>> govman >>>" and "# <<< govman <<<" comments,
+    so an existing block is detected and running it again never adds a duplicate
+  • With --remove, exactly that block is deleted, wherever it is in the file;
+    everything outside it is left untouched
+
+Unlike 'govman hook', which only prints the auto-switch hook, and 'govman completion',
+which prints tab completion, this sets up everything govman needs in a new shell.
+
+Supported shells: bash, zsh, fish, nushell, powershell
+
+Examples:
+  govman shell-init                  # Print the lines for the detected shell
+  govman shell-init --write          # Add them to the shell's configuration file
+  govman shell-init --remove         # Remove them from the shell's configuration file
+  govman shell-init --shell zsh      # Print the lines for zsh
+  govman shell-init >> ~/.bashrc     # Append them yourself`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			sh := _shell.Detect()
+			if shellName != "" {
+				sh = getShellByName(shellName)
+				if sh == nil {
+					_logger.ErrorWithHelp("Unsupported shell: %s", "Supported shells: bash, zsh, fish, nushell, powershell.", shellName)
+					return fmt.Errorf("unsupported shell: %s", shellName)
+				}
+			}
+			cmd.SilenceUsage = true
+
+			binPath := getConfig().GetBinPath()
+			configFile := sh.ConfigFile()
+
+			// Command Prompt has no startup file to hold these lines
+			isCmd := sh.Name() == "cmd"
+
+			if remove {
+				if isCmd {
+					_logger.ErrorWithHelp("Command Prompt has no configuration file to remove from", "Delete the govman wrapper script instead.")
+					return fmt.Errorf("cannot remove shell integration for %s", sh.DisplayName())
+				}
+				return removeShellIntegration(sh)
+			}
+
+			configured := false
+			if !isCmd {
+				var err error
+				configured, err = _shell.IsInitialized(sh)
+				if err != nil {
+					_logger.ErrorWithHelp("Cannot read %s", fmt.Sprintf("Check the permissions of %s.", configFile), configFile)
+					return err
+				}
+			}
+
+			if !write {
+				for _, line := range sh.SetupCommands(binPath) {
+					fmt.Println(line)
+				}
+
+				switch {
+				case isCmd:
+					_logger.Info("Command Prompt has no startup file; run 'govman init' to install the govman wrapper instead")
+				case configured:
+					_logger.Info("%s is already configured in %s", sh.DisplayName(), configFile)
+				default:
+					_logger.Info("Add these lines to %s, or rerun with --write to add them for you", configFile)
+				}
+				return nil
+			}
+
+			if isCmd {
+				_logger.ErrorWithHelp("Command Prompt has no configuration file to write to", "Run 'govman init' to install the govman wrapper instead.")
+				return fmt.Errorf("cannot write shell integration for %s", sh.DisplayName())
+			}
+
+			if configured {
+				_logger.Success("%s is already configured in %s", sh.DisplayName(), configFile)
+				return nil
+			}
+
+			if err := _shell.InitializeShell(sh, binPath, false); err != nil {
+				_logger.ErrorWithHelp("Failed to write shell integration to %s", "Ensure you have write permissions to your shell configuration file and try again.", configFile)
+				return err
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&write, "write", false, "Append the lines to the shell's configuration file if govman is not already configured there")
+	cmd.Flags().BoolVar(&remove, "remove", false, "Remove the govman block from the shell's configuration file")
+	cmd.Flags().StringVar(&shellName, "shell", "", "Target specific shell (bash, zsh, fish, nushell, powershell)")
+
+	cmd.MarkFlagsMutuallyExclusive("write", "remove")
+
+	return cmd
+}
+
+// removeShellIntegration deletes the govman block from sh's configuration file and reports the outcome.
+// Warns when govman lines remain outside a marked block. Returns an error if the file cannot be updated.
+func removeShellIntegration(sh _shell.Shell) error {
+	configFile := sh.ConfigFile()
+
+	removed, err := _shell.RemoveIntegration(sh)
+	if err != nil {
+		_logger.ErrorWithHelp("Failed to remove shell integration from %s", fmt.Sprintf("Edit %s and delete the govman lines by hand.", configFile), configFile)
+		return err
+	}
+
+	if removed {
+		_logger.Success("Removed govman integration from %s", configFile)
+	} else {
+		_logger.Info("No govman block found in %s", configFile)
+	}
+
+	if configured, err := _shell.IsInitialized(sh); err == nil && configured {
+		_logger.Warning("%s still mentions govman outside a marked block; edit it by hand to finish", configFile)
+	}
+
+	return nil
+}
@@ -0,0 +1,91 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	cobra "github.com/spf13/cobra"
+
+	_logger "github.com/justjundana/govman/internal/logger"
+	_manager "github.com/justjundana/govman/internal/manager"
+)
+
+// newShimCmd creates the 'shim' Cobra command that lists, or with --rehash regenerates, the shims for tools
+// installed with 'go install'. Returns a *cobra.Command.
+func newShimCmd() *cobra.Command {
+	var rehash bool
+
+	cmd := &cobra.Command{
+		Use:   "shim",
+		Short: "Keep tools installed with 'go install' on PATH across version switches",
+		Long: `Create wrapper shims in the govman bin directory for tools installed with 'go install'.
+
+How it works:
+  • --rehash scans the active version's GOBIN (GOPATH/bin when unset) and writes a shim per tool
+  • The govman bin directory is already on PATH, so the tools stay available after 'govman use'
+  • A shim runs its tool with the Go version the tool was built with first on PATH, or with
+    whatever version is active when shims.version is set to active
+  • Shims whose tool was removed are deleted; files govman did not create are never touched
+  • Run 'govman shim --rehash' again after installing new tools
+
+Examples:
+  go install golang.org/x/tools/gopls@latest
+  govman shim --rehash              # Create or refresh shims for installed tools
+  govman shim                       # List the current shims`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			mgr := _manager.New(getConfig())
+
+			if !rehash {
+				shims, err := mgr.Shims()
+				if err != nil {
+					return err
+				}
+				if len(shims) == 0 {
+					_logger.Info("No shims yet; run 'govman shim --rehash' after installing tools with 'go install'")
+					return nil
+				}
+				printShims(shims)
+				return nil
+			}
+
+			unlock, err := lockHome(cmd)
+			if err != nil {
+				return err
+			}
+			defer unlock()
+
+			shims, removed, err := mgr.Rehash(cmd.Context())
+			if err != nil {
+				_logger.ErrorWithHelp("Failed to regenerate shims", "Activate a Go version with 'govman use' and make sure 'go env GOBIN GOPATH' works.")
+				return err
+			}
+
+			if len(removed) > 0 {
+				_logger.Info("Removed shims for tools that no longer exist: %s", strings.Join(removed, ", "))
+			}
+			if len(shims) == 0 {
+				_logger.Info("No tools found; install one with 'go install', then run 'govman shim --rehash' again")
+				return nil
+			}
+			_logger.Success("%d tool shim(s) up to date", len(shims))
+			printShims(shims)
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&rehash, "rehash", false, "Regenerate shims for the tools in the active version's GOBIN")
+
+	return cmd
+}
+
+// printShims writes one line per shim to stdout: its name, the tool it runs, and the Go version it was built with if known.
+func printShims(shims []_manager.Shim) {
+	for _, shim := range shims {
+		if shim.Version == "" {
+			fmt.Printf("%-20s %s\n", shim.Name, shim.Target)
+			continue
+		}
+		fmt.Printf("%-20s %s (built with Go %s)\n", shim.Name, shim.Target, shim.Version)
+	}
+}
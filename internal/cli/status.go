@@ -0,0 +1,98 @@
+package cli
+
+import (
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	cobra "github.com/spf13/cobra"
+
+	_logger "github.com/justjundana/govman/internal/logger"
+	_manager "github.com/justjundana/govman/internal/manager"
+	_util "github.com/justjundana/govman/internal/util"
+)
+
+// newStatusCmd creates the 'status' Cobra command to summarize the govman environment.
+// It returns a *cobra.Command that prints the active version, activation method, default and local versions,
+// PATH state, the resolved go binary, and total disk usage without modifying anything.
+func newStatusCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "status",
+		Short: "Show a read-only overview of your Go environment",
+		Long: `Summarize your govman environment in a single view.
+
+Information displayed:
+  • Active Go version and how it was activated
+  • Configured system default version
+  • Project-local version from .govman-goversion (if present)
+  • Whether the govman bin directory is on PATH
+  • The go binary your shell resolves
+  • Total disk space used by all installed versions
+
+This command is read-only and never changes your environment.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg := getConfig()
+			mgr := _manager.New(cfg)
+
+			_logger.Info("govman Status:")
+			_logger.Info(strings.Repeat("─", 50))
+
+			// One snapshot, so the version and how it was activated cannot disagree
+			state, err := mgr.CurrentDetailed()
+			if err == nil {
+				_logger.Info("Active Version:  Go %s", state.Version)
+				_logger.Info("Activation:      %s", state.Method)
+			} else {
+				_logger.Info("Active Version:  none")
+				_logger.Verbose("Could not determine active version: %v", err)
+			}
+
+			defaultVersion := state.Default
+			if defaultVersion == "" {
+				defaultVersion = "not set"
+			}
+			_logger.Info("Default Version: %s", defaultVersion)
+
+			localVersion := "none"
+			if state.LocalRaw != "" {
+				localVersion = state.LocalRaw + " (" + state.LocalFile + ")"
+			}
+			_logger.Info("Local Version:   %s", localVersion)
+
+			binPath := cfg.GetBinPath()
+			if isOnPath(binPath) {
+				_logger.Info("PATH:            %s is on PATH", binPath)
+			} else {
+				_logger.Info("PATH:            %s is NOT on PATH", binPath)
+			}
+
+			goBinary := "not found on PATH"
+			if path, err := exec.LookPath(cfg.GetBinaryName()); err == nil {
+				goBinary = path
+				if resolved, err := filepath.EvalSymlinks(path); err == nil && resolved != path {
+					goBinary = path + " -> " + resolved
+				}
+			}
+			_logger.Info("Go Binary:       %s", goBinary)
+
+			versions, err := mgr.ListInstalled()
+			if err != nil {
+				_logger.Verbose("Failed to list installed versions: %v", err)
+			}
+			var totalSize int64
+			for _, size := range mgr.VersionSizes(versions) {
+				totalSize += size
+			}
+			_logger.Info("Disk Usage:      %s across %d version(s)", _util.FormatBytes(totalSize), len(versions))
+
+			_logger.Info(strings.Repeat("─", 50))
+			if !isOnPath(binPath) {
+				_logger.Info("Run 'govman init' to add govman to your PATH")
+			}
+
+			return nil
+		},
+	}
+
+	return cmd
+}
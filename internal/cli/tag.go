@@ -0,0 +1,88 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	cobra "github.com/spf13/cobra"
+
+	_logger "github.com/justjundana/govman/internal/logger"
+	_manager "github.com/justjundana/govman/internal/manager"
+)
+
+// newTagCmd creates the 'tag' Cobra command to attach a free-text label to an installed Go version.
+// With only a version it prints the current tag; --remove clears it. Returns a *cobra.Command.
+func newTagCmd() *cobra.Command {
+	var remove bool
+
+	cmd := &cobra.Command{
+		Use:   "tag <version> [label]",
+		Short: "Label an installed Go version",
+		Long: `Attach a short free-text label to an installed Go version.
+
+Tags help you remember why a version is installed:
+  • Shown next to the version in 'govman list' and 'govman info'
+  • Kept across 'use' and 'prune', removed together with the version
+  • Included in 'govman export' manifests and restored by 'govman import'
+
+Examples:
+  govman tag 1.24.7 "work project"   # Set or replace a tag
+  govman tag 1.24                    # Show the tag of the newest installed 1.24.x
+  govman tag 1.24.7 --remove         # Remove the tag`,
+		Args:              cobra.RangeArgs(1, 2),
+		ValidArgsFunction: singleArgCompletion(completeInstalledVersions),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if remove && len(args) > 1 {
+				return fmt.Errorf("a label cannot be combined with --remove")
+			}
+
+			if remove || len(args) > 1 {
+				unlock, err := lockHome(cmd)
+				if err != nil {
+					return err
+				}
+				defer unlock()
+			}
+
+			mgr := _manager.New(getConfig())
+
+			version, err := resolveInstalledVersion(mgr, args[0])
+			if err != nil {
+				return err
+			}
+			if !mgr.IsInstalled(version) {
+				_logger.ErrorWithHelp("Go %s is not installed", "Tags can only be attached to installed versions; see 'govman list'.", version)
+				return fmt.Errorf("go version %s is not installed", version)
+			}
+
+			switch {
+			case remove:
+				if err := mgr.SetTag(version, ""); err != nil {
+					return err
+				}
+				_logger.Success("Removed tag from Go %s", version)
+			case len(args) == 1:
+				if tag := mgr.Tag(version); tag != "" {
+					fmt.Println(tag)
+				} else {
+					_logger.Info("Go %s has no tag", version)
+				}
+			default:
+				label := strings.TrimSpace(args[1])
+				if label == "" {
+					return fmt.Errorf("label must not be empty; use --remove to clear a tag")
+				}
+				if err := mgr.SetTag(version, label); err != nil {
+					return err
+				}
+				_logger.Success("Tagged Go %s as %q", version, label)
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&remove, "remove", false, "Remove the tag from the version")
+
+	return cmd
+}
@@ -1,6 +1,7 @@
 package cli
 
 import (
+	"errors"
 	"fmt"
 	"strings"
 
@@ -8,13 +9,17 @@ import (
 
 	_logger "github.com/justjundana/govman/internal/logger"
 	_manager "github.com/justjundana/govman/internal/manager"
+	_picker "github.com/justjundana/govman/internal/picker"
 	_util "github.com/justjundana/govman/internal/util"
 )
 
 // getActivationMode returns a human-friendly label for the activation mode.
-// Parameters: setDefault (system-wide default), setLocal (project-local).
-// Returns "project-local", "system-default", or "session-only" based on flags.
-func getActivationMode(setDefault, setLocal bool) string {
+// Parameters: setDefault (system-wide default), setLocal (project-local), temp (recorded for this terminal).
+// Returns "project-local", "system-default", "session-only (recorded)", or "session-only" based on flags.
+func getActivationMode(setDefault, setLocal, temp bool) string {
+	if temp {
+		return "session-only (recorded)"
+	}
 	if setLocal {
 		return "project-local"
 	}
@@ -24,22 +29,25 @@ func getActivationMode(setDefault, setLocal bool) string {
 	return "session-only"
 }
 
-// newUseCmd creates the 'use' Cobra command to activate a Go version.
-// Flags: setDefault (system default) and setLocal (project-local) control activation scope.
-// Returns a *cobra.Command that validates installation, calls Manager.Use, and reports status.
+// newUseCmd creates the 'use' Cobra command to activate a Go version for the session, as default, for the project,
+// or with --temp for this terminal. Returns a *cobra.Command.
 func newUseCmd() *cobra.Command {
 	var (
-		setDefault bool
-		setLocal   bool
+		setDefault  bool
+		setLocal    bool
+		temp        bool
+		interactive bool
+		noSession   bool
 	)
 
 	cmd := &cobra.Command{
-		Use:   "use <version>",
+		Use:   "use [version]",
 		Short: "Switch between Go versions with flexible activation options",
 		Long: `Activate a specific Go version for your development environment.
 
 Activation Modes:
   • Session-only: Temporary activation for current terminal session
+  • Terminal (--temp): Session-only, but kept by this terminal even in projects with their own version
   • System default: Permanent activation across all new sessions
   • Project-local: Version tied to specific project directory
 
@@ -48,108 +56,108 @@ Smart Features:
   • Shell integration with PATH management
   • Project-specific .govman-goversion file support
   • Seamless switching between versions
+  • Interactive picker when no version is given
+  • 'govman use -' switches back to the previous version, like 'cd -'
+    (with --default, to the previous system default)
+  • --default --no-session changes the default for new shells while this one stays put
 
 Examples:
+  govman use                        # Pick from installed versions
+  govman use -i --default           # Pick and set as system default
   govman use 1.25.1                 # Session-only activation
+  govman use 1.24 --temp            # Keep Go 1.24 in this terminal until it closes
   govman use 1.25.1 --default       # Set as system default
-  govman use 1.25.1 --local         # Project-specific version`,
-		Args: cobra.ExactArgs(1),
+  govman use 1.25.1 --default --no-session  # Set the default for new shells only
+  govman use 1.25.1 --local         # Project-specific version
+  govman use -                      # Back to the version used before in this terminal
+  govman use - --default            # Back to the previous system default`,
+		Args:              cobra.MaximumNArgs(1),
+		ValidArgsFunction: singleArgCompletion(completeInstalledVersions),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			version := args[0]
+			if noSession && !setDefault {
+				return fmt.Errorf("--no-session only applies with --default")
+			}
+
 			mgr := _manager.New(getConfig())
 
-			if version != "default" {
-				// Check if version is an alias like "latest", "stable", etc.
-				// Aliases have no dots in them (except for partial versions like "1.24")
-				isAlias := version == "latest" || version == "stable"
-				isPartialVersion := strings.Count(version, ".") == 1
-
-				if isAlias {
-					// Alias (e.g., "latest"): resolve to installed version first
-					installedVersions, err := mgr.ListInstalled()
-					if err != nil {
-						_logger.Verbose("Failed to list installed versions: %v", err)
-					}
-					if len(installedVersions) > 0 {
-						// For "latest", use the newest installed version
-						if version == "latest" || version == "stable" {
-							version = installedVersions[0] // installed versions are sorted in descending order
-							_logger.Verbose("Resolved alias to installed version %s", version)
-						}
-					} else {
-						// No versions installed, resolve from remote
-						resolved, err := mgr.ResolveVersion(version)
-						if err != nil {
-							return fmt.Errorf("failed to resolve version %s: %w", version, err)
-						}
-						version = resolved
-					}
-				} else if isPartialVersion {
-					// Partial version (e.g., "1.24"): use flexible matching
-					installedVersions, err := mgr.ListInstalled()
-					if err != nil {
-						_logger.Verbose("Failed to list installed versions: %v", err)
-					}
-					if len(installedVersions) > 0 {
-						if matchedVersion, err := _util.FindBestMatchingVersion(version, installedVersions); err == nil {
-							_logger.Verbose("Resolved %s to installed version %s", version, matchedVersion)
-							version = matchedVersion
-						} else {
-							// No installed version matches, resolve from remote
-							resolved, err := mgr.ResolveVersion(version)
-							if err != nil {
-								return fmt.Errorf("failed to resolve version %s: %w", version, err)
-							}
-							version = resolved
-						}
-					} else {
-						// No versions installed, resolve from remote
-						resolved, err := mgr.ResolveVersion(version)
-						if err != nil {
-							return fmt.Errorf("failed to resolve version %s: %w", version, err)
-						}
-						version = resolved
-					}
-				} else {
-					// Full version (e.g., "1.24.1"): check exact match first
-					if !mgr.IsInstalled(version) {
-						// Exact version not found, try flexible matching as fallback
-						installedVersions, err := mgr.ListInstalled()
-						if err != nil {
-							_logger.Verbose("Failed to list installed versions: %v", err)
-						}
-						if len(installedVersions) > 0 {
-							if matchedVersion, err := _util.FindBestMatchingVersion(version, installedVersions); err == nil {
-								_logger.Verbose("Exact version %s not found, using %s (closest match)", version, matchedVersion)
-								version = matchedVersion
-							}
-						}
-					}
+			var version string
+			if len(args) == 0 || interactive {
+				if len(args) > 0 {
+					return fmt.Errorf("--interactive cannot be combined with a version argument")
+				}
+
+				selected, err := pickInstalledVersion(mgr)
+				if errors.Is(err, _picker.ErrCancelled) {
+					_logger.Info("No version selected")
+					return nil
+				}
+				if err != nil {
+					return err
 				}
+				version = selected
+			} else if version = args[0]; version == "-" {
+				previous, err := previousVersion(cmd, mgr, setDefault, setLocal)
+				if err != nil {
+					return err
+				}
+				version = previous
+			} else if version != "default" {
+				resolved, err := resolveInstalledVersion(mgr, version)
+				if err != nil {
+					return err
+				}
+				version = resolved
+			}
 
-				if !mgr.IsInstalled(version) {
-					helpMsg := fmt.Sprintf("Install it first with 'govman install %s', or check available versions with 'govman list'.", version)
-					_logger.ErrorWithHelp("Go version %s is not installed", helpMsg, version)
-					return fmt.Errorf("version %s not installed", version)
+			if setDefault {
+				unlock, err := lockHome(cmd)
+				if err != nil {
+					return err
 				}
+				defer unlock()
 			}
 
-			_logger.Verbose("Activating Go %s with mode: %s", version, getActivationMode(setDefault, setLocal))
+			_logger.Verbose("Activating Go %s with mode: %s", version, getActivationMode(setDefault, setLocal, temp))
 
-			err := mgr.Use(version, setDefault, setLocal)
+			err := mgr.Use(version, setDefault, setLocal, noSession)
 			if err != nil {
 				_logger.ErrorWithHelp("Failed to activate Go %s", "Ensure the version is properly installed and you have sufficient permissions.", version)
 				return err
 			}
 
-			if setLocal {
+			if temp {
+				if version == "default" {
+					if version, err = mgr.CurrentGlobal(); err != nil {
+						return fmt.Errorf("failed to get default version: %w", err)
+					}
+				}
+				if err := mgr.SetSessionVersion(version); err != nil {
+					return err
+				}
+			} else if !noSession {
+				// Any other activation replaces the terminal's recorded version; --no-session leaves this terminal alone
+				if err := mgr.ClearSessionVersion(); err != nil {
+					_logger.Verbose("Could not clear the session version: %v", err)
+				}
+			}
+
+			if temp {
+				_logger.Success("Now using Go %s in this terminal", version)
+				_logger.Info("It stays active here, even in projects with their own version, until the terminal closes")
+				_logger.Info("Run 'govman use' without --temp to switch again")
+			} else if setLocal {
 				_logger.Success("Set Go %s as local version for this project", version)
 				_logger.Info("Created/updated .govman-goversion file in current directory")
 				_logger.Info("This version will be used automatically when working in this project")
+				hintLegacyMigration(mgr)
 			} else if setDefault {
 				_logger.Success("Set Go %s as system default version", version)
 				_logger.Info("All new terminal sessions will use this version")
-				_logger.Info("Current session updated - run 'go version' to verify")
+				if noSession {
+					_logger.Info("This terminal keeps its current Go version until you switch or open a new one")
+				} else {
+					_logger.Info("Current session updated - run 'go version' to verify")
+				}
 			} else {
 				_logger.Success("Now using Go %s for this session", version)
 				_logger.Info("This is temporary - use --default to make it permanent")
@@ -161,12 +169,149 @@ Examples:
 				_logger.Info("Version details: %s/%s, installed %s", info.OS, info.Arch, info.InstallDate.Format("2006-01-02"))
 			}
 
+			if setDefault {
+				// New shells find the default through the govman bin directory; this terminal's PATH is updated separately
+				if goPath, managed, err := mgr.GoOnPath(); err == nil && !managed {
+					_logger.Warning("Another go at %s comes before govman on PATH, so new shells may not run Go %s", goPath, version)
+					_logger.Info("  Help: %s", shadowingGoHelp(goPath, getConfig()))
+				}
+			}
+
 			return nil
 		},
 	}
 
 	cmd.Flags().BoolVarP(&setDefault, "default", "d", false, "Set as system-wide default version (persistent)")
 	cmd.Flags().BoolVarP(&setLocal, "local", "l", false, "Set as project-local version (creates .govman-goversion file)")
+	cmd.Flags().BoolVarP(&temp, "temp", "t", false, "Keep the version in this terminal, overriding project versions, until it closes")
+	cmd.Flags().BoolVarP(&interactive, "interactive", "i", false, "Choose from installed versions in an interactive picker")
+	cmd.Flags().BoolVar(&noSession, "no-session", false, "With --default, leave this terminal's Go version unchanged; only new shells use the new default")
+	cmd.MarkFlagsMutuallyExclusive("temp", "default")
+	cmd.MarkFlagsMutuallyExclusive("temp", "local")
 
 	return cmd
 }
+
+// previousVersion returns the version 'govman use -' switches back to: the previous default with setDefault, otherwise
+// the one this terminal used before. Returns an error, with cmd's usage silenced, if there is none or it was uninstalled.
+func previousVersion(cmd *cobra.Command, mgr *_manager.Manager, setDefault, setLocal bool) (string, error) {
+	if setLocal {
+		return "", fmt.Errorf("'govman use -' cannot be combined with --local; name the project's version instead")
+	}
+
+	cmd.SilenceUsage = true
+	version, err := mgr.PreviousVersion(setDefault)
+	switch {
+	case errors.Is(err, _manager.ErrNoPreviousVersion):
+		scope := "in this terminal"
+		if setDefault {
+			scope = "as the system default"
+		}
+		_logger.ErrorWithHelp("No previous Go version to switch back to %s", "Switch versions with 'govman use <version>' first; 'govman use -' then toggles between the last two.", scope)
+		return "", err
+	case errors.Is(err, _manager.ErrNotInstalled):
+		_logger.ErrorWithHelp("The previous Go version, %s, is no longer installed", fmt.Sprintf("Reinstall it with 'govman install %s', or pick another with 'govman use'.", version), version)
+		return "", err
+	case err != nil:
+		return "", err
+	}
+
+	_logger.Verbose("Switching back to the previous version %s", version)
+	return version, nil
+}
+
+// pickInstalledVersion lets the user choose an installed version in an interactive picker, starting on the active one.
+// Returns the chosen version, _picker.ErrCancelled if the user backs out, or an error if nothing is installed.
+func pickInstalledVersion(mgr *_manager.Manager) (string, error) {
+	installed, err := mgr.ListInstalled()
+	if err != nil {
+		return "", fmt.Errorf("failed to list installed versions: %w", err)
+	}
+	if len(installed) == 0 {
+		_logger.ErrorWithHelp("No Go versions are installed", "Install one first with 'govman install latest'.")
+		return "", fmt.Errorf("no installed versions to choose from")
+	}
+
+	current, _ := mgr.Current()
+	return _picker.Select("Select a Go version to use:", installed, current)
+}
+
+// resolveInstalledVersion resolves an alias, partial, or exact version to an installed version,
+// falling back to remote resolution when nothing installed matches. Returns an error if the result is not installed.
+func resolveInstalledVersion(mgr *_manager.Manager, version string) (string, error) {
+	// Check if version is an alias like "latest", "stable", etc.
+	// Aliases have no dots in them (except for partial versions like "1.24")
+	isAlias := version == "latest" || version == "stable"
+	isPartialVersion := strings.Count(version, ".") == 1
+
+	if isAlias {
+		// Alias (e.g., "latest"): resolve to installed version first
+		installedVersions, err := mgr.ListInstalled()
+		if err != nil {
+			_logger.Verbose("Failed to list installed versions: %v", err)
+		}
+		if len(installedVersions) > 0 {
+			// For "latest", use the newest installed version
+			if version == "latest" || version == "stable" {
+				version = installedVersions[0] // installed versions are sorted in descending order
+				_logger.Verbose("Resolved alias to installed version %s", version)
+			}
+		} else {
+			// No versions installed, resolve from remote
+			resolved, err := mgr.ResolveVersion(version)
+			if err != nil {
+				return "", fmt.Errorf("failed to resolve version %s: %w", version, err)
+			}
+			version = resolved
+		}
+	} else if isPartialVersion {
+		// Partial version (e.g., "1.24"): use flexible matching
+		installedVersions, err := mgr.ListInstalled()
+		if err != nil {
+			_logger.Verbose("Failed to list installed versions: %v", err)
+		}
+		if len(installedVersions) > 0 {
+			if matchedVersion, err := _util.FindBestMatchingVersion(version, installedVersions); err == nil {
+				_logger.Verbose("Resolved %s to installed version %s", version, matchedVersion)
+				version = matchedVersion
+			} else {
+				// No installed version matches, resolve from remote
+				resolved, err := mgr.ResolveVersion(version)
+				if err != nil {
+					return "", fmt.Errorf("failed to resolve version %s: %w", version, err)
+				}
+				version = resolved
+			}
+		} else {
+			// No versions installed, resolve from remote
+			resolved, err := mgr.ResolveVersion(version)
+			if err != nil {
+				return "", fmt.Errorf("failed to resolve version %s: %w", version, err)
+			}
+			version = resolved
+		}
+	} else {
+		// Full version (e.g., "1.24.1"): check exact match first
+		if !mgr.IsInstalled(version) {
+			// Exact version not found, try flexible matching as fallback
+			installedVersions, err := mgr.ListInstalled()
+			if err != nil {
+				_logger.Verbose("Failed to list installed versions: %v", err)
+			}
+			if len(installedVersions) > 0 {
+				if matchedVersion, err := _util.FindBestMatchingVersion(version, installedVersions); err == nil {
+					_logger.Verbose("Exact version %s not found, using %s (closest match)", version, matchedVersion)
+					version = matchedVersion
+				}
+			}
+		}
+	}
+
+	if !mgr.IsInstalled(version) {
+		helpMsg := fmt.Sprintf("Install it first with 'govman install %s', or check available versions with 'govman list'.", version)
+		_logger.ErrorWithHelp("Go version %s is not installed", helpMsg, version)
+		return "", fmt.Errorf("go version %s is %w", version, _manager.ErrNotInstalled)
+	}
+
+	return version, nil
+}
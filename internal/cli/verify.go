@@ -0,0 +1,107 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	cobra "github.com/spf13/cobra"
+
+	_logger "github.com/justjundana/govman/internal/logger"
+	_manager "github.com/justjundana/govman/internal/manager"
+)
+
+// newVerifyCmd creates the 'verify' Cobra command that re-checks installed versions for corruption.
+// Returns a *cobra.Command that runs Manager.VerifyInstall on the given versions, or all with --all, and
+// fails when any of them does not pass.
+func newVerifyCmd() *cobra.Command {
+	var all bool
+
+	cmd := &cobra.Command{
+		Use:   "verify [version...]",
+		Short: "Check installed Go versions for corruption",
+		Long: `Re-check installed Go versions, catching damage from disk problems before it breaks a build.
+
+Checks:
+  • bin/go runs with GOROOT set to the version and reports that version
+  • The install tree has a pkg directory and a matching VERSION file
+  • A cached archive, when there is one, still matches the release checksum
+
+Nothing is changed; reinstall a version that fails with 'govman uninstall' and 'govman install'.
+
+Examples:
+  govman verify 1.25.1               # Check one version
+  govman verify 1.24 1.25            # Check the newest installed patch of each
+  govman verify --all                # Check every installed version`,
+		ValidArgsFunction: completeInstalledVersions,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if all == (len(args) > 0) {
+				return fmt.Errorf("give one or more versions, or --all")
+			}
+			cmd.SilenceUsage = true
+
+			mgr := _manager.New(getConfig())
+
+			versions := args
+			if all {
+				installed, err := mgr.ListInstalled()
+				if err != nil {
+					return fmt.Errorf("failed to list installed versions: %w", err)
+				}
+				if len(installed) == 0 {
+					_logger.Info("No Go versions are installed")
+					return nil
+				}
+				versions = installed
+			} else {
+				versions = make([]string, 0, len(args))
+				for _, arg := range args {
+					version, err := resolveInstalledVersion(mgr, arg)
+					if err != nil {
+						return err
+					}
+					versions = append(versions, version)
+				}
+			}
+
+			var failures []error
+			for _, version := range versions {
+				result, err := mgr.VerifyInstall(cmd.Context(), version)
+				if err != nil {
+					return err
+				}
+				printVerifyResult(result)
+				if err := result.Err(); err != nil {
+					failures = append(failures, err)
+				}
+			}
+
+			if len(failures) > 0 {
+				cmd.SilenceErrors = true
+				return &summaryError{msg: fmt.Sprintf("%d of %d version(s) failed verification", len(failures), len(versions)), causes: failures}
+			}
+
+			_logger.Success("%d version(s) passed verification", len(versions))
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVarP(&all, "all", "a", false, "Verify every installed version")
+
+	return cmd
+}
+
+// printVerifyResult reports each check of one version in the style of 'govman doctor'.
+func printVerifyResult(result *_manager.VerifyResult) {
+	_logger.Info("Go %s", result.Version)
+	for _, check := range result.Checks {
+		switch {
+		case check.Err != nil:
+			helpMsg := fmt.Sprintf("Reinstall with 'govman uninstall %s && govman install %s'.", result.Version, result.Version)
+			_logger.ErrorWithHelp("✗ %s: %v", helpMsg, check.Name, check.Err)
+		case check.Skipped:
+			_logger.Info("  - %s: %s", check.Name, check.Detail)
+		default:
+			_logger.Info("  ✓ %s: %s", check.Name, strings.TrimSpace(check.Detail))
+		}
+	}
+}
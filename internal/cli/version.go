@@ -0,0 +1,55 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+
+	cobra "github.com/spf13/cobra"
+
+	_version "github.com/justjundana/govman/internal/version"
+)
+
+// newVersionCmd creates the 'version' Cobra command that prints build information.
+// The jsonOutput flag switches from the human-readable report to JSON. Returns a *cobra.Command.
+func newVersionCmd() *cobra.Command {
+	var jsonOutput bool
+
+	cmd := &cobra.Command{
+		Use:   "version",
+		Short: "Show govman version and build information",
+		Long: `Display the govman version and details about how it was built.
+
+Information Shown:
+  • govman version (semantic version, or dev-<commit> for source builds)
+  • Git commit and build date
+  • Go version used to build govman
+  • Operating system and architecture
+
+Include this output when reporting bugs.
+
+Examples:
+  govman version          # Human-readable build information
+  govman version --json   # Machine-readable output
+  govman --version        # Same as 'govman version'`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			info := _version.Get()
+
+			if jsonOutput {
+				data, err := json.MarshalIndent(info, "", "  ")
+				if err != nil {
+					return fmt.Errorf("failed to encode version information: %w", err)
+				}
+				fmt.Println(string(data))
+				return nil
+			}
+
+			fmt.Println(info.String())
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output build information as JSON")
+
+	return cmd
+}
@@ -2,28 +2,45 @@ package config
 
 import (
 	"fmt"
+	"net"
+	"net/http"
+	"net/url"
 	"os"
 	"path/filepath"
+	"reflect"
 	"runtime"
+	"strconv"
 	"strings"
 	"time"
 
 	viper "github.com/spf13/viper"
+
+	_golang "github.com/justjundana/govman/internal/golang"
+	_theme "github.com/justjundana/govman/internal/theme"
+	_util "github.com/justjundana/govman/internal/util"
 )
 
 type Config struct {
 	InstallDir     string           `mapstructure:"install_dir"`
 	CacheDir       string           `mapstructure:"cache_dir"`
 	DefaultVersion string           `mapstructure:"default_version"`
+	BinaryName     string           `mapstructure:"binary_name"`
 	Download       DownloadConfig   `mapstructure:"download"`
+	Network        NetworkConfig    `mapstructure:"network"`
 	Mirror         MirrorConfig     `mapstructure:"mirror"`
 	AutoSwitch     AutoSwitchConfig `mapstructure:"auto_switch"`
 	Shell          ShellConfig      `mapstructure:"shell"`
 	GoReleases     GoReleasesConfig `mapstructure:"go_releases"`
 	SelfUpdate     SelfUpdateConfig `mapstructure:"self_update"`
+	Hooks          HooksConfig      `mapstructure:"hooks"`
+	Shims          ShimsConfig      `mapstructure:"shims"`
+	UI             UIConfig         `mapstructure:"ui"`
 	Quiet          bool             `mapstructure:"quiet"`
 	Verbose        bool             `mapstructure:"verbose"`
-	configPath     string
+
+	// Offline keeps every operation off the network. It comes from --offline or GOVMAN_OFFLINE and is never saved.
+	Offline    bool `mapstructure:"-"`
+	configPath string
 }
 
 type DownloadConfig struct {
@@ -32,6 +49,22 @@ type DownloadConfig struct {
 	Timeout        time.Duration `mapstructure:"timeout"`
 	RetryCount     int           `mapstructure:"retry_count"`
 	RetryDelay     time.Duration `mapstructure:"retry_delay"`
+	MaxRetryDelay  time.Duration `mapstructure:"max_retry_delay"`
+	UseCache       bool          `mapstructure:"use_cache"`
+
+	// VerifySignature checks each archive's detached signature against SignatureKey, a path to an
+	// ASCII-armored OpenPGP key or a minisign public key.
+	VerifySignature bool   `mapstructure:"verify_signature"`
+	SignatureKey    string `mapstructure:"signature_key"`
+
+	// RateLimit caps the download speed in bytes per second, 0 for no limit. It comes from install --limit-rate
+	// and is never saved.
+	RateLimit int64 `mapstructure:"-"`
+}
+
+type NetworkConfig struct {
+	Proxy   string        `mapstructure:"proxy"`
+	Timeout time.Duration `mapstructure:"timeout"`
 }
 
 type MirrorConfig struct {
@@ -39,20 +72,31 @@ type MirrorConfig struct {
 	URL     string `mapstructure:"url"`
 }
 
+// LegacyProjectFile is the project version file name used by earlier releases. It is still read next to
+// auto_switch.project_file when that file is absent, and refresh and 'use --local' offer to migrate it.
+const LegacyProjectFile = ".govman-version"
+
 type AutoSwitchConfig struct {
-	Enabled     bool   `mapstructure:"enabled"`
-	ProjectFile string `mapstructure:"project_file"`
+	Enabled      bool     `mapstructure:"enabled"`
+	ProjectFile  string   `mapstructure:"project_file"`
+	ProjectFiles []string `mapstructure:"project_files"`
 }
 
 type ShellConfig struct {
-	AutoDetect bool `mapstructure:"auto_detect"`
-	Completion bool `mapstructure:"completion"`
+	AutoDetect   bool `mapstructure:"auto_detect"`
+	Completion   bool `mapstructure:"completion"`
+	PinToolchain bool `mapstructure:"pin_toolchain"`
 }
 
 type GoReleasesConfig struct {
+	// Source selects where releases are listed: "api" (APIURL) or "checksums" (the sha256sum index at IndexURL).
+	Source      string        `mapstructure:"source"`
 	APIURL      string        `mapstructure:"api_url"`
+	IndexURL    string        `mapstructure:"index_url"`
 	DownloadURL string        `mapstructure:"download_url"`
+	Mirrors     []string      `mapstructure:"mirrors"`
 	CacheExpiry time.Duration `mapstructure:"cache_expiry"`
+	GitHubToken string        `mapstructure:"github_token"`
 }
 
 type SelfUpdateConfig struct {
@@ -60,8 +104,45 @@ type SelfUpdateConfig struct {
 	GitHubReleasesURL string `mapstructure:"github_releases_url"`
 }
 
+type HooksConfig struct {
+	PostInstall []string `mapstructure:"post_install"`
+	Strict      bool     `mapstructure:"strict"`
+}
+
+// Shim versions select which Go version a tool shim puts first on PATH when it runs the tool.
+const (
+	ShimVersionInstalled = "installed" // the version the tool was built with, when it is installed
+	ShimVersionActive    = "active"    // whatever version is active when the tool runs
+)
+
+type ShimsConfig struct {
+	Version string `mapstructure:"version"`
+}
+
+type UIConfig struct {
+	Theme string `mapstructure:"theme"`
+}
+
+// ValidationError reports a configuration key whose value would break govman at runtime.
+type ValidationError struct {
+	Key    string
+	Value  string
+	Reason string
+}
+
+// Error implements the error interface.
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("invalid value for %s (%q): %s", e.Key, e.Value, e.Reason)
+}
+
+// HomeEnvVar names the environment variable that relocates the govman home directory (default ~/.govman).
+const HomeEnvVar = "GOVMAN_HOME"
+
+// layoutOS selects the directory layout: XDG base directories on Linux, ~/.govman elsewhere. Tests override it.
+var layoutOS = runtime.GOOS
+
 // Load loads configuration from a YAML file.
-// If configFile is empty, it defaults to ~/.govman/config.yaml.
+// If configFile is empty, it defaults to config.yaml in ConfigHome.
 // It applies defaults, reads/unmarshals the file, expands paths, ensures directories, and returns the Config or an error.
 func Load(configFile string) (*Config, error) {
 	cfg := &Config{}
@@ -71,11 +152,11 @@ func Load(configFile string) (*Config, error) {
 	if configFile != "" {
 		cfg.configPath = configFile
 	} else {
-		homeDir, err := getHomeDir()
+		configDir, err := ConfigHome()
 		if err != nil {
-			return nil, fmt.Errorf("failed to get home directory: %w", err)
+			return nil, fmt.Errorf("failed to resolve govman home: %w", err)
 		}
-		cfg.configPath = filepath.Join(homeDir, ".govman", "config.yaml")
+		cfg.configPath = filepath.Join(configDir, "config.yaml")
 	}
 
 	viper.SetConfigFile(cfg.configPath)
@@ -95,10 +176,18 @@ func Load(configFile string) (*Config, error) {
 		return nil, fmt.Errorf("failed to unmarshal config: %w", err)
 	}
 
+	if err := cfg.Network.validate(); err != nil {
+		return nil, fmt.Errorf("invalid network configuration: %w", err)
+	}
+
 	if err := cfg.expandPaths(); err != nil {
 		return nil, fmt.Errorf("failed to expand paths: %w", err)
 	}
 
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid configuration: %w", err)
+	}
+
 	if err := cfg.createDirectories(); err != nil {
 		return nil, fmt.Errorf("failed to create directories: %w", err)
 	}
@@ -109,15 +198,15 @@ func Load(configFile string) (*Config, error) {
 // setDefaults initializes default values for all Config fields:
 // install/cache directories, download behavior, mirror, autoswitch, shell, releases API, and self-update endpoints.
 func (c *Config) setDefaults() {
-	homeDir, err := getHomeDir()
+	govmanDir, err := GovmanHome()
 	if err != nil {
-		homeDir = "."
+		govmanDir = filepath.Join(".", ".govman")
 	}
-	govmanDir := filepath.Join(homeDir, ".govman")
 
 	c.InstallDir = filepath.Join(govmanDir, "versions")
 	c.CacheDir = filepath.Join(govmanDir, "cache")
 	c.DefaultVersion = ""
+	c.BinaryName = DefaultBinaryName
 	c.Quiet = false
 	c.Verbose = false
 
@@ -127,6 +216,13 @@ func (c *Config) setDefaults() {
 		Timeout:        300 * time.Second,
 		RetryCount:     3,
 		RetryDelay:     5 * time.Second,
+		MaxRetryDelay:  60 * time.Second,
+		UseCache:       true,
+	}
+
+	c.Network = NetworkConfig{
+		Proxy:   "",
+		Timeout: 30 * time.Second,
 	}
 
 	c.Mirror = MirrorConfig{
@@ -135,18 +231,22 @@ func (c *Config) setDefaults() {
 	}
 
 	c.AutoSwitch = AutoSwitchConfig{
-		Enabled:     true,
-		ProjectFile: ".govman-goversion",
+		Enabled:      true,
+		ProjectFile:  ".govman-goversion",
+		ProjectFiles: []string{".govman-goversion", ".go-version", "go.mod"},
 	}
 
 	c.Shell = ShellConfig{
-		AutoDetect: true,
-		Completion: true,
+		AutoDetect:   true,
+		Completion:   true,
+		PinToolchain: true,
 	}
 
 	c.GoReleases = GoReleasesConfig{
+		Source:      _golang.APISourceName,
 		APIURL:      "https://go.dev/dl/?mode=json&include=all",
 		DownloadURL: "https://go.dev/dl/%s",
+		Mirrors:     []string{},
 		CacheExpiry: 10 * time.Minute,
 	}
 
@@ -154,6 +254,19 @@ func (c *Config) setDefaults() {
 		GitHubAPIURL:      "https://api.github.com/repos/justjundana/govman/releases/latest",
 		GitHubReleasesURL: "https://api.github.com/repos/justjundana/govman/releases?per_page=1",
 	}
+
+	c.Hooks = HooksConfig{
+		PostInstall: []string{},
+		Strict:      false,
+	}
+
+	c.Shims = ShimsConfig{
+		Version: ShimVersionInstalled,
+	}
+
+	c.UI = UIConfig{
+		Theme: _theme.Default.Name,
+	}
 }
 
 // expandPaths expands and validates configured paths (e.g., handles ~), preventing traversal outside HOME.
@@ -171,6 +284,13 @@ func (c *Config) expandPaths() error {
 		return fmt.Errorf("failed to expand cache_dir: %w", err)
 	}
 
+	if c.Download.SignatureKey != "" {
+		c.Download.SignatureKey, err = expandPath(c.Download.SignatureKey)
+		if err != nil {
+			return fmt.Errorf("failed to expand download.signature_key: %w", err)
+		}
+	}
+
 	return nil
 }
 
@@ -197,17 +317,11 @@ func (c *Config) Save() error {
 		return fmt.Errorf("failed to create config directory: %w", err)
 	}
 
-	viper.Set("default_version", c.DefaultVersion)
-	viper.Set("install_dir", c.InstallDir)
-	viper.Set("cache_dir", c.CacheDir)
-	viper.Set("quiet", c.Quiet)
-	viper.Set("verbose", c.Verbose)
-	viper.Set("download", c.Download)
-	viper.Set("mirror", c.Mirror)
-	viper.Set("auto_switch", c.AutoSwitch)
-	viper.Set("shell", c.Shell)
-	viper.Set("go_releases", c.GoReleases)
-	viper.Set("self_update", c.SelfUpdate)
+	// Set each leaf by its dotted mapstructure key so nested fields keep their
+	// snake_case names (e.g. download.retry_count) and are read back by Load.
+	collectKeys(reflect.ValueOf(c).Elem(), "", func(key string, field reflect.Value) {
+		viper.Set(key, field.Interface())
+	})
 
 	// Write to temp file first for atomic save
 	// Use .yaml extension so viper can recognize the config type
@@ -226,24 +340,715 @@ func (c *Config) Save() error {
 	return nil
 }
 
+// validate checks that the timeout is non-negative and the proxy, if any, is an absolute http, https, or socks5 URL.
+// Returns an error describing the malformed value; nil when the settings are valid.
+func (n NetworkConfig) validate() error {
+	if n.Timeout < 0 {
+		return fmt.Errorf("network timeout cannot be negative: %v", n.Timeout)
+	}
+
+	if n.Proxy == "" {
+		return nil
+	}
+
+	proxyURL, err := url.Parse(n.Proxy)
+	if err != nil {
+		return fmt.Errorf("malformed proxy URL %q: %w", n.Proxy, err)
+	}
+
+	switch proxyURL.Scheme {
+	case "http", "https", "socks5":
+	default:
+		return fmt.Errorf("malformed proxy URL %q: scheme must be http, https, or socks5", n.Proxy)
+	}
+
+	if proxyURL.Host == "" {
+		return fmt.Errorf("malformed proxy URL %q: missing host", n.Proxy)
+	}
+
+	return nil
+}
+
+// Validate checks the settings that otherwise fail far from their cause: install and cache directories must be
+// absolute and creatable, the releases cache expiry non-negative, the releases API URL well-formed, project
+// file names plain file names, and hook commands parseable. Returns a *ValidationError for the first invalid value.
+func (c *Config) Validate() error {
+	if err := validateDir("install_dir", c.InstallDir); err != nil {
+		return err
+	}
+	if err := validateDir("cache_dir", c.CacheDir); err != nil {
+		return err
+	}
+
+	if c.GoReleases.CacheExpiry < 0 {
+		return &ValidationError{Key: "go_releases.cache_expiry", Value: c.GoReleases.CacheExpiry.String(), Reason: "must not be negative"}
+	}
+
+	if err := validateURL(c.GoReleases.APIURL); err != nil {
+		return &ValidationError{Key: "go_releases.api_url", Value: c.GoReleases.APIURL, Reason: err.Error()}
+	}
+
+	switch c.GoReleases.Source {
+	case "", _golang.APISourceName:
+	case _golang.ChecksumIndexSourceName:
+		if err := validateURL(c.GoReleases.IndexURL); err != nil {
+			return &ValidationError{Key: "go_releases.index_url", Value: c.GoReleases.IndexURL, Reason: err.Error()}
+		}
+	default:
+		return &ValidationError{Key: "go_releases.source", Value: c.GoReleases.Source, Reason: fmt.Sprintf("must be %s or %s", _golang.APISourceName, _golang.ChecksumIndexSourceName)}
+	}
+
+	if c.BinaryName != "" {
+		if err := validateBinaryName(c.BinaryName); err != nil {
+			return &ValidationError{Key: "binary_name", Value: c.BinaryName, Reason: err.Error()}
+		}
+	}
+
+	if !isPlainFileName(c.AutoSwitch.ProjectFile) {
+		return &ValidationError{Key: "auto_switch.project_file", Value: c.AutoSwitch.ProjectFile, Reason: "must be a plain file name such as .govman-goversion"}
+	}
+	for _, name := range c.AutoSwitch.ProjectFiles {
+		if !isPlainFileName(name) {
+			return &ValidationError{Key: "auto_switch.project_files", Value: name, Reason: "must be a plain file name such as .go-version"}
+		}
+	}
+
+	for _, command := range c.Hooks.PostInstall {
+		if _, err := _util.SplitCommand(command); err != nil {
+			return &ValidationError{Key: "hooks.post_install", Value: command, Reason: err.Error()}
+		}
+	}
+
+	switch c.Shims.Version {
+	case "", ShimVersionInstalled, ShimVersionActive:
+	default:
+		return &ValidationError{Key: "shims.version", Value: c.Shims.Version, Reason: fmt.Sprintf("must be %s or %s", ShimVersionInstalled, ShimVersionActive)}
+	}
+
+	if _, err := _theme.Lookup(c.UI.Theme); err != nil {
+		return &ValidationError{Key: "ui.theme", Value: c.UI.Theme, Reason: "must be one of " + strings.Join(_theme.Names(), ", ")}
+	}
+
+	return nil
+}
+
+// validateDir checks that dir is absolute and that it, or its nearest existing ancestor, is a directory.
+// Returns a *ValidationError naming key when the directory could not be created.
+func validateDir(key, dir string) error {
+	if !filepath.IsAbs(dir) {
+		return &ValidationError{Key: key, Value: dir, Reason: "must be an absolute path or start with ~/"}
+	}
+
+	for path := dir; ; path = filepath.Dir(path) {
+		info, err := os.Stat(path)
+		if err == nil {
+			if !info.IsDir() {
+				return &ValidationError{Key: key, Value: dir, Reason: fmt.Sprintf("%s exists and is not a directory", path)}
+			}
+			return nil
+		}
+		if !os.IsNotExist(err) {
+			return &ValidationError{Key: key, Value: dir, Reason: fmt.Sprintf("cannot access %s: %v", path, err)}
+		}
+		if filepath.Dir(path) == path {
+			return nil
+		}
+	}
+}
+
+// isPlainFileName reports whether name is a non-empty file name without directory components.
+func isPlainFileName(name string) bool {
+	return name != "" && name != "." && name != ".." && !strings.ContainsAny(name, "/\\\x00")
+}
+
+// validateBinaryName checks that name can be the managed go command in the bin directory: a plain file name that is
+// not hidden, since govman keeps its own records there, and without .exe, which is added on Windows.
+// Returns an error describing the problem.
+func validateBinaryName(name string) error {
+	switch {
+	case !isPlainFileName(name) || strings.ContainsAny(name, " \t"):
+		return fmt.Errorf("must be a plain command name such as go or go2")
+	case strings.HasPrefix(name, "."):
+		return fmt.Errorf("must not start with a dot")
+	case strings.EqualFold(filepath.Ext(name), ".exe"):
+		return fmt.Errorf("must not end in .exe; it is added on Windows")
+	}
+	return nil
+}
+
+// Transport builds an HTTP transport honoring the configured proxy, falling back to the
+// HTTP_PROXY/HTTPS_PROXY/NO_PROXY environment variables when no proxy is set.
+// Timeout bounds connection setup and the wait for response headers, not the body transfer.
+func (n NetworkConfig) Transport() *http.Transport {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+
+	transport.Proxy = http.ProxyFromEnvironment
+	if n.Proxy != "" {
+		if proxyURL, err := url.Parse(n.Proxy); err == nil {
+			transport.Proxy = http.ProxyURL(proxyURL)
+		}
+	}
+
+	if n.Timeout > 0 {
+		transport.DialContext = (&net.Dialer{
+			Timeout:   n.Timeout,
+			KeepAlive: 30 * time.Second,
+		}).DialContext
+		transport.TLSHandshakeTimeout = n.Timeout
+		transport.ResponseHeaderTimeout = n.Timeout
+	}
+
+	return transport
+}
+
+// HTTPClient returns a client with the given overall timeout that uses Network.Transport.
+// In offline mode the client fails every request with an error wrapping _golang.ErrOffline, without dialing.
+func (c *Config) HTTPClient(timeout time.Duration) *http.Client {
+	if c.Offline {
+		return &http.Client{Transport: offlineTransport{}, Timeout: timeout}
+	}
+	return &http.Client{Transport: c.Network.Transport(), Timeout: timeout}
+}
+
+// offlineTransport refuses every request, so nothing in offline mode can reach the network by accident.
+type offlineTransport struct{}
+
+// RoundTrip implements http.RoundTripper.
+func (offlineTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Body != nil {
+		req.Body.Close()
+	}
+	return nil, fmt.Errorf("%w: not connecting to %s", _golang.ErrOffline, req.URL.Host)
+}
+
+// DownloadURLs returns the download locations to try in order: the enabled mirror first,
+// then the primary go_releases.download_url, then any go_releases.mirrors fallbacks.
+func (c *Config) DownloadURLs() []string {
+	var urls []string
+	if c.Mirror.Enabled && c.Mirror.URL != "" {
+		urls = append(urls, c.Mirror.URL)
+	}
+	if c.GoReleases.DownloadURL != "" {
+		urls = append(urls, c.GoReleases.DownloadURL)
+	}
+
+	return append(urls, c.GoReleases.Mirrors...)
+}
+
+// ReleaseSource returns the release source selected by go_releases.source, or the download cache in offline mode.
+func (c *Config) ReleaseSource() _golang.ReleaseSource {
+	if c.Offline {
+		return &_golang.CacheSource{CacheDir: c.CacheDir}
+	}
+	source, err := _golang.NewReleaseSource(c.GoReleases.Source, c.GoReleases.APIURL, c.GoReleases.IndexURL,
+		c.GoReleases.CacheExpiry, c.DownloadURLs())
+	if err != nil {
+		source = &_golang.APISource{APIURL: c.GoReleases.APIURL, CacheDuration: c.GoReleases.CacheExpiry, DownloadURLs: c.DownloadURLs()}
+	}
+	if _, ok := source.(*_golang.APISource); ok {
+		// The built-in release list keeps installs working while the release API is unreachable
+		return &_golang.FallbackSource{Primary: source, Fallback: &_golang.EmbeddedSource{DownloadURLs: c.DownloadURLs()}}
+	}
+	return source
+}
+
+// ReleasesToken returns the token to send with release API requests and where it came from.
+// go_releases.github_token applies to any api_url; GITHUB_TOKEN and GH_TOKEN are only used when api_url is a
+// github.com host, so a CI token is never sent to go.dev. Returns an empty token when none applies.
+func (g GoReleasesConfig) ReleasesToken() (token, source string) {
+	if g.GitHubToken != "" {
+		return g.GitHubToken, "go_releases.github_token"
+	}
+
+	u, err := url.Parse(g.APIURL)
+	if err != nil {
+		return "", ""
+	}
+	host := strings.ToLower(u.Hostname())
+	if host != "github.com" && !strings.HasSuffix(host, ".github.com") {
+		return "", ""
+	}
+
+	for _, name := range []string{"GITHUB_TOKEN", "GH_TOKEN"} {
+		if value := os.Getenv(name); value != "" {
+			return value, name
+		}
+	}
+
+	return "", ""
+}
+
+// IsSecretKey reports whether the value of a configuration key must be masked when displayed.
+func IsSecretKey(key string) bool {
+	return key == "go_releases.github_token"
+}
+
+// ProjectFileCandidates returns the project version file names to search, in precedence order:
+// project_file first, then each entry of project_files that is not already listed.
+func (a AutoSwitchConfig) ProjectFileCandidates() []string {
+	var candidates []string
+	seen := make(map[string]bool)
+	for _, name := range append([]string{a.ProjectFile}, a.ProjectFiles...) {
+		if name == "" || seen[name] {
+			continue
+		}
+		seen[name] = true
+		candidates = append(candidates, name)
+	}
+
+	return candidates
+}
+
+// ConfigPath returns the path of the configuration file this Config was loaded from.
+func (c *Config) ConfigPath() string {
+	return c.configPath
+}
+
+// CheckFile reports whether the configuration file at path can be read and parsed as YAML.
+// It uses a separate viper instance so the loaded configuration is not affected.
+func CheckFile(path string) error {
+	v := viper.New()
+	v.SetConfigFile(path)
+	v.SetConfigType("yaml")
+
+	if err := v.ReadInConfig(); err != nil {
+		return fmt.Errorf("failed to parse config file %s: %w", path, err)
+	}
+
+	return nil
+}
+
+// Keys returns the dotted paths of all settable configuration keys, e.g. go_releases.api_url.
+func (c *Config) Keys() []string {
+	var keys []string
+	collectKeys(reflect.ValueOf(c).Elem(), "", func(key string, _ reflect.Value) {
+		keys = append(keys, key)
+	})
+
+	return keys
+}
+
+// Get returns the value of a configuration key formatted as a string.
+// Lists are joined with commas. Returns an error if the key is unknown.
+func (c *Config) Get(key string) (string, error) {
+	field, err := c.lookupKey(key)
+	if err != nil {
+		return "", err
+	}
+
+	return formatValue(field), nil
+}
+
+// Set parses and validates value for a configuration key and assigns it.
+// Lists are given as comma-separated values. Returns an error for unknown keys or invalid values; call Save to persist.
+func (c *Config) Set(key, value string) error {
+	field, err := c.lookupKey(key)
+	if err != nil {
+		return err
+	}
+
+	switch key {
+	case "install_dir", "cache_dir":
+		value, err = expandPath(value)
+		if err != nil {
+			return fmt.Errorf("invalid value for %s: %w", key, err)
+		}
+		if err := validateDir(key, value); err != nil {
+			return err
+		}
+	case "go_releases.api_url", "self_update.github_api_url", "self_update.github_releases_url":
+		if err := validateURL(value); err != nil {
+			return fmt.Errorf("invalid value for %s: %w", key, err)
+		}
+	case "go_releases.index_url":
+		if value != "" {
+			if err := validateURL(value); err != nil {
+				return fmt.Errorf("invalid value for %s: %w", key, err)
+			}
+		} else if c.GoReleases.Source == _golang.ChecksumIndexSourceName {
+			return fmt.Errorf("invalid value for %s: required while go_releases.source is %s", key, _golang.ChecksumIndexSourceName)
+		}
+	case "go_releases.source":
+		switch value {
+		case _golang.APISourceName:
+		case _golang.ChecksumIndexSourceName:
+			if c.GoReleases.IndexURL == "" {
+				return fmt.Errorf("invalid value for %s: set go_releases.index_url first", key)
+			}
+		default:
+			return fmt.Errorf("invalid value for %s: must be %s or %s", key, _golang.APISourceName, _golang.ChecksumIndexSourceName)
+		}
+	case "go_releases.download_url", "mirror.url":
+		if err := validateURL(strings.ReplaceAll(value, "%s", "")); err != nil {
+			return fmt.Errorf("invalid value for %s: %w", key, err)
+		}
+	case "go_releases.mirrors":
+		for _, mirror := range splitList(value) {
+			if err := validateURL(strings.ReplaceAll(mirror, "%s", "")); err != nil {
+				return fmt.Errorf("invalid value for %s: %w", key, err)
+			}
+		}
+	case "binary_name":
+		if err := validateBinaryName(value); err != nil {
+			return fmt.Errorf("invalid value for %s: %w", key, err)
+		}
+	case "auto_switch.project_file":
+		if !isPlainFileName(value) {
+			return fmt.Errorf("invalid value for %s: must be a plain file name", key)
+		}
+	case "hooks.post_install":
+		for _, command := range splitList(value) {
+			if _, err := _util.SplitCommand(command); err != nil {
+				return fmt.Errorf("invalid value for %s: %w", key, err)
+			}
+		}
+	case "auto_switch.project_files":
+		for _, name := range splitList(value) {
+			if !isPlainFileName(name) {
+				return fmt.Errorf("invalid value for %s: %q must be a plain file name", key, name)
+			}
+		}
+	case "shims.version":
+		if value != ShimVersionInstalled && value != ShimVersionActive {
+			return fmt.Errorf("invalid value for %s: must be %s or %s", key, ShimVersionInstalled, ShimVersionActive)
+		}
+	case "ui.theme":
+		if _, err := _theme.Lookup(value); err != nil {
+			return fmt.Errorf("invalid value for %s: %w", key, err)
+		}
+	}
+
+	parsed, err := parseValue(field.Type(), value)
+	if err != nil {
+		return fmt.Errorf("invalid value for %s: %w", key, err)
+	}
+
+	switch key {
+	case "go_releases.cache_expiry", "download.timeout", "download.retry_delay", "download.max_retry_delay":
+		if parsed.Int() <= 0 {
+			return fmt.Errorf("invalid value for %s: must be a positive duration", key)
+		}
+	case "download.retry_count", "download.max_connections":
+		if parsed.Int() < 1 {
+			return fmt.Errorf("invalid value for %s: must be at least 1", key)
+		}
+	}
+
+	previous := reflect.New(field.Type()).Elem()
+	previous.Set(field)
+	field.Set(parsed)
+
+	if strings.HasPrefix(key, "network.") {
+		if err := c.Network.validate(); err != nil {
+			field.Set(previous)
+			return fmt.Errorf("invalid value for %s: %w", key, err)
+		}
+	}
+
+	return nil
+}
+
+// lookupKey resolves a dotted key to the addressable struct field it names.
+// Returns an error if no such key exists.
+func (c *Config) lookupKey(key string) (reflect.Value, error) {
+	var found reflect.Value
+	collectKeys(reflect.ValueOf(c).Elem(), "", func(k string, v reflect.Value) {
+		if k == key {
+			found = v
+		}
+	})
+
+	if !found.IsValid() {
+		return reflect.Value{}, fmt.Errorf("unknown configuration key: %s", key)
+	}
+
+	return found, nil
+}
+
+// collectKeys walks struct fields by their mapstructure tags and calls fn for every leaf with its dotted key.
+func collectKeys(v reflect.Value, prefix string, fn func(key string, field reflect.Value)) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		tag := t.Field(i).Tag.Get("mapstructure")
+		if tag == "" || tag == "-" {
+			continue
+		}
+
+		key := prefix + tag
+		field := v.Field(i)
+		if field.Kind() == reflect.Struct {
+			collectKeys(field, key+".", fn)
+			continue
+		}
+		fn(key, field)
+	}
+}
+
+// formatValue renders a config field as a string suitable for display and for round-tripping through Set.
+func formatValue(v reflect.Value) string {
+	if v.Kind() == reflect.Slice {
+		items := make([]string, v.Len())
+		for i := range items {
+			items[i] = fmt.Sprint(v.Index(i).Interface())
+		}
+		return strings.Join(items, ",")
+	}
+
+	return fmt.Sprint(v.Interface())
+}
+
+// parseValue converts a string into a value of type t (string, bool, int, time.Duration, or []string).
+// Returns an error if the string cannot be parsed.
+func parseValue(t reflect.Type, value string) (reflect.Value, error) {
+	if t == reflect.TypeOf(time.Duration(0)) {
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			return reflect.Value{}, fmt.Errorf("expected a duration like 30s or 10m: %w", err)
+		}
+		return reflect.ValueOf(d), nil
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return reflect.ValueOf(value), nil
+	case reflect.Bool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return reflect.Value{}, fmt.Errorf("expected true or false")
+		}
+		return reflect.ValueOf(b), nil
+	case reflect.Int:
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return reflect.Value{}, fmt.Errorf("expected an integer")
+		}
+		return reflect.ValueOf(n), nil
+	case reflect.Slice:
+		return reflect.ValueOf(splitList(value)), nil
+	}
+
+	return reflect.Value{}, fmt.Errorf("unsupported type %s", t)
+}
+
+// splitList splits a comma-separated list, trimming whitespace and dropping empty items.
+func splitList(value string) []string {
+	items := []string{}
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+
+	return items
+}
+
+// validateURL checks that value is an absolute http or https URL.
+func validateURL(value string) error {
+	u, err := url.Parse(value)
+	if err != nil {
+		return fmt.Errorf("malformed URL %q: %w", value, err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("malformed URL %q: scheme must be http or https", value)
+	}
+	if u.Host == "" {
+		return fmt.Errorf("malformed URL %q: missing host", value)
+	}
+
+	return nil
+}
+
 // GetVersionDir returns the installation directory for a given Go version, e.g., ~/.govman/versions/go1.25.1.
 func (c *Config) GetVersionDir(version string) string {
 	return filepath.Join(c.InstallDir, fmt.Sprintf("go%s", version))
 }
 
-// GetBinPath returns the path to the govman bin directory, typically ~/.govman/bin.
+// GetBinPath returns the path to the govman bin directory inside GovmanHome, e.g. ~/.govman/bin.
 func (c *Config) GetBinPath() string {
-	homeDir, err := getHomeDir()
+	govmanDir, err := GovmanHome()
 	if err != nil {
-		homeDir = "."
+		govmanDir = filepath.Join(".", ".govman")
 	}
 
-	return filepath.Join(homeDir, ".govman", "bin")
+	return filepath.Join(govmanDir, "bin")
+}
+
+// SessionFilePrefix starts the names of the files in GovmanHome that record the version chosen with
+// 'govman use --temp' for one terminal; the rest of the name is the shell's process ID.
+const SessionFilePrefix = "session-"
+
+// GetSessionFile returns the file recording the session version of the shell with the given process ID, e.g. ~/.govman/session-1234.
+func (c *Config) GetSessionFile(pid int) string {
+	return filepath.Join(filepath.Dir(c.GetBinPath()), SessionFilePrefix+strconv.Itoa(pid))
 }
 
-// GetCurrentSymlink returns the path to the global "go" symlink inside the bin directory.
+// DefaultBinaryName is the name of the managed go command in the bin directory when binary_name is unset.
+const DefaultBinaryName = "go"
+
+// GetBinaryName returns the name of the managed go command in the bin directory: binary_name, or "go" when unset.
+func (c *Config) GetBinaryName() string {
+	if c.BinaryName == "" {
+		return DefaultBinaryName
+	}
+	return c.BinaryName
+}
+
+// GetCurrentSymlink returns the path to the global go symlink inside the bin directory, named after GetBinaryName.
 func (c *Config) GetCurrentSymlink() string {
-	return filepath.Join(c.GetBinPath(), "go")
+	return filepath.Join(c.GetBinPath(), c.GetBinaryName())
+}
+
+// GovmanHome returns the base directory for govman's versions, cache, and bin directory: $GOVMAN_HOME when set,
+// otherwise ${XDG_DATA_HOME:-~/.local/share}/govman on Linux and ~/.govman elsewhere. An existing ~/.govman keeps
+// being used on Linux. Returns an error if GOVMAN_HOME is invalid or the home directory cannot be determined.
+func GovmanHome() (string, error) {
+	_, dataDir, err := resolveHomes()
+	return dataDir, err
+}
+
+// ConfigHome returns the directory holding config.yaml: $GOVMAN_HOME when set, otherwise
+// ${XDG_CONFIG_HOME:-~/.config}/govman on Linux and ~/.govman elsewhere, with the same ~/.govman fallback as GovmanHome.
+func ConfigHome() (string, error) {
+	configDir, _, err := resolveHomes()
+	return configDir, err
+}
+
+// LegacyHome returns ~/.govman and whether it is in use only because it predates the XDG layout on Linux.
+// Moving it to the XDG locations is then possible; see 'govman doctor'.
+func LegacyHome() (string, bool) {
+	homeDir, err := getHomeDir()
+	if err != nil {
+		return "", false
+	}
+
+	legacyDir := filepath.Join(homeDir, ".govman")
+	if os.Getenv(HomeEnvVar) != "" || layoutOS != "linux" {
+		return legacyDir, false
+	}
+
+	return legacyDir, isDir(legacyDir)
+}
+
+// MigrateLegacyHome moves a legacy ~/.govman to the XDG config and data directories on Linux, rewriting install_dir
+// and cache_dir inside it. Returns the new directories, or an error if there is nothing to migrate or a target exists.
+func MigrateLegacyHome() (configDir, dataDir string, err error) {
+	legacyDir, inUse := LegacyHome()
+	if !inUse {
+		return "", "", fmt.Errorf("no legacy %s to migrate: the XDG layout only applies on Linux without %s", legacyDir, HomeEnvVar)
+	}
+
+	homeDir, err := getHomeDir()
+	if err != nil {
+		return "", "", err
+	}
+	configDir = filepath.Join(xdgDir("XDG_CONFIG_HOME", homeDir, ".config"), "govman")
+	dataDir = filepath.Join(xdgDir("XDG_DATA_HOME", homeDir, ".local", "share"), "govman")
+
+	newConfigPath := filepath.Join(configDir, "config.yaml")
+	for _, target := range []string{dataDir, newConfigPath} {
+		if _, err := os.Stat(target); err == nil {
+			return "", "", fmt.Errorf("cannot migrate: %s already exists", target)
+		}
+	}
+
+	if err := os.MkdirAll(configDir, 0755); err != nil {
+		return "", "", fmt.Errorf("failed to create config directory: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(dataDir), 0755); err != nil {
+		return "", "", fmt.Errorf("failed to create data directory: %w", err)
+	}
+
+	legacyConfigPath := filepath.Join(legacyDir, "config.yaml")
+	if _, err := os.Stat(legacyConfigPath); err == nil {
+		if err := writeMigratedConfig(legacyConfigPath, newConfigPath, legacyDir, dataDir); err != nil {
+			return "", "", err
+		}
+	}
+
+	if err := os.Rename(legacyDir, dataDir); err != nil {
+		os.Remove(newConfigPath) // Leave the legacy home in charge if it could not be moved
+		return "", "", fmt.Errorf("failed to move %s to %s: %w", legacyDir, dataDir, err)
+	}
+
+	// A leftover copy is harmless: the migrated file in configDir is the one Load reads
+	os.Remove(filepath.Join(dataDir, "config.yaml"))
+
+	return configDir, dataDir, nil
+}
+
+// writeMigratedConfig copies the config file at from to to, rewriting install_dir and cache_dir values that
+// point inside oldHome so they point inside newHome. Returns an error if either file cannot be processed.
+func writeMigratedConfig(from, to, oldHome, newHome string) error {
+	v := viper.New()
+	v.SetConfigFile(from)
+	v.SetConfigType("yaml")
+	if err := v.ReadInConfig(); err != nil {
+		return fmt.Errorf("failed to read config file %s: %w", from, err)
+	}
+
+	for _, key := range []string{"install_dir", "cache_dir"} {
+		dir, err := expandPath(v.GetString(key))
+		if err != nil {
+			continue
+		}
+		rel, err := filepath.Rel(oldHome, dir)
+		if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			continue
+		}
+		v.Set(key, filepath.Join(newHome, rel))
+	}
+
+	if err := v.WriteConfigAs(to); err != nil {
+		return fmt.Errorf("failed to write config file %s: %w", to, err)
+	}
+
+	return nil
+}
+
+// resolveHomes resolves the config and data directories in precedence order: GOVMAN_HOME for both, ~/.govman for
+// both outside Linux or when it already exists, then the XDG base directories. Returns an error if none can be determined.
+func resolveHomes() (configDir, dataDir string, err error) {
+	if dir := os.Getenv(HomeEnvVar); dir != "" {
+		expanded, err := expandPath(dir)
+		if err != nil {
+			return "", "", fmt.Errorf("invalid %s: %w", HomeEnvVar, err)
+		}
+		if !filepath.IsAbs(expanded) {
+			return "", "", fmt.Errorf("invalid %s %q: must be an absolute path", HomeEnvVar, dir)
+		}
+		expanded = filepath.Clean(expanded)
+		return expanded, expanded, nil
+	}
+
+	homeDir, err := getHomeDir()
+	if err != nil {
+		return "", "", err
+	}
+
+	legacyDir := filepath.Join(homeDir, ".govman")
+	if layoutOS != "linux" || isDir(legacyDir) {
+		return legacyDir, legacyDir, nil
+	}
+
+	configDir = filepath.Join(xdgDir("XDG_CONFIG_HOME", homeDir, ".config"), "govman")
+	dataDir = filepath.Join(xdgDir("XDG_DATA_HOME", homeDir, ".local", "share"), "govman")
+	return configDir, dataDir, nil
+}
+
+// xdgDir returns the XDG base directory named by env, or homeDir joined with fallback when it is unset.
+// Relative values are ignored, as the XDG Base Directory specification requires.
+func xdgDir(env, homeDir string, fallback ...string) string {
+	if dir := os.Getenv(env); filepath.IsAbs(dir) {
+		return dir
+	}
+
+	return filepath.Join(append([]string{homeDir}, fallback...)...)
+}
+
+// isDir reports whether path exists and is a directory.
+func isDir(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.IsDir()
 }
 
 // getHomeDir returns the current user's HOME directory (USERPROFILE on Windows).
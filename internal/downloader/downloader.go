@@ -4,12 +4,16 @@ import (
 	"archive/tar"
 	"archive/zip"
 	"compress/gzip"
+	"context"
 	"crypto/sha256"
+	"errors"
 	"fmt"
 	"io"
+	"math/rand/v2"
 	"net/http"
 	"os"
 	"path/filepath"
+	"runtime"
 	"strings"
 	"time"
 
@@ -17,86 +21,252 @@ import (
 	_golang "github.com/justjundana/govman/internal/golang"
 	_logger "github.com/justjundana/govman/internal/logger"
 	_progress "github.com/justjundana/govman/internal/progress"
+	_signature "github.com/justjundana/govman/internal/signature"
 )
 
+// maxSignatureSize caps how much of a detached signature response is read; real signatures are a few hundred bytes.
+const maxSignatureSize = 64 << 10
+
 // maxExtractFileSize is the maximum size allowed per file during archive extraction (2 GB).
 // This prevents zip bomb attacks from exhausting disk space.
 const maxExtractFileSize = 2 << 30 // 2 GB
 
+// ErrDownload is wrapped by Download errors from fetching the archive, as opposed to verifying or extracting it.
+var ErrDownload = errors.New("failed to download")
+
+// StatusError reports a download response with an unexpected HTTP status.
+type StatusError struct {
+	StatusCode int
+	Status     string
+}
+
+// Error implements the error interface.
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("download failed with status %d: %s", e.StatusCode, e.Status)
+}
+
 type Downloader struct {
 	config *_config.Config
 	client *http.Client
+	source _golang.ReleaseSource
+
+	// sleep and jitter are swappable so tests can exercise retry backoff without real delays.
+	sleep  func(context.Context, time.Duration) error
+	jitter func(time.Duration) time.Duration
 }
 
 // New creates a Downloader using the provided configuration.
-// It initializes an HTTP client with the timeout from cfg.Download.Timeout and returns *Downloader.
+// It initializes an HTTP client with the proxy settings from cfg.Network and the timeout from cfg.Download.Timeout and returns *Downloader.
 func New(cfg *_config.Config) *Downloader {
+	return NewWithSource(cfg, nil)
+}
+
+// NewWithSource is like New but reads archive metadata (checksums and sizes) from source.
+// A nil source follows go_releases.source in cfg.
+func NewWithSource(cfg *_config.Config, source _golang.ReleaseSource) *Downloader {
 	return &Downloader{
 		config: cfg,
-		client: &http.Client{
-			Timeout: cfg.Download.Timeout,
-		},
+		client: cfg.HTTPClient(cfg.Download.Timeout),
+		source: source,
+		sleep:  sleepContext,
+		jitter: randomJitter,
+	}
+}
+
+// releaseSource returns the injected release source, or the one configured by go_releases.source, which warns when
+// it falls back to the built-in release list.
+func (d *Downloader) releaseSource() _golang.ReleaseSource {
+	if d.source != nil {
+		return d.source
+	}
+
+	source := d.config.ReleaseSource()
+	if fallback, ok := source.(*_golang.FallbackSource); ok {
+		fallback.OnFallback = func(err error) {
+			_, generated := _golang.EmbeddedVersions()
+			_logger.Warning("Release API unreachable (%v); using the release list built into govman on %s, which may be outdated", err, generated)
+		}
 	}
+	return source
 }
 
-// Download orchestrates fetching file metadata, downloading the archive, verifying its SHA-256 checksum,
-// and extracting it into installDir for the specified version. Returns an error on any failure.
-func (d *Downloader) Download(url, installDir, version string) error {
+// Download fetches, verifies, and atomically installs the archive into installDir for the specified version.
+// Returns an error on any failure, wrapping ctx.Err() when canceled; a partial archive stays cached to resume.
+func (d *Downloader) Download(ctx context.Context, url, installDir, version string) error {
+	// Load the key first so a misconfigured key fails before a long download
+	var key *_signature.PublicKey
+	if d.config.Download.VerifySignature {
+		if d.config.Offline {
+			return fmt.Errorf("%w: signature verification downloads the signature; turn off download.verify_signature to install from the cache", _golang.ErrOffline)
+		}
+		var err error
+		if key, err = d.signatureKey(); err != nil {
+			return err
+		}
+	}
+
 	_logger.InternalProgress("Retrieving file information")
 	timer := _logger.StartTimer("file info retrieval")
-	fileInfo, err := _golang.GetFileInfoWithConfig(version,
-		d.config.GoReleases.APIURL,
-		d.config.GoReleases.CacheExpiry)
+	fileInfo, err := d.releaseSource().FileInfo(ctx, version)
 	if err != nil {
 		_logger.StopTimer(timer)
 		return fmt.Errorf("failed to get file info: %w", err)
 	}
 	_logger.StopTimer(timer)
 
-	_logger.InternalProgress("Downloading file")
-	archivePath, err := d.downloadFile(url, fileInfo)
-	if err != nil {
-		return fmt.Errorf("failed to download: %w", err)
+	archivePath, cached := d.cachedArchive(url, fileInfo)
+	if !cached {
+		_logger.InternalProgress("Downloading file")
+		archivePath, err = d.downloadFile(ctx, url, fileInfo)
+		if err != nil {
+			return fmt.Errorf("%w: %w", ErrDownload, err)
+		}
+
+		// Note: We intentionally don't delete the archive here to preserve the cache.
+		// Users can run 'govman clean' to manage cache when needed.
+
+		_logger.InternalProgress("Verifying checksum")
+		timer = _logger.StartTimer("checksum verification")
+		if err := d.verifyChecksum(archivePath, fileInfo.Sha256); err != nil {
+			_logger.StopTimer(timer)
+			// Remove corrupted file from cache
+			os.Remove(archivePath)
+			return fmt.Errorf("checksum verification failed: %w", err)
+		}
+		_logger.StopTimer(timer)
 	}
 
-	// Note: We intentionally don't delete the archive here to preserve the cache.
-	// Users can run 'govman clean' to manage cache when needed.
+	// Remember the verified checksum so offline mode can install this archive from the cache later
+	if err := _golang.RecordArchiveChecksum(d.config.CacheDir, filepath.Base(archivePath), fileInfo.Sha256); err != nil {
+		_logger.Verbose("Failed to record archive checksum: %v", err)
+	}
 
-	_logger.InternalProgress("Verifying checksum")
-	timer = _logger.StartTimer("checksum verification")
-	if err := d.verifyChecksum(archivePath, fileInfo.Sha256); err != nil {
+	if key != nil {
+		_logger.InternalProgress("Verifying signature")
+		timer = _logger.StartTimer("signature verification")
+		err := d.verifySignature(ctx, key, url, archivePath)
 		_logger.StopTimer(timer)
-		// Remove corrupted file from cache
-		os.Remove(archivePath)
-		return fmt.Errorf("checksum verification failed: %w", err)
+		if err != nil {
+			if errors.Is(err, _signature.ErrInvalidSignature) {
+				// Never reuse an archive that failed verification
+				os.Remove(archivePath)
+			}
+			return fmt.Errorf("signature verification failed: %w", err)
+		}
 	}
-	_logger.StopTimer(timer)
 
 	_logger.InternalProgress("Extracting archive")
 	timer = _logger.StartTimer("archive extraction")
-	if err := d.extractArchive(archivePath, installDir); err != nil {
+	if err := d.InstallArchive(ctx, archivePath, installDir, version); err != nil {
 		_logger.StopTimer(timer)
-		return fmt.Errorf("failed to extract archive: %w", err)
+		return err
 	}
 	_logger.StopTimer(timer)
 
 	return nil
 }
 
+// InstallArchive extracts archivePath into a temporary sibling of installDir and renames it into place once
+// ValidateToolchain passes, without checksum checks of its own. Returns an error on failure.
+func (d *Downloader) InstallArchive(ctx context.Context, archivePath, installDir, version string) error {
+	parent := filepath.Dir(installDir)
+	if err := os.MkdirAll(parent, 0755); err != nil {
+		return fmt.Errorf("failed to create install directory: %w", err)
+	}
+
+	// The leading dot keeps temporary directories out of the installed version list
+	tmpPrefix := "." + filepath.Base(installDir) + ".tmp-"
+	if stale, err := filepath.Glob(filepath.Join(parent, tmpPrefix+"*")); err == nil {
+		for _, dir := range stale {
+			_logger.Verbose("Removing leftover temporary install directory: %s", dir)
+			os.RemoveAll(dir)
+		}
+	}
+
+	tmpDir, err := os.MkdirTemp(parent, tmpPrefix)
+	if err != nil {
+		return fmt.Errorf("failed to create temporary install directory: %w", err)
+	}
+
+	if err := d.extractArchive(ctx, archivePath, tmpDir); err != nil {
+		os.RemoveAll(tmpDir)
+		return fmt.Errorf("failed to extract archive: %w", err)
+	}
+
+	if err := ValidateToolchain(tmpDir, version); err != nil {
+		os.RemoveAll(tmpDir)
+		return fmt.Errorf("extracted archive is not a usable Go %s toolchain: %w", version, err)
+	}
+
+	// MkdirTemp creates the directory as 0700; installed versions must be readable by other users
+	if err := os.Chmod(tmpDir, 0755); err != nil {
+		os.RemoveAll(tmpDir)
+		return fmt.Errorf("failed to set install directory permissions: %w", err)
+	}
+
+	if err := os.Rename(tmpDir, installDir); err != nil {
+		os.RemoveAll(tmpDir)
+		return fmt.Errorf("failed to move extracted files into place: %w", err)
+	}
+
+	return nil
+}
+
+// cachedArchive looks for a complete archive for url in the cache directory and verifies its checksum.
+// Invalid or oversized entries are removed so they are downloaded again; with caching disabled any existing entry is removed.
+// Returns the cached path and true on a verified hit, or false when the archive must be fetched.
+func (d *Downloader) cachedArchive(url string, fileInfo *_golang.File) (string, bool) {
+	filename := filepath.Base(url)
+	cachePath := filepath.Join(d.config.CacheDir, filename)
+
+	if !d.config.Download.UseCache {
+		if err := os.Remove(cachePath); err == nil {
+			_logger.Verbose("Cache disabled, discarded cached %s", filename)
+		}
+		return "", false
+	}
+
+	// Sources without sizes report 0; the checksum below then decides whether the cached file is complete
+	stat, err := os.Stat(cachePath)
+	if err != nil || stat.Size() < fileInfo.Size {
+		// Missing, or a partial download that downloadFile will resume
+		return "", false
+	}
+
+	if fileInfo.Size > 0 && stat.Size() > fileInfo.Size {
+		_logger.Verbose("Cached %s is larger than expected, downloading a fresh copy", filename)
+		os.Remove(cachePath)
+		return "", false
+	}
+
+	timer := _logger.StartTimer("cached archive verification")
+	err = d.verifyChecksum(cachePath, fileInfo.Sha256)
+	_logger.StopTimer(timer)
+	if err != nil {
+		_logger.Warning("Cached %s failed verification, downloading a fresh copy: %v", filename, err)
+		os.Remove(cachePath)
+		return "", false
+	}
+
+	_logger.WithFields(_logger.Fields{"file": filename, "bytes": stat.Size()}).Success("Using cached file: %s", filename)
+	return cachePath, true
+}
+
 // downloadFile downloads (or resumes) the archive to the cache directory with retries and a progress bar.
-// Parameters: url (download URL), fileInfo (expected file metadata). Returns the cached file path or an error.
-func (d *Downloader) downloadFile(url string, fileInfo *_golang.File) (string, error) {
+// Parameters: ctx (cancels the request and retry waits), url (download URL), fileInfo (expected file metadata).
+// Returns the cached file path or an error.
+func (d *Downloader) downloadFile(ctx context.Context, url string, fileInfo *_golang.File) (string, error) {
 	filename := filepath.Base(url)
 	cachePath := filepath.Join(d.config.CacheDir, filename)
 
 	if stat, err := os.Stat(cachePath); err == nil {
-		if stat.Size() == fileInfo.Size {
-			_logger.Success("Using cached file: %s", filename)
+		if fileInfo.Size > 0 && stat.Size() == fileInfo.Size {
+			_logger.WithFields(_logger.Fields{"file": filename, "bytes": stat.Size()}).Success("Using cached file: %s", filename)
 			return cachePath, nil
 		}
-		_logger.Download("Resuming download: %s", filename)
+		_logger.WithFields(_logger.Fields{"file": filename, "bytes": fileInfo.Size, "offset": stat.Size()}).Download("Resuming download: %s", filename)
 	} else {
-		_logger.Download("Downloading: %s", filename)
+		_logger.WithFields(_logger.Fields{"file": filename, "bytes": fileInfo.Size}).Download("Downloading: %s", filename)
 	}
 
 	file, err := os.OpenFile(cachePath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
@@ -111,7 +281,7 @@ func (d *Downloader) downloadFile(url string, fileInfo *_golang.File) (string, e
 	}
 	currentSize := stat.Size()
 
-	req, err := http.NewRequest("GET", url, nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
 		return "", fmt.Errorf("failed to create request: %w", err)
 	}
@@ -120,45 +290,82 @@ func (d *Downloader) downloadFile(url string, fileInfo *_golang.File) (string, e
 		req.Header.Set("Range", fmt.Sprintf("bytes=%d-", currentSize))
 	}
 
+	maxAttempts := d.config.Download.RetryCount
+	if maxAttempts < 1 {
+		maxAttempts = 1
+	}
+
 	var resp *http.Response
-	for attempt := 0; attempt < d.config.Download.RetryCount; attempt++ {
+	for attempt := 1; ; attempt++ {
 		resp, err = d.client.Do(req)
-		if err != nil {
-			if attempt < d.config.Download.RetryCount-1 {
-				_logger.Warning("Download failed, retrying in %v... (%d/%d)",
-					d.config.Download.RetryDelay, attempt+1, d.config.Download.RetryCount)
-				time.Sleep(d.config.Download.RetryDelay)
-				continue
+		if ctx.Err() != nil {
+			return "", fmt.Errorf("download interrupted: %w", ctx.Err())
+		}
+		if err == nil {
+			if resp.StatusCode == http.StatusOK || resp.StatusCode == http.StatusPartialContent {
+				break
+			}
+			resp.Body.Close()
+			err = &StatusError{StatusCode: resp.StatusCode, Status: resp.Status}
+			if !isRetryableStatus(resp.StatusCode) {
+				return "", err
 			}
-			return "", fmt.Errorf("failed to download after %d attempts: %w",
-				attempt+1, err)
 		}
-		break
-	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusPartialContent {
-		return "", fmt.Errorf("download failed with status %d: %s", resp.StatusCode, resp.Status)
+		if attempt >= maxAttempts {
+			return "", fmt.Errorf("failed to download after %d attempts: %w", attempt, err)
+		}
+
+		delay := d.retryBackoff(attempt)
+		_logger.Warning("Download attempt %d/%d failed: %v - retrying in %v...",
+			attempt, maxAttempts, err, delay.Round(time.Millisecond))
+		if err := d.sleep(ctx, delay); err != nil {
+			return "", fmt.Errorf("download interrupted: %w", err)
+		}
 	}
+	defer resp.Body.Close()
 
+	// A total of zero or less puts the progress bar in indeterminate (spinner) mode
 	totalSize := fileInfo.Size
 	if resp.StatusCode == http.StatusPartialContent {
-		totalSize = currentSize + resp.ContentLength
+		if resp.ContentLength >= 0 {
+			totalSize = currentSize + resp.ContentLength
+		}
+	} else {
+		if currentSize > 0 {
+			// The server ignored the Range header and sent the whole file, so start over
+			if err := file.Truncate(0); err != nil {
+				return "", fmt.Errorf("failed to reset partial cache file: %w", err)
+			}
+			currentSize = 0
+		}
+		if totalSize <= 0 {
+			// The release API gave no size, so rely on the response, which is -1 for chunked transfers
+			totalSize = resp.ContentLength
+		}
 	}
 
-	progressBar := _progress.New(totalSize, fmt.Sprintf("Downloading %s", filename))
+	progressBar := newProgressBar(totalSize, fmt.Sprintf("Downloading %s", filename))
 	if progressBar != nil {
 		progressBar.Set(currentSize)
 	}
 
-	var reader io.Reader
+	// Throttle before the progress bar, so its speed and ETA show the limited rate
+	var reader io.Reader = resp.Body
+	if limit := d.config.Download.RateLimit; limit > 0 {
+		reader = &rateLimitedReader{ctx: ctx, r: reader, limit: limit, start: time.Now(), now: time.Now, sleep: d.sleep}
+	}
 	if progressBar != nil {
-		reader = io.TeeReader(resp.Body, progressBar)
-	} else {
-		reader = resp.Body
+		reader = io.TeeReader(reader, progressBar)
 	}
 
 	if _, err := io.Copy(file, reader); err != nil {
+		if progressBar != nil {
+			progressBar.Abort()
+		}
+		if ctx.Err() != nil {
+			return "", fmt.Errorf("download interrupted: %w", ctx.Err())
+		}
 		return "", fmt.Errorf("failed to write file: %w", err)
 	}
 
@@ -168,68 +375,349 @@ func (d *Downloader) downloadFile(url string, fileInfo *_golang.File) (string, e
 	return cachePath, nil
 }
 
+// stderrIsTerminal reports whether stderr, where progress bars are drawn, is a terminal. Replaced in tests.
+var stderrIsTerminal = func() bool {
+	info, err := os.Stderr.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// newProgressBar returns a progress bar for one phase of an install, or nil when it would be noise: in quiet mode,
+// with JSON logs it would corrupt, or when stderr is not a terminal and its redraws would fill a log file.
+func newProgressBar(total int64, description string) *_progress.ProgressBar {
+	if _logger.Get().Level() < _logger.NormalLevel || _logger.Get().Format() != _logger.TextFormat || !stderrIsTerminal() {
+		return nil
+	}
+	return _progress.New(total, description)
+}
+
+// retryBackoff returns the delay before the next attempt: RetryDelay doubled for each failed attempt,
+// capped at MaxRetryDelay, plus jitter so concurrent clients don't retry in lockstep.
+func (d *Downloader) retryBackoff(attempt int) time.Duration {
+	delay := d.config.Download.RetryDelay
+	for i := 1; i < attempt && delay > 0; i++ {
+		delay *= 2
+	}
+
+	if maxDelay := d.config.Download.MaxRetryDelay; maxDelay > 0 && (delay > maxDelay || delay < 0) {
+		delay = maxDelay
+	}
+
+	return delay + d.jitter(delay)
+}
+
+// Backoff waits before retrying a failed request after attempt failed attempts, with the same delays as download
+// retries. Returns ctx's error if ctx is canceled while waiting.
+func (d *Downloader) Backoff(ctx context.Context, attempt int) error {
+	return d.sleep(ctx, d.retryBackoff(attempt))
+}
+
+// rateLimitedReader reads from r at no more than limit bytes per second on average since start. It waits with sleep,
+// which returns ctx's error as soon as ctx is canceled, so a throttled download stops promptly when interrupted.
+type rateLimitedReader struct {
+	ctx   context.Context
+	r     io.Reader
+	limit int64
+	start time.Time
+	read  int64
+	now   func() time.Time
+	sleep func(context.Context, time.Duration) error
+}
+
+// Read reads at most a tenth of a second's worth of data, then waits until the total read is within the limit.
+// Returns the bytes read, and ctx's error if it was canceled while waiting.
+func (l *rateLimitedReader) Read(p []byte) (int, error) {
+	// Small reads keep the rate smooth instead of bursting a whole buffer and then stalling
+	if burst := max(l.limit/10, 1); int64(len(p)) > burst {
+		p = p[:burst]
+	}
+
+	n, err := l.r.Read(p)
+	l.read += int64(n)
+
+	due := l.start.Add(time.Duration(float64(l.read) / float64(l.limit) * float64(time.Second)))
+	if wait := due.Sub(l.now()); wait > 0 {
+		if sleepErr := l.sleep(l.ctx, wait); sleepErr != nil {
+			return n, sleepErr
+		}
+	}
+	return n, err
+}
+
+// sleepContext waits for delay or until ctx is canceled. Returns ctx.Err() if canceled first.
+func sleepContext(ctx context.Context, delay time.Duration) error {
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// randomJitter returns a random duration in [0, delay/2].
+func randomJitter(delay time.Duration) time.Duration {
+	if delay <= 0 {
+		return 0
+	}
+	return rand.N(delay/2 + 1)
+}
+
+// isRetryableStatus reports whether an HTTP status indicates a transient failure worth retrying.
+// Client errors such as 404 (nonexistent version) are permanent and fail immediately.
+func isRetryableStatus(status int) bool {
+	return status >= 500 || status == http.StatusRequestTimeout || status == http.StatusTooManyRequests
+}
+
+// signatureKey loads the public key named by download.signature_key.
+// Returns an error if no key is configured or it cannot be read.
+func (d *Downloader) signatureKey() (*_signature.PublicKey, error) {
+	if d.config.Download.SignatureKey == "" {
+		return nil, errors.New("signature verification requires download.signature_key to name a public key file")
+	}
+	key, err := _signature.LoadPublicKey(d.config.Download.SignatureKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load signature key %s: %w", d.config.Download.SignatureKey, err)
+	}
+	return key, nil
+}
+
+// verifySignature checks archivePath against key using the detached signature published next to url, skipping
+// with a warning when there is none (404). Returns an error wrapping signature.ErrInvalidSignature on a bad signature.
+func (d *Downloader) verifySignature(ctx context.Context, key *_signature.PublicKey, url, archivePath string) error {
+	sigURL := url + key.Kind().Extension()
+	_logger.Verify("Verifying %s signature...", key.Kind())
+
+	req, err := http.NewRequestWithContext(ctx, "GET", sigURL, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to download signature: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		_logger.Warning("No %s signature is published at %s, skipping signature verification", key.Kind(), sigURL)
+		return nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to download signature: %w", &StatusError{StatusCode: resp.StatusCode, Status: resp.Status})
+	}
+
+	sig, err := io.ReadAll(io.LimitReader(resp.Body, maxSignatureSize))
+	if err != nil {
+		return fmt.Errorf("failed to read signature: %w", err)
+	}
+
+	if err := key.Verify(archivePath, sig); err != nil {
+		return err
+	}
+
+	_logger.WithFields(_logger.Fields{"signature": sigURL}).Success("Signature verified")
+	return nil
+}
+
 // verifyChecksum computes the SHA-256 of filePath and compares it to expectedSHA256.
 // Returns an error on mismatch or I/O failure; nil when the checksum matches.
 func (d *Downloader) verifyChecksum(filePath, expectedSHA256 string) error {
 	_logger.Verify("Verifying checksum...")
 
+	actualSHA256, err := fileSHA256(filePath)
+	if err != nil {
+		return err
+	}
+	if actualSHA256 != expectedSHA256 {
+		return fmt.Errorf("checksum mismatch: expected %s, got %s",
+			expectedSHA256, actualSHA256)
+	}
+
+	_logger.WithFields(_logger.Fields{"sha256": actualSHA256}).Success("Checksum verified")
+	return nil
+}
+
+// fileSHA256 returns the hex-encoded SHA-256 of the file at filePath, or an error if it cannot be read.
+func fileSHA256(filePath string) (string, error) {
 	file, err := os.Open(filePath)
 	if err != nil {
-		return fmt.Errorf("failed to open file: %w", err)
+		return "", fmt.Errorf("failed to open file: %w", err)
 	}
 	defer file.Close()
 
 	hasher := sha256.New()
 	if _, err := io.Copy(hasher, file); err != nil {
-		return fmt.Errorf("failed to calculate checksum: %w", err)
+		return "", fmt.Errorf("failed to calculate checksum: %w", err)
 	}
 
-	actualSHA256 := fmt.Sprintf("%x", hasher.Sum(nil))
-	if actualSHA256 != expectedSHA256 {
-		return fmt.Errorf("checksum mismatch: expected %s, got %s",
-			expectedSHA256, actualSHA256)
+	return fmt.Sprintf("%x", hasher.Sum(nil)), nil
+}
+
+// VerifyCachedArchive checks the cached archive of version for this platform against the checksum from the release source,
+// without logging or removing anything. Returns false when there is no complete cached archive to check, or an error
+// if the release metadata cannot be read or the checksum does not match.
+func (d *Downloader) VerifyCachedArchive(ctx context.Context, version string) (bool, error) {
+	fileInfo, err := d.releaseSource().FileInfo(ctx, version)
+	if err != nil {
+		return false, fmt.Errorf("failed to get file info: %w", err)
+	}
+
+	cachePath := filepath.Join(d.config.CacheDir, fileInfo.Filename)
+	if stat, err := os.Stat(cachePath); err != nil || (fileInfo.Size > 0 && stat.Size() != fileInfo.Size) {
+		return false, nil
+	}
+
+	actualSHA256, err := fileSHA256(cachePath)
+	if err != nil {
+		return true, err
+	}
+	if actualSHA256 != fileInfo.Sha256 {
+		return true, fmt.Errorf("checksum mismatch for %s: expected %s, got %s", fileInfo.Filename, fileInfo.Sha256, actualSHA256)
+	}
+	return true, nil
+}
+
+// VerifyRecordedArchive checks archivePath against the checksum recorded when the official archive of version was
+// last downloaded and verified. Returns false when none was recorded, or an error if the checksum does not match.
+func (d *Downloader) VerifyRecordedArchive(archivePath, version string) (bool, error) {
+	expectedSHA256 := _golang.RecordedArchiveChecksum(d.config.CacheDir, _golang.ArchiveName(version))
+	if expectedSHA256 == "" {
+		return false, nil
+	}
+	return true, d.verifyChecksum(archivePath, expectedSHA256)
+}
+
+// ValidateToolchain checks that root holds a usable Go toolchain: a bin/go binary (bin/go.exe on Windows),
+// a pkg directory, and a VERSION file whose first line names version. Returns an error describing the first problem.
+func ValidateToolchain(root, version string) error {
+	goBinary := filepath.Join(root, "bin", "go")
+	if runtime.GOOS == "windows" {
+		goBinary += ".exe"
+	}
+	if info, err := os.Stat(goBinary); err != nil || !info.Mode().IsRegular() {
+		return fmt.Errorf("missing %s", filepath.Join("bin", filepath.Base(goBinary)))
+	}
+
+	if info, err := os.Stat(filepath.Join(root, "pkg")); err != nil || !info.IsDir() {
+		return fmt.Errorf("missing pkg directory")
+	}
+
+	data, err := os.ReadFile(filepath.Join(root, "VERSION"))
+	if err != nil {
+		return fmt.Errorf("unreadable VERSION file: %w", err)
+	}
+	firstLine, _, _ := strings.Cut(string(data), "\n")
+	if got := strings.TrimSpace(firstLine); got != "go"+version {
+		return fmt.Errorf("VERSION file reports %q, expected %q", got, "go"+version)
 	}
 
-	_logger.Success("Checksum verified")
 	return nil
 }
 
-// extractArchive ensures installDir exists and extracts archivePath based on its extension (.tar.gz or .zip).
-// Returns an error for unsupported formats or extraction failures.
-func (d *Downloader) extractArchive(archivePath, installDir string) error {
+// extractArchive extracts archivePath into installDir as a .tar.gz or .zip, detected from its leading bytes or else
+// its extension, stopping between entries once ctx is canceled. Returns an error on failure.
+func (d *Downloader) extractArchive(ctx context.Context, archivePath, installDir string) error {
 	_logger.Extract("Extracting archive...")
 
 	if err := os.MkdirAll(installDir, 0755); err != nil {
 		return fmt.Errorf("failed to create install directory: %w", err)
 	}
 
+	switch detectArchiveFormat(archivePath) {
+	case ".tar.gz":
+		return d.extractTarGz(ctx, archivePath, installDir)
+	case ".zip":
+		return d.extractZip(ctx, archivePath, installDir)
+	}
+
+	return fmt.Errorf("unsupported archive format")
+}
+
+// detectArchiveFormat returns ".tar.gz" or ".zip" for archivePath from its magic bytes (gzip 1f 8b, zip PK),
+// falling back to its extension when the content is neither, so a corrupt archive still reports why it cannot be read.
+// Returns "" when neither identifies a supported format.
+func detectArchiveFormat(archivePath string) string {
+	extension := ""
 	if strings.HasSuffix(archivePath, ".tar.gz") {
-		return d.extractTarGz(archivePath, installDir)
+		extension = ".tar.gz"
 	} else if strings.HasSuffix(archivePath, ".zip") {
-		return d.extractZip(archivePath, installDir)
+		extension = ".zip"
 	}
 
-	return fmt.Errorf("unsupported archive format")
+	file, err := os.Open(archivePath)
+	if err != nil {
+		return extension
+	}
+	defer file.Close()
+
+	magic := make([]byte, 2)
+	if _, err := io.ReadFull(file, magic); err != nil {
+		return extension
+	}
+
+	detected := extension
+	switch {
+	case magic[0] == 0x1f && magic[1] == 0x8b:
+		detected = ".tar.gz"
+	case magic[0] == 'P' && magic[1] == 'K':
+		detected = ".zip"
+	}
+	if detected != extension {
+		_logger.Verbose("Archive %s is a %s archive despite its name", filepath.Base(archivePath), detected)
+	}
+	return detected
 }
 
 // extractTarGz extracts a .tar.gz archive into installDir with path safety checks and file permissions preserved.
 // Returns an error on I/O issues or unsafe paths.
-func (d *Downloader) extractTarGz(archivePath, installDir string) error {
+func (d *Downloader) extractTarGz(ctx context.Context, archivePath, installDir string) error {
 	file, err := os.Open(archivePath)
 	if err != nil {
 		return fmt.Errorf("failed to open archive: %w", err)
 	}
 	defer file.Close()
 
-	gzReader, err := gzip.NewReader(file)
+	// Progress follows the compressed bytes read, whose total is known up front from the archive size
+	var archive io.Reader = file
+	var progressBar *_progress.ProgressBar
+	if info, err := file.Stat(); err == nil {
+		progressBar = newProgressBar(info.Size(), fmt.Sprintf("Extracting %s", filepath.Base(archivePath)))
+	}
+	if progressBar != nil {
+		archive = io.TeeReader(file, progressBar)
+	}
+
+	gzReader, err := gzip.NewReader(archive)
 	if err != nil {
+		if progressBar != nil {
+			progressBar.Abort()
+		}
 		return fmt.Errorf("failed to create gzip reader: %w", err)
 	}
 	defer gzReader.Close()
 
-	tarReader := tar.NewReader(gzReader)
+	if err := extractTarEntries(ctx, tar.NewReader(gzReader), installDir); err != nil {
+		if progressBar != nil {
+			progressBar.Abort()
+		}
+		return err
+	}
+
+	if progressBar != nil {
+		progressBar.Finish()
+	}
+	return nil
+}
 
+// extractTarEntries writes the entries of tarReader into installDir, rejecting unsafe paths and symlinks.
+// Stops between entries once ctx is canceled. Returns an error on I/O issues or unsafe paths.
+func extractTarEntries(ctx context.Context, tarReader *tar.Reader, installDir string) error {
 	for {
+		if err := ctx.Err(); err != nil {
+			return fmt.Errorf("extraction interrupted: %w", err)
+		}
+
 		header, err := tarReader.Next()
 		if err == io.EOF {
 			break
@@ -305,14 +793,41 @@ func (d *Downloader) extractTarGz(archivePath, installDir string) error {
 
 // extractZip extracts a .zip archive into installDir with path safety checks and directory creation as needed.
 // Returns an error on I/O issues or unsafe paths.
-func (d *Downloader) extractZip(archivePath, installDir string) error {
+func (d *Downloader) extractZip(ctx context.Context, archivePath, installDir string) error {
 	reader, err := zip.OpenReader(archivePath)
 	if err != nil {
 		return fmt.Errorf("failed to open zip archive: %w", err)
 	}
 	defer reader.Close()
 
+	// Progress follows the uncompressed bytes written, as the zip directory lists every entry's size
+	var total int64
 	for _, file := range reader.File {
+		total += int64(min(file.UncompressedSize64, maxExtractFileSize))
+	}
+	progressBar := newProgressBar(total, fmt.Sprintf("Extracting %s", filepath.Base(archivePath)))
+
+	if err := extractZipEntries(ctx, reader.File, installDir, progressBar); err != nil {
+		if progressBar != nil {
+			progressBar.Abort()
+		}
+		return err
+	}
+
+	if progressBar != nil {
+		progressBar.Finish()
+	}
+	return nil
+}
+
+// extractZipEntries writes files into installDir, rejecting unsafe paths, and adds the bytes written to progressBar
+// when it is not nil. Stops between entries once ctx is canceled. Returns an error on I/O issues or unsafe paths.
+func extractZipEntries(ctx context.Context, files []*zip.File, installDir string, progressBar *_progress.ProgressBar) error {
+	for _, file := range files {
+		if err := ctx.Err(); err != nil {
+			return fmt.Errorf("extraction interrupted: %w", err)
+		}
+
 		path := file.Name
 		if strings.HasPrefix(path, "go/") || strings.HasPrefix(path, "go\\") {
 			path = path[3:]
@@ -358,7 +873,11 @@ func (d *Downloader) extractZip(archivePath, installDir string) error {
 			return fmt.Errorf("failed to create file %s: %w", targetPath, err)
 		}
 
-		if _, err := io.Copy(dstFile, io.LimitReader(srcFile, maxExtractFileSize)); err != nil {
+		var src io.Reader = io.LimitReader(srcFile, maxExtractFileSize)
+		if progressBar != nil {
+			src = io.TeeReader(src, progressBar)
+		}
+		if _, err := io.Copy(dstFile, src); err != nil {
 			srcFile.Close()
 			dstFile.Close()
 			return fmt.Errorf("failed to write file %s: %w", targetPath, err)
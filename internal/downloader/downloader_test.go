@@ -5,18 +5,25 @@ import (
 	"archive/zip"
 	"bytes"
 	"compress/gzip"
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
 	"crypto/sha256"
+	"encoding/base64"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"os"
 	"path/filepath"
+	"runtime"
 	"strings"
 	"testing"
 	"time"
 
 	_config "github.com/justjundana/govman/internal/config"
 	_golang "github.com/justjundana/govman/internal/golang"
+	_signature "github.com/justjundana/govman/internal/signature"
 )
 
 // createTestConfig creates a test configuration with temporary directories
@@ -30,6 +37,7 @@ func createTestConfig(t *testing.T) *_config.Config {
 			Timeout:    30 * time.Second,
 			RetryCount: 3,
 			RetryDelay: 1 * time.Second,
+			UseCache:   true,
 		},
 		GoReleases: _config.GoReleasesConfig{
 			APIURL:      "https://api.github.com/repos/golang/go/releases",
@@ -46,7 +54,9 @@ func createTestConfig(t *testing.T) *_config.Config {
 
 // createTestDownloader creates a downloader instance for testing
 func createTestDownloader(t *testing.T, config *_config.Config) *Downloader {
-	return New(config)
+	d := New(config)
+	d.sleep = func(context.Context, time.Duration) error { return nil }
+	return d
 }
 
 // mockFileInfo creates a mock File struct for testing
@@ -127,7 +137,7 @@ func TestDownloader_downloadFile_Cached(t *testing.T) {
 	fileInfo.Filename = "cached-file.tar.gz"
 	fileInfo.Size = int64(len(testContent))
 
-	resultPath, err := downloader.downloadFile("http://example.com/cached-file.tar.gz", fileInfo)
+	resultPath, err := downloader.downloadFile(context.Background(), "http://example.com/cached-file.tar.gz", fileInfo)
 	if err != nil {
 		t.Fatalf("downloadFile with cached file failed: %v", err)
 	}
@@ -137,6 +147,95 @@ func TestDownloader_downloadFile_Cached(t *testing.T) {
 	}
 }
 
+func TestDownloader_cachedArchive(t *testing.T) {
+	content := "cached archive content"
+	sum := fmt.Sprintf("%x", sha256.Sum256([]byte(content)))
+
+	testCases := []struct {
+		name        string
+		cached      string
+		useCache    bool
+		expectHit   bool
+		expectKept  bool
+		expectedSum string
+	}{
+		{name: "Valid archive is reused", cached: content, useCache: true, expectHit: true, expectKept: true},
+		{name: "Checksum mismatch is discarded", cached: strings.ToUpper(content), useCache: true, expectHit: false, expectKept: false},
+		{name: "Partial download is kept for resume", cached: content[:5], useCache: true, expectHit: false, expectKept: true},
+		{name: "Oversized file is discarded", cached: content + "extra", useCache: true, expectHit: false, expectKept: false},
+		{name: "Cache disabled discards valid archive", cached: content, useCache: false, expectHit: false, expectKept: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			config := createTestConfig(t)
+			config.Download.UseCache = tc.useCache
+			downloader := createTestDownloader(t, config)
+
+			cachePath := filepath.Join(config.CacheDir, "go1.21.0.linux-amd64.tar.gz")
+			if err := os.WriteFile(cachePath, []byte(tc.cached), 0644); err != nil {
+				t.Fatalf("Failed to create cached file: %v", err)
+			}
+
+			fileInfo := mockFileInfo()
+			fileInfo.Sha256 = sum
+			fileInfo.Size = int64(len(content))
+
+			path, hit := downloader.cachedArchive("https://go.dev/dl/go1.21.0.linux-amd64.tar.gz", fileInfo)
+			if hit != tc.expectHit {
+				t.Errorf("Expected hit=%v, got %v", tc.expectHit, hit)
+			}
+			if hit && path != cachePath {
+				t.Errorf("Expected cached path %s, got %s", cachePath, path)
+			}
+
+			_, err := os.Stat(cachePath)
+			if kept := err == nil; kept != tc.expectKept {
+				t.Errorf("Expected cached file kept=%v, got %v", tc.expectKept, kept)
+			}
+		})
+	}
+
+	t.Run("Missing file is a miss", func(t *testing.T) {
+		config := createTestConfig(t)
+		downloader := createTestDownloader(t, config)
+
+		if _, hit := downloader.cachedArchive("https://go.dev/dl/go1.21.0.linux-amd64.tar.gz", mockFileInfo()); hit {
+			t.Error("Expected miss for missing file")
+		}
+	})
+}
+
+func TestDownloader_downloadFile_RangeIgnored(t *testing.T) {
+	config := createTestConfig(t)
+	downloader := createTestDownloader(t, config)
+
+	content := "complete file content"
+	cachePath := filepath.Join(config.CacheDir, "range-ignored.txt")
+	if err := os.WriteFile(cachePath, []byte("stale"), 0644); err != nil {
+		t.Fatalf("Failed to create partial file: %v", err)
+	}
+
+	// Server always answers 200 with the full body, even for range requests
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(content))
+	}))
+	defer server.Close()
+
+	fileInfo := mockFileInfo()
+	fileInfo.Size = int64(len(content))
+
+	path, err := downloader.downloadFile(context.Background(), server.URL+"/range-ignored.txt", fileInfo)
+	if err != nil {
+		t.Fatalf("downloadFile failed: %v", err)
+	}
+
+	data, _ := os.ReadFile(path)
+	if string(data) != content {
+		t.Errorf("Expected partial file to be replaced with %q, got %q", content, string(data))
+	}
+}
+
 // TestDownloader_downloadFile_Timeout tests timeout handling
 func TestDownloader_downloadFile_Timeout(t *testing.T) {
 	config := createTestConfig(t)
@@ -152,7 +251,7 @@ func TestDownloader_downloadFile_Timeout(t *testing.T) {
 	fileInfo := mockFileInfo()
 	fileInfo.Size = 17
 
-	_, err := downloader.downloadFile(server.URL, fileInfo)
+	_, err := downloader.downloadFile(context.Background(), server.URL, fileInfo)
 	if err == nil {
 		t.Error("Expected timeout error but got none")
 	}
@@ -229,7 +328,7 @@ func TestDownloader_Download(t *testing.T) {
 			name:          "Download with no matching files",
 			version:       "1.19.0",
 			mockResponse:  `[{"version":"go1.20.0","stable":true,"files":[{"filename":"go1.20.0.darwin-amd64.tar.gz","os":"darwin","arch":"amd64","version":"go1.20.0","sha256":"1234567890abcdef","size":1024,"kind":"archive"}]}]`,
-			expectedError: "no file info available",
+			expectedError: "not a known release",
 		},
 		{
 			name:          "Successful download",
@@ -253,6 +352,13 @@ func TestDownloader_Download(t *testing.T) {
 				}
 				tarWriter.WriteHeader(header)
 				tarWriter.Write([]byte(content))
+
+				// Minimal toolchain layout so post-extraction validation passes
+				tarWriter.WriteHeader(&tar.Header{Name: "go/pkg/", Mode: 0755, Typeflag: tar.TypeDir})
+				for name, body := range map[string]string{"go/bin/go": "binary", "go/VERSION": "go1.21.0\n"} {
+					tarWriter.WriteHeader(&tar.Header{Name: name, Size: int64(len(body)), Mode: 0755})
+					tarWriter.Write([]byte(body))
+				}
 				tarWriter.Close()
 				gzWriter.Close()
 
@@ -307,7 +413,7 @@ func TestDownloader_Download(t *testing.T) {
 			}
 
 			installDir := filepath.Join(config.InstallDir, "test")
-			err := downloader.Download(downloadURL, installDir, tc.version)
+			err := downloader.Download(context.Background(), downloadURL, installDir, tc.version)
 
 			if tc.expectedError != "" {
 				if err == nil {
@@ -380,7 +486,7 @@ func TestDownloader_downloadFile(t *testing.T) {
 			fileInfo := mockFileInfo()
 			fileInfo.Size = int64(len(tc.fileContent))
 
-			cachePath, err := downloader.downloadFile(server.URL, fileInfo)
+			cachePath, err := downloader.downloadFile(context.Background(), server.URL, fileInfo)
 
 			if tc.expectError {
 				if err == nil {
@@ -461,7 +567,7 @@ func TestDownloader_downloadFile_Resume(t *testing.T) {
 			fileInfo.Size = int64(len(tc.testContent))
 			fileInfo.Filename = "test-resume.txt"
 
-			downloadedPath, err := downloader.downloadFile(server.URL, fileInfo)
+			downloadedPath, err := downloader.downloadFile(context.Background(), server.URL, fileInfo)
 
 			if tc.expectError {
 				if err == nil {
@@ -593,7 +699,7 @@ func TestDownloader_extractArchive(t *testing.T) {
 			}
 			defer os.Remove(archiveFile)
 
-			err = downloader.extractArchive(archiveFile, installDir)
+			err = downloader.extractArchive(context.Background(), archiveFile, installDir)
 
 			if tc.expectError {
 				if err == nil || !strings.Contains(err.Error(), tc.errorContains) {
@@ -680,7 +786,7 @@ func TestDownloader_extractTarGz(t *testing.T) {
 			}
 			defer os.Remove(tarFile)
 
-			err = downloader.extractTarGz(tarFile, installDir)
+			err = downloader.extractTarGz(context.Background(), tarFile, installDir)
 
 			if tc.expectError {
 				if err == nil {
@@ -784,7 +890,7 @@ func TestDownloader_extractZip(t *testing.T) {
 			}
 			defer os.Remove(zipFile)
 
-			err = downloader.extractZip(zipFile, installDir)
+			err = downloader.extractZip(context.Background(), zipFile, installDir)
 
 			if tc.expectError {
 				if err == nil {
@@ -879,7 +985,7 @@ func TestDownloader_extractTarGz_PathTraversal(t *testing.T) {
 			}
 			defer os.Remove(tarFile)
 
-			err = downloader.extractTarGz(tarFile, installDir)
+			err = downloader.extractTarGz(context.Background(), tarFile, installDir)
 			if err == nil || !strings.Contains(err.Error(), tc.expected) {
 				t.Errorf("Expected error containing %q, got: %v", tc.expected, err)
 			}
@@ -935,7 +1041,7 @@ func TestDownloader_extractZip_PathTraversal(t *testing.T) {
 			}
 			defer os.Remove(zipFile)
 
-			err = downloader.extractZip(zipFile, installDir)
+			err = downloader.extractZip(context.Background(), zipFile, installDir)
 			if err == nil || !strings.Contains(err.Error(), tc.expected) {
 				t.Errorf("Expected error containing %q, got: %v", tc.expected, err)
 			}
@@ -953,11 +1059,11 @@ func TestDownloader_Download_ErrorPaths(t *testing.T) {
 		errorContains string
 	}{
 		{
-			name:          "Invalid version - no file info",
+			name:          "Invalid version - not a known release",
 			version:       "invalid-version",
 			mockResponse:  `[{"version":"go1.20.0","stable":true,"files":[{"filename":"go1.20.0.darwin-amd64.tar.gz","os":"darwin","arch":"amd64","version":"go1.20.0","sha256":"1234567890abcdef","size":1024,"kind":"archive"}]}]`,
 			expectError:   true,
-			errorContains: "no file info available",
+			errorContains: "not a known release",
 		},
 		{
 			name:          "Network error during download",
@@ -982,7 +1088,7 @@ func TestDownloader_Download_ErrorPaths(t *testing.T) {
 			config.GoReleases.APIURL = server.URL
 
 			installDir := filepath.Join(config.InstallDir, "test-error")
-			err := downloader.Download("http://invalid-url-that-will-fail.com/test.tar.gz", installDir, tc.version)
+			err := downloader.Download(context.Background(), "http://invalid-url-that-will-fail.com/test.tar.gz", installDir, tc.version)
 
 			if tc.expectError {
 				if err == nil {
@@ -1058,7 +1164,7 @@ func TestDownloader_extractTarGz_ErrorHandling(t *testing.T) {
 			}
 			defer os.Remove(tarFile)
 
-			err = downloader.extractTarGz(tarFile, installDir)
+			err = downloader.extractTarGz(context.Background(), tarFile, installDir)
 
 			if tc.expectError {
 				if err == nil {
@@ -1123,7 +1229,7 @@ func TestDownloader_extractZip_ErrorHandling(t *testing.T) {
 			}
 			defer os.Remove(zipFile)
 
-			err = downloader.extractZip(zipFile, installDir)
+			err = downloader.extractZip(context.Background(), zipFile, installDir)
 
 			if tc.expectError {
 				if err == nil {
@@ -1177,7 +1283,7 @@ func TestDownloader_extractZip_Symlinks(t *testing.T) {
 	}
 	defer os.Remove(zipFile)
 
-	err = downloader.extractZip(zipFile, installDir)
+	err = downloader.extractZip(context.Background(), zipFile, installDir)
 	if err != nil {
 		t.Fatalf("extractZip failed: %v", err)
 	}
@@ -1258,7 +1364,7 @@ func TestDownloader_extractZip_DirectoryCreation(t *testing.T) {
 			}
 			defer os.Remove(zipFile)
 
-			err = downloader.extractZip(zipFile, installDir)
+			err = downloader.extractZip(context.Background(), zipFile, installDir)
 
 			if tc.expectError {
 				if err == nil {
@@ -1316,7 +1422,7 @@ func TestDownloader_GetFileInfoFailure(t *testing.T) {
 	}))
 	defer server.Close()
 
-	_, err := _golang.GetFileInfoWithConfig("1.20.0", server.URL, time.Minute)
+	_, err := _golang.GetFileInfoWithConfig(context.Background(), "1.20.0", server.URL, time.Minute)
 	if err == nil {
 		t.Fatal("Expected file info retrieval error but got none")
 	}
@@ -1335,7 +1441,7 @@ func TestDownloader_downloadFile_ServerError(t *testing.T) {
 
 	fileInfo := mockFileInfo()
 
-	_, err := downloader.downloadFile(server.URL, fileInfo)
+	_, err := downloader.downloadFile(context.Background(), server.URL, fileInfo)
 	if err == nil {
 		t.Error("Expected server error but got none")
 	}
@@ -1358,7 +1464,7 @@ func TestDownloader_downloadFile_NetworkTimeout(t *testing.T) {
 
 	fileInfo := mockFileInfo()
 
-	_, err := downloader.downloadFile(server.URL, fileInfo)
+	_, err := downloader.downloadFile(context.Background(), server.URL, fileInfo)
 	if err == nil {
 		t.Error("Expected timeout error but got none")
 	}
@@ -1378,7 +1484,7 @@ func TestDownloader_extractArchive_UnsupportedFormatDirect(t *testing.T) {
 	}
 	defer os.Remove(archiveFile)
 
-	err = downloader.extractArchive(archiveFile, installDir)
+	err = downloader.extractArchive(context.Background(), archiveFile, installDir)
 	if err == nil {
 		t.Error("Expected unsupported format error but got none")
 	}
@@ -1401,7 +1507,7 @@ func TestDownloader_downloadFile_RetryExhaustion(t *testing.T) {
 	fileInfo := mockFileInfo()
 	fileInfo.Size = 1024
 
-	_, err := downloader.downloadFile(server.URL, fileInfo)
+	_, err := downloader.downloadFile(context.Background(), server.URL, fileInfo)
 	if err == nil {
 		t.Error("Expected error but got none")
 	}
@@ -1458,7 +1564,7 @@ func TestDownloader_downloadFile_PartialResume(t *testing.T) {
 			fileInfo.Size = int64(len(tc.finalData))
 			fileInfo.Filename = "resume-test.txt"
 
-			resultPath, err := downloader.downloadFile(server.URL, fileInfo)
+			resultPath, err := downloader.downloadFile(context.Background(), server.URL, fileInfo)
 
 			if tc.expectError {
 				if err == nil {
@@ -1543,7 +1649,7 @@ func TestDownloader_extractArchive_UnsupportedFormat(t *testing.T) {
 			}
 			defer os.Remove(archiveFile)
 
-			err = downloader.extractArchive(archiveFile, installDir)
+			err = downloader.extractArchive(context.Background(), archiveFile, installDir)
 
 			if tc.expectError {
 				if err == nil {
@@ -1559,3 +1665,623 @@ func TestDownloader_extractArchive_UnsupportedFormat(t *testing.T) {
 		})
 	}
 }
+
+// TestDownloader_downloadFile_RetryBackoff tests that transient failures are retried with exponential backoff
+func TestDownloader_downloadFile_RetryBackoff(t *testing.T) {
+	config := createTestConfig(t)
+	config.Download.RetryCount = 4
+	config.Download.RetryDelay = 100 * time.Millisecond
+	config.Download.MaxRetryDelay = 250 * time.Millisecond
+	downloader := createTestDownloader(t, config)
+
+	var sleeps []time.Duration
+	downloader.sleep = func(_ context.Context, d time.Duration) error {
+		sleeps = append(sleeps, d)
+		return nil
+	}
+	downloader.jitter = func(time.Duration) time.Duration { return 0 }
+
+	content := "retried download"
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if requests < 4 {
+			http.Error(w, "unavailable", http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(content))
+	}))
+	defer server.Close()
+
+	fileInfo := mockFileInfo()
+	fileInfo.Size = int64(len(content))
+
+	path, err := downloader.downloadFile(context.Background(), server.URL+"/go-retry.tar.gz", fileInfo)
+	if err != nil {
+		t.Fatalf("Expected download to succeed after retries, got: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read downloaded file: %v", err)
+	}
+	if string(data) != content {
+		t.Errorf("Expected content %q, got %q", content, string(data))
+	}
+
+	expected := []time.Duration{100 * time.Millisecond, 200 * time.Millisecond, 250 * time.Millisecond}
+	if len(sleeps) != len(expected) {
+		t.Fatalf("Expected %d backoff sleeps, got %d: %v", len(expected), len(sleeps), sleeps)
+	}
+	for i, d := range expected {
+		if sleeps[i] != d {
+			t.Errorf("Backoff %d: expected %v, got %v", i+1, d, sleeps[i])
+		}
+	}
+}
+
+// TestDownloader_downloadFile_CanceledMidStream tests that canceling the context aborts an in-flight transfer
+// and keeps the partial file in the cache for a later resume.
+func TestDownloader_downloadFile_CanceledMidStream(t *testing.T) {
+	config := createTestConfig(t)
+	downloader := createTestDownloader(t, config)
+
+	firstChunk := strings.Repeat("a", 1024)
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Length", "4096")
+		w.Write([]byte(firstChunk))
+		w.(http.Flusher).Flush()
+		select {
+		case <-r.Context().Done():
+		case <-release:
+		}
+	}))
+	defer server.Close()
+	defer close(release)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	cachePath := filepath.Join(config.CacheDir, "go-cancel.tar.gz")
+	go func() {
+		// Cancel once the first chunk has reached the cache file
+		for {
+			if info, err := os.Stat(cachePath); err == nil && info.Size() >= int64(len(firstChunk)) {
+				cancel()
+				return
+			}
+			time.Sleep(5 * time.Millisecond)
+		}
+	}()
+
+	fileInfo := mockFileInfo()
+	fileInfo.Size = 4096
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := downloader.downloadFile(ctx, server.URL+"/go-cancel.tar.gz", fileInfo)
+		done <- err
+	}()
+
+	select {
+	case err := <-done:
+		if !errors.Is(err, context.Canceled) {
+			t.Fatalf("Expected context.Canceled, got %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("downloadFile did not return after the context was canceled")
+	}
+
+	data, err := os.ReadFile(cachePath)
+	if err != nil {
+		t.Fatalf("Expected the partial download to stay in the cache: %v", err)
+	}
+	if string(data) != firstChunk {
+		t.Errorf("Expected %d partial bytes, got %d", len(firstChunk), len(data))
+	}
+}
+
+// TestDownloader_downloadFile_CanceledDuringBackoff tests that a retry wait ends as soon as the context is canceled.
+func TestDownloader_downloadFile_CanceledDuringBackoff(t *testing.T) {
+	config := createTestConfig(t)
+	config.Download.RetryCount = 3
+	config.Download.RetryDelay = time.Hour
+	downloader := createTestDownloader(t, config)
+	downloader.sleep = sleepContext
+
+	ctx, cancel := context.WithCancel(context.Background())
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		cancel()
+		http.Error(w, "unavailable", http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	_, err := downloader.downloadFile(ctx, server.URL+"/go-backoff.tar.gz", mockFileInfo())
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("Expected context.Canceled, got %v", err)
+	}
+}
+
+// TestDownloader_downloadFile_NotFoundNotRetried tests that permanent client errors fail without retrying
+func TestDownloader_downloadFile_NotFoundNotRetried(t *testing.T) {
+	config := createTestConfig(t)
+	config.Download.RetryCount = 5
+	downloader := createTestDownloader(t, config)
+
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		http.NotFound(w, r)
+	}))
+	defer server.Close()
+
+	_, err := downloader.downloadFile(context.Background(), server.URL+"/go-missing.tar.gz", mockFileInfo())
+	if err == nil {
+		t.Fatal("Expected error for 404 response but got none")
+	}
+	if !strings.Contains(err.Error(), "download failed with status 404") {
+		t.Errorf("Expected 404 error, got: %v", err)
+	}
+	if requests != 1 {
+		t.Errorf("Expected exactly 1 request for non-retryable error, got %d", requests)
+	}
+}
+
+// TestDownloader_retryBackoff_Jitter tests that jitter is added on top of the base backoff
+func TestDownloader_retryBackoff_Jitter(t *testing.T) {
+	config := createTestConfig(t)
+	config.Download.RetryDelay = time.Second
+	config.Download.MaxRetryDelay = 0
+	downloader := createTestDownloader(t, config)
+
+	for attempt := 1; attempt <= 4; attempt++ {
+		base := time.Second << (attempt - 1)
+		got := downloader.retryBackoff(attempt)
+		if got < base || got > base+base/2 {
+			t.Errorf("Attempt %d: expected backoff in [%v, %v], got %v", attempt, base, base+base/2, got)
+		}
+	}
+}
+
+// TestDownloader_downloadFile_Proxy tests that downloads are routed through the configured proxy
+func TestDownloader_downloadFile_Proxy(t *testing.T) {
+	content := "proxied content"
+	var proxiedURL string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxiedURL = r.URL.String()
+		w.Write([]byte(content))
+	}))
+	defer proxy.Close()
+
+	config := createTestConfig(t)
+	config.Network.Proxy = proxy.URL
+	downloader := createTestDownloader(t, config)
+
+	fileInfo := mockFileInfo()
+	fileInfo.Size = int64(len(content))
+
+	target := "http://downloads.example.invalid/go-proxy.tar.gz"
+	if _, err := downloader.downloadFile(context.Background(), target, fileInfo); err != nil {
+		t.Fatalf("Expected proxied download to succeed, got: %v", err)
+	}
+	if proxiedURL != target {
+		t.Errorf("Expected proxy to receive request for %s, got %s", target, proxiedURL)
+	}
+}
+
+func TestDownloader_InstallArchive(t *testing.T) {
+	type entry struct {
+		name string
+		body string // entries ending in "/" are directories
+	}
+	toolchain := []entry{{"go/bin/go", "binary"}, {"go/pkg/", ""}, {"go/VERSION", "go1.25.0\ntime 2025-08-12T17:00:00Z\n"}}
+
+	writeArchive := func(t *testing.T, path string, entries []entry) {
+		t.Helper()
+		var buf bytes.Buffer
+		gzWriter := gzip.NewWriter(&buf)
+		tarWriter := tar.NewWriter(gzWriter)
+		for _, e := range entries {
+			header := &tar.Header{Name: e.name, Size: int64(len(e.body)), Mode: 0755, Typeflag: tar.TypeReg}
+			if strings.HasSuffix(e.name, "/") {
+				header.Typeflag = tar.TypeDir
+			}
+			if err := tarWriter.WriteHeader(header); err != nil {
+				t.Fatalf("Failed to write tar header: %v", err)
+			}
+			if _, err := tarWriter.Write([]byte(e.body)); err != nil {
+				t.Fatalf("Failed to write tar content: %v", err)
+			}
+		}
+		tarWriter.Close()
+		gzWriter.Close()
+		if err := os.WriteFile(path, buf.Bytes(), 0644); err != nil {
+			t.Fatalf("Failed to write archive: %v", err)
+		}
+	}
+
+	assertNoTempDirs := func(t *testing.T, parent string) {
+		t.Helper()
+		leftovers, _ := filepath.Glob(filepath.Join(parent, ".*.tmp-*"))
+		if len(leftovers) != 0 {
+			t.Errorf("Expected temporary directories to be removed, found %v", leftovers)
+		}
+	}
+
+	without := func(name string) []entry {
+		var entries []entry
+		for _, e := range toolchain {
+			if e.name != name {
+				entries = append(entries, e)
+			}
+		}
+		return entries
+	}
+
+	failures := []struct {
+		name    string
+		entries []entry
+		wantErr string
+	}{
+		{
+			name:    "failure mid-extraction",
+			entries: append(append([]entry{}, toolchain...), entry{"go/../../escape", "evil"}),
+			wantErr: "failed to extract archive",
+		},
+		{
+			name:    "missing go binary",
+			entries: without("go/bin/go"),
+			wantErr: "missing bin/go",
+		},
+		{
+			name:    "missing pkg directory",
+			entries: without("go/pkg/"),
+			wantErr: "missing pkg directory",
+		},
+		{
+			name:    "missing VERSION file",
+			entries: without("go/VERSION"),
+			wantErr: "unreadable VERSION file",
+		},
+		{
+			name:    "VERSION for another release",
+			entries: append(without("go/VERSION"), entry{"go/VERSION", "go1.24.0\n"}),
+			wantErr: `VERSION file reports "go1.24.0"`,
+		},
+	}
+
+	for _, tc := range failures {
+		t.Run(tc.name+" leaves no version directory", func(t *testing.T) {
+			if runtime.GOOS == "windows" && tc.name == "missing go binary" {
+				t.Skip("archive entries use the Unix binary name")
+			}
+			config := createTestConfig(t)
+			downloader := createTestDownloader(t, config)
+			installDir := config.GetVersionDir("1.25.0")
+
+			archive := filepath.Join(config.CacheDir, "broken.tar.gz")
+			writeArchive(t, archive, tc.entries)
+
+			err := downloader.InstallArchive(context.Background(), archive, installDir, "1.25.0")
+			if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Fatalf("Expected error containing %q, got %v", tc.wantErr, err)
+			}
+			if _, err := os.Stat(installDir); !os.IsNotExist(err) {
+				t.Errorf("Expected no version directory after a failed install, stat error: %v", err)
+			}
+			assertNoTempDirs(t, filepath.Dir(installDir))
+		})
+	}
+
+	t.Run("canceled context leaves no version directory", func(t *testing.T) {
+		config := createTestConfig(t)
+		downloader := createTestDownloader(t, config)
+		installDir := config.GetVersionDir("1.25.0")
+
+		archive := filepath.Join(config.CacheDir, "good.tar.gz")
+		writeArchive(t, archive, toolchain)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		err := downloader.InstallArchive(ctx, archive, installDir, "1.25.0")
+		if !errors.Is(err, context.Canceled) {
+			t.Fatalf("Expected context.Canceled, got %v", err)
+		}
+		if _, err := os.Stat(installDir); !os.IsNotExist(err) {
+			t.Errorf("Expected no version directory after cancellation, stat error: %v", err)
+		}
+		assertNoTempDirs(t, filepath.Dir(installDir))
+	})
+
+	t.Run("success moves the tree into place", func(t *testing.T) {
+		if runtime.GOOS == "windows" {
+			t.Skip("archive entries use the Unix binary name")
+		}
+		config := createTestConfig(t)
+		downloader := createTestDownloader(t, config)
+		installDir := config.GetVersionDir("1.25.0")
+		parent := filepath.Dir(installDir)
+
+		// A temporary directory left by an interrupted install is cleaned up
+		stale := filepath.Join(parent, "."+filepath.Base(installDir)+".tmp-123")
+		if err := os.MkdirAll(stale, 0755); err != nil {
+			t.Fatal(err)
+		}
+
+		archive := filepath.Join(config.CacheDir, "good.tar.gz")
+		writeArchive(t, archive, toolchain)
+
+		if err := downloader.InstallArchive(context.Background(), archive, installDir, "1.25.0"); err != nil {
+			t.Fatalf("InstallArchive() error = %v", err)
+		}
+		if _, err := os.Stat(filepath.Join(installDir, "bin", "go")); err != nil {
+			t.Errorf("Expected bin/go in the install directory: %v", err)
+		}
+		info, err := os.Stat(installDir)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if info.Mode().Perm() != 0755 {
+			t.Errorf("Expected install directory mode 0755, got %v", info.Mode().Perm())
+		}
+		assertNoTempDirs(t, parent)
+	})
+}
+
+// TestDownloader_verifySignature tests detached signature checks against a minisign key
+func TestDownloader_verifySignature(t *testing.T) {
+	config := createTestConfig(t)
+	downloader := createTestDownloader(t, config)
+
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyID := []byte("govmanid")
+	keyPath := filepath.Join(t.TempDir(), "minisign.pub")
+	os.WriteFile(keyPath, []byte(base64.StdEncoding.EncodeToString(append(append([]byte("Ed"), keyID...), pub...))), 0644)
+	config.Download.SignatureKey = keyPath
+
+	archive := []byte("archive contents")
+	fileSig := ed25519.Sign(priv, archive)
+	comment := "timestamp:1700000000"
+	sigFile := "untrusted comment: test\n" +
+		base64.StdEncoding.EncodeToString(append(append([]byte("Ed"), keyID...), fileSig...)) + "\n" +
+		"trusted comment: " + comment + "\n" +
+		base64.StdEncoding.EncodeToString(ed25519.Sign(priv, append(append([]byte{}, fileSig...), comment...))) + "\n"
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/go.tar.gz.minisig", "/tampered.tar.gz.minisig":
+			w.Write([]byte(sigFile))
+		case "/broken.tar.gz.minisig":
+			w.WriteHeader(http.StatusInternalServerError)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	key, err := downloader.signatureKey()
+	if err != nil {
+		t.Fatalf("signatureKey() error = %v", err)
+	}
+
+	archivePath := filepath.Join(config.CacheDir, "go.tar.gz")
+	os.WriteFile(archivePath, archive, 0644)
+	tamperedPath := filepath.Join(config.CacheDir, "tampered.tar.gz")
+	os.WriteFile(tamperedPath, []byte("tampered contents"), 0644)
+
+	ctx := context.Background()
+	if err := downloader.verifySignature(ctx, key, server.URL+"/go.tar.gz", archivePath); err != nil {
+		t.Errorf("valid signature error = %v", err)
+	}
+	if err := downloader.verifySignature(ctx, key, server.URL+"/tampered.tar.gz", tamperedPath); !errors.Is(err, _signature.ErrInvalidSignature) {
+		t.Errorf("tampered archive error = %v, want ErrInvalidSignature", err)
+	}
+	if err := downloader.verifySignature(ctx, key, server.URL+"/unsigned.tar.gz", archivePath); err != nil {
+		t.Errorf("missing signature should be skipped, got error = %v", err)
+	}
+	if err := downloader.verifySignature(ctx, key, server.URL+"/broken.tar.gz", archivePath); err == nil || errors.Is(err, _signature.ErrInvalidSignature) {
+		t.Errorf("server error = %v, want a download error", err)
+	}
+
+	// Requesting verification without a key fails before anything is downloaded
+	config.Download.SignatureKey = ""
+	config.Download.VerifySignature = true
+	err = downloader.Download(ctx, server.URL+"/go.tar.gz", filepath.Join(config.InstallDir, "go1.25.1"), "1.25.1")
+	if err == nil || !strings.Contains(err.Error(), "signature_key") {
+		t.Errorf("Download() without a key error = %v, want a missing signature_key error", err)
+	}
+}
+
+func TestDownloader_Download_OfflineSignature(t *testing.T) {
+	config := createTestConfig(t)
+	config.Offline = true
+	config.Download.VerifySignature = true
+	downloader := createTestDownloader(t, config)
+
+	err := downloader.Download(context.Background(), "file:///nonexistent/go.tar.gz", filepath.Join(config.InstallDir, "go1.25.1"), "1.25.1")
+	if !errors.Is(err, _golang.ErrOffline) {
+		t.Errorf("Download() offline with signature verification error = %v, want ErrOffline", err)
+	}
+}
+
+// TestRateLimitedReader tests that reads are paced to the limit and split into small chunks
+func TestRateLimitedReader(t *testing.T) {
+	clock := time.Unix(0, 0)
+	var slept time.Duration
+	reader := &rateLimitedReader{
+		ctx:   context.Background(),
+		r:     bytes.NewReader(make([]byte, 1000)),
+		limit: 100,
+		start: clock,
+		now:   func() time.Time { return clock },
+		sleep: func(_ context.Context, d time.Duration) error {
+			slept += d
+			clock = clock.Add(d)
+			return nil
+		},
+	}
+
+	buf := make([]byte, 512)
+	reads := 0
+	for {
+		n, err := reader.Read(buf)
+		if n > 10 {
+			t.Fatalf("Expected reads of at most 10 bytes at 100 B/s, got %d", n)
+		}
+		if n > 0 {
+			reads++
+		}
+		if err != nil {
+			break
+		}
+	}
+
+	if reads != 100 {
+		t.Errorf("Expected 100 reads, got %d", reads)
+	}
+	if slept != 10*time.Second {
+		t.Errorf("Expected 10s of throttling for 1000 bytes at 100 B/s, got %v", slept)
+	}
+}
+
+// TestDownloader_downloadFile_CanceledWhileThrottled tests that a throttled download stops promptly when canceled
+func TestDownloader_downloadFile_CanceledWhileThrottled(t *testing.T) {
+	config := createTestConfig(t)
+	config.Download.RateLimit = 1
+	downloader := createTestDownloader(t, config)
+	downloader.sleep = sleepContext
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write(make([]byte, 100))
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	started := time.Now()
+	_, err := downloader.downloadFile(ctx, server.URL+"/go-throttled.tar.gz", mockFileInfo())
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Expected context.DeadlineExceeded, got %v", err)
+	}
+	if elapsed := time.Since(started); elapsed > 5*time.Second {
+		t.Errorf("Expected throttled download to stop promptly, took %v", elapsed)
+	}
+}
+
+// TestDownloader_extractArchive_WithProgress tests that extraction with a progress bar shown still writes every file
+func TestDownloader_extractArchive_WithProgress(t *testing.T) {
+	original := stderrIsTerminal
+	stderrIsTerminal = func() bool { return true }
+	defer func() { stderrIsTerminal = original }()
+
+	content := strings.Repeat("go", 4096)
+
+	var tarBuf bytes.Buffer
+	gzWriter := gzip.NewWriter(&tarBuf)
+	tarWriter := tar.NewWriter(gzWriter)
+	tarWriter.WriteHeader(&tar.Header{Name: "go/bin/go", Size: int64(len(content)), Mode: 0755, Typeflag: tar.TypeReg})
+	tarWriter.Write([]byte(content))
+	tarWriter.Close()
+	gzWriter.Close()
+
+	var zipBuf bytes.Buffer
+	zipWriter := zip.NewWriter(&zipBuf)
+	entry, _ := zipWriter.Create("go/bin/go")
+	entry.Write([]byte(content))
+	zipWriter.Close()
+
+	for name, data := range map[string][]byte{"go.tar.gz": tarBuf.Bytes(), "go.zip": zipBuf.Bytes()} {
+		t.Run(name, func(t *testing.T) {
+			config := createTestConfig(t)
+			downloader := createTestDownloader(t, config)
+
+			archivePath := filepath.Join(config.CacheDir, name)
+			if err := os.WriteFile(archivePath, data, 0644); err != nil {
+				t.Fatalf("Failed to write archive: %v", err)
+			}
+
+			installDir := filepath.Join(config.InstallDir, "progress")
+			if err := downloader.extractArchive(context.Background(), archivePath, installDir); err != nil {
+				t.Fatalf("extractArchive failed: %v", err)
+			}
+
+			extracted, err := os.ReadFile(filepath.Join(installDir, "bin", "go"))
+			if err != nil {
+				t.Fatalf("Extracted file missing: %v", err)
+			}
+			if string(extracted) != content {
+				t.Errorf("Extracted %d bytes, want %d", len(extracted), len(content))
+			}
+		})
+	}
+}
+
+// TestNewProgressBar_NotTerminal tests that no progress bar is drawn when stderr is not a terminal
+func TestNewProgressBar_NotTerminal(t *testing.T) {
+	original := stderrIsTerminal
+	stderrIsTerminal = func() bool { return false }
+	defer func() { stderrIsTerminal = original }()
+
+	if bar := newProgressBar(100, "Extracting go.tar.gz"); bar != nil {
+		t.Error("Expected no progress bar when stderr is not a terminal")
+	}
+}
+
+// TestDownloader_extractArchive_DetectsByContent tests that archives are extracted by their magic bytes, not their name
+func TestDownloader_extractArchive_DetectsByContent(t *testing.T) {
+	var tarBuf bytes.Buffer
+	gzWriter := gzip.NewWriter(&tarBuf)
+	tarWriter := tar.NewWriter(gzWriter)
+	tarWriter.WriteHeader(&tar.Header{Name: "go/VERSION", Size: 8, Mode: 0644, Typeflag: tar.TypeReg})
+	tarWriter.Write([]byte("go1.25.1"))
+	tarWriter.Close()
+	gzWriter.Close()
+
+	var zipBuf bytes.Buffer
+	zipWriter := zip.NewWriter(&zipBuf)
+	entry, _ := zipWriter.Create("go/VERSION")
+	entry.Write([]byte("go1.25.1"))
+	zipWriter.Close()
+
+	testCases := []struct {
+		name        string
+		archiveName string
+		data        []byte
+	}{
+		{name: "Gzip stream with .bin name", archiveName: "go1.25.1.bin", data: tarBuf.Bytes()},
+		{name: "Gzip stream with .zip name", archiveName: "go1.25.1.zip", data: tarBuf.Bytes()},
+		{name: "Zip with no extension", archiveName: "go1.25.1", data: zipBuf.Bytes()},
+		{name: "Zip with .tar.gz name", archiveName: "go1.25.1.tar.gz", data: zipBuf.Bytes()},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			config := createTestConfig(t)
+			downloader := createTestDownloader(t, config)
+
+			archivePath := filepath.Join(config.CacheDir, tc.archiveName)
+			if err := os.WriteFile(archivePath, tc.data, 0644); err != nil {
+				t.Fatalf("Failed to write archive: %v", err)
+			}
+
+			installDir := filepath.Join(config.InstallDir, "detected")
+			if err := downloader.extractArchive(context.Background(), archivePath, installDir); err != nil {
+				t.Fatalf("extractArchive failed: %v", err)
+			}
+
+			version, err := os.ReadFile(filepath.Join(installDir, "VERSION"))
+			if err != nil {
+				t.Fatalf("Extracted file missing: %v", err)
+			}
+			if string(version) != "go1.25.1" {
+				t.Errorf("Extracted VERSION = %q, want %q", version, "go1.25.1")
+			}
+		})
+	}
+}
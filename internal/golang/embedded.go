@@ -0,0 +1,204 @@
+package golang
+
+import (
+	"context"
+	_ "embed"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"runtime"
+	"slices"
+	"strings"
+	"sync"
+)
+
+//go:generate go run gen_embedded.go
+
+// embeddedReleasesData is the built-in release list written by gen_embedded.go: the stable releases of the last few
+// minor lines when govman was built.
+//
+//go:embed embedded_releases.json
+var embeddedReleasesData []byte
+
+// embeddedIndex is the format of embedded_releases.json.
+type embeddedIndex struct {
+	Generated string   `json:"generated"`
+	Versions  []string `json:"versions"`
+}
+
+// loadEmbeddedIndex parses embeddedReleasesData once.
+var loadEmbeddedIndex = sync.OnceValue(func() embeddedIndex {
+	var index embeddedIndex
+	if err := json.Unmarshal(embeddedReleasesData, &index); err != nil {
+		panic(fmt.Sprintf("invalid embedded release list: %v", err))
+	}
+	return index
+})
+
+// EmbeddedVersions returns the built-in list of stable releases, newest first, and the date it was generated.
+// It is possibly outdated and only meant for when the release source cannot be reached.
+func EmbeddedVersions() (versions []string, generated string) {
+	index := loadEmbeddedIndex()
+	return slices.Clone(index.Versions), index.Generated
+}
+
+// EmbeddedSource serves the built-in release list, so installs work before the release API was ever reached.
+// The list has no checksums; FileInfo fetches the .sha256 file published next to each official archive.
+type EmbeddedSource struct {
+	DownloadURLs []string // archive download bases; the first one is used
+	ChecksumURL  string   // base of the .sha256 files, never a mirror; defaults to the official download site
+}
+
+// AvailableVersions implements ReleaseSource. The built-in list holds only stable releases.
+func (s *EmbeddedSource) AvailableVersions(ctx context.Context, includeUnstable bool) ([]string, error) {
+	versions, _ := EmbeddedVersions()
+	return versions, nil
+}
+
+// DownloadURL implements ReleaseSource with the archive URL under the first download base.
+// Returns an error wrapping ErrVersionNotFound for a version missing from the built-in list.
+func (s *EmbeddedSource) DownloadURL(ctx context.Context, version string) (string, error) {
+	if versions, _ := EmbeddedVersions(); !slices.Contains(versions, version) {
+		return "", fmt.Errorf("go version %s is %w", version, ErrVersionNotFound)
+	}
+
+	base := defaultGoDownloadURL
+	if len(s.DownloadURLs) > 0 {
+		base = s.DownloadURLs[0]
+	}
+	return buildDownloadURL(base, ArchiveName(version)), nil
+}
+
+// FileInfo implements ReleaseSource with the checksum read from the official archive's .sha256 file, so a mirror
+// cannot vouch for its own archive. Size is 0.
+func (s *EmbeddedSource) FileInfo(ctx context.Context, version string) (*File, error) {
+	if _, err := s.DownloadURL(ctx, version); err != nil {
+		return nil, err
+	}
+
+	base := defaultGoDownloadURL
+	if s.ChecksumURL != "" {
+		base = s.ChecksumURL
+	}
+	sha256, err := fetchArchiveChecksum(ctx, buildDownloadURL(base, ArchiveName(version)+".sha256"))
+	if err != nil {
+		return nil, err
+	}
+
+	return &File{
+		Filename: ArchiveName(version),
+		OS:       runtime.GOOS,
+		Arch:     resolveArch(version, runtime.GOOS, runtime.GOARCH),
+		Version:  "go" + version,
+		Sha256:   sha256,
+		Kind:     "archive",
+	}, nil
+}
+
+// fetchArchiveChecksum reads the hex SHA-256 from a .sha256 file such as those published next to official archives.
+// Returns the lower-case checksum, or an error if it cannot be fetched or does not hold one.
+func fetchArchiveChecksum(ctx context.Context, checksumURL string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, checksumURL, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch checksum: %w", err)
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return "", &networkError{fmt.Errorf("failed to fetch checksum: %w", err)}
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", &networkError{fmt.Errorf("failed to fetch checksum %s: HTTP %d (%s)", checksumURL, resp.StatusCode, resp.Status)}
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1024))
+	if err != nil {
+		return "", fmt.Errorf("failed to read checksum: %w", err)
+	}
+
+	fields := strings.Fields(string(body))
+	if len(fields) == 0 || len(fields[0]) != 64 {
+		return "", fmt.Errorf("invalid checksum file %s", checksumURL)
+	}
+	if _, err := hex.DecodeString(fields[0]); err != nil {
+		return "", fmt.Errorf("invalid checksum file %s", checksumURL)
+	}
+	return strings.ToLower(fields[0]), nil
+}
+
+// FallbackSource reads from Primary and switches to Fallback for DownloadURL and FileInfo when Primary cannot be
+// reached. AvailableVersions never falls back, since callers prefer a saved release list.
+type FallbackSource struct {
+	Primary  ReleaseSource
+	Fallback ReleaseSource
+
+	// OnFallback, when set, is called with Primary's error each time Fallback is used.
+	OnFallback func(err error)
+}
+
+// AvailableVersions implements ReleaseSource with Primary's list.
+func (s *FallbackSource) AvailableVersions(ctx context.Context, includeUnstable bool) ([]string, error) {
+	return s.Primary.AvailableVersions(ctx, includeUnstable)
+}
+
+// AllAvailableVersions implements PagedSource with Primary's complete history when it is paged.
+func (s *FallbackSource) AllAvailableVersions(ctx context.Context, includeUnstable bool) ([]string, error) {
+	if paged, ok := s.Primary.(PagedSource); ok {
+		return paged.AllAvailableVersions(ctx, includeUnstable)
+	}
+	return s.Primary.AvailableVersions(ctx, includeUnstable)
+}
+
+// DownloadURL implements ReleaseSource, falling back when Primary fails with a network error.
+func (s *FallbackSource) DownloadURL(ctx context.Context, version string) (string, error) {
+	downloadURL, err := s.Primary.DownloadURL(ctx, version)
+	if s.useFallback(ctx, err) {
+		return s.Fallback.DownloadURL(ctx, version)
+	}
+	return downloadURL, err
+}
+
+// ArchiveURLs implements MirroredSource with Primary's mirrors, or the single DownloadURL of a source without any.
+func (s *FallbackSource) ArchiveURLs(ctx context.Context, version string) ([]string, error) {
+	var downloadURL string
+	var err error
+	if mirrored, ok := s.Primary.(MirroredSource); ok {
+		urls, primaryErr := mirrored.ArchiveURLs(ctx, version)
+		if !s.useFallback(ctx, primaryErr) {
+			return urls, primaryErr
+		}
+		downloadURL, err = s.Fallback.DownloadURL(ctx, version)
+	} else {
+		downloadURL, err = s.DownloadURL(ctx, version)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return []string{downloadURL}, nil
+}
+
+// FileInfo implements ReleaseSource, falling back when Primary fails with a network error.
+func (s *FallbackSource) FileInfo(ctx context.Context, version string) (*File, error) {
+	file, err := s.Primary.FileInfo(ctx, version)
+	if s.useFallback(ctx, err) {
+		return s.Fallback.FileInfo(ctx, version)
+	}
+	return file, err
+}
+
+// useFallback reports whether err means Primary could not be reached, as opposed to a missing release or a
+// canceled ctx, and notifies OnFallback when it does.
+func (s *FallbackSource) useFallback(ctx context.Context, err error) bool {
+	if err == nil || ctx.Err() != nil || !errors.Is(err, ErrNetwork) {
+		return false
+	}
+	if s.OnFallback != nil {
+		s.OnFallback(err)
+	}
+	return true
+}
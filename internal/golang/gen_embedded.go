@@ -0,0 +1,80 @@
+//go:build ignore
+
+// gen_embedded.go refreshes embedded_releases.json from the release API with the stable releases of the newest
+// minor lines. Run it with 'go generate ./internal/golang' (or 'make generate') before a release.
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+// minorLines is how many of the newest minor lines the embedded list keeps, with every stable patch of each.
+const minorLines = 3
+
+const releasesURL = "https://go.dev/dl/?mode=json&include=all"
+
+func main() {
+	client := &http.Client{Timeout: 30 * time.Second}
+	resp, err := client.Get(releasesURL)
+	if err != nil {
+		fail(err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		fail(fmt.Errorf("%s: HTTP %s", releasesURL, resp.Status))
+	}
+
+	var releases []struct {
+		Version string `json:"version"`
+		Stable  bool   `json:"stable"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&releases); err != nil {
+		fail(err)
+	}
+
+	// The API lists releases newest first
+	var versions []string
+	lines := map[string]bool{}
+	for _, release := range releases {
+		if !release.Stable {
+			continue
+		}
+		version := strings.TrimPrefix(release.Version, "go")
+		parts := strings.SplitN(version, ".", 3)
+		if len(parts) < 2 {
+			continue
+		}
+		line := parts[0] + "." + parts[1]
+		if !lines[line] {
+			if len(lines) == minorLines {
+				continue
+			}
+			lines[line] = true
+		}
+		versions = append(versions, version)
+	}
+	if len(versions) == 0 {
+		fail(fmt.Errorf("no stable releases in %s", releasesURL))
+	}
+
+	data, err := json.MarshalIndent(map[string]any{
+		"generated": time.Now().UTC().Format("2006-01-02"),
+		"versions":  versions,
+	}, "", "  ")
+	if err != nil {
+		fail(err)
+	}
+	if err := os.WriteFile("embedded_releases.json", append(data, '\n'), 0644); err != nil {
+		fail(err)
+	}
+}
+
+func fail(err error) {
+	fmt.Fprintf(os.Stderr, "gen_embedded: %v\n", err)
+	os.Exit(1)
+}
@@ -1,29 +1,61 @@
 package golang
 
 import (
+	"cmp"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"io/fs"
 	"net/http"
+	"net/url"
 	"os"
 	"path/filepath"
 	"regexp"
 	"runtime"
-	"sort"
+	"slices"
 	"strconv"
 	"strings"
 	"sync"
 	"time"
 )
 
+var (
+	// ErrVersionNotFound is returned when the requested version does not appear in the release list at all,
+	// as opposed to a release that exists but has no archive for this platform.
+	ErrVersionNotFound = errors.New("not a known release")
+
+	// ErrNoDownload is returned when a release has no archive for the requested platform.
+	ErrNoDownload = errors.New("no download available")
+
+	// ErrNoFileInfo is returned when a release has no file metadata for the requested platform.
+	ErrNoFileInfo = errors.New("no file info available")
+
+	// ErrNetwork is wrapped by errors from requests that could not reach a server or were refused by it,
+	// including rate limiting.
+	ErrNetwork = errors.New("network error")
+)
+
 var (
 	releasesCache []Release
 	cacheMutex    sync.RWMutex
 	cacheExpiry   time.Time
 
+	// allReleasesCache holds the fully paginated release history fetched for --all listings.
+	allReleasesCache []Release
+	allCacheExpiry   time.Time
+
+	// httpClient is used for release API requests; replace it with SetHTTPClient to apply proxy settings.
+	httpClient = &http.Client{
+		Timeout: 30 * time.Second,
+	}
+
+	// authToken is sent as a bearer token to the release API host; set it with SetAuthToken.
+	authToken string
+
 	// Pre-compiled regex patterns to avoid repeated compilation
-	versionParseRegex     = regexp.MustCompile(`^(\d+)\.(\d+)(?:\.(\d+))?(?:-?(rc\d+|beta\d+|alpha\d+))?$`)
+	versionParseRegex     = regexp.MustCompile(`^(\d+)(?:\.(\d+))?(?:\.(\d+))?(?:-?((?:rc|beta|alpha)\d*))?$`)
 	prereleaseNumberRegex = regexp.MustCompile(`\d+$`)
 
 	// VersionExtractRegex extracts a Go version from paths like ".../go1.25.4/bin/go"
@@ -32,8 +64,54 @@ var (
 
 const (
 	GoDownloadURLTemplate = "%s"
+
+	// maxReleasePages bounds how many Link-header pages are followed so a misbehaving API cannot loop forever.
+	maxReleasePages = 100
 )
 
+// RateLimitError is returned when the release API rejects a request because its rate limit is exhausted.
+type RateLimitError struct {
+	StatusCode    int
+	Reset         time.Time // zero when the API did not say when the limit resets
+	Authenticated bool      // whether the request carried a token
+}
+
+// Error describes the rate limit, when it resets if known, and suggests a token for unauthenticated requests.
+func (e *RateLimitError) Error() string {
+	msg := fmt.Sprintf("release API rate limit exceeded (HTTP %d)", e.StatusCode)
+	if e.Reset.IsZero() {
+		msg += "; try again later"
+	} else {
+		wait := max(time.Until(e.Reset).Round(time.Second), 0)
+		msg += fmt.Sprintf("; resets at %s (in %s)", e.Reset.Local().Format("15:04:05 MST"), wait)
+	}
+	if !e.Authenticated {
+		msg += "; set GITHUB_TOKEN or go_releases.github_token to raise the limit"
+	}
+
+	return msg
+}
+
+// Is reports whether target is ErrNetwork, since a rate limit is a refusal by the server.
+func (e *RateLimitError) Is(target error) bool {
+	return target == ErrNetwork
+}
+
+// networkError marks err as a network failure for errors.Is(err, ErrNetwork) without changing its message.
+type networkError struct {
+	err error
+}
+
+// Error returns the message of the underlying error.
+func (e *networkError) Error() string {
+	return e.err.Error()
+}
+
+// Unwrap returns ErrNetwork and the underlying error.
+func (e *networkError) Unwrap() []error {
+	return []error{ErrNetwork, e.err}
+}
+
 var (
 	defaultGoReleasesAPI = "https://go.dev/dl/?mode=json&include=all"
 	defaultCacheDuration = 10 * time.Minute
@@ -63,72 +141,203 @@ type VersionInfo struct {
 	Arch        string
 	InstallDate time.Time
 	Size        int64
+	GOROOT      string
+	ReleaseDate time.Time // zero when the VERSION file has no time line
+	CGODefault  string    // "enabled", "disabled", "auto" (platform default), or "" when unknown
+}
+
+// cgoDefaultRegex matches the toolchain's built-in CGO_ENABLED default in its generated bootstrap source.
+var cgoDefaultRegex = regexp.MustCompile(`(?m)^const [dD]efaultCGO_ENABLED = "([01]?)"`)
+
+// cgoDefaultFiles are the generated sources holding the CGO_ENABLED default, newest layout first.
+var cgoDefaultFiles = []string{
+	filepath.Join("src", "internal", "buildcfg", "zbootstrap.go"),
+	filepath.Join("src", "go", "build", "zcgo.go"),
 }
 
 // GetAvailableVersions returns all available Go versions, optionally including unstable ones.
 // Parameter includeUnstable controls inclusion. Returns a sorted slice of version strings or an error.
 func GetAvailableVersions(includeUnstable bool) ([]string, error) {
-	return GetAvailableVersionsWithConfig(includeUnstable, defaultGoReleasesAPI, defaultCacheDuration)
+	return GetAvailableVersionsWithConfig(context.Background(), includeUnstable, defaultGoReleasesAPI, defaultCacheDuration)
 }
 
 // GetAvailableVersionsWithConfig fetches available versions using a specific API URL and cache duration.
-// Parameters: includeUnstable, apiURL, cacheDuration. Returns a sorted slice of version strings or an error.
-func GetAvailableVersionsWithConfig(includeUnstable bool, apiURL string, cacheDuration time.Duration) ([]string, error) {
-	releases, err := fetchReleasesWithConfig(apiURL, cacheDuration)
+// Parameters: ctx (cancels the request), includeUnstable, apiURL, cacheDuration. Returns a sorted slice of version strings or an error.
+func GetAvailableVersionsWithConfig(ctx context.Context, includeUnstable bool, apiURL string, cacheDuration time.Duration) ([]string, error) {
+	releases, err := fetchReleasesWithConfig(ctx, apiURL, cacheDuration)
 	if err != nil {
 		return nil, err
 	}
 
+	return versionsFromReleases(releases, includeUnstable), nil
+}
+
+// GetAllAvailableVersionsWithConfig is like GetAvailableVersionsWithConfig but follows the API's Link header
+// pagination until it is exhausted, returning the complete release history. Returns sorted versions or an error.
+func GetAllAvailableVersionsWithConfig(ctx context.Context, includeUnstable bool, apiURL string, cacheDuration time.Duration) ([]string, error) {
+	releases, err := fetchAllReleasesWithConfig(ctx, apiURL, cacheDuration)
+	if err != nil {
+		return nil, err
+	}
+
+	return versionsFromReleases(releases, includeUnstable), nil
+}
+
+// versionsFromReleases extracts version strings from releases, newest first, de-duplicating across pages.
+// Unstable releases are skipped unless includeUnstable is set.
+func versionsFromReleases(releases []Release, includeUnstable bool) []string {
 	var versions []string
+	seen := make(map[string]bool, len(releases))
 	for _, release := range releases {
 		if !includeUnstable && !release.Stable {
 			continue
 		}
 
 		version := strings.TrimPrefix(release.Version, "go")
+		if seen[version] {
+			continue
+		}
+		seen[version] = true
 		versions = append(versions, version)
 	}
 
-	sort.Slice(versions, func(i, j int) bool {
-		return CompareVersions(versions[i], versions[j]) > 0
-	})
+	SortVersions(versions, false)
 
-	return versions, nil
+	return versions
 }
 
 // GetDownloadURL returns the archive download URL for a given version using default endpoints.
 // Parameter version is the version string. Returns the URL or an error if unavailable for the platform.
 func GetDownloadURL(version string) (string, error) {
-	return GetDownloadURLWithConfig(version, defaultGoReleasesAPI, defaultCacheDuration, defaultGoDownloadURL)
+	return GetDownloadURLWithConfig(context.Background(), version, defaultGoReleasesAPI, defaultCacheDuration, defaultGoDownloadURL)
 }
 
-// GetDownloadURLWithConfig computes the archive download URL using custom API and URL template.
-// Parameters: version, apiURL, cacheDuration, downloadURL (format string). Returns URL or error.
-func GetDownloadURLWithConfig(version string, apiURL string, cacheDuration time.Duration, downloadURL string) (string, error) {
-	releases, err := fetchReleasesWithConfig(apiURL, cacheDuration)
+// GetDownloadURLWithConfig returns the archive URL of version under downloadURL, or the first of it and mirrors to
+// answer HTTP 200. Returns an error wrapping ErrVersionNotFound or ErrNoDownload when there is no such archive.
+func GetDownloadURLWithConfig(ctx context.Context, version string, apiURL string, cacheDuration time.Duration, downloadURL string, mirrors ...string) (string, error) {
+	releases, err := fetchReleasesWithConfig(ctx, apiURL, cacheDuration)
 	if err != nil {
 		return "", err
 	}
 
+	file, err := findArchive(releases, version, ErrNoDownload)
+	if err != nil {
+		return "", err
+	}
+	return selectMirror(ctx, file.Filename, append([]string{downloadURL}, mirrors...))
+}
+
+// GetArchiveURLsWithConfig returns the archive URL of version under downloadURL and each mirror, in order and
+// without duplicates, so a failed download can move on to the next one. Returns the same errors as
+// GetDownloadURLWithConfig.
+func GetArchiveURLsWithConfig(ctx context.Context, version string, apiURL string, cacheDuration time.Duration, downloadURL string, mirrors ...string) ([]string, error) {
+	releases, err := fetchReleasesWithConfig(ctx, apiURL, cacheDuration)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := findArchive(releases, version, ErrNoDownload)
+	if err != nil {
+		return nil, err
+	}
+	candidates := mirrorURLs(file.Filename, append([]string{downloadURL}, mirrors...))
+	if len(candidates) == 0 {
+		return nil, fmt.Errorf("no download URL configured")
+	}
+	return candidates, nil
+}
+
+// findArchive returns the archive of version for this platform among releases. Returns an error wrapping
+// ErrVersionNotFound if no release matches version, or wrapping missing if it has no archive for this platform.
+func findArchive(releases []Release, version string, missing error) (*File, error) {
 	targetVersion := "go" + version
 	goos := runtime.GOOS
 	goarch := runtime.GOARCH
 
 	resolvedArch := resolveArch(version, goos, goarch)
 
+	known := false
 	for _, release := range releases {
 		if release.Version != targetVersion {
 			continue
 		}
+		known = true
 
 		for _, file := range release.Files {
 			if file.OS == goos && file.Arch == resolvedArch && file.Kind == "archive" {
-				return fmt.Sprintf(downloadURL, file.Filename), nil
+				return &file, nil
+			}
+		}
+	}
+
+	if !known {
+		return nil, fmt.Errorf("go version %s is %w", version, ErrVersionNotFound)
+	}
+
+	return nil, fmt.Errorf("%w for Go %s on %s/%s", missing, version, goos, goarch)
+}
+
+// selectMirror builds the archive URL for each candidate base and returns the first reachable one.
+// A single candidate is returned without probing. Returns an error listing every failed mirror.
+func selectMirror(ctx context.Context, filename string, bases []string) (string, error) {
+	candidates := mirrorURLs(filename, bases)
+	if len(candidates) == 0 {
+		return "", fmt.Errorf("no download URL configured")
+	}
+	if len(candidates) == 1 {
+		return candidates[0], nil
+	}
+
+	cacheMutex.RLock()
+	client := httpClient
+	cacheMutex.RUnlock()
+
+	var failures []string
+	for _, url := range candidates {
+		resp, err := headRequest(ctx, client, url)
+		if err != nil {
+			if ctx.Err() != nil {
+				return "", ctx.Err()
 			}
+			failures = append(failures, fmt.Sprintf("%s: %v", url, err))
+			continue
+		}
+		resp.Body.Close()
+
+		if resp.StatusCode == http.StatusOK {
+			return url, nil
+		}
+		failures = append(failures, fmt.Sprintf("%s: HTTP %d", url, resp.StatusCode))
+	}
+
+	return "", &networkError{fmt.Errorf("no reachable download mirror for %s: %s", filename, strings.Join(failures, "; "))}
+}
+
+// mirrorURLs returns the archive URL of filename under each non-empty base, in order and without duplicates.
+func mirrorURLs(filename string, bases []string) []string {
+	var urls []string
+	seen := make(map[string]bool)
+	for _, base := range bases {
+		if base == "" {
+			continue
+		}
+		url := buildDownloadURL(base, filename)
+		if !seen[url] {
+			seen[url] = true
+			urls = append(urls, url)
 		}
 	}
+	return urls
+}
 
-	return "", fmt.Errorf("no download available for Go %s on %s/%s", version, goos, goarch)
+// buildDownloadURL combines a mirror with an archive filename.
+// base may be a format string containing %s (e.g., "https://go.dev/dl/%s") or a plain base URL.
+func buildDownloadURL(base, filename string) string {
+	if strings.Contains(base, "%s") {
+		return fmt.Sprintf(base, filename)
+	}
+
+	return strings.TrimSuffix(base, "/") + "/" + filename
 }
 
 // resolveArch determines the appropriate architecture for downloads (e.g., maps darwin/arm64 to amd64 pre-1.16).
@@ -146,41 +355,157 @@ func resolveArch(version, goos, goarch string) string {
 // GetFileInfo returns metadata for the current platform's archive for a version using defaults.
 // Parameter version is the version string. Returns *File or an error if not found.
 func GetFileInfo(version string) (*File, error) {
-	return GetFileInfoWithConfig(version, defaultGoReleasesAPI, defaultCacheDuration)
+	return GetFileInfoWithConfig(context.Background(), version, defaultGoReleasesAPI, defaultCacheDuration)
 }
 
 // GetFileInfoWithConfig returns archive metadata using a specific API URL and cache duration.
-// Parameters: version, apiURL, cacheDuration. Returns *File or an error.
-func GetFileInfoWithConfig(version string, apiURL string, cacheDuration time.Duration) (*File, error) {
-	releases, err := fetchReleasesWithConfig(apiURL, cacheDuration)
+// Parameters: ctx, version, apiURL, cacheDuration. Returns *File, or an error wrapping ErrVersionNotFound or ErrNoFileInfo.
+func GetFileInfoWithConfig(ctx context.Context, version string, apiURL string, cacheDuration time.Duration) (*File, error) {
+	releases, err := fetchReleasesWithConfig(ctx, apiURL, cacheDuration)
 	if err != nil {
 		return nil, err
 	}
 
-	targetVersion := "go" + version
-	goos := runtime.GOOS
-	goarch := runtime.GOARCH
+	return findArchive(releases, version, ErrNoFileInfo)
+}
 
-	resolvedArch := resolveArch(version, goos, goarch)
+// RemoteInfo describes a release archive for this platform that may not be installed yet.
+type RemoteInfo struct {
+	Version       string
+	Stable        bool
+	Filename      string
+	URL           string
+	Sha256        string
+	Size          int64     // archive size reported by the release API, 0 if absent
+	ContentLength int64     // archive size from a HEAD request, -1 when the server did not report it
+	LastModified  time.Time // zero when the server did not send Last-Modified
+}
 
-	for _, release := range releases {
-		if release.Version != targetVersion {
-			continue
-		}
+// GetRemoteInfoWithConfig describes the archive for version without downloading it: the URL chosen by
+// GetDownloadURLWithConfig, the checksum and size from the release API, and Content-Length and Last-Modified
+// from a HEAD request. A failed HEAD request leaves ContentLength at -1 rather than failing. Returns *RemoteInfo or an error.
+func GetRemoteInfoWithConfig(ctx context.Context, version string, apiURL string, cacheDuration time.Duration, downloadURL string, mirrors ...string) (*RemoteInfo, error) {
+	source := &APISource{APIURL: apiURL, CacheDuration: cacheDuration, DownloadURLs: append([]string{downloadURL}, mirrors...)}
+	return GetRemoteInfo(ctx, source, version)
+}
 
-		for _, file := range release.Files {
-			if file.OS == goos && file.Arch == resolvedArch && file.Kind == "archive" {
-				return &file, nil
-			}
+// GetRemoteInfo is like GetRemoteInfoWithConfig for any release source. A version is stable when the source lists it
+// among its stable versions. Returns *RemoteInfo or an error.
+func GetRemoteInfo(ctx context.Context, source ReleaseSource, version string) (*RemoteInfo, error) {
+	file, err := source.FileInfo(ctx, version)
+	if err != nil {
+		return nil, err
+	}
+
+	archiveURL, err := source.DownloadURL(ctx, version)
+	if err != nil {
+		return nil, err
+	}
+
+	info := &RemoteInfo{
+		Version:       version,
+		Filename:      file.Filename,
+		URL:           archiveURL,
+		Sha256:        file.Sha256,
+		Size:          file.Size,
+		ContentLength: -1,
+	}
+
+	if stable, err := source.AvailableVersions(ctx, false); err == nil {
+		info.Stable = slices.Contains(stable, version)
+	}
+
+	cacheMutex.RLock()
+	client := httpClient
+	cacheMutex.RUnlock()
+
+	resp, err := headRequest(ctx, client, archiveURL)
+	if err != nil {
+		return info, nil
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode == http.StatusOK {
+		info.ContentLength = resp.ContentLength
+		if modified, err := http.ParseTime(resp.Header.Get("Last-Modified")); err == nil {
+			info.LastModified = modified
 		}
 	}
 
-	return nil, fmt.Errorf("no file info available for Go %s on %s/%s", version, goos, goarch)
+	return info, nil
 }
 
-// GetVersionInfo collects local installation details (version, path, OS/arch, install date, size).
+// GetVersionInfo collects local installation details (version, path, OS/arch, install date, size) plus
+// provenance read from the install tree without running the toolchain: GOROOT, release date, and cgo default.
 // Parameter installPath is the Go installation root. Returns *VersionInfo or an error if missing binary.
 func GetVersionInfo(installPath string) (*VersionInfo, error) {
+	info, err := StatVersion(installPath)
+	if err != nil {
+		return nil, err
+	}
+
+	size, err := DirSize(installPath)
+	if err != nil {
+		size = 0
+	}
+	info.Size = size
+	info.GOROOT = installPath
+	info.ReleaseDate = readReleaseDate(installPath)
+	info.CGODefault = readCGODefault(installPath)
+
+	return info, nil
+}
+
+// readReleaseDate parses the "time <RFC3339>" line that Go 1.21+ writes after the version in GOROOT/VERSION.
+// Returns the zero time when the file or line is missing or malformed.
+func readReleaseDate(goroot string) time.Time {
+	data, err := os.ReadFile(filepath.Join(goroot, "VERSION"))
+	if err != nil {
+		return time.Time{}
+	}
+
+	for _, line := range strings.Split(string(data), "\n") {
+		value, found := strings.CutPrefix(strings.TrimSpace(line), "time ")
+		if !found {
+			continue
+		}
+		if released, err := time.Parse(time.RFC3339, strings.TrimSpace(value)); err == nil {
+			return released
+		}
+	}
+
+	return time.Time{}
+}
+
+// readCGODefault reads the CGO_ENABLED default the toolchain was built with from its generated sources.
+// Returns "enabled", "disabled", "auto" when cgo follows the platform default, or "" if the sources are absent.
+func readCGODefault(goroot string) string {
+	for _, name := range cgoDefaultFiles {
+		data, err := os.ReadFile(filepath.Join(goroot, name))
+		if err != nil {
+			continue
+		}
+
+		match := cgoDefaultRegex.FindSubmatch(data)
+		if match == nil {
+			continue
+		}
+		switch string(match[1]) {
+		case "1":
+			return "enabled"
+		case "0":
+			return "disabled"
+		default:
+			return "auto"
+		}
+	}
+
+	return ""
+}
+
+// StatVersion collects installation details like GetVersionInfo but skips walking the directory.
+// The returned Size is always zero. Returns *VersionInfo or an error if missing binary.
+func StatVersion(installPath string) (*VersionInfo, error) {
 	goBinary := filepath.Join(installPath, "bin", "go")
 	if runtime.GOOS == "windows" {
 		goBinary += ".exe"
@@ -194,22 +519,17 @@ func GetVersionInfo(installPath string) (*VersionInfo, error) {
 	version := filepath.Base(installPath)
 	version = strings.TrimPrefix(version, "go")
 
-	size, err := getDirSize(installPath)
-	if err != nil {
-		size = 0
-	}
-
 	return &VersionInfo{
 		Version:     version,
 		Path:        installPath,
 		OS:          runtime.GOOS,
 		Arch:        runtime.GOARCH,
 		InstallDate: stat.ModTime(),
-		Size:        size,
 	}, nil
 }
 
 // CompareVersions compares two semantic version strings with prerelease awareness.
+// A prerelease sorts before its final release (1.22rc2 < 1.22.0), ordered alpha < beta < rc by numeric suffix.
 // Returns 1 if v1 > v2, -1 if v1 < v2, and 0 if equal.
 func CompareVersions(v1, v2 string) int {
 	// Early return for identical strings
@@ -242,6 +562,75 @@ func CompareVersions(v1, v2 string) int {
 	return comparePrerelease(parts1.prerelease, parts2.prerelease)
 }
 
+// SortVersions sorts versions in place in the order of CompareVersions, ascending or descending.
+// Each version is parsed once rather than on every comparison, which matters for long lists.
+// Versions that compare equal, such as "1.22" and "1.22.0", keep their relative order.
+func SortVersions(versions []string, ascending bool) {
+	keyed := make([]keyedVersion, len(versions))
+	for i, version := range versions {
+		keyed[i] = keyedVersion{version: version, key: newVersionKey(version)}
+	}
+
+	slices.SortStableFunc(keyed, func(a, b keyedVersion) int {
+		if ascending {
+			return a.key.compare(b.key)
+		}
+		return b.key.compare(a.key)
+	})
+
+	for i := range keyed {
+		versions[i] = keyed[i].version
+	}
+}
+
+// keyedVersion pairs a version string with its parsed sort key.
+type keyedVersion struct {
+	version string
+	key     versionKey
+}
+
+// versionKey is a version reduced to the fields CompareVersions orders by.
+type versionKey struct {
+	numbers    [3]int
+	prerelease bool
+	preRank    int
+	preNumber  int
+}
+
+// newVersionKey parses version into a versionKey.
+func newVersionKey(version string) versionKey {
+	parts := parseVersion(normalizeVersion(version))
+	key := versionKey{numbers: parts.numbers}
+	if parts.prerelease != "" {
+		key.prerelease = true
+		key.preRank = getPrereleaseRank(parts.prerelease)
+		key.preNumber = extractPrereleaseNumber(parts.prerelease)
+	}
+	return key
+}
+
+// compare orders two keys like CompareVersions: by number, then a final release above its prereleases, then by
+// prerelease kind and numeric suffix. Returns 1, -1, or 0.
+func (k versionKey) compare(other versionKey) int {
+	for i := range k.numbers {
+		if c := cmp.Compare(k.numbers[i], other.numbers[i]); c != 0 {
+			return c
+		}
+	}
+
+	if k.prerelease != other.prerelease {
+		if k.prerelease {
+			return -1
+		}
+		return 1
+	}
+
+	if c := cmp.Compare(k.preRank, other.preRank); c != 0 {
+		return c
+	}
+	return cmp.Compare(k.preNumber, other.preNumber)
+}
+
 type versionParts struct {
 	numbers    [3]int
 	prerelease string
@@ -264,7 +653,7 @@ func parseVersion(version string) versionParts {
 		}
 	}
 
-	if len(matches) > 2 {
+	if len(matches) > 2 && matches[2] != "" {
 		if num, err := strconv.Atoi(matches[2]); err == nil {
 			parts.numbers[1] = num
 		}
@@ -307,8 +696,10 @@ func comparePrerelease(pre1, pre2 string) int {
 	rank1 := getPrereleaseRank(pre1)
 	rank2 := getPrereleaseRank(pre2)
 
-	if rank1 != rank2 {
-		return rank1 - rank2
+	if rank1 > rank2 {
+		return 1
+	} else if rank1 < rank2 {
+		return -1
 	}
 
 	num1 := extractPrereleaseNumber(pre1)
@@ -347,9 +738,9 @@ func extractPrereleaseNumber(prerelease string) int {
 }
 
 // fetchReleasesWithConfig fetches releases JSON, caches results with expiry, and returns parsed data.
-// Parameters: apiURL, cacheDuration. Returns []Release or an error.
+// Parameters: ctx, apiURL, cacheDuration. Returns []Release or an error.
 // Uses double-checked locking to avoid TOCTOU race condition.
-func fetchReleasesWithConfig(apiURL string, cacheDuration time.Duration) ([]Release, error) {
+func fetchReleasesWithConfig(ctx context.Context, apiURL string, cacheDuration time.Duration) ([]Release, error) {
 	// First check with read lock (fast path)
 	cacheMutex.RLock()
 	if time.Now().Before(cacheExpiry) && releasesCache != nil {
@@ -369,45 +760,186 @@ func fetchReleasesWithConfig(apiURL string, cacheDuration time.Duration) ([]Rele
 	}
 
 	// Fetch releases while holding write lock
-	client := &http.Client{
-		Timeout: 30 * time.Second,
+	releases, err := fetchReleasePages(ctx, apiURL, false)
+	if err != nil {
+		cacheMutex.Unlock()
+		return nil, err
+	}
+
+	releasesCache = releases
+	cacheExpiry = time.Now().Add(cacheDuration)
+	cacheMutex.Unlock()
+
+	return releases, nil
+}
+
+// fetchAllReleasesWithConfig fetches every page of release JSON and caches the combined result separately
+// from the first-page cache. Parameters: ctx, apiURL, cacheDuration. Returns []Release or an error.
+func fetchAllReleasesWithConfig(ctx context.Context, apiURL string, cacheDuration time.Duration) ([]Release, error) {
+	cacheMutex.RLock()
+	if time.Now().Before(allCacheExpiry) && allReleasesCache != nil {
+		result := allReleasesCache
+		cacheMutex.RUnlock()
+		return result, nil
 	}
+	cacheMutex.RUnlock()
 
-	resp, err := client.Get(apiURL)
+	cacheMutex.Lock()
+	defer cacheMutex.Unlock()
+	if time.Now().Before(allCacheExpiry) && allReleasesCache != nil {
+		return allReleasesCache, nil
+	}
+
+	releases, err := fetchReleasePages(ctx, apiURL, true)
 	if err != nil {
-		cacheMutex.Unlock()
-		return nil, fmt.Errorf("failed to fetch releases: %w", err)
+		return nil, err
+	}
+
+	allReleasesCache = releases
+	allCacheExpiry = time.Now().Add(cacheDuration)
+
+	return releases, nil
+}
+
+// fetchReleasePages requests apiURL and, when allPages is set, follows rel="next" Link headers until none remain.
+// The auth token is only sent to apiURL's host. Callers must hold cacheMutex. Returns the releases or an error.
+func fetchReleasePages(ctx context.Context, apiURL string, allPages bool) ([]Release, error) {
+	var releases []Release
+	visited := make(map[string]bool)
+	apiHost := ""
+	if u, err := url.Parse(apiURL); err == nil {
+		apiHost = u.Host
+	}
+
+	for pageURL := apiURL; pageURL != ""; {
+		if visited[pageURL] {
+			break
+		}
+		if len(visited) == maxReleasePages {
+			return nil, fmt.Errorf("failed to fetch releases: more than %d pages", maxReleasePages)
+		}
+		visited[pageURL] = true
+
+		page, next, err := fetchReleasePage(ctx, pageURL, apiHost)
+		if err != nil {
+			return nil, err
+		}
+		releases = append(releases, page...)
+
+		if !allPages {
+			break
+		}
+		pageURL = next
+	}
+
+	return releases, nil
+}
+
+// fetchReleasePage fetches and parses a single page of release JSON, authenticating when the page is on authHost.
+// Returns the page's releases, the absolute URL of the next page ("" if none), or an error.
+func fetchReleasePage(ctx context.Context, pageURL, authHost string) ([]Release, string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, pageURL, nil)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to fetch releases: %w", err)
+	}
+	authenticated := authToken != "" && req.URL.Host == authHost
+	if authenticated {
+		req.Header.Set("Authorization", "Bearer "+authToken)
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, "", &networkError{fmt.Errorf("failed to fetch releases: %w", err)}
 	}
 	defer resp.Body.Close()
 
+	if err := rateLimitError(resp, authenticated); err != nil {
+		return nil, "", err
+	}
+
 	if resp.StatusCode != http.StatusOK {
-		cacheMutex.Unlock()
-		return nil, fmt.Errorf("failed to fetch releases: HTTP %d (%s)", resp.StatusCode, resp.Status)
+		return nil, "", &networkError{fmt.Errorf("failed to fetch releases: HTTP %d (%s)", resp.StatusCode, resp.Status)}
 	}
 
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		cacheMutex.Unlock()
-		return nil, fmt.Errorf("failed to read response: %w", err)
+		return nil, "", fmt.Errorf("failed to read response: %w", err)
 	}
 
 	var releases []Release
 	if err := json.Unmarshal(body, &releases); err != nil {
-		cacheMutex.Unlock()
-		return nil, fmt.Errorf("failed to parse releases: %w", err)
+		return nil, "", fmt.Errorf("failed to parse releases: %w", err)
 	}
 
-	releasesCache = releases
-	cacheExpiry = time.Now().Add(cacheDuration)
-	cacheMutex.Unlock()
+	return releases, nextPageURL(resp), nil
+}
 
-	return releases, nil
+// headRequest sends a HEAD request for url with client, bound to ctx. Returns the response or an error.
+func headRequest(ctx context.Context, client *http.Client, url string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	return client.Do(req)
+}
+
+// rateLimitError returns a *RateLimitError when resp is a 403 or 429 with X-RateLimit-Remaining: 0, and nil otherwise.
+// The reset time comes from X-RateLimit-Reset (Unix seconds) when present.
+func rateLimitError(resp *http.Response, authenticated bool) error {
+	if resp.StatusCode != http.StatusForbidden && resp.StatusCode != http.StatusTooManyRequests {
+		return nil
+	}
+	if resp.Header.Get("X-RateLimit-Remaining") != "0" {
+		return nil
+	}
+
+	rateErr := &RateLimitError{StatusCode: resp.StatusCode, Authenticated: authenticated}
+	if reset, err := strconv.ParseInt(resp.Header.Get("X-RateLimit-Reset"), 10, 64); err == nil && reset > 0 {
+		rateErr.Reset = time.Unix(reset, 0)
+	}
+
+	return rateErr
+}
+
+// nextPageURL returns the rel="next" target from resp's Link headers, resolved against the request URL.
+// Returns "" when there is no next page or the link cannot be parsed.
+func nextPageURL(resp *http.Response) string {
+	for _, header := range resp.Header.Values("Link") {
+		for _, link := range strings.Split(header, ",") {
+			target, params, found := strings.Cut(strings.TrimSpace(link), ";")
+			if !found || !strings.HasPrefix(target, "<") || !strings.HasSuffix(target, ">") {
+				continue
+			}
+
+			isNext := false
+			for _, param := range strings.Split(params, ";") {
+				key, value, _ := strings.Cut(strings.TrimSpace(param), "=")
+				if strings.EqualFold(key, "rel") && slices.Contains(strings.Fields(strings.Trim(value, `"`)), "next") {
+					isNext = true
+				}
+			}
+			if !isNext {
+				continue
+			}
+
+			next, err := url.Parse(strings.Trim(target, "<>"))
+			if err != nil {
+				return ""
+			}
+			if resp.Request != nil && resp.Request.URL != nil {
+				next = resp.Request.URL.ResolveReference(next)
+			}
+			return next.String()
+		}
+	}
+
+	return ""
 }
 
-// getDirSize walks a directory and sums file sizes.
+// DirSize walks a directory and sums file sizes.
 // Uses filepath.WalkDir for better performance (avoids unnecessary os.Stat calls).
 // Parameter path. Returns total size in bytes or an error.
-func getDirSize(path string) (int64, error) {
+func DirSize(path string) (int64, error) {
 	var size int64
 
 	err := filepath.WalkDir(path, func(_ string, d fs.DirEntry, err error) error {
@@ -427,11 +959,36 @@ func getDirSize(path string) (int64, error) {
 	return size, err
 }
 
+// SetHTTPClient replaces the HTTP client used to fetch release metadata.
+// A nil client is ignored.
+func SetHTTPClient(client *http.Client) {
+	if client == nil {
+		return
+	}
+
+	cacheMutex.Lock()
+	httpClient = client
+	cacheMutex.Unlock()
+}
+
+// SetAuthToken sets the token sent as "Authorization: Bearer" on release API requests; "" disables it.
+// The token is only sent to the configured API host and is never logged.
+func SetAuthToken(token string) {
+	cacheMutex.Lock()
+	authToken = token
+	cacheMutex.Unlock()
+}
+
 // ClearReleasesCache clears the in-memory releases cache.
 // This is primarily used for testing to ensure a clean state.
 func ClearReleasesCache() {
 	cacheMutex.Lock()
 	releasesCache = nil
 	cacheExpiry = time.Time{}
+	allReleasesCache = nil
+	allCacheExpiry = time.Time{}
+	indexCache = nil
+	indexCacheURL = ""
+	indexCacheExpiry = time.Time{}
 	cacheMutex.Unlock()
 }
@@ -1,13 +1,19 @@
 package golang
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"os"
 	"path/filepath"
+	"reflect"
 	"runtime"
+	"slices"
+	"sort"
+	"strconv"
 	"strings"
 	"sync"
 	"testing"
@@ -61,7 +67,7 @@ func TestGetAvailableVersions(t *testing.T) {
 			server := createMockServer(tc.mockResponse, http.StatusOK)
 			defer server.Close()
 
-			versions, err := GetAvailableVersionsWithConfig(tc.includeUnstable, server.URL, 1*time.Minute)
+			versions, err := GetAvailableVersionsWithConfig(context.Background(), tc.includeUnstable, server.URL, 1*time.Minute)
 
 			if tc.shouldError && err == nil {
 				t.Error("Expected error but got none")
@@ -131,7 +137,7 @@ func TestGetAvailableVersionsWithConfig_Errors(t *testing.T) {
 			}
 			defer server.Close()
 
-			_, err := GetAvailableVersionsWithConfig(false, server.URL, 1*time.Minute)
+			_, err := GetAvailableVersionsWithConfig(context.Background(), false, server.URL, 1*time.Minute)
 
 			if tc.expectError && err == nil {
 				t.Error("Expected error but got none")
@@ -148,8 +154,10 @@ func TestGetDownloadURL(t *testing.T) {
 		name         string
 		version      string
 		mockResponse []Release
+		status       int
 		expectedURL  string
 		shouldError  bool
+		expectedErr  error
 	}{
 		{
 			name:    "Valid version with archive",
@@ -188,6 +196,7 @@ func TestGetDownloadURL(t *testing.T) {
 			},
 			expectedURL: "",
 			shouldError: true,
+			expectedErr: ErrVersionNotFound,
 		},
 		{
 			name:    "No matching platform",
@@ -207,6 +216,13 @@ func TestGetDownloadURL(t *testing.T) {
 			},
 			expectedURL: "",
 			shouldError: true,
+			expectedErr: ErrNoDownload,
+		},
+		{
+			name:        "Release API unavailable",
+			version:     "1.21.0",
+			status:      http.StatusServiceUnavailable,
+			shouldError: true,
 		},
 	}
 
@@ -215,10 +231,14 @@ func TestGetDownloadURL(t *testing.T) {
 			// Clear cache before each test
 			ClearReleasesCache()
 
-			server := createMockServer(tc.mockResponse, http.StatusOK)
+			status := tc.status
+			if status == 0 {
+				status = http.StatusOK
+			}
+			server := createMockServer(tc.mockResponse, status)
 			defer server.Close()
 
-			url, err := GetDownloadURLWithConfig(tc.version, server.URL, 1*time.Minute, defaultGoDownloadURL)
+			url, err := GetDownloadURLWithConfig(context.Background(), tc.version, server.URL, 1*time.Minute, defaultGoDownloadURL)
 
 			if tc.shouldError && err == nil {
 				t.Error("Expected error but got none")
@@ -228,6 +248,15 @@ func TestGetDownloadURL(t *testing.T) {
 				t.Errorf("Unexpected error: %v", err)
 			}
 
+			// A network failure must never look like a missing release, and vice versa
+			wantNotFound := tc.expectedErr == ErrVersionNotFound
+			if tc.shouldError && errors.Is(err, ErrVersionNotFound) != wantNotFound {
+				t.Errorf("errors.Is(err, ErrVersionNotFound) = %v, want %v (err: %v)", !wantNotFound, wantNotFound, err)
+			}
+			if tc.expectedErr != nil && !errors.Is(err, tc.expectedErr) {
+				t.Errorf("Expected error wrapping %v, got: %v", tc.expectedErr, err)
+			}
+
 			if !tc.shouldError && url != tc.expectedURL {
 				t.Errorf("Expected URL %q, got %q", tc.expectedURL, url)
 			}
@@ -314,6 +343,7 @@ func TestGetFileInfo(t *testing.T) {
 		version      string
 		mockResponse []Release
 		expectError  bool
+		expectedErr  error
 		checkFile    func(*testing.T, *File)
 	}{
 		{
@@ -357,8 +387,21 @@ func TestGetFileInfo(t *testing.T) {
 				},
 			},
 			expectError: true,
+			expectedErr: ErrVersionNotFound,
 			checkFile:   nil,
 		},
+		{
+			name:    "No file for this platform",
+			version: "1.21.0",
+			mockResponse: []Release{
+				{
+					Version: "go1.21.0",
+					Files:   []File{},
+				},
+			},
+			expectError: true,
+			expectedErr: ErrNoFileInfo,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -369,7 +412,7 @@ func TestGetFileInfo(t *testing.T) {
 			server := createMockServer(tc.mockResponse, http.StatusOK)
 			defer server.Close()
 
-			file, err := GetFileInfoWithConfig(tc.version, server.URL, 1*time.Minute)
+			file, err := GetFileInfoWithConfig(context.Background(), tc.version, server.URL, 1*time.Minute)
 
 			if tc.expectError && err == nil {
 				t.Error("Expected error but got none")
@@ -379,6 +422,10 @@ func TestGetFileInfo(t *testing.T) {
 				t.Errorf("Unexpected error: %v", err)
 			}
 
+			if tc.expectedErr != nil && !errors.Is(err, tc.expectedErr) {
+				t.Errorf("Expected error wrapping %v, got: %v", tc.expectedErr, err)
+			}
+
 			if !tc.expectError && tc.checkFile != nil {
 				tc.checkFile(t, file)
 			}
@@ -426,6 +473,77 @@ func TestGetVersionInfo(t *testing.T) {
 				if info.Arch != runtime.GOARCH {
 					t.Errorf("Expected Arch %q, got %q", runtime.GOARCH, info.Arch)
 				}
+				if info.GOROOT != info.Path {
+					t.Errorf("Expected GOROOT %q, got %q", info.Path, info.GOROOT)
+				}
+				if !info.ReleaseDate.IsZero() || info.CGODefault != "" {
+					t.Errorf("Expected no provenance without VERSION or sources, got %v and %q", info.ReleaseDate, info.CGODefault)
+				}
+			},
+		},
+		{
+			name: "Provenance from VERSION and bootstrap sources",
+			setupFunc: func(t *testing.T) string {
+				goDir := filepath.Join(t.TempDir(), "go1.21.0")
+				goBinary := filepath.Join(goDir, "bin", "go")
+				if runtime.GOOS == "windows" {
+					goBinary += ".exe"
+				}
+				files := map[string]string{
+					goBinary:                                 "fake",
+					filepath.Join(goDir, "VERSION"):          "go1.21.0\ntime 2023-08-08T15:21:37Z\n",
+					filepath.Join(goDir, cgoDefaultFiles[0]): "package buildcfg\n\nconst DefaultCGO_ENABLED = \"0\"\n",
+				}
+				for path, content := range files {
+					if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+						t.Fatal(err)
+					}
+					if err := os.WriteFile(path, []byte(content), 0755); err != nil {
+						t.Fatal(err)
+					}
+				}
+				return goDir
+			},
+			checkInfo: func(t *testing.T, info *VersionInfo) {
+				want := time.Date(2023, 8, 8, 15, 21, 37, 0, time.UTC)
+				if !info.ReleaseDate.Equal(want) {
+					t.Errorf("Expected release date %v, got %v", want, info.ReleaseDate)
+				}
+				if info.CGODefault != "disabled" {
+					t.Errorf("Expected CGO default 'disabled', got %q", info.CGODefault)
+				}
+			},
+		},
+		{
+			name: "Older layout without release time",
+			setupFunc: func(t *testing.T) string {
+				goDir := filepath.Join(t.TempDir(), "go1.20.5")
+				goBinary := filepath.Join(goDir, "bin", "go")
+				if runtime.GOOS == "windows" {
+					goBinary += ".exe"
+				}
+				files := map[string]string{
+					goBinary:                                 "fake",
+					filepath.Join(goDir, "VERSION"):          "go1.20.5",
+					filepath.Join(goDir, cgoDefaultFiles[1]): "package build\n\nconst defaultCGO_ENABLED = \"\"\n",
+				}
+				for path, content := range files {
+					if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+						t.Fatal(err)
+					}
+					if err := os.WriteFile(path, []byte(content), 0755); err != nil {
+						t.Fatal(err)
+					}
+				}
+				return goDir
+			},
+			checkInfo: func(t *testing.T, info *VersionInfo) {
+				if !info.ReleaseDate.IsZero() {
+					t.Errorf("Expected zero release date, got %v", info.ReleaseDate)
+				}
+				if info.CGODefault != "auto" {
+					t.Errorf("Expected CGO default 'auto', got %q", info.CGODefault)
+				}
 			},
 		},
 		{
@@ -589,6 +707,42 @@ func TestCompareVersions(t *testing.T) {
 			v2:       "1.21.0",
 			expected: 0,
 		},
+		{
+			name:     "Go-style RC before final release",
+			v1:       "1.22rc2",
+			v2:       "1.22.0",
+			expected: -1,
+		},
+		{
+			name:     "Go-style RC after previous minor",
+			v1:       "1.22rc1",
+			v2:       "1.21.13",
+			expected: 1,
+		},
+		{
+			name:     "RC suffix compared numerically",
+			v1:       "1.22rc10",
+			v2:       "1.22rc9",
+			expected: 1,
+		},
+		{
+			name:     "Alpha vs RC",
+			v1:       "1.22alpha3",
+			v2:       "1.22rc1",
+			expected: -1,
+		},
+		{
+			name:     "Prerelease without number",
+			v1:       "1.22rc",
+			v2:       "1.22rc1",
+			expected: -1,
+		},
+		{
+			name:     "Major only",
+			v1:       "2",
+			v2:       "1.25.1",
+			expected: 1,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -602,6 +756,145 @@ func TestCompareVersions(t *testing.T) {
 	}
 }
 
+func TestCompareVersions_SortMixed(t *testing.T) {
+	testCases := []struct {
+		name     string
+		versions []string
+		expected []string
+	}{
+		{
+			name:     "Stable above its prereleases",
+			versions: []string{"1.22rc2", "1.21.13", "1.22.0", "1.22rc1"},
+			expected: []string{"1.22.0", "1.22rc2", "1.22rc1", "1.21.13"},
+		},
+		{
+			name:     "Prerelease kinds and numeric suffixes",
+			versions: []string{"1.23rc1", "1.23beta1", "1.23rc10", "1.23alpha1", "1.23rc2", "1.22.5"},
+			expected: []string{"1.23rc10", "1.23rc2", "1.23rc1", "1.23beta1", "1.23alpha1", "1.22.5"},
+		},
+		{
+			name:     "Patches and prereleases across minors",
+			versions: []string{"1.22.1", "1.23rc1", "1.22.0", "1.23.0", "1.22rc2"},
+			expected: []string{"1.23.0", "1.23rc1", "1.22.1", "1.22.0", "1.22rc2"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			versions := append([]string(nil), tc.versions...)
+			sort.Slice(versions, func(i, j int) bool {
+				return CompareVersions(versions[i], versions[j]) > 0
+			})
+
+			if !reflect.DeepEqual(versions, tc.expected) {
+				t.Errorf("sorted = %v, expected %v", versions, tc.expected)
+			}
+		})
+	}
+}
+
+func TestSortVersions(t *testing.T) {
+	versions := []string{"1.22.1", "1.23rc1", "go1.22.0", "1.23.0", "1.22rc2", "1.23beta1", "1.23rc10", "1.21", "1.23rc2", "1.9.2"}
+
+	descending := append([]string(nil), versions...)
+	SortVersions(descending, false)
+	want := []string{"1.23.0", "1.23rc10", "1.23rc2", "1.23rc1", "1.23beta1", "1.22.1", "go1.22.0", "1.22rc2", "1.21", "1.9.2"}
+	if !reflect.DeepEqual(descending, want) {
+		t.Errorf("SortVersions(descending) = %v, expected %v", descending, want)
+	}
+
+	ascending := append([]string(nil), versions...)
+	SortVersions(ascending, true)
+	slices.Reverse(want)
+	if !reflect.DeepEqual(ascending, want) {
+		t.Errorf("SortVersions(ascending) = %v, expected %v", ascending, want)
+	}
+
+	// Every pair must be ordered exactly as CompareVersions orders it
+	for i := range versions {
+		for j := range versions {
+			got := newVersionKey(versions[i]).compare(newVersionKey(versions[j]))
+			if expected := CompareVersions(versions[i], versions[j]); got != expected {
+				t.Errorf("key compare(%q, %q) = %d, CompareVersions = %d", versions[i], versions[j], got, expected)
+			}
+		}
+	}
+
+	// Equal versions keep their order
+	equal := []string{"1.22", "1.20.0", "1.22.0"}
+	SortVersions(equal, false)
+	if !reflect.DeepEqual(equal, []string{"1.22", "1.22.0", "1.20.0"}) {
+		t.Errorf("SortVersions() with equal versions = %v, expected a stable order", equal)
+	}
+}
+
+// benchmarkVersions returns a shuffled list of releases and prereleases across many minor lines.
+func benchmarkVersions() []string {
+	var versions []string
+	for minor := 0; minor <= 25; minor++ {
+		versions = append(versions, fmt.Sprintf("1.%drc1", minor), fmt.Sprintf("1.%dbeta2", minor))
+		for patch := 0; patch <= 6; patch++ {
+			versions = append(versions, fmt.Sprintf("1.%d.%d", minor, patch))
+		}
+	}
+	// Deterministic interleaving so each run sorts the same unsorted input
+	shuffled := make([]string, 0, len(versions))
+	for stride := 0; stride < 7; stride++ {
+		for i := stride; i < len(versions); i += 7 {
+			shuffled = append(shuffled, versions[i])
+		}
+	}
+	return shuffled
+}
+
+func BenchmarkSortVersions(b *testing.B) {
+	input := benchmarkVersions()
+	versions := make([]string, len(input))
+	b.ReportAllocs()
+	for b.Loop() {
+		copy(versions, input)
+		SortVersions(versions, false)
+	}
+}
+
+// BenchmarkSortSliceCompareVersions is the previous approach, re-parsing both versions on every comparison.
+func BenchmarkSortSliceCompareVersions(b *testing.B) {
+	input := benchmarkVersions()
+	versions := make([]string, len(input))
+	b.ReportAllocs()
+	for b.Loop() {
+		copy(versions, input)
+		sort.Slice(versions, func(i, j int) bool {
+			return CompareVersions(versions[i], versions[j]) > 0
+		})
+	}
+}
+
+func TestVersionExtractRegex(t *testing.T) {
+	testCases := []struct {
+		path     string
+		expected string
+	}{
+		{"/home/user/.govman/versions/go1.22.0/bin/go", "1.22.0"},
+		{"../versions/go1.22.0/bin/go", "1.22.0"},
+		{"../../versions/go1.25rc1/bin/go", "1.25rc1"},
+		{`..\versions\go1.21.5\bin\go.exe`, "1.21.5"},
+		{"go1.23/bin/go", "1.23"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.path, func(t *testing.T) {
+			matches := VersionExtractRegex.FindStringSubmatch(tc.path)
+			if len(matches) < 2 {
+				t.Fatalf("VersionExtractRegex did not match %q", tc.path)
+			}
+			if matches[1] != tc.expected {
+				t.Errorf("VersionExtractRegex on %q = %q, expected %q", tc.path, matches[1], tc.expected)
+			}
+		})
+	}
+}
+
 func TestParseVersion(t *testing.T) {
 	testCases := []struct {
 		name               string
@@ -643,6 +936,22 @@ func TestParseVersion(t *testing.T) {
 			expectedPatch:      0,
 			expectedPrerelease: "beta2",
 		},
+		{
+			name:               "Go-style RC without separator",
+			version:            "1.22rc1",
+			expectedMajor:      1,
+			expectedMinor:      22,
+			expectedPatch:      0,
+			expectedPrerelease: "rc1",
+		},
+		{
+			name:               "Major only",
+			version:            "2",
+			expectedMajor:      2,
+			expectedMinor:      0,
+			expectedPatch:      0,
+			expectedPrerelease: "",
+		},
 		{
 			name:               "Invalid version",
 			version:            "invalid",
@@ -761,6 +1070,18 @@ func TestComparePrerelease(t *testing.T) {
 			pre2:     "rc1",
 			expected: 0,
 		},
+		{
+			name:     "Alpha vs rc",
+			pre1:     "alpha2",
+			pre2:     "rc1",
+			expected: -1,
+		},
+		{
+			name:     "RC10 vs RC9",
+			pre1:     "rc10",
+			pre2:     "rc9",
+			expected: 1,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -909,7 +1230,7 @@ func TestFetchReleasesWithConfig(t *testing.T) {
 			}
 			defer server.Close()
 
-			releases, err := fetchReleasesWithConfig(server.URL, tc.cacheDuration)
+			releases, err := fetchReleasesWithConfig(context.Background(), server.URL, tc.cacheDuration)
 
 			if tc.expectError && err == nil {
 				t.Error("Expected error but got none")
@@ -945,13 +1266,13 @@ func TestFetchReleasesCache(t *testing.T) {
 		defer server.Close()
 
 		// First call - populate cache
-		releases1, err := fetchReleasesWithConfig(server.URL, 5*time.Minute)
+		releases1, err := fetchReleasesWithConfig(context.Background(), server.URL, 5*time.Minute)
 		if err != nil {
 			t.Fatalf("Unexpected error: %v", err)
 		}
 
 		// Second call - should hit cache
-		releases2, err := fetchReleasesWithConfig(server.URL, 5*time.Minute)
+		releases2, err := fetchReleasesWithConfig(context.Background(), server.URL, 5*time.Minute)
 		if err != nil {
 			t.Fatalf("Unexpected error: %v", err)
 		}
@@ -973,7 +1294,7 @@ func TestFetchReleasesCache(t *testing.T) {
 		defer server.Close()
 
 		// First call with very short cache duration
-		_, err := fetchReleasesWithConfig(server.URL, 1*time.Millisecond)
+		_, err := fetchReleasesWithConfig(context.Background(), server.URL, 1*time.Millisecond)
 		if err != nil {
 			t.Fatalf("Unexpected error: %v", err)
 		}
@@ -982,14 +1303,14 @@ func TestFetchReleasesCache(t *testing.T) {
 		time.Sleep(2 * time.Millisecond)
 
 		// Second call should fetch again
-		_, err = fetchReleasesWithConfig(server.URL, 1*time.Minute)
+		_, err = fetchReleasesWithConfig(context.Background(), server.URL, 1*time.Minute)
 		if err != nil {
 			t.Fatalf("Unexpected error: %v", err)
 		}
 	})
 }
 
-func TestGetDirSize(t *testing.T) {
+func TestDirSize(t *testing.T) {
 	testCases := []struct {
 		name        string
 		setupFunc   func(t *testing.T) string
@@ -1049,7 +1370,7 @@ func TestGetDirSize(t *testing.T) {
 			setupFunc: func(t *testing.T) string {
 				tmpDir := t.TempDir()
 				// Create a valid directory that exists
-				// getDirSize handles walk errors gracefully and doesn't return error for non-existent paths
+				// DirSize handles walk errors gracefully and doesn't return error for non-existent paths
 				// It returns error from filepath.Walk which is nil when path doesn't exist (it just doesn't walk)
 				return tmpDir
 			},
@@ -1061,7 +1382,7 @@ func TestGetDirSize(t *testing.T) {
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
 			path := tc.setupFunc(t)
-			size, err := getDirSize(path)
+			size, err := DirSize(path)
 
 			if tc.expectError && err == nil {
 				t.Error("Expected error but got none")
@@ -1079,7 +1400,7 @@ func TestGetDirSize(t *testing.T) {
 	}
 }
 
-func TestGetDirSizeWithErrors(t *testing.T) {
+func TestDirSizeWithErrors(t *testing.T) {
 	t.Run("Handle file access errors gracefully", func(t *testing.T) {
 		tmpDir := t.TempDir()
 
@@ -1089,8 +1410,8 @@ func TestGetDirSizeWithErrors(t *testing.T) {
 			t.Fatal(err)
 		}
 
-		// getDirSize should handle errors gracefully and continue
-		size, err := getDirSize(tmpDir)
+		// DirSize should handle errors gracefully and continue
+		size, err := DirSize(tmpDir)
 		if err != nil {
 			t.Errorf("Unexpected error: %v", err)
 		}
@@ -1101,7 +1422,7 @@ func TestGetDirSizeWithErrors(t *testing.T) {
 
 	t.Run("Non-existent directory returns error from Walk", func(t *testing.T) {
 		nonExistentPath := filepath.Join(os.TempDir(), "nonexistent-dir-12345")
-		size, err := getDirSize(nonExistentPath)
+		size, err := DirSize(nonExistentPath)
 
 		// filepath.Walk returns an error for non-existent paths
 		if err == nil {
@@ -1179,7 +1500,7 @@ func TestConcurrency(t *testing.T) {
 					wg.Add(1)
 					go func() {
 						defer wg.Done()
-						_, _ = fetchReleasesWithConfig(server.URL, 1*time.Minute)
+						_, _ = fetchReleasesWithConfig(context.Background(), server.URL, 1*time.Minute)
 					}()
 				}
 
@@ -1207,7 +1528,7 @@ func TestConcurrency(t *testing.T) {
 					}()
 					go func() {
 						defer wg.Done()
-						_, _ = fetchReleasesWithConfig(server.URL, 1*time.Minute)
+						_, _ = fetchReleasesWithConfig(context.Background(), server.URL, 1*time.Minute)
 					}()
 				}
 
@@ -1374,13 +1695,16 @@ func TestFetchReleasesNetworkError(t *testing.T) {
 		// Clear cache before test
 		ClearReleasesCache()
 
-		_, err := fetchReleasesWithConfig("http://invalid-url-that-does-not-exist-12345.com", 1*time.Minute)
+		_, err := fetchReleasesWithConfig(context.Background(), "http://invalid-url-that-does-not-exist-12345.com", 1*time.Minute)
 		if err == nil {
 			t.Error("Expected error for invalid URL")
 		}
 		if !strings.Contains(err.Error(), "failed to fetch releases") {
 			t.Errorf("Expected 'failed to fetch releases' in error, got: %v", err)
 		}
+		if !errors.Is(err, ErrNetwork) {
+			t.Errorf("Expected error to wrap ErrNetwork, got: %v", err)
+		}
 	})
 }
 
@@ -1431,3 +1755,421 @@ func createMockServer(releases []Release, statusCode int) *httptest.Server {
 		json.NewEncoder(w).Encode(releases)
 	}))
 }
+
+func TestSetHTTPClient(t *testing.T) {
+	original := httpClient
+	defer func() { httpClient = original }()
+
+	ClearReleasesCache()
+	defer ClearReleasesCache()
+
+	requests := 0
+	client := &http.Client{
+		Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+			requests++
+			return http.DefaultTransport.RoundTrip(r)
+		}),
+	}
+	SetHTTPClient(client)
+
+	SetHTTPClient(nil)
+	if httpClient != client {
+		t.Fatal("SetHTTPClient(nil) should keep the existing client")
+	}
+
+	server := createMockServer([]Release{{Version: "go1.25.0", Stable: true}}, http.StatusOK)
+	defer server.Close()
+
+	if _, err := fetchReleasesWithConfig(context.Background(), server.URL, time.Minute); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if requests != 1 {
+		t.Errorf("Expected custom client to handle 1 request, got %d", requests)
+	}
+}
+
+// roundTripFunc adapts a function to http.RoundTripper
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
+
+func TestGetDownloadURLWithConfig_MirrorFallback(t *testing.T) {
+	filename := fmt.Sprintf("go1.25.0.%s-%s.tar.gz", runtime.GOOS, runtime.GOARCH)
+	releases := []Release{
+		{
+			Version: "go1.25.0",
+			Stable:  true,
+			Files: []File{
+				{Filename: filename, OS: runtime.GOOS, Arch: runtime.GOARCH, Kind: "archive"},
+			},
+		},
+	}
+
+	down := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "unavailable", http.StatusServiceUnavailable)
+	}))
+	defer down.Close()
+
+	var probed string
+	up := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		probed = r.Method + " " + r.URL.Path
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer up.Close()
+
+	ClearReleasesCache()
+	defer ClearReleasesCache()
+
+	api := createMockServer(releases, http.StatusOK)
+	defer api.Close()
+
+	url, err := GetDownloadURLWithConfig(context.Background(), "1.25.0", api.URL, time.Minute, down.URL+"/dl/%s", up.URL+"/golang/")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := up.URL + "/golang/" + filename
+	if url != expected {
+		t.Errorf("Expected fallback URL %q, got %q", expected, url)
+	}
+	if probed != "HEAD /golang/"+filename {
+		t.Errorf("Expected HEAD probe of archive on fallback mirror, got %q", probed)
+	}
+
+	_, err = GetDownloadURLWithConfig(context.Background(), "1.25.0", api.URL, time.Minute, down.URL+"/dl/%s", down.URL+"/other/")
+	if err == nil {
+		t.Fatal("Expected error when every mirror fails")
+	}
+	if !strings.Contains(err.Error(), "no reachable download mirror") {
+		t.Errorf("Expected mirror failure error, got: %v", err)
+	}
+}
+
+func TestGetArchiveURLsWithConfig(t *testing.T) {
+	filename := fmt.Sprintf("go1.25.0.%s-%s.tar.gz", runtime.GOOS, runtime.GOARCH)
+	releases := []Release{
+		{
+			Version: "go1.25.0",
+			Stable:  true,
+			Files: []File{
+				{Filename: filename, OS: runtime.GOOS, Arch: runtime.GOARCH, Kind: "archive"},
+			},
+		},
+	}
+
+	ClearReleasesCache()
+	defer ClearReleasesCache()
+
+	api := createMockServer(releases, http.StatusOK)
+	defer api.Close()
+
+	urls, err := GetArchiveURLsWithConfig(context.Background(), "1.25.0", api.URL, time.Minute, "https://go.dev/dl/%s", "https://mirror.example/golang/", "", "https://go.dev/dl/")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expected := []string{"https://go.dev/dl/" + filename, "https://mirror.example/golang/" + filename}
+	if !reflect.DeepEqual(urls, expected) {
+		t.Errorf("Expected %v, got %v", expected, urls)
+	}
+
+	if _, err := GetArchiveURLsWithConfig(context.Background(), "1.99.0", api.URL, time.Minute, "https://go.dev/dl/"); !errors.Is(err, ErrVersionNotFound) {
+		t.Errorf("Expected ErrVersionNotFound, got: %v", err)
+	}
+}
+
+func TestBuildDownloadURL(t *testing.T) {
+	testCases := []struct {
+		base     string
+		expected string
+	}{
+		{base: "https://go.dev/dl/%s", expected: "https://go.dev/dl/go1.25.0.linux-amd64.tar.gz"},
+		{base: "https://mirrors.aliyun.com/golang/", expected: "https://mirrors.aliyun.com/golang/go1.25.0.linux-amd64.tar.gz"},
+		{base: "https://golang.google.cn/dl", expected: "https://golang.google.cn/dl/go1.25.0.linux-amd64.tar.gz"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.base, func(t *testing.T) {
+			if got := buildDownloadURL(tc.base, "go1.25.0.linux-amd64.tar.gz"); got != tc.expected {
+				t.Errorf("Expected %q, got %q", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestGetAllAvailableVersionsWithConfig_Pagination(t *testing.T) {
+	ClearReleasesCache()
+	defer ClearReleasesCache()
+
+	pages := map[string][]Release{
+		"1": {{Version: "go1.25.1", Stable: true}, {Version: "go1.26rc1", Stable: false}},
+		"2": {{Version: "go1.24.0", Stable: true}},
+		"3": {{Version: "go1.23.0", Stable: true}, {Version: "go1.24.0", Stable: true}},
+	}
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		page := r.URL.Query().Get("page")
+		if page == "" {
+			page = "1"
+		}
+		switch page {
+		case "1":
+			w.Header().Add("Link", `</releases?page=2>; rel="next", </releases?page=3>; rel="last"`)
+		case "2":
+			w.Header().Add("Link", `<`+"http://"+r.Host+`/releases?page=3>; rel="next"`)
+		}
+		_ = json.NewEncoder(w).Encode(pages[page])
+	}))
+	defer server.Close()
+
+	first, err := GetAvailableVersionsWithConfig(context.Background(), true, server.URL+"/releases", time.Minute)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if want := []string{"1.26rc1", "1.25.1"}; !reflect.DeepEqual(first, want) {
+		t.Errorf("first page = %v, want %v", first, want)
+	}
+
+	all, err := GetAllAvailableVersionsWithConfig(context.Background(), false, server.URL+"/releases", time.Minute)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if want := []string{"1.25.1", "1.24.0", "1.23.0"}; !reflect.DeepEqual(all, want) {
+		t.Errorf("all pages = %v, want %v", all, want)
+	}
+	if requests != 4 {
+		t.Errorf("Expected 4 requests, got %d", requests)
+	}
+
+	if _, err := GetAllAvailableVersionsWithConfig(context.Background(), true, server.URL+"/releases", time.Minute); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if requests != 4 {
+		t.Errorf("Expected the full history to be cached, got %d requests", requests)
+	}
+}
+
+func TestFetchReleasePages_StopsOnLoop(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Link", `<`+r.URL.Path+`>; rel="next"`)
+		_ = json.NewEncoder(w).Encode([]Release{{Version: "go1.25.0", Stable: true}})
+	}))
+	defer server.Close()
+
+	releases, err := fetchReleasePages(context.Background(), server.URL+"/releases", true)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if requests != 1 || len(releases) != 1 {
+		t.Errorf("Expected a single page for a self-referencing link, got %d requests and %d releases", requests, len(releases))
+	}
+}
+
+func TestFetchReleasesWithConfig_RateLimit(t *testing.T) {
+	reset := time.Now().Add(15 * time.Minute).Truncate(time.Second)
+
+	testCases := []struct {
+		name          string
+		status        int
+		headers       map[string]string
+		wantRateLimit bool
+		wantReset     time.Time
+	}{
+		{
+			name:          "403 with exhausted limit",
+			status:        http.StatusForbidden,
+			headers:       map[string]string{"X-RateLimit-Remaining": "0", "X-RateLimit-Reset": strconv.FormatInt(reset.Unix(), 10)},
+			wantRateLimit: true,
+			wantReset:     reset,
+		},
+		{
+			name:          "429 without reset time",
+			status:        http.StatusTooManyRequests,
+			headers:       map[string]string{"X-RateLimit-Remaining": "0"},
+			wantRateLimit: true,
+		},
+		{
+			name:    "403 with remaining quota",
+			status:  http.StatusForbidden,
+			headers: map[string]string{"X-RateLimit-Remaining": "10"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ClearReleasesCache()
+			defer ClearReleasesCache()
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				for key, value := range tc.headers {
+					w.Header().Set(key, value)
+				}
+				w.WriteHeader(tc.status)
+			}))
+			defer server.Close()
+
+			_, err := fetchReleasesWithConfig(context.Background(), server.URL, time.Minute)
+			if err == nil {
+				t.Fatal("Expected an error")
+			}
+			if !errors.Is(err, ErrNetwork) {
+				t.Errorf("errors.Is(ErrNetwork) = false for %v", err)
+			}
+
+			var rateErr *RateLimitError
+			if got := errors.As(err, &rateErr); got != tc.wantRateLimit {
+				t.Fatalf("errors.As(RateLimitError) = %v, want %v (err: %v)", got, tc.wantRateLimit, err)
+			}
+			if !tc.wantRateLimit {
+				return
+			}
+			if !rateErr.Reset.Equal(tc.wantReset) {
+				t.Errorf("Reset = %v, want %v", rateErr.Reset, tc.wantReset)
+			}
+			if !strings.Contains(err.Error(), "rate limit exceeded") {
+				t.Errorf("Error %q should mention the rate limit", err)
+			}
+			if !tc.wantReset.IsZero() && !strings.Contains(err.Error(), "resets at") {
+				t.Errorf("Error %q should include the reset time", err)
+			}
+		})
+	}
+}
+
+func TestFetchReleasesWithConfig_AuthToken(t *testing.T) {
+	ClearReleasesCache()
+	defer ClearReleasesCache()
+	SetAuthToken("secret-token")
+	defer SetAuthToken("")
+
+	var otherAuth string
+	other := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		otherAuth = r.Header.Get("Authorization")
+		_ = json.NewEncoder(w).Encode([]Release{{Version: "go1.24.0", Stable: true}})
+	}))
+	defer other.Close()
+
+	var apiAuth string
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		apiAuth = r.Header.Get("Authorization")
+		w.Header().Set("Link", `<`+other.URL+`/page2>; rel="next"`)
+		_ = json.NewEncoder(w).Encode([]Release{{Version: "go1.25.0", Stable: true}})
+	}))
+	defer api.Close()
+
+	if _, err := fetchAllReleasesWithConfig(context.Background(), api.URL, time.Minute); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if apiAuth != "Bearer secret-token" {
+		t.Errorf("API request Authorization = %q, want bearer token", apiAuth)
+	}
+	if otherAuth != "" {
+		t.Errorf("Token must not be sent to a different host, got %q", otherAuth)
+	}
+}
+
+func TestRateLimitError_Error(t *testing.T) {
+	unauthenticated := (&RateLimitError{StatusCode: http.StatusForbidden}).Error()
+	if !strings.Contains(unauthenticated, "GITHUB_TOKEN") {
+		t.Errorf("Unauthenticated error %q should suggest setting a token", unauthenticated)
+	}
+
+	authenticated := (&RateLimitError{StatusCode: http.StatusForbidden, Authenticated: true}).Error()
+	if strings.Contains(authenticated, "GITHUB_TOKEN") {
+		t.Errorf("Authenticated error %q should not suggest setting a token", authenticated)
+	}
+}
+
+func TestGetRemoteInfoWithConfig(t *testing.T) {
+	filename := fmt.Sprintf("go1.25.0.%s-%s.tar.gz", runtime.GOOS, runtime.GOARCH)
+	published := time.Date(2025, 8, 12, 17, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name              string
+		archiveHandler    http.HandlerFunc
+		wantContentLength int64
+		wantLastModified  time.Time
+	}{
+		{
+			name: "HEAD reports size and date",
+			archiveHandler: func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Length", "1234")
+				w.Header().Set("Last-Modified", published.Format(http.TimeFormat))
+			},
+			wantContentLength: 1234,
+			wantLastModified:  published,
+		},
+		{
+			name:              "HEAD without Content-Length",
+			archiveHandler:    func(w http.ResponseWriter, r *http.Request) {},
+			wantContentLength: -1,
+		},
+		{
+			name: "HEAD fails",
+			archiveHandler: func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusNotFound)
+			},
+			wantContentLength: -1,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ClearReleasesCache()
+			defer ClearReleasesCache()
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path == "/"+filename {
+					if r.Method != http.MethodHead {
+						t.Errorf("Expected only HEAD requests for the archive, got %s", r.Method)
+					}
+					tc.archiveHandler(w, r)
+					return
+				}
+				_ = json.NewEncoder(w).Encode([]Release{{
+					Version: "go1.25.0",
+					Stable:  true,
+					Files: []File{{
+						Filename: filename, OS: runtime.GOOS, Arch: runtime.GOARCH, Kind: "archive",
+						Sha256: "abc123", Size: 999,
+					}},
+				}})
+			}))
+			defer server.Close()
+
+			info, err := GetRemoteInfoWithConfig(context.Background(), "1.25.0", server.URL+"/api", time.Minute, server.URL+"/%s")
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if info.URL != server.URL+"/"+filename {
+				t.Errorf("URL = %q, want %q", info.URL, server.URL+"/"+filename)
+			}
+			if info.Sha256 != "abc123" || info.Size != 999 || !info.Stable {
+				t.Errorf("Expected API metadata (abc123, 999, stable), got (%q, %d, %v)", info.Sha256, info.Size, info.Stable)
+			}
+			if info.ContentLength != tc.wantContentLength {
+				t.Errorf("ContentLength = %d, want %d", info.ContentLength, tc.wantContentLength)
+			}
+			if !info.LastModified.Equal(tc.wantLastModified) {
+				t.Errorf("LastModified = %v, want %v", info.LastModified, tc.wantLastModified)
+			}
+		})
+	}
+
+	t.Run("unknown version", func(t *testing.T) {
+		ClearReleasesCache()
+		defer ClearReleasesCache()
+
+		server := createMockServer([]Release{{Version: "go1.25.0", Stable: true}}, http.StatusOK)
+		defer server.Close()
+
+		if _, err := GetRemoteInfoWithConfig(context.Background(), "1.99.0", server.URL, time.Minute, server.URL+"/%s"); err == nil {
+			t.Error("Expected an error for a version without an archive")
+		}
+	})
+}
@@ -0,0 +1,359 @@
+package golang
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrOffline is wrapped by errors from operations that need the network while offline mode is on.
+var ErrOffline = errors.New("offline mode is on")
+
+// Release source names accepted by NewReleaseSource and go_releases.source.
+const (
+	APISourceName           = "api"
+	ChecksumIndexSourceName = "checksums"
+)
+
+// ReleaseSource supplies the Go releases govman can install and where to download them.
+// The default is the release API (go.dev or a GitHub-style releases endpoint); other implementations let govman
+// work against servers that publish releases in a different format.
+type ReleaseSource interface {
+	// AvailableVersions returns the released versions newest first, including prereleases only with includeUnstable.
+	AvailableVersions(ctx context.Context, includeUnstable bool) ([]string, error)
+	// DownloadURL returns the archive URL of version for this platform.
+	DownloadURL(ctx context.Context, version string) (string, error)
+	// FileInfo returns the archive metadata of version for this platform, including its SHA-256 checksum.
+	// Size is 0 when the source does not know it.
+	FileInfo(ctx context.Context, version string) (*File, error)
+}
+
+// PagedSource is implemented by sources whose AvailableVersions returns only the first page of a longer history.
+type PagedSource interface {
+	// AllAvailableVersions returns the complete release history, newest first.
+	AllAvailableVersions(ctx context.Context, includeUnstable bool) ([]string, error)
+}
+
+// MirroredSource is implemented by sources that can download an archive from several mirrors.
+type MirroredSource interface {
+	// ArchiveURLs returns every archive URL of version for this platform, in the order they should be tried.
+	ArchiveURLs(ctx context.Context, version string) ([]string, error)
+}
+
+// NewReleaseSource returns the source named by name: APISourceName reads releases JSON from apiURL, and
+// ChecksumIndexSourceName reads the checksum index at indexURL. downloadURLs are the archive download bases tried
+// in order by the API source. Returns an error for an unknown name or a missing index URL.
+func NewReleaseSource(name, apiURL, indexURL string, cacheDuration time.Duration, downloadURLs []string) (ReleaseSource, error) {
+	switch name {
+	case "", APISourceName:
+		return &APISource{APIURL: apiURL, CacheDuration: cacheDuration, DownloadURLs: downloadURLs}, nil
+	case ChecksumIndexSourceName:
+		if indexURL == "" {
+			return nil, fmt.Errorf("release source %q needs an index URL", name)
+		}
+		return &ChecksumIndexSource{IndexURL: indexURL, CacheDuration: cacheDuration}, nil
+	default:
+		return nil, fmt.Errorf("unknown release source %q: expected %q or %q", name, APISourceName, ChecksumIndexSourceName)
+	}
+}
+
+// APISource reads releases from a JSON release API such as https://go.dev/dl/?mode=json&include=all.
+type APISource struct {
+	APIURL        string
+	CacheDuration time.Duration
+	DownloadURLs  []string // archive download bases, tried in order
+}
+
+// AvailableVersions implements ReleaseSource.
+func (s *APISource) AvailableVersions(ctx context.Context, includeUnstable bool) ([]string, error) {
+	return GetAvailableVersionsWithConfig(ctx, includeUnstable, s.APIURL, s.CacheDuration)
+}
+
+// AllAvailableVersions implements PagedSource by following the API's pagination.
+func (s *APISource) AllAvailableVersions(ctx context.Context, includeUnstable bool) ([]string, error) {
+	return GetAllAvailableVersionsWithConfig(ctx, includeUnstable, s.APIURL, s.CacheDuration)
+}
+
+// DownloadURL implements ReleaseSource, probing the download bases in order when there are several.
+func (s *APISource) DownloadURL(ctx context.Context, version string) (string, error) {
+	if len(s.DownloadURLs) == 0 {
+		return "", fmt.Errorf("no download URL configured")
+	}
+	return GetDownloadURLWithConfig(ctx, version, s.APIURL, s.CacheDuration, s.DownloadURLs[0], s.DownloadURLs[1:]...)
+}
+
+// ArchiveURLs implements MirroredSource with the archive URL under each download base.
+func (s *APISource) ArchiveURLs(ctx context.Context, version string) ([]string, error) {
+	if len(s.DownloadURLs) == 0 {
+		return nil, fmt.Errorf("no download URL configured")
+	}
+	return GetArchiveURLsWithConfig(ctx, version, s.APIURL, s.CacheDuration, s.DownloadURLs[0], s.DownloadURLs[1:]...)
+}
+
+// FileInfo implements ReleaseSource.
+func (s *APISource) FileInfo(ctx context.Context, version string) (*File, error) {
+	return GetFileInfoWithConfig(ctx, version, s.APIURL, s.CacheDuration)
+}
+
+// CacheSource serves releases from the download cache without touching the network, for offline mode.
+// It knows the archives whose checksum RecordArchiveChecksum noted after they were downloaded and verified.
+type CacheSource struct {
+	CacheDir string
+}
+
+// AvailableVersions implements ReleaseSource. The release list is never cached, so it always fails with ErrOffline.
+func (s *CacheSource) AvailableVersions(ctx context.Context, includeUnstable bool) ([]string, error) {
+	return nil, fmt.Errorf("%w: listing releases needs the network", ErrOffline)
+}
+
+// DownloadURL implements ReleaseSource with a file URL of the cached archive.
+func (s *CacheSource) DownloadURL(ctx context.Context, version string) (string, error) {
+	file, err := s.FileInfo(ctx, version)
+	if err != nil {
+		return "", err
+	}
+	return (&url.URL{Scheme: "file", Path: filepath.ToSlash(filepath.Join(s.CacheDir, file.Filename))}).String(), nil
+}
+
+// FileInfo implements ReleaseSource with the checksum recorded for the cached archive. Size is 0.
+// Returns an error wrapping ErrOffline if the archive is not cached or was never verified.
+func (s *CacheSource) FileInfo(ctx context.Context, version string) (*File, error) {
+	filename := ArchiveName(version)
+	sha256 := loadArchiveChecksums(s.CacheDir)[filename]
+	if _, err := os.Stat(filepath.Join(s.CacheDir, filename)); err != nil || sha256 == "" {
+		return nil, fmt.Errorf("%w: Go %s has no verified archive in the download cache", ErrOffline, version)
+	}
+
+	return &File{
+		Filename: filename,
+		OS:       runtime.GOOS,
+		Arch:     resolveArch(version, runtime.GOOS, runtime.GOARCH),
+		Version:  "go" + version,
+		Sha256:   sha256,
+		Kind:     "archive",
+	}, nil
+}
+
+// archiveChecksumsFile is the name of the file in the cache directory that maps verified archives to their SHA-256.
+const archiveChecksumsFile = "archive-checksums.json"
+
+// archiveChecksumsMutex serializes updates to the checksum file by concurrent installs.
+var archiveChecksumsMutex sync.Mutex
+
+// RecordArchiveChecksum notes that filename in cacheDir matched sha256, so CacheSource can install it offline.
+// Returns an error if the checksum file cannot be written.
+func RecordArchiveChecksum(cacheDir, filename, sha256 string) error {
+	archiveChecksumsMutex.Lock()
+	defer archiveChecksumsMutex.Unlock()
+
+	checksums := loadArchiveChecksums(cacheDir)
+	if checksums[filename] == sha256 {
+		return nil
+	}
+	checksums[filename] = sha256
+
+	data, err := json.MarshalIndent(checksums, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode archive checksums: %w", err)
+	}
+
+	// Write to a temporary file and rename so readers never see a partial file
+	path := filepath.Join(cacheDir, archiveChecksumsFile)
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return fmt.Errorf("failed to write archive checksums: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("failed to write archive checksums: %w", err)
+	}
+	return nil
+}
+
+// RecordedArchiveChecksum returns the SHA-256 RecordArchiveChecksum noted for filename in cacheDir, or "" if none.
+func RecordedArchiveChecksum(cacheDir, filename string) string {
+	return loadArchiveChecksums(cacheDir)[filename]
+}
+
+// loadArchiveChecksums reads the recorded archive checksums in cacheDir. Returns an empty map if there are none.
+func loadArchiveChecksums(cacheDir string) map[string]string {
+	checksums := make(map[string]string)
+	if data, err := os.ReadFile(filepath.Join(cacheDir, archiveChecksumsFile)); err == nil {
+		json.Unmarshal(data, &checksums)
+	}
+	return checksums
+}
+
+// ArchiveName returns the file name of the official archive of version for this platform,
+// e.g. go1.22.5.linux-amd64.tar.gz.
+func ArchiveName(version string) string {
+	ext := ".tar.gz"
+	if runtime.GOOS == "windows" {
+		ext = ".zip"
+	}
+	return fmt.Sprintf("go%s.%s-%s%s", version, runtime.GOOS, resolveArch(version, runtime.GOOS, runtime.GOARCH), ext)
+}
+
+// ParseArchiveName splits an official archive name such as go1.22.5.linux-amd64.tar.gz into its version, OS, and
+// architecture. ok is false for any other name.
+func ParseArchiveName(filename string) (version, goos, goarch string, ok bool) {
+	matches := archiveNameRegex.FindStringSubmatch(filename)
+	if matches == nil {
+		return "", "", "", false
+	}
+	return matches[1], matches[2], matches[3], true
+}
+
+// archiveNameRegex matches official archive names such as go1.22.5.linux-amd64.tar.gz, capturing version, OS, and arch.
+var archiveNameRegex = regexp.MustCompile(`^go(\d+\.\d+(?:\.\d+)?(?:(?:rc|beta|alpha)\d+)?)\.([a-z0-9]+)-([a-z0-9]+)\.(?:tar\.gz|zip)$`)
+
+// ChecksumIndexSource reads releases from a checksum index in sha256sum format: one "<sha256>  <filename>" line per
+// archive, as written by 'sha256sum go*.tar.gz go*.zip > SHA256SUMS'. Archives are downloaded from the directory
+// holding the index, which suits artifact servers that host the official files without the release API.
+type ChecksumIndexSource struct {
+	IndexURL      string
+	CacheDuration time.Duration
+}
+
+var (
+	// indexCache holds the parsed checksum index, guarded by cacheMutex like the release API cache.
+	indexCache       []Release
+	indexCacheURL    string
+	indexCacheExpiry time.Time
+)
+
+// AvailableVersions implements ReleaseSource. Versions with an rc, beta, or alpha suffix count as unstable.
+func (s *ChecksumIndexSource) AvailableVersions(ctx context.Context, includeUnstable bool) ([]string, error) {
+	releases, err := s.fetch(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return versionsFromReleases(releases, includeUnstable), nil
+}
+
+// DownloadURL implements ReleaseSource, resolving the archive name against the index URL.
+func (s *ChecksumIndexSource) DownloadURL(ctx context.Context, version string) (string, error) {
+	releases, err := s.fetch(ctx)
+	if err != nil {
+		return "", err
+	}
+	file, err := findArchive(releases, version, ErrNoDownload)
+	if err != nil {
+		return "", err
+	}
+
+	base, err := url.Parse(s.IndexURL)
+	if err != nil {
+		return "", fmt.Errorf("invalid index URL: %w", err)
+	}
+	return base.ResolveReference(&url.URL{Path: file.Filename}).String(), nil
+}
+
+// FileInfo implements ReleaseSource. The index has no sizes, so Size is 0.
+func (s *ChecksumIndexSource) FileInfo(ctx context.Context, version string) (*File, error) {
+	releases, err := s.fetch(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return findArchive(releases, version, ErrNoFileInfo)
+}
+
+// fetch returns the parsed index, downloading it again once the cached copy expires or the index URL changes.
+func (s *ChecksumIndexSource) fetch(ctx context.Context) ([]Release, error) {
+	cacheMutex.RLock()
+	if indexCache != nil && indexCacheURL == s.IndexURL && time.Now().Before(indexCacheExpiry) {
+		result := indexCache
+		cacheMutex.RUnlock()
+		return result, nil
+	}
+	cacheMutex.RUnlock()
+
+	cacheMutex.Lock()
+	defer cacheMutex.Unlock()
+	if indexCache != nil && indexCacheURL == s.IndexURL && time.Now().Before(indexCacheExpiry) {
+		return indexCache, nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.IndexURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch checksum index: %w", err)
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, &networkError{fmt.Errorf("failed to fetch checksum index: %w", err)}
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, &networkError{fmt.Errorf("failed to fetch checksum index: HTTP %d (%s)", resp.StatusCode, resp.Status)}
+	}
+
+	releases, err := parseChecksumIndex(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	indexCache = releases
+	indexCacheURL = s.IndexURL
+	indexCacheExpiry = time.Now().Add(s.CacheDuration)
+	return releases, nil
+}
+
+// parseChecksumIndex groups the archives listed in a sha256sum-format index into releases.
+// Lines naming anything other than an official archive (installers, source tarballs) are skipped.
+// Returns an error if the index cannot be read or lists no archives.
+func parseChecksumIndex(r io.Reader) ([]Release, error) {
+	var releases []Release
+	index := make(map[string]int)
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) != 2 || len(fields[0]) != 64 {
+			continue
+		}
+		// sha256sum marks files hashed in binary mode with a leading '*'
+		filename := strings.TrimPrefix(fields[1], "*")
+		matches := archiveNameRegex.FindStringSubmatch(filename)
+		if matches == nil {
+			continue
+		}
+
+		version := "go" + matches[1]
+		i, ok := index[version]
+		if !ok {
+			i = len(releases)
+			index[version] = i
+			releases = append(releases, Release{Version: version, Stable: parseVersion(matches[1]).prerelease == ""})
+		}
+		releases[i].Files = append(releases[i].Files, File{
+			Filename: filename,
+			OS:       matches[2],
+			Arch:     matches[3],
+			Version:  version,
+			Sha256:   strings.ToLower(fields[0]),
+			Kind:     "archive",
+		})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read checksum index: %w", err)
+	}
+	if len(releases) == 0 {
+		return nil, fmt.Errorf("checksum index lists no Go archives")
+	}
+
+	return releases, nil
+}
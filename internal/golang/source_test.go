@@ -0,0 +1,312 @@
+package golang
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// checksumIndex returns a sha256sum-format index listing archives of versions for this platform,
+// plus entries the parser must skip.
+func checksumIndex(versions ...string) string {
+	var b strings.Builder
+	for i, v := range versions {
+		fmt.Fprintf(&b, "%064x  go%s.%s-%s.tar.gz\n", i+1, v, runtime.GOOS, runtime.GOARCH)
+		fmt.Fprintf(&b, "%064x *go%s.plan9-386.zip\n", i+100, v)
+	}
+	b.WriteString(strings.Repeat("a", 64) + "  go1.22.5.src.tar.gz\n")
+	b.WriteString(strings.Repeat("b", 64) + "  go1.22.5.darwin-arm64.pkg\n")
+	b.WriteString("not a checksum line\n")
+	return b.String()
+}
+
+func TestParseChecksumIndex(t *testing.T) {
+	releases, err := parseChecksumIndex(strings.NewReader(checksumIndex("1.22.5", "1.23rc1")))
+	if err != nil {
+		t.Fatalf("parseChecksumIndex() error = %v", err)
+	}
+	if len(releases) != 2 {
+		t.Fatalf("parseChecksumIndex() = %d releases, want 2: %+v", len(releases), releases)
+	}
+
+	stable := releases[0]
+	if stable.Version != "go1.22.5" || !stable.Stable || len(stable.Files) != 2 {
+		t.Errorf("first release = %+v, want stable go1.22.5 with 2 archives", stable)
+	}
+	if got := stable.Files[1]; got.Filename != "go1.22.5.plan9-386.zip" || got.OS != "plan9" || got.Arch != "386" {
+		t.Errorf("binary-mode entry = %+v, want go1.22.5.plan9-386.zip without the '*'", got)
+	}
+	if releases[1].Version != "go1.23rc1" || releases[1].Stable {
+		t.Errorf("second release = %+v, want unstable go1.23rc1", releases[1])
+	}
+
+	if _, err := parseChecksumIndex(strings.NewReader("not a checksum line\n")); err == nil {
+		t.Error("parseChecksumIndex() of an index without archives should fail")
+	}
+}
+
+func TestChecksumIndexSource(t *testing.T) {
+	ClearReleasesCache()
+	t.Cleanup(ClearReleasesCache)
+
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/golang/SHA256SUMS" {
+			http.NotFound(w, r)
+			return
+		}
+		requests.Add(1)
+		fmt.Fprint(w, checksumIndex("1.21.13", "1.22.5", "1.23rc1"))
+	}))
+	defer server.Close()
+
+	source, err := NewReleaseSource(ChecksumIndexSourceName, "", server.URL+"/golang/SHA256SUMS", time.Hour, nil)
+	if err != nil {
+		t.Fatalf("NewReleaseSource() error = %v", err)
+	}
+	ctx := context.Background()
+
+	versions, err := source.AvailableVersions(ctx, false)
+	if err != nil || !reflect.DeepEqual(versions, []string{"1.22.5", "1.21.13"}) {
+		t.Errorf("AvailableVersions(false) = %v, %v; want [1.22.5 1.21.13]", versions, err)
+	}
+	versions, _ = source.AvailableVersions(ctx, true)
+	if !reflect.DeepEqual(versions, []string{"1.23rc1", "1.22.5", "1.21.13"}) {
+		t.Errorf("AvailableVersions(true) = %v, want [1.23rc1 1.22.5 1.21.13]", versions)
+	}
+
+	filename := fmt.Sprintf("go1.22.5.%s-%s.tar.gz", runtime.GOOS, runtime.GOARCH)
+	url, err := source.DownloadURL(ctx, "1.22.5")
+	if err != nil || url != server.URL+"/golang/"+filename {
+		t.Errorf("DownloadURL() = %q, %v; want the archive next to the index", url, err)
+	}
+
+	file, err := source.FileInfo(ctx, "1.22.5")
+	if err != nil {
+		t.Fatalf("FileInfo() error = %v", err)
+	}
+	if file.Filename != filename || file.Sha256 != fmt.Sprintf("%064x", 2) || file.Size != 0 {
+		t.Errorf("FileInfo() = %+v", file)
+	}
+
+	if _, err := source.DownloadURL(ctx, "1.99.0"); !errors.Is(err, ErrVersionNotFound) {
+		t.Errorf("DownloadURL() of an unknown version error = %v, want ErrVersionNotFound", err)
+	}
+
+	if got := requests.Load(); got != 1 {
+		t.Errorf("index fetched %d times, want 1 while cached", got)
+	}
+
+	missing, _ := NewReleaseSource(ChecksumIndexSourceName, "", server.URL+"/missing/SHA256SUMS", time.Hour, nil)
+	if _, err := missing.AvailableVersions(ctx, false); !errors.Is(err, ErrNetwork) {
+		t.Errorf("AvailableVersions() of a missing index error = %v, want ErrNetwork", err)
+	}
+}
+
+func TestNewReleaseSource(t *testing.T) {
+	source, err := NewReleaseSource("", "https://go.dev/dl/?mode=json", "", time.Hour, []string{"https://go.dev/dl/%s"})
+	if err != nil {
+		t.Fatalf("NewReleaseSource(\"\") error = %v", err)
+	}
+	if _, ok := source.(PagedSource); !ok {
+		t.Errorf("NewReleaseSource(\"\") = %T, want the paginating API source", source)
+	}
+
+	if _, err := NewReleaseSource(ChecksumIndexSourceName, "", "", time.Hour, nil); err == nil {
+		t.Error("NewReleaseSource(checksums) without an index URL should fail")
+	}
+	if _, err := NewReleaseSource("ftp", "", "", time.Hour, nil); err == nil {
+		t.Error("NewReleaseSource() of an unknown source should fail")
+	}
+}
+
+func TestCacheSource(t *testing.T) {
+	cacheDir := t.TempDir()
+	source := &CacheSource{CacheDir: cacheDir}
+	ctx := context.Background()
+
+	if _, err := source.AvailableVersions(ctx, false); !errors.Is(err, ErrOffline) {
+		t.Errorf("AvailableVersions() error = %v, want ErrOffline", err)
+	}
+
+	filename := ArchiveName("1.22.5")
+	archive := filepath.Join(cacheDir, filename)
+	os.WriteFile(archive, []byte("archive"), 0644)
+	if _, err := source.FileInfo(ctx, "1.22.5"); !errors.Is(err, ErrOffline) {
+		t.Errorf("FileInfo() of an archive without a recorded checksum error = %v, want ErrOffline", err)
+	}
+
+	sum := strings.Repeat("c", 64)
+	if err := RecordArchiveChecksum(cacheDir, filename, sum); err != nil {
+		t.Fatalf("RecordArchiveChecksum() error = %v", err)
+	}
+	RecordArchiveChecksum(cacheDir, "go1.21.0.plan9-386.tar.gz", strings.Repeat("d", 64))
+
+	file, err := source.FileInfo(ctx, "1.22.5")
+	if err != nil {
+		t.Fatalf("FileInfo() error = %v", err)
+	}
+	if file.Filename != filename || file.Sha256 != sum || file.Version != "go1.22.5" {
+		t.Errorf("FileInfo() = %+v", file)
+	}
+
+	url, err := source.DownloadURL(ctx, "1.22.5")
+	if err != nil || !strings.HasPrefix(url, "file://") || path.Base(url) != filename {
+		t.Errorf("DownloadURL() = %q, %v; want a file URL of the cached archive", url, err)
+	}
+
+	os.Remove(archive)
+	if _, err := source.DownloadURL(ctx, "1.22.5"); !errors.Is(err, ErrOffline) {
+		t.Errorf("DownloadURL() of a removed archive error = %v, want ErrOffline", err)
+	}
+}
+
+func TestParseArchiveName(t *testing.T) {
+	tests := []struct {
+		filename              string
+		version, goos, goarch string
+		ok                    bool
+	}{
+		{"go1.22.5.linux-amd64.tar.gz", "1.22.5", "linux", "amd64", true},
+		{"go1.23rc1.windows-arm64.zip", "1.23rc1", "windows", "arm64", true},
+		{"go1.22.5.src.tar.gz", "", "", "", false},
+		{"go.tar.gz", "", "", "", false},
+	}
+
+	for _, tt := range tests {
+		version, goos, goarch, ok := ParseArchiveName(tt.filename)
+		if version != tt.version || goos != tt.goos || goarch != tt.goarch || ok != tt.ok {
+			t.Errorf("ParseArchiveName(%q) = %q, %q, %q, %v", tt.filename, version, goos, goarch, ok)
+		}
+	}
+
+	if version, goos, goarch, ok := ParseArchiveName(ArchiveName("1.22.5")); !ok || version != "1.22.5" ||
+		goos != runtime.GOOS || goarch != resolveArch("1.22.5", runtime.GOOS, runtime.GOARCH) {
+		t.Errorf("ArchiveName() = %q does not parse back to this platform", ArchiveName("1.22.5"))
+	}
+}
+
+func TestEmbeddedVersions(t *testing.T) {
+	versions, generated := EmbeddedVersions()
+	if len(versions) == 0 || generated == "" {
+		t.Fatalf("EmbeddedVersions() = %v, %q; want a dated, non-empty list", versions, generated)
+	}
+	for i, v := range versions {
+		if parseVersion(v).prerelease != "" {
+			t.Errorf("built-in list holds prerelease %s", v)
+		}
+		if i > 0 && CompareVersions(versions[i-1], v) <= 0 {
+			t.Errorf("built-in list not newest first at %s, %s", versions[i-1], v)
+		}
+	}
+
+	// Callers may modify the returned slice without affecting later calls
+	versions[0] = "0.0.0"
+	if again, _ := EmbeddedVersions(); again[0] == "0.0.0" {
+		t.Error("EmbeddedVersions() returned the shared slice")
+	}
+}
+
+func TestEmbeddedSource(t *testing.T) {
+	versions, _ := EmbeddedVersions()
+	version := versions[0]
+	checksum := strings.Repeat("ab", 32)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case path.Base(r.URL.Path) != ArchiveName(version)+".sha256":
+			http.NotFound(w, r)
+		case strings.HasPrefix(r.URL.Path, "/mirror/"):
+			// A mirror's own checksum must not be trusted
+			fmt.Fprintln(w, strings.Repeat("cd", 32))
+		default:
+			fmt.Fprintln(w, strings.ToUpper(checksum))
+		}
+	}))
+	defer server.Close()
+
+	source := &EmbeddedSource{DownloadURLs: []string{server.URL + "/mirror/"}, ChecksumURL: server.URL + "/dl/"}
+
+	if got, err := source.DownloadURL(context.Background(), version); err != nil || got != server.URL+"/mirror/"+ArchiveName(version) {
+		t.Errorf("DownloadURL() = %q, %v", got, err)
+	}
+
+	file, err := source.FileInfo(context.Background(), version)
+	if err != nil {
+		t.Fatalf("FileInfo() error = %v", err)
+	}
+	if file.Sha256 != checksum || file.Filename != ArchiveName(version) || file.Version != "go"+version {
+		t.Errorf("FileInfo() = %+v", file)
+	}
+
+	if _, err := source.FileInfo(context.Background(), "0.1.0"); !errors.Is(err, ErrVersionNotFound) {
+		t.Errorf("FileInfo() of an unlisted version error = %v, want ErrVersionNotFound", err)
+	}
+
+	missing := &EmbeddedSource{ChecksumURL: server.URL + "/missing/%s.bin"}
+	if _, err := missing.FileInfo(context.Background(), version); !errors.Is(err, ErrNetwork) {
+		t.Errorf("FileInfo() without a checksum file error = %v, want ErrNetwork", err)
+	}
+}
+
+// stubSource is a ReleaseSource that fails every call with err
+type stubSource struct {
+	err error
+}
+
+func (s *stubSource) AvailableVersions(ctx context.Context, includeUnstable bool) ([]string, error) {
+	return nil, s.err
+}
+
+func (s *stubSource) DownloadURL(ctx context.Context, version string) (string, error) {
+	return "", s.err
+}
+
+func (s *stubSource) FileInfo(ctx context.Context, version string) (*File, error) {
+	return nil, s.err
+}
+
+func TestFallbackSource(t *testing.T) {
+	versions, _ := EmbeddedVersions()
+	fallback := &EmbeddedSource{DownloadURLs: []string{"https://example.invalid/dl/"}}
+
+	var notified int
+	unreachable := &FallbackSource{
+		Primary:    &stubSource{err: fmt.Errorf("failed to fetch releases: %w", ErrNetwork)},
+		Fallback:   fallback,
+		OnFallback: func(error) { notified++ },
+	}
+
+	if got, err := unreachable.DownloadURL(context.Background(), versions[0]); err != nil || !strings.HasPrefix(got, "https://example.invalid/dl/") {
+		t.Errorf("DownloadURL() with an unreachable primary = %q, %v; want the built-in list's URL", got, err)
+	}
+	if notified != 1 {
+		t.Errorf("OnFallback calls = %d, want 1", notified)
+	}
+	if _, err := unreachable.AvailableVersions(context.Background(), false); !errors.Is(err, ErrNetwork) {
+		t.Errorf("AvailableVersions() error = %v, want the primary's error", err)
+	}
+
+	notFound := &FallbackSource{
+		Primary:    &stubSource{err: fmt.Errorf("go version 1.99.0 is %w", ErrVersionNotFound)},
+		Fallback:   fallback,
+		OnFallback: func(error) { notified++ },
+	}
+	if _, err := notFound.DownloadURL(context.Background(), versions[0]); !errors.Is(err, ErrVersionNotFound) {
+		t.Errorf("DownloadURL() error = %v, want the primary's ErrVersionNotFound", err)
+	}
+	if notified != 1 {
+		t.Errorf("OnFallback calls = %d, want no fallback for a missing release", notified)
+	}
+}
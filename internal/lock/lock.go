@@ -0,0 +1,103 @@
+package lock
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrLocked is returned by Acquire when another running process holds the lock.
+var ErrLocked = errors.New("another govman operation is in progress")
+
+// orphanAge is how long a lock file without a readable process ID is trusted before it is treated as left over
+// from a process that died while creating it.
+const orphanAge = 10 * time.Second
+
+var processAlive = isProcessAlive
+
+// Lock is an exclusive, advisory lock held by this process through a lock file containing its process ID.
+// A lock file left by a process that no longer runs, for example after a crash or kill, is taken over.
+type Lock struct {
+	path string
+	once sync.Once
+	err  error
+}
+
+// Acquire creates the lock file at path, creating its directory if needed.
+// Returns the held Lock, an error wrapping ErrLocked naming the holder's process ID if a running process
+// holds it, or another error if the file cannot be created.
+func Acquire(path string) (*Lock, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, fmt.Errorf("failed to create lock directory: %w", err)
+	}
+
+	// One retry covers removing a stale lock; a second conflict means another process won the race
+	for attempt := 0; attempt < 2; attempt++ {
+		file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+		if err == nil {
+			_, err = file.WriteString(strconv.Itoa(os.Getpid()) + "\n")
+			if closeErr := file.Close(); err == nil {
+				err = closeErr
+			}
+			if err != nil {
+				os.Remove(path)
+				return nil, fmt.Errorf("failed to write lock file %s: %w", path, err)
+			}
+			return &Lock{path: path}, nil
+		}
+		if !os.IsExist(err) {
+			return nil, fmt.Errorf("failed to create lock file %s: %w", path, err)
+		}
+
+		pid, stale := inspect(path)
+		if !stale {
+			if pid > 0 {
+				return nil, fmt.Errorf("%w (process %d holds %s)", ErrLocked, pid, path)
+			}
+			return nil, fmt.Errorf("%w (%s exists)", ErrLocked, path)
+		}
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return nil, fmt.Errorf("failed to remove stale lock file %s: %w", path, err)
+		}
+	}
+
+	return nil, fmt.Errorf("%w (%s was taken by another process)", ErrLocked, path)
+}
+
+// Release removes the lock file. Calling it more than once is safe; later calls return the first result.
+func (l *Lock) Release() error {
+	l.once.Do(func() {
+		if err := os.Remove(l.path); err != nil && !os.IsNotExist(err) {
+			l.err = fmt.Errorf("failed to remove lock file %s: %w", l.path, err)
+		}
+	})
+	return l.err
+}
+
+// inspect reads the process ID from an existing lock file and reports whether the lock is stale:
+// its process is gone, or it has no valid process ID and is older than orphanAge.
+func inspect(path string) (pid int, stale bool) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		// Removed between our create attempt and now; let the caller retry
+		return 0, os.IsNotExist(err)
+	}
+
+	pid, err = strconv.Atoi(strings.TrimSpace(string(data)))
+	if err != nil || pid <= 0 {
+		info, statErr := os.Stat(path)
+		return 0, statErr == nil && time.Since(info.ModTime()) > orphanAge
+	}
+
+	return pid, pid != os.Getpid() && !processAlive(pid)
+}
+
+// ProcessAlive reports whether a process with the given ID is running.
+func ProcessAlive(pid int) bool {
+	return processAlive(pid)
+}
@@ -0,0 +1,129 @@
+package lock
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestAcquireRelease(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "home", ".lock")
+
+	l, err := Acquire(path)
+	if err != nil {
+		t.Fatalf("Acquire() error = %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("lock file not created: %v", err)
+	}
+	if got := strings.TrimSpace(string(data)); got != strconv.Itoa(os.Getpid()) {
+		t.Errorf("lock file holds %q, want this process ID %d", got, os.Getpid())
+	}
+
+	if err := l.Release(); err != nil {
+		t.Fatalf("Release() error = %v", err)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Error("Release() should remove the lock file")
+	}
+	if err := l.Release(); err != nil {
+		t.Errorf("second Release() error = %v", err)
+	}
+}
+
+func TestAcquireContention(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".lock")
+
+	original := processAlive
+	processAlive = func(int) bool { return true }
+	t.Cleanup(func() { processAlive = original })
+
+	// Another live process holds the lock
+	os.WriteFile(path, []byte("4242\n"), 0644)
+
+	_, err := Acquire(path)
+	if !errors.Is(err, ErrLocked) {
+		t.Fatalf("Acquire() error = %v, want ErrLocked", err)
+	}
+	if !strings.Contains(err.Error(), "4242") {
+		t.Errorf("error should name the holding process, got %q", err)
+	}
+
+	// The holder's lock file must be left alone
+	if data, _ := os.ReadFile(path); string(data) != "4242\n" {
+		t.Errorf("lock file was modified: %q", data)
+	}
+}
+
+func TestAcquireHeldBySameProcess(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".lock")
+
+	l, err := Acquire(path)
+	if err != nil {
+		t.Fatalf("Acquire() error = %v", err)
+	}
+	defer l.Release()
+
+	if _, err := Acquire(path); !errors.Is(err, ErrLocked) {
+		t.Errorf("second Acquire() error = %v, want ErrLocked", err)
+	}
+}
+
+func TestAcquireStale(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		age     time.Duration
+		alive   bool
+		wantErr bool
+	}{
+		{name: "Holder exited", content: "4242\n", alive: false},
+		{name: "Holder running", content: "4242\n", alive: true, wantErr: true},
+		{name: "Old file without a process ID", content: "", age: time.Minute},
+		{name: "Fresh file without a process ID", content: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), ".lock")
+
+			original := processAlive
+			processAlive = func(int) bool { return tt.alive }
+			t.Cleanup(func() { processAlive = original })
+
+			os.WriteFile(path, []byte(tt.content), 0644)
+			if tt.age > 0 {
+				old := time.Now().Add(-tt.age)
+				os.Chtimes(path, old, old)
+			}
+
+			l, err := Acquire(path)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Acquire() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				if !errors.Is(err, ErrLocked) {
+					t.Errorf("Acquire() error = %v, want ErrLocked", err)
+				}
+				return
+			}
+			defer l.Release()
+
+			if data, _ := os.ReadFile(path); strings.TrimSpace(string(data)) != strconv.Itoa(os.Getpid()) {
+				t.Errorf("stale lock was not taken over, file holds %q", data)
+			}
+		})
+	}
+}
+
+func TestIsProcessAlive(t *testing.T) {
+	if !isProcessAlive(os.Getpid()) {
+		t.Error("isProcessAlive() should report this process as running")
+	}
+}
@@ -0,0 +1,29 @@
+package lock
+
+import (
+	"errors"
+	"syscall"
+)
+
+// processQueryLimitedInformation is the PROCESS_QUERY_LIMITED_INFORMATION access right, enough to read an exit code.
+const processQueryLimitedInformation = 0x1000
+
+// stillActive is the exit code GetExitCodeProcess reports for a process that has not exited.
+const stillActive = 259
+
+// isProcessAlive reports whether a process with the given ID is running. An exited process can still be opened
+// while a handle to it is held, so its exit code tells whether it is running.
+func isProcessAlive(pid int) bool {
+	handle, err := syscall.OpenProcess(processQueryLimitedInformation, false, uint32(pid))
+	if err != nil {
+		// Access denied means the process exists but belongs to another user
+		return errors.Is(err, syscall.ERROR_ACCESS_DENIED)
+	}
+	defer syscall.CloseHandle(handle)
+
+	var code uint32
+	if err := syscall.GetExitCodeProcess(handle, &code); err != nil {
+		return true
+	}
+	return code == stillActive
+}
@@ -1,13 +1,17 @@
 package logger
 
 import (
+	"encoding/json"
 	"fmt"
 	"io"
 	"os"
+	"strings"
 	"sync"
 	"time"
 
 	viper "github.com/spf13/viper"
+
+	_theme "github.com/justjundana/govman/internal/theme"
 )
 
 type LogLevel int
@@ -18,8 +22,20 @@ const (
 	VerboseLevel
 )
 
+// LogFormat selects how log lines are rendered.
+type LogFormat int
+
+const (
+	TextFormat LogFormat = iota
+	JSONFormat
+)
+
+// Fields holds structured values attached to a log line; they are only rendered in JSON format.
+type Fields map[string]interface{}
+
 type Logger struct {
 	level         LogLevel
+	format        LogFormat
 	normalWriter  io.Writer
 	verboseWriter io.Writer
 	mutex         sync.Mutex
@@ -30,6 +46,49 @@ type Timer struct {
 	name  string
 }
 
+// Entry is a logger bound to a set of structured fields, created by WithFields.
+type Entry struct {
+	logger *Logger
+	fields Fields
+}
+
+// kind describes one category of log line: its minimum level, destination, JSON level name, text prefix,
+// and the theme style its prefix is colored with on a terminal.
+type kind struct {
+	minLevel LogLevel
+	verbose  bool
+	name     string
+	prefix   string
+	style    _theme.Style
+}
+
+var (
+	errorKind    = kind{QuietLevel, false, "error", "Error: ", _theme.Error}
+	warningKind  = kind{QuietLevel, false, "warning", "Warning: ", _theme.Warning}
+	infoKind     = kind{NormalLevel, false, "info", "", _theme.Plain}
+	successKind  = kind{NormalLevel, false, "success", "Success: ", _theme.Success}
+	progressKind = kind{NormalLevel, false, "progress", "Progress: ", _theme.Accent}
+	downloadKind = kind{NormalLevel, false, "download", "Download: ", _theme.Accent}
+	extractKind  = kind{NormalLevel, false, "extract", "Extract: ", _theme.Accent}
+	verifyKind   = kind{NormalLevel, false, "verify", "Verify: ", _theme.Accent}
+	verboseKind  = kind{VerboseLevel, true, "verbose", "[VERBOSE] ", _theme.Muted}
+	debugKind    = kind{VerboseLevel, true, "debug", "[DEBUG] ", _theme.Muted}
+	internalKind = kind{VerboseLevel, true, "internal", "[INTERNAL] ", _theme.Muted}
+)
+
+// ParseFormat converts a --log-format value ("text" or "json") into a LogFormat.
+// Returns an error for any other value.
+func ParseFormat(s string) (LogFormat, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "", "text":
+		return TextFormat, nil
+	case "json":
+		return JSONFormat, nil
+	default:
+		return TextFormat, fmt.Errorf("invalid log format %q: must be 'text' or 'json'", s)
+	}
+}
+
 // New constructs a Logger and sets its initial level based on viper flags (quiet/verbose).
 func New() *Logger {
 	l := &Logger{
@@ -45,6 +104,10 @@ func New() *Logger {
 		l.level = NormalLevel
 	}
 
+	if format, err := ParseFormat(viper.GetString("log-format")); err == nil {
+		l.format = format
+	}
+
 	return l
 }
 
@@ -55,6 +118,20 @@ func (l *Logger) SetLevel(level LogLevel) {
 	l.level = level
 }
 
+// SetFormat switches between human-readable text and one-JSON-object-per-line output.
+func (l *Logger) SetFormat(format LogFormat) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	l.format = format
+}
+
+// Format returns the current output format.
+func (l *Logger) Format() LogFormat {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	return l.format
+}
+
 // SetNormalWriter sets the destination writer for normal-level logs.
 func (l *Logger) SetNormalWriter(writer io.Writer) {
 	l.mutex.Lock()
@@ -94,9 +171,7 @@ func (l *Logger) VerboseWriter() io.Writer {
 func (l *Logger) Error(format string, args ...interface{}) {
 	l.mutex.Lock()
 	defer l.mutex.Unlock()
-	if l.level >= QuietLevel {
-		fmt.Fprintf(l.normalWriter, "Error: "+format+"\n", args...)
-	}
+	l.logf(errorKind, nil, format, args...)
 }
 
 // ErrorWithHelp logs an error message and an optional help hint.
@@ -104,7 +179,15 @@ func (l *Logger) ErrorWithHelp(errorMsg, helpMsg string, args ...interface{}) {
 	l.mutex.Lock()
 	defer l.mutex.Unlock()
 	if l.level >= QuietLevel {
-		fmt.Fprintf(l.normalWriter, "Error: "+errorMsg+"\n", args...)
+		if l.format == JSONFormat {
+			var fields Fields
+			if helpMsg != "" {
+				fields = Fields{"help": helpMsg}
+			}
+			l.logf(errorKind, fields, errorMsg, args...)
+			return
+		}
+		fmt.Fprintf(l.normalWriter, _theme.Paint(l.normalWriter, _theme.Error, "Error: ")+errorMsg+"\n", args...)
 		if helpMsg != "" {
 			fmt.Fprintf(l.normalWriter, "Help: %s\n", helpMsg)
 		}
@@ -115,9 +198,7 @@ func (l *Logger) ErrorWithHelp(errorMsg, helpMsg string, args ...interface{}) {
 func (l *Logger) StartTimer(name string) *Timer {
 	l.mutex.Lock()
 	defer l.mutex.Unlock()
-	if l.level >= VerboseLevel {
-		fmt.Fprintf(l.verboseWriter, "[VERBOSE] Starting %s...\n", name)
-	}
+	l.logf(verboseKind, Fields{"timer": name}, "Starting %s...", name)
 	return &Timer{
 		start: time.Now(),
 		name:  name,
@@ -125,103 +206,176 @@ func (l *Logger) StartTimer(name string) *Timer {
 }
 
 // StopTimer stops a timer and logs the elapsed duration in verbose mode.
+// In JSON format the duration is also emitted as a duration_ms field.
 func (l *Logger) StopTimer(t *Timer) {
 	l.mutex.Lock()
 	defer l.mutex.Unlock()
-	if l.level >= VerboseLevel && t != nil {
-		duration := time.Since(t.start)
-		fmt.Fprintf(l.verboseWriter, "[VERBOSE] Completed %s in %v\n", t.name, duration)
+	if t == nil {
+		return
+	}
+	duration := time.Since(t.start)
+	fields := Fields{
+		"timer":       t.name,
+		"duration_ms": float64(duration.Microseconds()) / 1000,
 	}
+	l.logf(verboseKind, fields, "Completed %s in %v", t.name, duration)
+}
+
+// WithFields returns an Entry that attaches fields to every line it logs.
+func (l *Logger) WithFields(fields Fields) *Entry {
+	return &Entry{logger: l, fields: fields}
+}
+
+// logf formats a message and emits it if the current level allows kind. Callers must hold the mutex.
+func (l *Logger) logf(k kind, fields Fields, format string, args ...interface{}) {
+	if l.level < k.minLevel {
+		return
+	}
+	line := fmt.Sprintf(format+"\n", args...)
+	l.emit(k, fields, strings.TrimSuffix(line, "\n"))
+}
+
+// emit writes a single log line in the configured format. Callers must hold the mutex and check the level.
+func (l *Logger) emit(k kind, fields Fields, msg string) {
+	w := l.normalWriter
+	if k.verbose {
+		w = l.verboseWriter
+	}
+
+	if l.format != JSONFormat {
+		fmt.Fprintln(w, _theme.Paint(w, k.style, k.prefix)+msg)
+		return
+	}
+
+	record := make(map[string]interface{}, len(fields)+3)
+	for key, value := range fields {
+		record[key] = value
+	}
+	record["time"] = time.Now().UTC().Format(time.RFC3339Nano)
+	record["level"] = k.name
+	record["message"] = msg
+
+	data, err := json.Marshal(record)
+	if err != nil {
+		data, _ = json.Marshal(map[string]interface{}{
+			"time":    record["time"],
+			"level":   k.name,
+			"message": msg,
+		})
+	}
+	w.Write(append(data, '\n'))
+}
+
+// Info logs an informational message with the entry's fields at normal level.
+func (e *Entry) Info(format string, args ...interface{}) {
+	e.log(infoKind, format, args...)
+}
+
+// Success logs a success message with the entry's fields at normal level.
+func (e *Entry) Success(format string, args ...interface{}) {
+	e.log(successKind, format, args...)
+}
+
+// Warning logs a warning message with the entry's fields; shown even in quiet mode.
+func (e *Entry) Warning(format string, args ...interface{}) {
+	e.log(warningKind, format, args...)
+}
+
+// Download logs a download-related message with the entry's fields at normal level.
+func (e *Entry) Download(format string, args ...interface{}) {
+	e.log(downloadKind, format, args...)
+}
+
+// Extract logs an extraction-related message with the entry's fields at normal level.
+func (e *Entry) Extract(format string, args ...interface{}) {
+	e.log(extractKind, format, args...)
+}
+
+// Verify logs a verification-related message with the entry's fields at normal level.
+func (e *Entry) Verify(format string, args ...interface{}) {
+	e.log(verifyKind, format, args...)
+}
+
+// Verbose logs a detailed message with the entry's fields at verbose level.
+func (e *Entry) Verbose(format string, args ...interface{}) {
+	e.log(verboseKind, format, args...)
+}
+
+// log emits a message of the given kind through the entry's logger.
+func (e *Entry) log(k kind, format string, args ...interface{}) {
+	e.logger.mutex.Lock()
+	defer e.logger.mutex.Unlock()
+	e.logger.logf(k, e.fields, format, args...)
 }
 
 // Info logs an informational message at normal level.
 func (l *Logger) Info(format string, args ...interface{}) {
 	l.mutex.Lock()
 	defer l.mutex.Unlock()
-	if l.level >= NormalLevel {
-		fmt.Fprintf(l.normalWriter, format+"\n", args...)
-	}
+	l.logf(infoKind, nil, format, args...)
 }
 
 // Success logs a success message at normal level.
 func (l *Logger) Success(format string, args ...interface{}) {
 	l.mutex.Lock()
 	defer l.mutex.Unlock()
-	if l.level >= NormalLevel {
-		fmt.Fprintf(l.normalWriter, "Success: "+format+"\n", args...)
-	}
+	l.logf(successKind, nil, format, args...)
 }
 
-// Warning logs a warning message at normal level.
+// Warning logs a warning message; like errors, warnings are shown even in quiet mode.
 func (l *Logger) Warning(format string, args ...interface{}) {
 	l.mutex.Lock()
 	defer l.mutex.Unlock()
-	if l.level >= NormalLevel {
-		fmt.Fprintf(l.normalWriter, "Warning: "+format+"\n", args...)
-	}
+	l.logf(warningKind, nil, format, args...)
 }
 
 // Verbose logs a detailed message at verbose level.
 func (l *Logger) Verbose(format string, args ...interface{}) {
 	l.mutex.Lock()
 	defer l.mutex.Unlock()
-	if l.level >= VerboseLevel {
-		fmt.Fprintf(l.verboseWriter, "[VERBOSE] "+format+"\n", args...)
-	}
+	l.logf(verboseKind, nil, format, args...)
 }
 
 // Debug logs a debug message at verbose level.
 func (l *Logger) Debug(format string, args ...interface{}) {
 	l.mutex.Lock()
 	defer l.mutex.Unlock()
-	if l.level >= VerboseLevel {
-		fmt.Fprintf(l.verboseWriter, "[DEBUG] "+format+"\n", args...)
-	}
+	l.logf(debugKind, nil, format, args...)
 }
 
 // Progress logs a progress update at normal level.
 func (l *Logger) Progress(format string, args ...interface{}) {
 	l.mutex.Lock()
 	defer l.mutex.Unlock()
-	if l.level >= NormalLevel {
-		fmt.Fprintf(l.normalWriter, "Progress: "+format+"\n", args...)
-	}
+	l.logf(progressKind, nil, format, args...)
 }
 
 // Download logs a download-related message at normal level.
 func (l *Logger) Download(format string, args ...interface{}) {
 	l.mutex.Lock()
 	defer l.mutex.Unlock()
-	if l.level >= NormalLevel {
-		fmt.Fprintf(l.normalWriter, "Download: "+format+"\n", args...)
-	}
+	l.logf(downloadKind, nil, format, args...)
 }
 
 // Extract logs an extraction-related message at normal level.
 func (l *Logger) Extract(format string, args ...interface{}) {
 	l.mutex.Lock()
 	defer l.mutex.Unlock()
-	if l.level >= NormalLevel {
-		fmt.Fprintf(l.normalWriter, "Extract: "+format+"\n", args...)
-	}
+	l.logf(extractKind, nil, format, args...)
 }
 
 // Verify logs a verification-related message at normal level.
 func (l *Logger) Verify(format string, args ...interface{}) {
 	l.mutex.Lock()
 	defer l.mutex.Unlock()
-	if l.level >= NormalLevel {
-		fmt.Fprintf(l.normalWriter, "Verify: "+format+"\n", args...)
-	}
+	l.logf(verifyKind, nil, format, args...)
 }
 
 // InternalProgress logs internal progress details at verbose level.
 func (l *Logger) InternalProgress(format string, args ...interface{}) {
 	l.mutex.Lock()
 	defer l.mutex.Unlock()
-	if l.level >= VerboseLevel {
-		fmt.Fprintf(l.verboseWriter, "[INTERNAL] "+format+"\n", args...)
-	}
+	l.logf(internalKind, nil, format, args...)
 }
 
 var globalLogger *Logger
@@ -295,6 +449,11 @@ func StopTimer(t *Timer) {
 	Get().StopTimer(t)
 }
 
+// WithFields is a package-level proxy to Logger.WithFields.
+func WithFields(fields Fields) *Entry {
+	return Get().WithFields(fields)
+}
+
 // ErrorWithHelp is a package-level proxy to Logger.ErrorWithHelp.
 func ErrorWithHelp(errorMsg, helpMsg string, args ...interface{}) {
 	Get().ErrorWithHelp(errorMsg, helpMsg, args...)
@@ -2,6 +2,8 @@ package logger
 
 import (
 	"bytes"
+	"encoding/json"
+	"os"
 	"strings"
 	"sync"
 	"testing"
@@ -298,12 +300,12 @@ func TestWarning(t *testing.T) {
 		shouldLog      bool
 	}{
 		{
-			name:           "Warning does not log at QuietLevel",
+			name:           "Warning logs at QuietLevel",
 			level:          QuietLevel,
 			format:         "deprecated",
 			args:           []interface{}{},
-			expectedOutput: "",
-			shouldLog:      false,
+			expectedOutput: "Warning: deprecated\n",
+			shouldLog:      true,
 		},
 		{
 			name:           "Warning logs at NormalLevel",
@@ -1219,3 +1221,210 @@ func TestTimerFields(t *testing.T) {
 		})
 	}
 }
+
+func TestNew_WritesToStderr(t *testing.T) {
+	viper.Reset()
+	logger := New()
+
+	if logger.NormalWriter() != os.Stderr {
+		t.Error("Expected normal logs to go to stderr")
+	}
+	if logger.VerboseWriter() != os.Stderr {
+		t.Error("Expected verbose logs to go to stderr")
+	}
+}
+
+func TestParseFormat(t *testing.T) {
+	testCases := []struct {
+		name     string
+		input    string
+		expected LogFormat
+		wantErr  bool
+	}{
+		{"Empty defaults to text", "", TextFormat, false},
+		{"Text", "text", TextFormat, false},
+		{"JSON", "json", JSONFormat, false},
+		{"Case insensitive", "JSON", JSONFormat, false},
+		{"Unknown format", "xml", TextFormat, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := ParseFormat(tc.input)
+			if tc.wantErr && err == nil {
+				t.Fatal("Expected error but got none")
+			}
+			if !tc.wantErr && err != nil {
+				t.Fatalf("Expected no error but got: %v", err)
+			}
+			if got != tc.expected {
+				t.Errorf("Expected format %v, got %v", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestNew_LogFormat(t *testing.T) {
+	testCases := []struct {
+		name     string
+		flag     string
+		expected LogFormat
+	}{
+		{"Default is text", "", TextFormat},
+		{"JSON flag", "json", JSONFormat},
+		{"Invalid flag falls back to text", "xml", TextFormat},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			viper.Reset()
+			viper.Set("log-format", tc.flag)
+
+			if got := New().Format(); got != tc.expected {
+				t.Errorf("Expected format %v, got %v", tc.expected, got)
+			}
+		})
+	}
+}
+
+// decodeLines parses each line of output as a JSON object.
+func decodeLines(t *testing.T, output string) []map[string]interface{} {
+	t.Helper()
+
+	var records []map[string]interface{}
+	for _, line := range strings.Split(strings.TrimSpace(output), "\n") {
+		var record map[string]interface{}
+		if err := json.Unmarshal([]byte(line), &record); err != nil {
+			t.Fatalf("Expected a JSON object per line, got %q: %v", line, err)
+		}
+		records = append(records, record)
+	}
+	return records
+}
+
+func TestJSONFormat(t *testing.T) {
+	testCases := []struct {
+		name          string
+		log           func(l *Logger)
+		expectedLevel string
+		expectedMsg   string
+		expectedField map[string]interface{}
+	}{
+		{"Info", func(l *Logger) { l.Info("Installing Go %s...", "1.21.0") }, "info", "Installing Go 1.21.0...", nil},
+		{"Success", func(l *Logger) { l.Success("done") }, "success", "done", nil},
+		{"Warning", func(l *Logger) { l.Warning("careful") }, "warning", "careful", nil},
+		{"Error", func(l *Logger) { l.Error("failed: %v", "boom") }, "error", "failed: boom", nil},
+		{"Download", func(l *Logger) { l.Download("Downloading: %s", "go.tar.gz") }, "download", "Downloading: go.tar.gz", nil},
+		{"Verbose", func(l *Logger) { l.Verbose("detail") }, "verbose", "detail", nil},
+		{"Debug", func(l *Logger) { l.Debug("debug") }, "debug", "debug", nil},
+		{
+			"Error with help",
+			func(l *Logger) { l.ErrorWithHelp("Go %s not found", "Run 'govman list'", "1.99.0") },
+			"error", "Go 1.99.0 not found",
+			map[string]interface{}{"help": "Run 'govman list'"},
+		},
+		{
+			"Fields",
+			func(l *Logger) {
+				l.WithFields(Fields{"version": "1.21.0", "bytes": 1024}).Success("Go %s installed", "1.21.0")
+			},
+			"success", "Go 1.21.0 installed",
+			map[string]interface{}{"version": "1.21.0", "bytes": float64(1024)},
+		},
+		{
+			"Fields cannot override reserved keys",
+			func(l *Logger) { l.WithFields(Fields{"level": "fake", "message": "fake"}).Info("real") },
+			"info", "real", nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			viper.Reset()
+			logger := New()
+			buf := &bytes.Buffer{}
+			logger.SetNormalWriter(buf)
+			logger.SetVerboseWriter(buf)
+			logger.SetLevel(VerboseLevel)
+			logger.SetFormat(JSONFormat)
+
+			tc.log(logger)
+
+			records := decodeLines(t, buf.String())
+			if len(records) != 1 {
+				t.Fatalf("Expected 1 line, got %d: %q", len(records), buf.String())
+			}
+			record := records[0]
+
+			if record["level"] != tc.expectedLevel {
+				t.Errorf("Expected level %q, got %v", tc.expectedLevel, record["level"])
+			}
+			if record["message"] != tc.expectedMsg {
+				t.Errorf("Expected message %q, got %v", tc.expectedMsg, record["message"])
+			}
+			if ts, ok := record["time"].(string); !ok {
+				t.Error("Expected a time field")
+			} else if _, err := time.Parse(time.RFC3339Nano, ts); err != nil {
+				t.Errorf("Expected RFC 3339 timestamp, got %q", ts)
+			}
+			for key, expected := range tc.expectedField {
+				if record[key] != expected {
+					t.Errorf("Expected field %s=%v, got %v", key, expected, record[key])
+				}
+			}
+		})
+	}
+}
+
+func TestJSONFormat_RespectsLevel(t *testing.T) {
+	viper.Reset()
+	logger := New()
+	buf := &bytes.Buffer{}
+	logger.SetNormalWriter(buf)
+	logger.SetVerboseWriter(buf)
+	logger.SetLevel(QuietLevel)
+	logger.SetFormat(JSONFormat)
+
+	logger.Info("hidden")
+	logger.WithFields(Fields{"version": "1.21.0"}).Success("hidden")
+	logger.Verbose("hidden")
+
+	if buf.Len() != 0 {
+		t.Errorf("Expected no output in quiet mode, got %q", buf.String())
+	}
+}
+
+func TestJSONFormat_Timer(t *testing.T) {
+	viper.Reset()
+	logger := New()
+	buf := &bytes.Buffer{}
+	logger.SetVerboseWriter(buf)
+	logger.SetLevel(VerboseLevel)
+	logger.SetFormat(JSONFormat)
+
+	timer := logger.StartTimer("archive extraction")
+	time.Sleep(10 * time.Millisecond)
+	logger.StopTimer(timer)
+
+	records := decodeLines(t, buf.String())
+	if len(records) != 2 {
+		t.Fatalf("Expected start and stop lines, got %d: %q", len(records), buf.String())
+	}
+
+	for _, record := range records {
+		if record["timer"] != "archive extraction" {
+			t.Errorf("Expected timer field, got %v", record["timer"])
+		}
+	}
+	if _, ok := records[0]["duration_ms"]; ok {
+		t.Error("Expected no duration on the start line")
+	}
+
+	duration, ok := records[1]["duration_ms"].(float64)
+	if !ok {
+		t.Fatalf("Expected numeric duration_ms, got %v", records[1]["duration_ms"])
+	}
+	if duration < 10 {
+		t.Errorf("Expected duration of at least 10ms, got %v", duration)
+	}
+}
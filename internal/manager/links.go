@@ -0,0 +1,282 @@
+package manager
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+
+	_config "github.com/justjundana/govman/internal/config"
+	_golang "github.com/justjundana/govman/internal/golang"
+	_logger "github.com/justjundana/govman/internal/logger"
+	_symlink "github.com/justjundana/govman/internal/symlink"
+	_util "github.com/justjundana/govman/internal/util"
+)
+
+// activeVersionFile records the global version in the bin directory when it is activated without a symlink.
+const activeVersionFile = ".govman-active-version"
+
+// companionLinksFile lists, one per line, the links createSymlink made in the bin directory for the default version's
+// binaries besides go, such as gofmt, so switching versions removes only links govman created.
+const companionLinksFile = ".govman-companions"
+
+// globalLinkFile records the file name of the global go link in the bin directory, so changing binary_name removes
+// the link made under the old name.
+const globalLinkFile = ".govman-link"
+
+// createSymlink creates/replaces the global go symlink, named after binary_name, targeting the selected version's binary,
+// and links the version's other binaries, such as gofmt, beside it. Returns an error if directory creation or a
+// symlink operation fails.
+func (m *Manager) createSymlink(version string) error {
+	versionRoot := m.config.GetVersionDir(version)
+
+	goExecutablePath := filepath.Join(versionRoot, "bin", "go")
+
+	if runtime.GOOS == "windows" {
+		goExecutablePath += ".exe"
+	}
+
+	symlinkPath := m.globalSymlinkPath()
+
+	binDir := m.config.GetBinPath()
+	if err := os.MkdirAll(binDir, 0755); err != nil {
+		return _util.WithPermissionHint(fmt.Errorf("failed to create bin directory: %w", err), filepath.Dir(binDir))
+	}
+
+	// Remove the old symlink if it exists
+	if err := _symlink.Remove(symlinkPath); err != nil {
+		return _util.WithPermissionHint(fmt.Errorf("failed to remove existing symlink: %w", err), binDir)
+	}
+
+	// A relative target keeps the link valid if the govman home is moved or mounted elsewhere
+	strategy, err := _symlink.Create(goExecutablePath, symlinkPath, true)
+	if err != nil {
+		return _util.WithPermissionHint(fmt.Errorf("failed to create symlink: %w", err), binDir)
+	}
+
+	// A shim has no link target to read the version from, so record it alongside
+	activeFile := filepath.Join(binDir, activeVersionFile)
+	if strategy == _symlink.StrategySymlink {
+		if err := os.Remove(activeFile); err != nil && !os.IsNotExist(err) {
+			_logger.Verbose("Failed to remove %s: %v", activeFile, err)
+		}
+	} else {
+		_logger.Verbose("Symlinks are unavailable; activated Go %s using a %s instead", version, strategy)
+		if err := os.WriteFile(activeFile, []byte(version+"\n"), 0644); err != nil {
+			return _util.WithPermissionHint(fmt.Errorf("failed to record active version: %w", err), binDir)
+		}
+	}
+
+	if err := m.replaceRenamedLink(symlinkPath); err != nil {
+		return err
+	}
+	return m.linkCompanions(version)
+}
+
+// replaceRenamedLink removes the global link made under an earlier binary_name and records symlinkPath as the link.
+// Without a record, an older go symlink counts as govman's only if it points into a version directory.
+// Returns an error if the old link cannot be removed or the record written.
+func (m *Manager) replaceRenamedLink(symlinkPath string) error {
+	binDir := m.config.GetBinPath()
+	name := filepath.Base(symlinkPath)
+
+	previous := m.readGlobalLinkName()
+	if previous == "" {
+		legacy := _config.DefaultBinaryName
+		if runtime.GOOS == "windows" {
+			legacy += ".exe"
+		}
+		if target, err := os.Readlink(filepath.Join(binDir, legacy)); err == nil && _golang.VersionExtractRegex.MatchString(target) {
+			previous = legacy
+		}
+	}
+	if previous != "" && previous != name {
+		if err := _symlink.Remove(filepath.Join(binDir, previous)); err != nil {
+			return _util.WithPermissionHint(fmt.Errorf("failed to remove the %s link made under the old binary_name: %w", previous, err), binDir)
+		}
+		_logger.Verbose("Removed %s, the link made under the previous binary_name", previous)
+	}
+
+	if err := os.WriteFile(filepath.Join(binDir, globalLinkFile), []byte(name+"\n"), 0644); err != nil {
+		return _util.WithPermissionHint(fmt.Errorf("failed to record the global link: %w", err), binDir)
+	}
+	return nil
+}
+
+// readGlobalLinkName returns the file name of the global link recorded by replaceRenamedLink, or "" if none.
+func (m *Manager) readGlobalLinkName() string {
+	data, err := os.ReadFile(filepath.Join(m.config.GetBinPath(), globalLinkFile))
+	if err != nil {
+		return ""
+	}
+	// Never trust the record to point outside the bin directory
+	if name := strings.TrimSpace(string(data)); name != "" && filepath.Base(name) == name {
+		return name
+	}
+	return ""
+}
+
+// linkCompanions links version's other binaries, such as gofmt, next to the go link unless binary_name is custom,
+// and removes previous companion links it does not ship. Returns an error if a link cannot be changed.
+func (m *Manager) linkCompanions(version string) error {
+	binDir := m.config.GetBinPath()
+	versionBin := filepath.Join(m.config.GetVersionDir(version), "bin")
+
+	previous := m.readCompanions()
+	var entries []os.DirEntry
+	if m.config.GetBinaryName() == _config.DefaultBinaryName {
+		var err error
+		if entries, err = os.ReadDir(versionBin); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to read %s: %w", versionBin, err)
+		}
+	}
+
+	var linked []string
+	for _, entry := range entries {
+		name, ok := toolName(entry)
+		if !ok || name == "go" {
+			continue
+		}
+
+		linkPath := filepath.Join(binDir, entry.Name())
+		if linkPath == m.globalSymlinkPath() {
+			continue
+		}
+		if !slices.Contains(previous, entry.Name()) {
+			if _, err := _symlink.Lstat(linkPath); err == nil {
+				_logger.Verbose("Not linking %s: %s already exists and was not created by govman", name, linkPath)
+				continue
+			}
+		}
+
+		if err := _symlink.Remove(linkPath); err != nil {
+			return _util.WithPermissionHint(fmt.Errorf("failed to remove existing %s link: %w", name, err), binDir)
+		}
+		if _, err := _symlink.Create(filepath.Join(versionBin, entry.Name()), linkPath, true); err != nil {
+			return _util.WithPermissionHint(fmt.Errorf("failed to link %s: %w", name, err), binDir)
+		}
+		linked = append(linked, entry.Name())
+	}
+
+	for _, name := range previous {
+		if slices.Contains(linked, name) {
+			continue
+		}
+		if err := _symlink.Remove(filepath.Join(binDir, name)); err != nil {
+			return _util.WithPermissionHint(fmt.Errorf("failed to remove stale %s link: %w", name, err), binDir)
+		}
+		_logger.Verbose("Removed %s, which Go %s does not ship", name, version)
+	}
+
+	return m.saveCompanions(linked)
+}
+
+// readCompanions returns the file names of the companion links recorded by linkCompanions.
+// Returns nil if none are recorded.
+func (m *Manager) readCompanions() []string {
+	data, err := os.ReadFile(filepath.Join(m.config.GetBinPath(), companionLinksFile))
+	if err != nil {
+		return nil
+	}
+
+	var names []string
+	for _, name := range strings.Split(string(data), "\n") {
+		// Never trust the record to point outside the bin directory
+		if name = strings.TrimSpace(name); name != "" && filepath.Base(name) == name {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// saveCompanions records the file names of the companion links in the bin directory, removing the record when
+// there are none. Returns an error if the record cannot be written.
+func (m *Manager) saveCompanions(names []string) error {
+	path := filepath.Join(m.config.GetBinPath(), companionLinksFile)
+	if len(names) == 0 {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to remove %s: %w", path, err)
+		}
+		return nil
+	}
+
+	if err := os.WriteFile(path, []byte(strings.Join(names, "\n")+"\n"), 0644); err != nil {
+		return _util.WithPermissionHint(fmt.Errorf("failed to record companion links: %w", err), filepath.Dir(path))
+	}
+	return nil
+}
+
+// globalSymlinkPath returns the path of the global go link, named after binary_name, with the .exe suffix on Windows.
+func (m *Manager) globalSymlinkPath() string {
+	symlinkPath := m.config.GetCurrentSymlink()
+	if runtime.GOOS == "windows" && !strings.HasSuffix(symlinkPath, ".exe") {
+		symlinkPath += ".exe"
+	}
+	return symlinkPath
+}
+
+// globalLinkVersion returns the version the global link refers to without validating the installation.
+// Returns an empty string if there is no link or its version cannot be determined.
+func (m *Manager) globalLinkVersion() string {
+	symlinkPath := m.globalSymlinkPath()
+	linkInfo, err := _symlink.Lstat(symlinkPath)
+	if err != nil {
+		return ""
+	}
+
+	if linkInfo.Mode()&os.ModeSymlink == 0 {
+		return m.readActiveVersionFile()
+	}
+
+	target, err := os.Readlink(symlinkPath)
+	if err != nil {
+		return ""
+	}
+	if matches := _golang.VersionExtractRegex.FindStringSubmatch(target); len(matches) >= 2 {
+		return matches[1]
+	}
+	return ""
+}
+
+// removeGlobalLink deletes the global go link, including one made under an earlier binary_name, its companion links,
+// and the recorded active version, if any. Returns an error if a link exists but cannot be removed.
+func (m *Manager) removeGlobalLink() error {
+	if err := _symlink.Remove(m.globalSymlinkPath()); err != nil {
+		return err
+	}
+	if previous := m.readGlobalLinkName(); previous != "" {
+		if err := _symlink.Remove(filepath.Join(m.config.GetBinPath(), previous)); err != nil {
+			return err
+		}
+	}
+	if err := os.Remove(filepath.Join(m.config.GetBinPath(), globalLinkFile)); err != nil && !os.IsNotExist(err) {
+		_logger.Verbose("Failed to remove %s: %v", globalLinkFile, err)
+	}
+
+	for _, name := range m.readCompanions() {
+		if err := _symlink.Remove(filepath.Join(m.config.GetBinPath(), name)); err != nil {
+			return err
+		}
+	}
+	if err := m.saveCompanions(nil); err != nil {
+		_logger.Verbose("%v", err)
+	}
+
+	activeFile := filepath.Join(m.config.GetBinPath(), activeVersionFile)
+	if err := os.Remove(activeFile); err != nil && !os.IsNotExist(err) {
+		_logger.Verbose("Failed to remove %s: %v", activeFile, err)
+	}
+	return nil
+}
+
+// readActiveVersionFile returns the version recorded by createSymlink when a symlink could not be used.
+// Returns an empty string if no version is recorded.
+func (m *Manager) readActiveVersionFile() string {
+	data, err := os.ReadFile(filepath.Join(m.config.GetBinPath(), activeVersionFile))
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(data))
+}
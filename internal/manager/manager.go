@@ -1,14 +1,25 @@
 package manager
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io/fs"
+	"net"
+	"net/http"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"regexp"
 	"runtime"
+	"slices"
 	"sort"
+	"strconv"
 	"strings"
+	"sync"
+	"syscall"
+	"time"
 
 	_config "github.com/justjundana/govman/internal/config"
 	_downloader "github.com/justjundana/govman/internal/downloader"
@@ -20,85 +31,610 @@ import (
 )
 
 // VersionFormatRegex validates Go version format for security.
-// Matches: 1.25.4, 1.25, 1.25rc1, 1.25.4-beta1, latest, stable
-var VersionFormatRegex = regexp.MustCompile(`^(latest|stable|\d+\.\d+(\.\d+)?(-?(rc|beta|alpha)\d*)?)$`)
+// Matches: 1.25.4, 1.25, 1, 1.25rc1, 1.25.4-beta1, latest, stable
+var VersionFormatRegex = regexp.MustCompile(`^(latest|stable|\d+|\d+\.\d+(\.\d+)?(-?(rc|beta|alpha)\d*)?)$`)
+
+// installedVersionRegex matches the version part of an install directory name: VersionFormatRegex without
+// the aliases and bare majors, since every install directory is named after a concrete release.
+var installedVersionRegex = regexp.MustCompile(`^\d+\.\d+(\.\d+)?(-?(rc|beta|alpha)\d*)?$`)
+
+// majorOnlyRegex matches a bare major version such as "1".
+var majorOnlyRegex = regexp.MustCompile(`^\d+$`)
+
+// firstReleaseRegex matches the first release of a line spelled either way, such as "1.22" or "1.22.0".
+var firstReleaseRegex = regexp.MustCompile(`^(\d+)\.(\d+)(\.0)?$`)
+
+var (
+	// ErrAlreadyInstalled is returned by Install when the resolved version is already present.
+	ErrAlreadyInstalled = errors.New("already installed")
+
+	// ErrVersionNotFound is returned when a version alias or partial version cannot be resolved.
+	ErrVersionNotFound = errors.New("version not found")
+
+	// ErrNotInstalled is returned when an operation needs a version that is not installed.
+	ErrNotInstalled = errors.New("not installed")
+
+	// ErrHookFailed is returned by Install when a post-install hook fails and hooks.strict is enabled.
+	// The version itself stays installed.
+	ErrHookFailed = errors.New("post-install hook failed")
+
+	// ErrNoPreviousVersion is returned by PreviousVersion when 'govman use' has not switched versions in that scope yet.
+	ErrNoPreviousVersion = errors.New("no previous version")
+)
+
+// tagFile holds the free-text label attached to a version, inside that version's install directory.
+const tagFile = ".govman-tag"
+
+// maxTagLength caps the length of a version tag.
+const maxTagLength = 200
+
+// InstalledVersion describes an installed version together with its metadata.
+type InstalledVersion struct {
+	Version string
+	Tag     string
+}
+
+// sessionVersionTTL bounds how long a memoized 'go version' result is reused within one process.
+const sessionVersionTTL = 10 * time.Second
+
+// sessionVersionEntry is a memoized 'go version' result for one resolved go binary.
+type sessionVersionEntry struct {
+	modTime time.Time
+	size    int64
+	version string
+	expires time.Time
+}
+
+var (
+	sessionVersionMu    sync.Mutex
+	sessionVersionCache = make(map[string]sessionVersionEntry)
+)
+
+// remoteCacheFile is the name of the remote version list cache inside the cache directory.
+const remoteCacheFile = "remote-versions.json"
+
+// remoteVersionsCache records the last remote version list fetched by ListRemote.
+type remoteVersionsCache struct {
+	FetchedAt time.Time `json:"fetched_at"`
+	Versions  []string  `json:"versions"`
+}
 
 type Manager struct {
 	config     *_config.Config
+	source     _golang.ReleaseSource
 	downloader *_downloader.Downloader
 	shell      _shell.Shell
+
+	// SkipHooks skips hooks.post_install for installs made by this Manager, leaving the config untouched.
+	SkipHooks bool
 }
 
 // New constructs a Manager with the provided configuration.
-// It initializes a downloader and detects the user's shell.
+// It initializes a downloader, applies network settings and any token to release API requests, and detects the user's shell.
+// Releases come from the source selected by go_releases.source.
 func New(cfg *_config.Config) *Manager {
+	return NewWithSource(cfg, nil)
+}
+
+// NewWithSource is like New but lists, resolves, and downloads releases through source.
+// A nil source follows go_releases.source in cfg.
+func NewWithSource(cfg *_config.Config, source _golang.ReleaseSource) *Manager {
+	_golang.SetHTTPClient(cfg.HTTPClient(cfg.Network.Timeout))
+	token, tokenSource := cfg.GoReleases.ReleasesToken()
+	if token != "" {
+		_logger.Verbose("Authenticating release API requests with token from %s", tokenSource)
+	}
+	_golang.SetAuthToken(token)
+
 	return &Manager{
 		config:     cfg,
-		downloader: _downloader.New(cfg),
+		source:     source,
+		downloader: _downloader.NewWithSource(cfg, source),
 		shell:      _shell.Detect(),
 	}
 }
 
+// releaseSource returns the injected release source, or the one configured by go_releases.source.
+func (m *Manager) releaseSource() _golang.ReleaseSource {
+	if m.source != nil {
+		return m.source
+	}
+	return m.config.ReleaseSource()
+}
+
 // Install downloads and installs the specified Go version.
 // version may be an exact string or "latest". Returns an error if resolution, download, or installation fails.
 func (m *Manager) Install(version string) error {
-	// Validate version format for security
-	if !VersionFormatRegex.MatchString(version) {
-		return fmt.Errorf("invalid version format: %s", version)
+	return m.InstallContext(context.Background(), version)
+}
+
+// InstallContext is like Install but stops the download or extraction when ctx is canceled,
+// leaving no partial version directory behind. Returns an error wrapping ctx.Err() when canceled.
+func (m *Manager) InstallContext(ctx context.Context, version string) error {
+	_, err := m.install(ctx, version)
+	return err
+}
+
+// install implements InstallContext. Returns the release version resolves to, or an error.
+func (m *Manager) install(ctx context.Context, version string) (string, error) {
+	// Validate version format for security; constraints are validated by their parser
+	if !VersionFormatRegex.MatchString(version) && !_util.IsVersionConstraint(version) {
+		return "", fmt.Errorf("invalid version format: %s", version)
 	}
 
 	timer := _logger.StartTimer("version resolution")
-	resolvedVersion, err := m.ResolveVersion(version)
+	resolvedVersion, err := m.resolveVersion(ctx, version)
 	if err != nil {
 		_logger.StopTimer(timer)
-		return fmt.Errorf("failed to resolve version %s: %w", version, err)
+		return "", fmt.Errorf("failed to resolve version %s: %w", version, err)
 	}
 	_logger.StopTimer(timer)
 
 	_logger.InternalProgress("Checking if version is already installed")
 	if m.IsInstalled(resolvedVersion) {
-		return fmt.Errorf("go version %s is already installed", resolvedVersion)
+		return "", fmt.Errorf("go version %s is %w", resolvedVersion, ErrAlreadyInstalled)
 	}
 
-	_logger.Info("Installing Go %s...", resolvedVersion)
+	_logger.WithFields(_logger.Fields{"version": resolvedVersion}).Info("Installing Go %s...", resolvedVersion)
 
 	timer = _logger.StartTimer("download URL retrieval")
-	downloadURL, err := _golang.GetDownloadURLWithConfig(resolvedVersion,
-		m.config.GoReleases.APIURL,
-		m.config.GoReleases.CacheExpiry,
-		m.config.GoReleases.DownloadURL)
+	downloadURL, err := m.downloadURL(ctx, resolvedVersion)
 	if err != nil {
 		_logger.StopTimer(timer)
-		return fmt.Errorf("failed to get download URL: %w", err)
+		return "", err
 	}
 	_logger.StopTimer(timer)
+	_logger.Verbose("Using download mirror: %s", downloadURL)
 
 	installDir := m.config.GetVersionDir(resolvedVersion)
 	timer = _logger.StartTimer("download and installation")
-	if err := m.downloader.Download(downloadURL, installDir, resolvedVersion); err != nil {
+	if err := m.downloadFromMirrors(ctx, downloadURL, installDir, resolvedVersion); err != nil {
 		_logger.StopTimer(timer)
-		return fmt.Errorf("failed to download and install: %w", err)
+		return "", _util.WithPermissionHint(fmt.Errorf("failed to download and install: %w", err), m.config.InstallDir)
 	}
 	_logger.StopTimer(timer)
 
-	_logger.Success("Go %s installed successfully", resolvedVersion)
+	_logger.WithFields(_logger.Fields{"version": resolvedVersion}).Success("Go %s installed successfully", resolvedVersion)
+
+	return resolvedVersion, m.runPostInstallHooks(ctx, resolvedVersion)
+}
+
+// InstallFromFile installs the archive at archivePath without the network, checked against any checksum recorded by
+// an earlier download; version may be empty for an officially named file. Returns the installed version or an error.
+func (m *Manager) InstallFromFile(ctx context.Context, archivePath, version string) (string, error) {
+	if info, err := os.Stat(archivePath); err != nil {
+		return "", fmt.Errorf("failed to read archive: %w", err)
+	} else if info.IsDir() {
+		return "", fmt.Errorf("%s is a directory, not a Go archive", archivePath)
+	}
+
+	filename := filepath.Base(archivePath)
+	if named, goos, goarch, ok := _golang.ParseArchiveName(filename); ok {
+		if version == "" {
+			version = named
+		}
+		if named != version {
+			return "", fmt.Errorf("%s is an archive of Go %s, not Go %s", filename, named, version)
+		}
+		if filename != _golang.ArchiveName(version) {
+			return "", fmt.Errorf("%s is an archive for %s/%s, not this platform", filename, goos, goarch)
+		}
+	}
+	if version == "" {
+		return "", fmt.Errorf("cannot tell the Go version from the file name %s; pass the version too", filename)
+	}
+	if !VersionFormatRegex.MatchString(version) || version == "latest" || version == "stable" {
+		return "", fmt.Errorf("invalid version format: %s", version)
+	}
+
+	version = m.installedSpelling(version)
+	if m.IsInstalled(version) {
+		return "", fmt.Errorf("go version %s is %w", version, ErrAlreadyInstalled)
+	}
+
+	if _, err := m.downloader.VerifyRecordedArchive(archivePath, version); err != nil {
+		return "", fmt.Errorf("%s is not the Go %s archive downloaded before: %w", archivePath, version, err)
+	}
+
+	_logger.WithFields(_logger.Fields{"version": version, "file": archivePath}).Info("Installing Go %s from %s...", version, archivePath)
+	installDir := m.config.GetVersionDir(version)
+	if err := m.downloader.InstallArchive(ctx, archivePath, installDir, version); err != nil {
+		return "", _util.WithPermissionHint(fmt.Errorf("failed to install from %s: %w", archivePath, err), m.config.InstallDir)
+	}
+
+	_logger.WithFields(_logger.Fields{"version": version}).Success("Go %s installed successfully", version)
+
+	return version, m.runPostInstallHooks(ctx, version)
+}
+
+// runPostInstallHooks runs each hooks.post_install command for a freshly installed version, without a shell,
+// with the version's bin directory first on PATH. Nothing runs with SkipHooks; failures warn unless hooks.strict is set.
+// Returns an error wrapping ErrHookFailed for the first failure in strict mode; nil otherwise.
+func (m *Manager) runPostInstallHooks(ctx context.Context, version string) error {
+	if m.SkipHooks {
+		return nil
+	}
+
+	hooks := m.config.Hooks.PostInstall
+	for i, command := range hooks {
+		if ctx.Err() != nil {
+			_logger.Warning("Skipped %d remaining post-install hook(s) for Go %s: interrupted", len(hooks)-i, version)
+			return nil
+		}
+
+		log := _logger.WithFields(_logger.Fields{"version": version, "hook": command})
+		log.Info("Running post-install hook (%d/%d): %s", i+1, len(hooks), command)
+
+		output, err := m.runHook(ctx, version, command)
+		if err == nil {
+			if output != "" {
+				_logger.Verbose("%s", output)
+			}
+			continue
+		}
+
+		if output != "" {
+			err = fmt.Errorf("%w\n%s", err, output)
+		}
+		if m.config.Hooks.Strict {
+			return fmt.Errorf("go %s was installed, but %w: %s: %v", version, ErrHookFailed, command, err)
+		}
+		log.Warning("Post-install hook failed: %s: %v", command, err)
+	}
+
 	return nil
 }
 
+// runHook executes a single hook command for version and returns its combined, trimmed output.
+// Returns an error if the command cannot be parsed or run, or exits non-zero.
+func (m *Manager) runHook(ctx context.Context, version, command string) (string, error) {
+	args, err := _util.SplitCommand(command)
+	if err != nil {
+		return "", err
+	}
+
+	cmd, err := m.Command(ctx, version, args)
+	if err != nil {
+		return "", err
+	}
+
+	output, err := cmd.CombinedOutput()
+	return strings.TrimSpace(string(output)), err
+}
+
+// Command prepares args to run against an installed version without activating it: the program is looked up in the
+// version's bin directory first, then on PATH; GOROOT points at the version and, with shell.pin_toolchain,
+// GOTOOLCHAIN=local keeps 'go' from switching toolchains. Returns an error if args is empty or the version is not installed.
+func (m *Manager) Command(ctx context.Context, version string, args []string) (*exec.Cmd, error) {
+	if len(args) == 0 {
+		return nil, fmt.Errorf("no command given")
+	}
+	if !m.IsInstalled(version) {
+		return nil, fmt.Errorf("go version %s is %w", version, ErrNotInstalled)
+	}
+
+	versionDir := m.config.GetVersionDir(version)
+	binDir := filepath.Join(versionDir, "bin")
+
+	program := args[0]
+	if !strings.ContainsAny(program, `/\`) {
+		candidate := filepath.Join(binDir, program)
+		if runtime.GOOS == "windows" && filepath.Ext(candidate) == "" {
+			candidate += ".exe"
+		}
+		if info, err := os.Stat(candidate); err == nil && !info.IsDir() {
+			program = candidate
+		}
+	}
+
+	cmd := exec.CommandContext(ctx, program, args[1:]...)
+	// Later entries win over the inherited ones, so the command sees this version first
+	cmd.Env = append(os.Environ(),
+		"PATH="+binDir+string(os.PathListSeparator)+os.Getenv("PATH"),
+		"GOROOT="+versionDir,
+	)
+	if m.config.Shell.PinToolchain {
+		cmd.Env = append(cmd.Env, "GOTOOLCHAIN=local")
+	}
+
+	return cmd, nil
+}
+
+// ShellCommand prepares an interactive shell with version activated as in Command, and with
+// _shell.SubshellVersionEnv set to version so the shell's integration and prompt can tell.
+// Returns an error if the version is not installed.
+func (m *Manager) ShellCommand(ctx context.Context, version, shellPath string) (*exec.Cmd, error) {
+	cmd, err := m.Command(ctx, version, []string{shellPath})
+	if err != nil {
+		return nil, err
+	}
+	cmd.Env = append(cmd.Env, _shell.SubshellVersionEnv+"="+version)
+	return cmd, nil
+}
+
+// downloadURL returns the archive URL for version from the release source; the API source tries the configured
+// download URL and mirrors in order. Returns an error if no download URL is configured, version is not a known
+// release (wrapping _golang.ErrVersionNotFound), or the release has no archive for this platform.
+func (m *Manager) downloadURL(ctx context.Context, version string) (string, error) {
+	downloadURL, err := m.releaseSource().DownloadURL(ctx, version)
+	if errors.Is(err, _golang.ErrVersionNotFound) {
+		// Not a download problem: say so plainly instead of hinting at the network
+		return "", err
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to get download URL: %w", err)
+	}
+	return downloadURL, nil
+}
+
+// downloadFromMirrors downloads and installs version from downloadURL, moving on to the source's other mirrors in
+// order while the archive cannot be fetched. Returns the last download error, or any verification or extraction error.
+func (m *Manager) downloadFromMirrors(ctx context.Context, downloadURL, installDir, version string) error {
+	err := m.downloader.Download(ctx, downloadURL, installDir, version)
+	if err == nil || ctx.Err() != nil || !errors.Is(err, _downloader.ErrDownload) {
+		return err
+	}
+
+	mirrored, ok := m.releaseSource().(_golang.MirroredSource)
+	if !ok {
+		return err
+	}
+	urls, urlsErr := mirrored.ArchiveURLs(ctx, version)
+	if urlsErr != nil {
+		return err
+	}
+
+	for _, url := range urls {
+		if url == downloadURL {
+			continue
+		}
+		_logger.Warning("Download from %s failed, trying %s: %v", downloadURL, url, err)
+		downloadURL = url
+		err = m.downloader.Download(ctx, url, installDir, version)
+		if err == nil || ctx.Err() != nil || !errors.Is(err, _downloader.ErrDownload) {
+			return err
+		}
+	}
+	return err
+}
+
+// InstallPlan describes what Install would do for a requested version.
+type InstallPlan struct {
+	Requested  string
+	Version    string
+	URL        string
+	InstallDir string
+	Installed  bool
+}
+
+// PlanInstall resolves version and looks up its download URL and target directory without downloading anything.
+// Returns the plan or an error if the version is invalid, cannot be resolved, or has no archive for this platform.
+func (m *Manager) PlanInstall(version string) (*InstallPlan, error) {
+	if !VersionFormatRegex.MatchString(version) && !_util.IsVersionConstraint(version) {
+		return nil, fmt.Errorf("invalid version format: %s", version)
+	}
+
+	ctx := context.Background()
+	resolvedVersion, err := m.resolveVersion(ctx, version)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve version %s: %w", version, err)
+	}
+
+	downloadURL, err := m.downloadURL(ctx, resolvedVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	return &InstallPlan{
+		Requested:  version,
+		Version:    resolvedVersion,
+		URL:        downloadURL,
+		InstallDir: m.config.GetVersionDir(resolvedVersion),
+		Installed:  m.IsInstalled(resolvedVersion),
+	}, nil
+}
+
+// InstallErrorKind classifies why a version failed to install.
+type InstallErrorKind string
+
+const (
+	InstallErrorNotFound         InstallErrorKind = "not-found"
+	InstallErrorNetwork          InstallErrorKind = "network"
+	InstallErrorOffline          InstallErrorKind = "offline"
+	InstallErrorAlreadyInstalled InstallErrorKind = "already-installed"
+	InstallErrorDisk             InstallErrorKind = "disk"
+	InstallErrorInterrupted      InstallErrorKind = "interrupted"
+	InstallErrorHook             InstallErrorKind = "hook"
+	InstallErrorUnknown          InstallErrorKind = "unknown"
+)
+
+// InstallError records a failed installation from InstallMany.
+type InstallError struct {
+	Version string
+	Kind    InstallErrorKind
+	Err     error
+}
+
+// Error implements the error interface.
+func (e InstallError) Error() string {
+	return fmt.Sprintf("Go %s: %v", e.Version, e.Err)
+}
+
+// Unwrap returns the underlying installation error.
+func (e InstallError) Unwrap() error {
+	return e.Err
+}
+
+// InstallMany installs each version in order, continuing past failures.
+// Returns the releases installed successfully, as resolved, and a classified InstallError for each failure.
+func (m *Manager) InstallMany(versions []string) ([]string, []InstallError) {
+	return m.InstallManyContext(context.Background(), versions)
+}
+
+// InstallManyContext is like InstallMany but stops once ctx is canceled; versions not yet started are skipped.
+func (m *Manager) InstallManyContext(ctx context.Context, versions []string) ([]string, []InstallError) {
+	var successful []string
+	var failures []InstallError
+
+	for i, version := range versions {
+		if ctx.Err() != nil {
+			break
+		}
+
+		_logger.Info("[%d/%d] Installing Go %s...", i+1, len(versions), version)
+		installed, err := m.install(ctx, version)
+		if err != nil {
+			failures = append(failures, InstallError{
+				Version: version,
+				Kind:    classifyInstallError(err),
+				Err:     err,
+			})
+			_logger.Warning("Failed to install Go %s: %v", version, err)
+			continue
+		}
+
+		successful = append(successful, installed)
+		_logger.Success("Successfully installed Go %s", installed)
+	}
+
+	return successful, failures
+}
+
+// PlanInstallMany plans each version like InstallMany would install it, without downloading anything.
+// Returns a plan for every version that could be resolved and a classified InstallError for each that could not.
+func (m *Manager) PlanInstallMany(versions []string) ([]*InstallPlan, []InstallError) {
+	var plans []*InstallPlan
+	var failures []InstallError
+
+	for _, version := range versions {
+		plan, err := m.PlanInstall(version)
+		if err != nil {
+			failures = append(failures, InstallError{
+				Version: version,
+				Kind:    classifyInstallError(err),
+				Err:     err,
+			})
+			continue
+		}
+		plans = append(plans, plan)
+	}
+
+	return plans, failures
+}
+
+// classifyInstallError maps an error returned by Install to an InstallErrorKind.
+func classifyInstallError(err error) InstallErrorKind {
+	var statusErr *_downloader.StatusError
+	var netErr net.Error
+	var pathErr *fs.PathError
+
+	switch {
+	case errors.Is(err, ErrAlreadyInstalled):
+		return InstallErrorAlreadyInstalled
+	case errors.Is(err, ErrHookFailed):
+		// Checked early because a hook that cannot start fails with an *fs.PathError.
+		return InstallErrorHook
+	case errors.Is(err, context.Canceled):
+		return InstallErrorInterrupted
+	case errors.Is(err, _golang.ErrOffline):
+		// Checked before not-found and network errors, which an offline failure may also wrap.
+		return InstallErrorOffline
+	case errors.Is(err, ErrVersionNotFound),
+		errors.Is(err, _golang.ErrVersionNotFound),
+		errors.Is(err, _golang.ErrNoDownload),
+		errors.Is(err, _golang.ErrNoFileInfo):
+		return InstallErrorNotFound
+	case errors.As(err, &statusErr):
+		if statusErr.StatusCode == http.StatusNotFound {
+			return InstallErrorNotFound
+		}
+		return InstallErrorNetwork
+	case errors.Is(err, syscall.ENOSPC), errors.As(err, &pathErr):
+		// Checked before net.Error because syscall.Errno also satisfies that interface.
+		return InstallErrorDisk
+	case errors.As(err, &netErr):
+		return InstallErrorNetwork
+	default:
+		return InstallErrorUnknown
+	}
+}
+
 // Uninstall removes an installed Go version.
 // Returns an error if the version is not installed, is active, or removal fails.
 func (m *Manager) Uninstall(version string) error {
 	_logger.InternalProgress("Checking if version is installed")
 	if !m.IsInstalled(version) {
-		return fmt.Errorf("go version %s is not installed", version)
+		return fmt.Errorf("go version %s is %w", version, ErrNotInstalled)
 	}
 
 	_logger.InternalProgress("Checking if version is currently active")
-	current, err := m.Current()
+	current, err := m.CurrentUncached()
 	if err == nil && current == version {
 		return fmt.Errorf("cannot uninstall currently active version %s", version)
 	}
 
+	return m.removeVersion(version)
+}
+
+// ForceUninstall removes an installed Go version even if it is active, removing the global symlink and clearing
+// the default that refer to it. Returns an error if the version is not installed or removal fails.
+func (m *Manager) ForceUninstall(version string) error {
+	_logger.InternalProgress("Checking if version is installed")
+	if !m.IsInstalled(version) {
+		return fmt.Errorf("go version %s is %w", version, ErrNotInstalled)
+	}
+
+	if err := m.removeVersion(version); err != nil {
+		return err
+	}
+
+	if m.globalLinkVersion() == version {
+		_logger.InternalProgress("Removing global symlink to Go %s", version)
+		if err := m.removeGlobalLink(); err != nil {
+			return fmt.Errorf("go %s was removed but its symlink could not be: %w", version, err)
+		}
+	}
+
+	if m.config.DefaultVersion == version {
+		_logger.InternalProgress("Clearing default version")
+		m.config.DefaultVersion = ""
+		if err := m.config.Save(); err != nil {
+			_logger.Warning("Failed to clear default version in config: %v", err)
+		}
+	}
+
+	return nil
+}
+
+// ReplaceActive points the default and this terminal's session version at replacement wherever they select version,
+// leaving project files alone. Returns the scopes moved ("default", "session"), or an error.
+func (m *Manager) ReplaceActive(version, replacement string) ([]string, error) {
+	replacement = m.installedSpelling(replacement)
+	if !m.IsInstalled(replacement) {
+		return nil, fmt.Errorf("go version %s is %w", replacement, ErrNotInstalled)
+	}
+
+	var moved []string
+	if m.config.DefaultVersion == version || m.globalLinkVersion() == version {
+		if err := m.SetDefault(replacement); err != nil {
+			return moved, fmt.Errorf("failed to switch the default to Go %s: %w", replacement, err)
+		}
+		moved = append(moved, "default")
+	}
+
+	if m.SessionVersion() == version {
+		if err := m.SetSessionVersion(replacement); err != nil {
+			return moved, fmt.Errorf("failed to switch this terminal to Go %s: %w", replacement, err)
+		}
+		moved = append(moved, "session")
+	}
+
+	return moved, nil
+}
+
+// removeVersion deletes the installation directory of version.
+// Returns an error if removal fails.
+func (m *Manager) removeVersion(version string) error {
 	installDir := m.config.GetVersionDir(version)
 	_logger.InternalProgress("Removing installation directory: %s", installDir)
 	timer := _logger.StartTimer("uninstallation")
@@ -108,27 +644,49 @@ func (m *Manager) Uninstall(version string) error {
 	}
 	_logger.StopTimer(timer)
 
-	_logger.Success("Go %s uninstalled successfully", version)
+	_logger.WithFields(_logger.Fields{"version": version}).Success("Go %s uninstalled successfully", version)
 	return nil
 }
 
 // Use activates a Go version for the current session, as default, or for the local project.
-// setDefault sets it globally; setLocal writes a project version file. Returns an error if activation fails.
-func (m *Manager) Use(version string, setDefault, setLocal bool) error {
+// setDefault sets it globally; setLocal writes a project version file; skipSession keeps the current session on the
+// version it ran before, even through the global link, so only new shells pick up the change. Returns an error if activation fails.
+func (m *Manager) Use(version string, setDefault, setLocal, skipSession bool) error {
 	if version == "default" {
-		defaultVersion, err := m.CurrentGlobal()
-		if err != nil {
-			return fmt.Errorf("failed to get default version: %w", err)
+		// The configured default wins over a link that drifted from it; only --default relinks
+		defaultVersion := m.config.DefaultVersion
+		if defaultVersion == "" || !m.IsInstalled(defaultVersion) {
+			var err error
+			if defaultVersion, err = m.CurrentGlobal(); err != nil {
+				return fmt.Errorf("failed to get default version: %w", err)
+			}
 		}
 		version = defaultVersion
 	} else {
+		version = m.installedSpelling(version)
+
 		// Validate version is installed
 		_logger.InternalProgress("Checking if version is installed")
 		if !m.IsInstalled(version) {
-			return fmt.Errorf("go version %s is not installed. Run 'govman install %s' first", version, version)
+			return fmt.Errorf("go version %s is %w. Run 'govman install %s' first", version, ErrNotInstalled, version)
 		}
 	}
 
+	// The version this terminal runs, which skipSession pins so it does not follow the relinked default
+	var sessionVersion string
+	if skipSession {
+		sessionVersion, _ = m.CurrentUncached()
+	}
+
+	// What this switch replaces, so 'govman use -' can come back to it
+	var previous string
+	switch {
+	case setDefault:
+		previous, _ = m.CurrentGlobal()
+	case !setLocal:
+		previous, _ = m.Current()
+	}
+
 	// Apply the version based on scope
 	switch {
 	case setLocal:
@@ -139,25 +697,22 @@ func (m *Manager) Use(version string, setDefault, setLocal bool) error {
 		_logger.Success("Set Go %s as local version for this project", version)
 
 	case setDefault:
-		_logger.InternalProgress("Setting as system default version")
-
-		// Update config
-		m.config.DefaultVersion = version
-		if err := m.config.Save(); err != nil {
-			_logger.Warning("Failed to save default version to config: %v", err)
+		if err := m.SetDefault(version); err != nil {
+			return err
 		}
+	}
 
-		// Create symlink
-		_logger.InternalProgress("Creating symlink for Go %s", version)
-		timer := _logger.StartTimer("symlink creation")
-		if err := m.createSymlink(version); err != nil {
-			_logger.StopTimer(timer)
-			return fmt.Errorf("failed to create symlink: %w", err)
+	if !setLocal && previous != "" && previous != version {
+		if err := m.recordPreviousVersion(setDefault, previous); err != nil {
+			_logger.Verbose("Could not record the previous version: %v", err)
 		}
-		_logger.StopTimer(timer)
+	}
 
-	default:
-		// Session-only, no additional action needed
+	if skipSession {
+		if sessionVersion == "" {
+			return nil
+		}
+		version = sessionVersion
 	}
 
 	// Update PATH
@@ -165,65 +720,281 @@ func (m *Manager) Use(version string, setDefault, setLocal bool) error {
 	return m.shell.ExecutePathCommand(versionBinPath)
 }
 
+// SetDefault records version as the system default and points the global symlink at it.
+// Returns an error if the version is not installed or the symlink cannot be created.
+func (m *Manager) SetDefault(version string) error {
+	if !m.IsInstalled(version) {
+		return fmt.Errorf("go version %s is %w", version, ErrNotInstalled)
+	}
+
+	_logger.InternalProgress("Setting as system default version")
+
+	// Update config
+	m.config.DefaultVersion = version
+	if err := m.config.Save(); err != nil {
+		_logger.Warning("Failed to save default version to config: %v", err)
+	}
+
+	// Create symlink
+	_logger.InternalProgress("Creating symlink for Go %s", version)
+	timer := _logger.StartTimer("symlink creation")
+	if err := m.createSymlink(version); err != nil {
+		_logger.StopTimer(timer)
+		return fmt.Errorf("failed to create symlink: %w", err)
+	}
+	_logger.StopTimer(timer)
+
+	if linked := m.globalLinkVersion(); linked != version {
+		return fmt.Errorf("symlink at %s does not refer to Go %s after activation", m.globalSymlinkPath(), version)
+	}
+
+	return nil
+}
+
+// VerifyCheck is one integrity check VerifyInstall ran on an installed version.
+type VerifyCheck struct {
+	Name    string // what was checked, e.g. "go binary"
+	Detail  string // what was found when the check passed, or why it was skipped
+	Err     error  // why the check failed, nil otherwise
+	Skipped bool   // true when there was nothing to check, e.g. no cached archive
+}
+
+// VerifyResult lists the checks VerifyInstall ran on one installed version.
+type VerifyResult struct {
+	Version string
+	Checks  []VerifyCheck
+}
+
+// Err returns the first failed check as an error naming the version and check, or nil when none failed.
+func (r *VerifyResult) Err() error {
+	for _, check := range r.Checks {
+		if check.Err != nil {
+			return fmt.Errorf("go %s failed verification: %s: %w", r.Version, check.Name, check.Err)
+		}
+	}
+	return nil
+}
+
+// VerifyInstall re-checks an installed version without changing it: its bin/go, its install tree, and any cached
+// archive against the release checksum. Returns the result of every check, or an error if it is not installed.
+func (m *Manager) VerifyInstall(ctx context.Context, version string) (*VerifyResult, error) {
+	version = m.installedSpelling(version)
+	if !m.IsInstalled(version) {
+		return nil, fmt.Errorf("go version %s is %w", version, ErrNotInstalled)
+	}
+
+	versionDir := m.config.GetVersionDir(version)
+	result := &VerifyResult{Version: version}
+
+	goBinary := filepath.Join(versionDir, "bin", "go")
+	if runtime.GOOS == "windows" {
+		goBinary += ".exe"
+	}
+	binaryCheck := VerifyCheck{Name: "go binary"}
+	if cmd, err := m.Command(ctx, version, []string{goBinary, "version"}); err != nil {
+		binaryCheck.Err = err
+	} else if output, err := cmd.Output(); err != nil {
+		binaryCheck.Err = fmt.Errorf("%s does not run: %w", goBinary, err)
+	} else if reported, err := parseGoVersionOutput(string(output)); err != nil {
+		binaryCheck.Err = err
+	} else if reported != version {
+		binaryCheck.Err = fmt.Errorf("%s reports Go %s", goBinary, reported)
+	} else {
+		binaryCheck.Detail = strings.TrimSpace(string(output))
+	}
+	result.Checks = append(result.Checks, binaryCheck)
+
+	treeCheck := VerifyCheck{Name: "install tree", Detail: "bin/go, pkg, and VERSION are present"}
+	if err := _downloader.ValidateToolchain(versionDir, version); err != nil {
+		treeCheck.Detail, treeCheck.Err = "", err
+	}
+	result.Checks = append(result.Checks, treeCheck)
+
+	archiveCheck := VerifyCheck{Name: "archive checksum", Detail: "cached archive matches the release checksum"}
+	checked, err := m.downloader.VerifyCachedArchive(ctx, version)
+	switch {
+	case checked && err != nil:
+		archiveCheck.Detail, archiveCheck.Err = "", err
+	case err != nil:
+		archiveCheck.Detail, archiveCheck.Skipped = fmt.Sprintf("skipped, %v", err), true
+	case !checked:
+		archiveCheck.Detail, archiveCheck.Skipped = "skipped, no cached archive", true
+	}
+	result.Checks = append(result.Checks, archiveCheck)
+
+	return result, nil
+}
+
+// VerifyDefault checks, without changing anything, that the global link refers to the configured default version
+// and resolves to an existing file. Returns nil if it does or no default is configured, or an error describing the drift.
+func (m *Manager) VerifyDefault() error {
+	defaultVersion := m.config.DefaultVersion
+	if defaultVersion == "" {
+		return nil
+	}
+	if !m.IsInstalled(defaultVersion) {
+		return fmt.Errorf("default version %s is configured but %w", defaultVersion, ErrNotInstalled)
+	}
+
+	symlinkPath := m.globalSymlinkPath()
+	if _, err := _symlink.Lstat(symlinkPath); err != nil {
+		if os.IsNotExist(err) {
+			return fmt.Errorf("default version %s is configured but there is no link at %s", defaultVersion, symlinkPath)
+		}
+		return fmt.Errorf("failed to check link at %s: %w", symlinkPath, err)
+	}
+
+	switch linked := m.globalLinkVersion(); linked {
+	case defaultVersion:
+	case "":
+		return fmt.Errorf("link at %s does not refer to a govman version, but the default version is %s", symlinkPath, defaultVersion)
+	default:
+		return fmt.Errorf("link at %s refers to Go %s, but the default version is %s", symlinkPath, linked, defaultVersion)
+	}
+
+	if _, err := _symlink.Stat(symlinkPath); err != nil {
+		return fmt.Errorf("link at %s refers to Go %s but does not resolve: %w", symlinkPath, defaultVersion, err)
+	}
+
+	return nil
+}
+
+// Reconcile recreates the global link when VerifyDefault reports that it drifted from the configured default
+// version, for example after it was deleted by hand. Returns true if the link was recreated, or an error if the
+// default version is not installed or the link still does not match afterwards.
+func (m *Manager) Reconcile() (bool, error) {
+	if m.VerifyDefault() == nil {
+		return false, nil
+	}
+
+	defaultVersion := m.config.DefaultVersion
+	if !m.IsInstalled(defaultVersion) {
+		return false, fmt.Errorf("default version %s is %w. Run 'govman install %s' first", defaultVersion, ErrNotInstalled, defaultVersion)
+	}
+
+	_logger.InternalProgress("Relinking Go %s as the default version", defaultVersion)
+	if err := m.createSymlink(defaultVersion); err != nil {
+		return false, fmt.Errorf("failed to recreate symlink for default version %s: %w", defaultVersion, err)
+	}
+	if err := m.VerifyDefault(); err != nil {
+		return false, err
+	}
+
+	_logger.Info("Restored the link to default Go version %s", defaultVersion)
+	return true, nil
+}
+
 // Current returns the currently active Go version, checking session, local project, or global symlink.
+// The session version is memoized briefly per go binary; use CurrentUncached when a stale answer is unacceptable.
 // Returns the version string or an error if none is active or validation fails.
 func (m *Manager) Current() (string, error) {
-	sessionVersion, err := m.getCurrentSessionVersion()
-	if err != nil {
-		_logger.Verbose("Could not get session version: %v", err)
-	} else if sessionVersion != "" {
-		if !m.IsInstalled(sessionVersion) {
-			_logger.Warning("Session version %s is active but not managed by GOVMAN", sessionVersion)
+	return m.current(true)
+}
+
+// CurrentUncached is like Current but always executes 'go version' instead of reusing a memoized result.
+func (m *Manager) CurrentUncached() (string, error) {
+	return m.current(false)
+}
+
+// current implements Current and CurrentUncached; useCache controls the session version memoization.
+func (m *Manager) current(useCache bool) (string, error) {
+	state, err := m.currentDetailed(useCache)
+	return state.Version, err
+}
+
+// CurrentState is a consistent snapshot of the active Go version, where it comes from, and the versions each
+// activation scope selects, as returned by CurrentDetailed.
+type CurrentState struct {
+	Version     string // the active version, as Current reports it, or "" when none is active
+	Method      string // how Version is active: "session-only", "project-local", or "system-default"
+	Session     string // version recorded for this terminal with 'govman use --temp', or ""
+	PathVersion string // version the go on PATH reports, or "" when none runs or Session is set
+	LocalFile   string // project version file in effect, or ""
+	LocalRaw    string // version LocalFile requests, e.g. "1.25", or ""
+	Local       string // installed version LocalRaw resolves to, or ""
+	Global      string // version the global link refers to, or "" when it is missing or broken
+	GlobalErr   error  // why Global is empty
+	Default     string // configured default version, or ""
+}
+
+// CurrentDetailed determines the active version, its activation method, and the session, project, global, and
+// default versions in one pass, running 'go version' at most once, so the version and method cannot disagree.
+// Returns the snapshot, and the same error Current would return when no valid version is active.
+func (m *Manager) CurrentDetailed() (CurrentState, error) {
+	return m.currentDetailed(true)
+}
+
+// currentDetailed implements CurrentDetailed; useCache controls the session version memoization.
+func (m *Manager) currentDetailed(useCache bool) (CurrentState, error) {
+	state := CurrentState{Default: m.config.DefaultVersion}
+	state.LocalFile, state.LocalRaw = m.FindProjectVersionFile()
+	if state.LocalRaw != "" {
+		if installed, err := m.ListInstalled(); err != nil {
+			_logger.Verbose("Failed to list installed versions: %v", err)
+		} else if matched, err := _util.FindBestMatchingVersion(state.LocalRaw, installed); err == nil {
+			state.Local = matched
 		}
+	}
+	state.Global, state.GlobalErr = m.CurrentGlobal()
 
-		return sessionVersion, nil
+	// A version chosen with 'govman use --temp' wins for the rest of this terminal session
+	if state.Session = m.SessionVersion(); state.Session != "" {
+		state.Version, state.Method = state.Session, "session-only"
+		return state, nil
 	}
 
-	if localVersion := m.getLocalVersion(); localVersion != "" {
-		if !m.IsInstalled(localVersion) {
-			return "", fmt.Errorf("local version %s specified in %s is not installed - run 'govman install %s' to install it",
-				localVersion, m.config.AutoSwitch.ProjectFile, localVersion)
+	pathVersion, err := m.getCurrentSessionVersion(useCache)
+	if err != nil {
+		_logger.Verbose("Could not get session version: %v", err)
+	} else if pathVersion != "" {
+		if !m.IsInstalled(pathVersion) {
+			_logger.Warning("Session version %s is active but not managed by GOVMAN", pathVersion)
+		}
+
+		state.PathVersion, state.Version = pathVersion, pathVersion
+		switch pathVersion {
+		case state.Local:
+			state.Method = "project-local"
+		case state.Global:
+			state.Method = "system-default"
+		default:
+			state.Method = "session-only"
 		}
+		return state, nil
+	}
 
-		return localVersion, nil
+	if state.Local != "" {
+		state.Version, state.Method = state.Local, "project-local"
+		return state, nil
 	}
 
-	// Check if there's a raw local version that doesn't have a matching installed version
-	if rawLocalVersion := m.getLocalVersionRaw(); rawLocalVersion != "" {
+	state.Method = "system-default"
+	if state.LocalRaw != "" {
 		installedVersions, err := m.ListInstalled()
 		if err != nil {
 			_logger.Verbose("Failed to list installed versions: %v", err)
 		}
 		if len(installedVersions) > 0 {
-			return "", fmt.Errorf("no installed version matches %s (from %s) - install a version with matching major.minor (e.g., 'govman install %s')",
-				rawLocalVersion, m.config.AutoSwitch.ProjectFile, rawLocalVersion)
+			return state, fmt.Errorf("local version %s specified in %s is %w: no installed version matches its major.minor (e.g., 'govman install %s')",
+				state.LocalRaw, state.LocalFile, ErrNotInstalled, state.LocalRaw)
 		}
-		return "", fmt.Errorf("local version %s specified in %s but no Go versions are installed - run 'govman install %s' to install it",
-			rawLocalVersion, m.config.AutoSwitch.ProjectFile, rawLocalVersion)
+		return state, fmt.Errorf("local version %s specified in %s is %w: no Go versions are installed - run 'govman install %s' to install it",
+			state.LocalRaw, state.LocalFile, ErrNotInstalled, state.LocalRaw)
 	}
 
-	version, err := m.CurrentGlobal()
-	if err != nil {
-		return "", err
+	if state.GlobalErr != nil {
+		return state, state.GlobalErr
 	}
-
-	return version, nil
+	state.Version = state.Global
+	return state, nil
 }
 
 // CurrentGlobal resolves the active global version from the symlink and validates installation integrity.
 // Returns the version or an error for missing/corrupt symlink or installation.
 func (m *Manager) CurrentGlobal() (string, error) {
-	symlinkPath := m.config.GetCurrentSymlink()
-
-	// On Windows, the symlink for the current go binary is created with .exe suffix.
-	// Mirror that here to check/read the correct path.
-	if runtime.GOOS == "windows" {
-		if !strings.HasSuffix(symlinkPath, ".exe") {
-			symlinkPath += ".exe"
-		}
-	}
+	symlinkPath := m.globalSymlinkPath()
 
-	linkInfo, err := os.Lstat(symlinkPath)
+	linkInfo, err := _symlink.Lstat(symlinkPath)
 	if err != nil {
 		if os.IsNotExist(err) {
 			if m.config.DefaultVersion != "" {
@@ -231,8 +1002,8 @@ func (m *Manager) CurrentGlobal() (string, error) {
 					return "", fmt.Errorf("no active Go version found - default version %s is configured but symlink is missing. Run 'govman use %s' to activate it",
 						m.config.DefaultVersion, m.config.DefaultVersion)
 				} else {
-					return "", fmt.Errorf("no active Go version found - default version %s is configured but not installed. Run 'govman install %s' first, then 'govman use %s'",
-						m.config.DefaultVersion, m.config.DefaultVersion, m.config.DefaultVersion)
+					return "", fmt.Errorf("no active Go version found - default version %s is configured but %w. Run 'govman install %s' first, then 'govman use %s'",
+						m.config.DefaultVersion, ErrNotInstalled, m.config.DefaultVersion, m.config.DefaultVersion)
 				}
 			}
 
@@ -244,24 +1015,29 @@ func (m *Manager) CurrentGlobal() (string, error) {
 			symlinkPath, err)
 	}
 
+	var version string
 	if linkInfo.Mode()&os.ModeSymlink == 0 {
-		return "", fmt.Errorf("expected symlink at %s but found %s instead - this may indicate a corrupted govman installation. Try running 'govman use <version>' to recreate the symlink",
-			symlinkPath, linkInfo.Mode().Type().String())
-	}
-
-	target, err := os.Readlink(symlinkPath)
-	if err != nil {
-		return "", fmt.Errorf("failed to read symlink target from %s: %w - the symlink may be corrupted",
-			symlinkPath, err)
-	}
+		// Without symlink support the binary is a shim and the version lives in a sidecar file
+		version = m.readActiveVersionFile()
+		if version == "" {
+			return "", fmt.Errorf("expected symlink at %s but found %s instead - this may indicate a corrupted govman installation. Try running 'govman use <version>' to recreate the symlink",
+				symlinkPath, linkInfo.Mode().Type().String())
+		}
+	} else {
+		target, err := os.Readlink(symlinkPath)
+		if err != nil {
+			return "", fmt.Errorf("failed to read symlink target from %s: %w - the symlink may be corrupted",
+				symlinkPath, err)
+		}
 
-	// Use regex to extract version from the symlink target path
-	// This is more robust than path manipulation across platforms
-	matches := _golang.VersionExtractRegex.FindStringSubmatch(target)
-	if len(matches) < 2 {
-		return "", fmt.Errorf("could not extract version from symlink target: %s - the symlink may be corrupted", target)
+		// Use regex to extract version from the symlink target path, which is always the version's bin/go whatever the
+		// link is named. This is more robust than path manipulation across platforms
+		matches := _golang.VersionExtractRegex.FindStringSubmatch(target)
+		if len(matches) < 2 {
+			return "", fmt.Errorf("could not extract version from symlink target: %s - the symlink may be corrupted", target)
+		}
+		version = matches[1]
 	}
-	version := matches[1]
 
 	expectedVersionDir := m.config.GetVersionDir(version)
 	if _, err := os.Stat(expectedVersionDir); err != nil {
@@ -293,6 +1069,7 @@ func (m *Manager) CurrentGlobal() (string, error) {
 }
 
 // ListInstalled returns installed Go versions sorted in descending order.
+// Directories whose name is not "go" followed by a version, such as "gopls-cache", are skipped.
 // Returns the slice of versions or an error if the install directory cannot be read.
 func (m *Manager) ListInstalled() ([]string, error) {
 	entries, err := os.ReadDir(m.config.InstallDir)
@@ -306,25 +1083,161 @@ func (m *Manager) ListInstalled() ([]string, error) {
 
 	var versions []string
 	for _, entry := range entries {
-		if entry.IsDir() && strings.HasPrefix(entry.Name(), "go") {
-			version := entry.Name()[2:]
-			versions = append(versions, version)
+		if !entry.IsDir() || !strings.HasPrefix(entry.Name(), "go") {
+			continue
+		}
+
+		version := entry.Name()[2:]
+		if !installedVersionRegex.MatchString(version) {
+			_logger.Verbose("Ignoring %s in install directory: not a Go version", entry.Name())
+			continue
 		}
+		versions = append(versions, version)
 	}
 
-	sort.Slice(versions, func(i, j int) bool {
-		return _golang.CompareVersions(versions[i], versions[j]) > 0
-	})
+	_golang.SortVersions(versions, false)
 
 	return versions, nil
 }
 
+// ListInstalledWithMeta returns installed Go versions with their tags, sorted like ListInstalled.
+// Returns an error if the install directory cannot be read.
+func (m *Manager) ListInstalledWithMeta() ([]InstalledVersion, error) {
+	versions, err := m.ListInstalled()
+	if err != nil {
+		return nil, err
+	}
+
+	installed := make([]InstalledVersion, 0, len(versions))
+	for _, version := range versions {
+		installed = append(installed, InstalledVersion{Version: version, Tag: m.Tag(version)})
+	}
+	return installed, nil
+}
+
+// Tag returns the label attached to an installed version, or an empty string if it has none.
+func (m *Manager) Tag(version string) string {
+	data, err := os.ReadFile(filepath.Join(m.config.GetVersionDir(version), tagFile))
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(data))
+}
+
+// SetTag attaches a free-text label to an installed version; an empty tag removes the label.
+// The tag lives in the version directory, so it is removed together with the version.
+// Returns an error if the version is not installed, the tag is invalid, or the file cannot be written.
+func (m *Manager) SetTag(version, tag string) error {
+	if !m.IsInstalled(version) {
+		return fmt.Errorf("go version %s is %w", version, ErrNotInstalled)
+	}
+
+	path := filepath.Join(m.config.GetVersionDir(version), tagFile)
+	tag = strings.TrimSpace(tag)
+	if tag == "" {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to remove tag: %w", err)
+		}
+		return nil
+	}
+
+	if err := validateTag(tag); err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, []byte(tag+"\n"), 0644); err != nil {
+		return fmt.Errorf("failed to write tag: %w", err)
+	}
+	return nil
+}
+
+// validateTag rejects tags that span lines or exceed maxTagLength.
+func validateTag(tag string) error {
+	if strings.ContainsAny(tag, "\r\n") {
+		return fmt.Errorf("tag must be a single line")
+	}
+	if len(tag) > maxTagLength {
+		return fmt.Errorf("tag is longer than %d characters", maxTagLength)
+	}
+	return nil
+}
+
 // ListRemote fetches available remote Go versions.
 // includeUnstable controls inclusion of beta/rc versions. Returns the list or an error.
+// The result is also written to the cache directory for CachedRemoteVersions.
 func (m *Manager) ListRemote(includeUnstable bool) ([]string, error) {
-	return _golang.GetAvailableVersionsWithConfig(includeUnstable,
-		m.config.GoReleases.APIURL,
-		m.config.GoReleases.CacheExpiry)
+	return m.listRemote(context.Background(), includeUnstable)
+}
+
+// listRemote implements ListRemote with a context that cancels the release source request.
+// The full list, prereleases included, is cached whatever includeUnstable is.
+func (m *Manager) listRemote(ctx context.Context, includeUnstable bool) ([]string, error) {
+	versions, err := m.releaseSource().AvailableVersions(ctx, true)
+	if err != nil {
+		return nil, err
+	}
+
+	m.saveRemoteVersions(versions)
+	return filterUnstable(versions, includeUnstable), nil
+}
+
+// ListRemoteAll is like ListRemote but follows the release API's pagination to return the complete history.
+// Sources without pagination return the same list as ListRemote. Returns the list or an error.
+func (m *Manager) ListRemoteAll(includeUnstable bool) ([]string, error) {
+	source := m.releaseSource()
+	var versions []string
+	var err error
+	if paged, ok := source.(_golang.PagedSource); ok {
+		versions, err = paged.AllAvailableVersions(context.Background(), true)
+	} else {
+		versions, err = source.AvailableVersions(context.Background(), true)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	m.saveRemoteVersions(versions)
+	return filterUnstable(versions, includeUnstable), nil
+}
+
+// filterUnstable returns versions without prereleases unless includeUnstable is set.
+func filterUnstable(versions []string, includeUnstable bool) []string {
+	if includeUnstable {
+		return versions
+	}
+	return slices.DeleteFunc(slices.Clone(versions), _util.IsPrereleaseVersion)
+}
+
+// saveRemoteVersions records versions in the cache directory for CachedRemoteVersions.
+// Failures are logged verbosely and otherwise ignored.
+func (m *Manager) saveRemoteVersions(versions []string) {
+	cachePath := filepath.Join(m.config.CacheDir, remoteCacheFile)
+	if err := saveRemoteCache(cachePath, remoteVersionsCache{FetchedAt: time.Now(), Versions: versions}); err != nil {
+		_logger.Verbose("Failed to save remote version cache: %v", err)
+	}
+}
+
+// CachedRemoteVersions returns the remote versions last fetched by ListRemote, prereleases included, without touching
+// the network.
+// Returns nil if nothing is cached or the cache is older than the configured release cache expiry.
+func (m *Manager) CachedRemoteVersions() []string {
+	cache, ok := m.loadRemoteVersions()
+	if !ok || time.Since(cache.FetchedAt) > m.config.GoReleases.CacheExpiry {
+		return nil
+	}
+
+	return cache.Versions
+}
+
+// loadRemoteVersions reads the remote versions last saved by saveRemoteVersions, however old.
+// Returns false if nothing is cached or the cache is unreadable.
+func (m *Manager) loadRemoteVersions() (remoteVersionsCache, bool) {
+	var cache remoteVersionsCache
+	data, err := os.ReadFile(filepath.Join(m.config.CacheDir, remoteCacheFile))
+	if err != nil || json.Unmarshal(data, &cache) != nil || len(cache.Versions) == 0 {
+		return remoteVersionsCache{}, false
+	}
+
+	return cache, true
 }
 
 // IsInstalled reports whether a given version is installed by checking its directory.
@@ -340,46 +1253,224 @@ func (m *Manager) IsInstalled(version string) bool {
 // Returns VersionInfo or an error if the version is not installed or info retrieval fails.
 func (m *Manager) Info(version string) (*_golang.VersionInfo, error) {
 	if !m.IsInstalled(version) {
-		return nil, fmt.Errorf("go version %s is not installed", version)
+		return nil, fmt.Errorf("go version %s is %w", version, ErrNotInstalled)
 	}
 
 	installDir := m.config.GetVersionDir(version)
 	return _golang.GetVersionInfo(installDir)
 }
 
+// RemoteInfo describes the downloadable archive for a version without installing it.
+// version may be an alias, partial version, or constraint. Returns RemoteInfo or an error if it cannot be resolved.
+func (m *Manager) RemoteInfo(version string) (*_golang.RemoteInfo, error) {
+	resolvedVersion, err := m.ResolveVersion(version)
+	if err != nil {
+		return nil, err
+	}
+
+	return _golang.GetRemoteInfo(context.Background(), m.releaseSource(), resolvedVersion)
+}
+
+// Stat returns metadata about an installed version without computing its disk usage.
+// Returns VersionInfo with a zero Size or an error if the version is not installed.
+func (m *Manager) Stat(version string) (*_golang.VersionInfo, error) {
+	if !m.IsInstalled(version) {
+		return nil, fmt.Errorf("go version %s is %w", version, ErrNotInstalled)
+	}
+
+	installDir := m.config.GetVersionDir(version)
+	return _golang.StatVersion(installDir)
+}
+
+// saveRemoteCache writes the remote version list cache to path, creating its directory if needed.
+// Returns an error if the cache cannot be written.
+func saveRemoteCache(path string, cache remoteVersionsCache) error {
+	data, err := json.Marshal(cache)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, data, 0644)
+}
+
 // Clean removes and recreates the cache directory.
 // Returns an error if cleanup fails; nil on success.
 func (m *Manager) Clean() error {
 	if err := os.RemoveAll(m.config.CacheDir); err != nil {
-		return fmt.Errorf("failed to clean cache: %w", err)
+		return _util.WithPermissionHint(fmt.Errorf("failed to clean cache: %w", err), m.config.CacheDir)
 	}
 
 	if err := os.MkdirAll(m.config.CacheDir, 0755); err != nil {
-		return fmt.Errorf("failed to recreate cache directory: %w", err)
+		return _util.WithPermissionHint(fmt.Errorf("failed to recreate cache directory: %w", err), filepath.Dir(m.config.CacheDir))
 	}
 
 	_logger.Success("Cache cleaned successfully")
 	return nil
 }
 
-// ResolveVersion resolves aliases and partial versions to a concrete version.
-// "latest" becomes the newest stable; "major.minor" expands to the latest patch. Returns the resolved version or an error.
+// CacheEntry describes a file or directory at the top level of the cache directory.
+type CacheEntry struct {
+	Name    string
+	Path    string
+	Size    int64
+	ModTime time.Time
+}
+
+// CacheEntries lists the top-level entries of the cache directory, oldest first.
+// Directory sizes include their contents. Returns an empty list if the cache does not exist.
+func (m *Manager) CacheEntries() ([]CacheEntry, error) {
+	dirEntries, err := os.ReadDir(m.config.CacheDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read cache directory: %w", err)
+	}
+
+	entries := make([]CacheEntry, 0, len(dirEntries))
+	for _, dirEntry := range dirEntries {
+		info, err := dirEntry.Info()
+		if err != nil {
+			continue
+		}
+
+		path := filepath.Join(m.config.CacheDir, dirEntry.Name())
+		size := info.Size()
+		if info.IsDir() {
+			if size, err = _golang.DirSize(path); err != nil {
+				continue
+			}
+		}
+
+		entries = append(entries, CacheEntry{
+			Name:    dirEntry.Name(),
+			Path:    path,
+			Size:    size,
+			ModTime: info.ModTime(),
+		})
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].ModTime.Before(entries[j].ModTime)
+	})
+
+	return entries, nil
+}
+
+// CleanSelective removes cache entries last modified more than olderThan ago; a zero olderThan matches every entry.
+// With dryRun nothing is deleted. Returns the matching entries, the bytes they occupy, and an error if any removal fails.
+func (m *Manager) CleanSelective(olderThan time.Duration, dryRun bool) ([]CacheEntry, int64, error) {
+	entries, err := m.CacheEntries()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	cutoff := time.Now().Add(-olderThan)
+	var (
+		matched  []CacheEntry
+		freed    int64
+		failures []string
+	)
+	for _, entry := range entries {
+		if olderThan > 0 && !entry.ModTime.Before(cutoff) {
+			continue
+		}
+
+		if !dryRun {
+			if err := os.RemoveAll(entry.Path); err != nil {
+				failures = append(failures, fmt.Sprintf("%s: %v", entry.Name, err))
+				continue
+			}
+		}
+
+		matched = append(matched, entry)
+		freed += entry.Size
+	}
+
+	if len(failures) > 0 {
+		return matched, freed, fmt.Errorf("failed to remove %d cache entries: %s", len(failures), strings.Join(failures, "; "))
+	}
+
+	return matched, freed, nil
+}
+
+// ResolveVersion resolves aliases, partial versions, and constraints to a concrete version.
+// "latest" becomes the newest stable; "1" becomes the newest stable 1.x; "major.minor" expands to the latest patch; constraints such as "^1.22" or
+// ">=1.21 <1.23" select the highest matching stable release. Returns the resolved version or an error.
 func (m *Manager) ResolveVersion(version string) (string, error) {
+	return m.resolveVersion(context.Background(), version)
+}
+
+// resolveVersion implements ResolveVersion with a context that cancels release API requests.
+// The result is spelled like an existing installation of the same release, so "1.22.0" never gets a second
+// directory next to "go1.22".
+func (m *Manager) resolveVersion(ctx context.Context, version string) (string, error) {
+	resolved, err := m.resolveRelease(ctx, version)
+	if err != nil {
+		if m.config.Offline && errors.Is(err, ErrVersionNotFound) {
+			return "", fmt.Errorf("%w (offline mode only considers installed versions)", err)
+		}
+		return "", err
+	}
+	return m.installedSpelling(resolved), nil
+}
+
+// resolveRelease maps version to a concrete release, consulting the release API for aliases, partial versions,
+// and constraints, or only the installed versions in offline mode. Returns the release or an error.
+func (m *Manager) resolveRelease(ctx context.Context, version string) (string, error) {
+	if _util.IsVersionConstraint(version) {
+		if _, err := _util.ParseVersionConstraint(version); err != nil {
+			return "", err
+		}
+
+		versions, err := m.releaseCandidates(ctx, false)
+		if err != nil {
+			return "", err
+		}
+
+		resolved, err := _util.ResolveVersionConstraint(version, versions)
+		if err != nil {
+			return "", fmt.Errorf("%w: %v", ErrVersionNotFound, err)
+		}
+
+		_logger.Verbose("Resolved constraint %s to Go %s", version, resolved)
+		return resolved, nil
+	}
+
 	if version == "latest" || version == "stable" {
-		versions, err := m.ListRemote(false)
+		versions, err := m.releaseCandidates(ctx, false)
 		if err != nil {
 			return "", err
 		}
 
 		if len(versions) == 0 {
-			return "", fmt.Errorf("no stable versions available")
+			return "", fmt.Errorf("%w: no stable versions available", ErrVersionNotFound)
 		}
 
 		return versions[0], nil
 	}
 
+	if majorOnlyRegex.MatchString(version) {
+		versions, err := m.releaseCandidates(ctx, false)
+		if err != nil {
+			return "", err
+		}
+
+		prefix := version + "."
+		for _, v := range versions {
+			// The prefix keeps "1" from matching "10.x"; prereleases are skipped even if marked stable
+			if strings.HasPrefix(v, prefix) && !_util.IsPrereleaseVersion(v) {
+				return v, nil
+			}
+		}
+		return "", fmt.Errorf("%w: no stable release found for Go %s.x", ErrVersionNotFound, version)
+	}
+
 	if strings.Count(version, ".") == 1 {
-		versions, err := m.ListRemote(true)
+		versions, err := m.releaseCandidates(ctx, true)
 		if err != nil {
 			return "", err
 		}
@@ -390,94 +1481,128 @@ func (m *Manager) ResolveVersion(version string) (string, error) {
 				return v, nil
 			}
 		}
-		return "", fmt.Errorf("no patch version found for %s", version)
+		return "", fmt.Errorf("%w: no patch version found for %s", ErrVersionNotFound, version)
 	}
 
 	return version, nil
 }
 
-// createSymlink creates/replaces the global "go" symlink targeting the selected version's binary.
-// Returns an error if directory creation or symlink operation fails.
-func (m *Manager) createSymlink(version string) error {
-	versionRoot := m.config.GetVersionDir(version)
-
-	goExecutablePath := filepath.Join(versionRoot, "bin", "go")
-
-	if runtime.GOOS == "windows" {
-		goExecutablePath += ".exe"
+// releaseCandidates returns the versions resolveRelease chooses from, newest first: the remote releases, or the
+// installed versions in offline mode. Prereleases are left out unless includeUnstable is set.
+func (m *Manager) releaseCandidates(ctx context.Context, includeUnstable bool) ([]string, error) {
+	if !m.config.Offline {
+		return m.resilientListRemote(ctx, includeUnstable)
 	}
 
-	symlinkPath := m.config.GetCurrentSymlink()
-
-	if runtime.GOOS == "windows" {
-		symlinkPath += ".exe"
+	installed, err := m.ListInstalled()
+	if err != nil {
+		return nil, err
 	}
+	return filterUnstable(installed, includeUnstable), nil
+}
 
-	binDir := m.config.GetBinPath()
-	if err := os.MkdirAll(binDir, 0755); err != nil {
-		return fmt.Errorf("failed to create bin directory: %w", err)
-	}
+// resilientListRemote is like listRemote but retries network failures up to download.retry_count attempts with the
+// download backoff, except for rate limits, which a retry cannot fix. If the release source stays unreachable it falls
+// back to the list saved by the last successful lookup, however old. Returns an error only when neither is available.
+func (m *Manager) resilientListRemote(ctx context.Context, includeUnstable bool) ([]string, error) {
+	attempts := max(m.config.Download.RetryCount, 1)
+
+	var err error
+	for attempt := 1; ; attempt++ {
+		var versions []string
+		if versions, err = m.listRemote(ctx, includeUnstable); err == nil {
+			return versions, nil
+		}
 
-	// Remove the old symlink if it exists
-	if err := os.Remove(symlinkPath); err != nil && !os.IsNotExist(err) {
-		return fmt.Errorf("failed to remove existing symlink: %w", err)
+		var rateErr *_golang.RateLimitError
+		if attempt >= attempts || ctx.Err() != nil || !errors.Is(err, _golang.ErrNetwork) || errors.As(err, &rateErr) {
+			break
+		}
+		_logger.Verbose("Fetching the release list failed (attempt %d/%d): %v", attempt, attempts, err)
+		if waitErr := m.downloader.Backoff(ctx, attempt); waitErr != nil {
+			return nil, waitErr
+		}
+	}
+	if ctx.Err() != nil {
+		return nil, err
 	}
 
-	if err := _symlink.Create(goExecutablePath, symlinkPath); err != nil {
-		return fmt.Errorf("failed to create symlink: %w", err)
+	cache, ok := m.loadRemoteVersions()
+	if !ok {
+		return m.embeddedVersions(ctx, includeUnstable, err)
 	}
 
-	return nil
+	_logger.Verbose("Could not fetch the release list (%v); using the cached list from %s", err, cache.FetchedAt.Format("2006-01-02 15:04"))
+	return filterUnstable(cache.Versions, includeUnstable), nil
 }
 
-// setLocalVersion writes the project's autoswitch file with the specified version.
-// Returns an error if the file write fails.
-func (m *Manager) setLocalVersion(version string) error {
-	filename := m.config.AutoSwitch.ProjectFile
-	return os.WriteFile(filename, []byte(version), 0644)
-}
+// embeddedVersions returns the built-in release list of a source that has one, after lookupErr left no live or saved
+// list, with a warning that it may be outdated. Returns lookupErr for sources without a built-in list.
+func (m *Manager) embeddedVersions(ctx context.Context, includeUnstable bool, lookupErr error) ([]string, error) {
+	fallback, ok := m.releaseSource().(*_golang.FallbackSource)
+	if !ok {
+		return nil, lookupErr
+	}
 
-// getLocalVersionRaw reads the project's autoswitch file and returns the raw version string.
-// Returns an empty string if the file does not exist or cannot be read.
-func (m *Manager) getLocalVersionRaw() string {
-	filename := m.config.AutoSwitch.ProjectFile
-	data, err := os.ReadFile(filename)
+	versions, err := fallback.Fallback.AvailableVersions(ctx, includeUnstable)
 	if err != nil {
-		return ""
+		return nil, lookupErr
 	}
 
-	return strings.TrimSpace(string(data))
+	_, generated := _golang.EmbeddedVersions()
+	_logger.Warning("Could not fetch the release list (%v); using the list built into govman on %s, which may be outdated", lookupErr, generated)
+	return versions, nil
 }
 
-// GetLocalVersionRaw returns the raw version string from the project's autoswitch file.
-// Returns an empty string if the file does not exist or cannot be read.
-func (m *Manager) GetLocalVersionRaw() string {
-	return m.getLocalVersionRaw()
-}
+// installedSpelling returns the spelling of version that is already installed when "X.Y" and "X.Y.0" name the
+// same release, so both share one directory. When both directories exist it warns and points to the canonical one.
+// Returns version unchanged if it has no other spelling or that spelling is not installed.
+func (m *Manager) installedSpelling(version string) string {
+	alternate, canonical := releaseSpellings(version)
+	if alternate == "" {
+		return version
+	}
 
-// getLocalVersion reads the project's autoswitch file and returns the best matching installed version.
-// It uses flexible version matching based on major.minor version (e.g., "1.25" matches "1.25.4").
-// Returns an empty string if the file does not exist or no matching version is installed.
-func (m *Manager) getLocalVersion() string {
-	rawVersion := m.getLocalVersionRaw()
-	if rawVersion == "" {
-		return ""
+	installed, alternateInstalled := m.IsInstalled(version), m.IsInstalled(alternate)
+	switch {
+	case installed && alternateInstalled:
+		duplicate := version
+		if duplicate == canonical {
+			duplicate = alternate
+		}
+		_logger.Warning("Go %s and %s are the same release installed twice; %s is the canonical directory. Remove the duplicate with 'govman uninstall %s'",
+			version, alternate, m.config.GetVersionDir(canonical), duplicate)
+		return canonical
+	case alternateInstalled:
+		_logger.Verbose("Go %s is installed as %s", version, alternate)
+		return alternate
+	default:
+		return version
 	}
+}
 
-	// Get all installed versions
-	installedVersions, err := m.ListInstalled()
-	if err != nil || len(installedVersions) == 0 {
-		return ""
+// releaseSpellings returns the other spelling of a line's first release ("1.22" for "1.22.0" and vice versa) and
+// the canonical one, matching upstream tags: "1.21.0" and later include the patch, "1.20" and earlier do not.
+// Returns empty strings for any other version.
+func releaseSpellings(version string) (alternate, canonical string) {
+	matches := firstReleaseRegex.FindStringSubmatch(version)
+	if matches == nil {
+		return "", ""
 	}
 
-	// Find a matching version based on major.minor
-	matchedVersion, err := _util.FindBestMatchingVersion(rawVersion, installedVersions)
-	if err != nil {
-		// No matching version found, return empty string
-		return ""
+	bare := matches[1] + "." + matches[2]
+	withPatch := bare + ".0"
+	alternate = withPatch
+	if matches[3] != "" {
+		alternate = bare
 	}
 
-	return matchedVersion
+	major, _ := strconv.Atoi(matches[1])
+	minor, _ := strconv.Atoi(matches[2])
+	if major > 1 || (major == 1 && minor >= 21) {
+		return alternate, withPatch
+	}
+	return alternate, bare
 }
 
 // DefaultVersion returns the configured default version string.
@@ -487,47 +1612,117 @@ func (m *Manager) DefaultVersion() string {
 
 // CurrentActivationMethod returns the activation method for the currently active Go version.
 // Returns "session-only", "project-local", or "system-default" based on how the current version is activated.
+// Use CurrentDetailed to get the version and its method from the same snapshot.
 func (m *Manager) CurrentActivationMethod() string {
-	sessionVersion, err := m.getCurrentSessionVersion()
-	if err == nil && sessionVersion != "" {
-		if localVersion := m.getLocalVersion(); localVersion != "" && localVersion == sessionVersion {
-			return "project-local"
+	state, _ := m.CurrentDetailed()
+	return state.Method
+}
+
+// getCurrentSessionVersion executes "go version" and parses the active version.
+// With useCache, a result memoized for the same resolved go binary (path, size, and modtime) is reused until it expires,
+// so switching the symlink target invalidates it. Returns the version string or an error if execution or parsing fails.
+func (m *Manager) getCurrentSessionVersion(useCache bool) (string, error) {
+	goPath, resolved, err := lookPathGo(m.config.GetBinaryName())
+	if err != nil {
+		return "", fmt.Errorf("failed to execute 'go version': %w", err)
+	}
+	info, statErr := os.Stat(resolved)
+
+	if useCache && statErr == nil {
+		sessionVersionMu.Lock()
+		entry, ok := sessionVersionCache[resolved]
+		sessionVersionMu.Unlock()
+		if ok && time.Now().Before(entry.expires) && entry.modTime.Equal(info.ModTime()) && entry.size == info.Size() {
+			return entry.version, nil
 		}
+	}
+
+	version, err := runGoVersion(goPath)
+	if err != nil {
+		return "", err
+	}
 
-		globalVersion, err := m.CurrentGlobal()
-		if err == nil && globalVersion == sessionVersion {
-			return "system-default"
+	if statErr == nil {
+		sessionVersionMu.Lock()
+		sessionVersionCache[resolved] = sessionVersionEntry{
+			modTime: info.ModTime(),
+			size:    info.Size(),
+			version: version,
+			expires: time.Now().Add(sessionVersionTTL),
 		}
+		sessionVersionMu.Unlock()
+	}
+
+	return version, nil
+}
 
-		return "session-only"
+// lookPathGo returns the go binary PATH resolves to for the command name and that path with symlinks evaluated,
+// or the same path twice when they cannot be. Returns an error if there is no such command on PATH.
+func lookPathGo(name string) (goPath, resolved string, err error) {
+	goPath, err = exec.LookPath(name)
+	if err != nil {
+		return "", "", err
 	}
 
-	if localVersion := m.getLocalVersion(); localVersion != "" {
-		return "project-local"
+	resolved, err = filepath.EvalSymlinks(goPath)
+	if err != nil {
+		resolved = goPath
+	}
+	return goPath, resolved, nil
+}
+
+// GoOnPath returns the go binary PATH resolves to for binary_name and whether it is govman's: the global link in the
+// bin directory or the binary of an installed version. A go that is not govman's, such as a system or Homebrew install
+// earlier on PATH, shadows every version govman activates. Returns an error if there is no such command on PATH.
+func (m *Manager) GoOnPath() (string, bool, error) {
+	goPath, resolved, err := lookPathGo(m.config.GetBinaryName())
+	if err != nil {
+		return "", false, err
 	}
 
-	return "system-default"
+	if filepath.Clean(filepath.Dir(goPath)) == filepath.Clean(m.config.GetBinPath()) {
+		return goPath, true, nil
+	}
+
+	installDir := m.config.InstallDir
+	if evaluated, err := filepath.EvalSymlinks(installDir); err == nil {
+		installDir = evaluated
+	}
+	rel, err := filepath.Rel(installDir, resolved)
+	managed := err == nil && rel != "." && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+	return goPath, managed, nil
 }
 
-// getCurrentSessionVersion executes "go version" and parses the active version.
+// resetSessionVersionCache discards every memoized 'go version' result.
+func resetSessionVersionCache() {
+	sessionVersionMu.Lock()
+	clear(sessionVersionCache)
+	sessionVersionMu.Unlock()
+}
+
+// runGoVersion executes "<goPath> version" and parses the version it reports.
 // Returns the version string or an error if command execution or parsing fails.
-func (m *Manager) getCurrentSessionVersion() (string, error) {
-	cmd := exec.Command("go", "version")
+func runGoVersion(goPath string) (string, error) {
+	cmd := exec.Command(goPath, "version")
 	output, err := cmd.Output()
 	if err != nil {
 		return "", fmt.Errorf("failed to execute 'go version': %w", err)
 	}
 
-	versionStr := strings.TrimSpace(string(output))
-	parts := strings.Split(versionStr, " ")
-	if len(parts) < 3 {
-		return "", fmt.Errorf("unexpected 'go version' output format: %s", versionStr)
-	}
+	return parseGoVersionOutput(string(output))
+}
 
-	version := strings.TrimPrefix(parts[2], "go")
-	if version == "" {
-		return "", fmt.Errorf("could not extract version from 'go version' output: %s", versionStr)
+// parseGoVersionOutput extracts the version from 'go version' output such as "go version go1.25.1 linux/amd64".
+// The first field that starts with a goX.Y token wins, so extra whitespace, pre-releases, and devel builds
+// ("go version devel go1.23-abcdef ...") are handled. Returns an error if no version is present.
+func parseGoVersionOutput(output string) (string, error) {
+	versionStr := strings.TrimSpace(output)
+	for _, field := range strings.Fields(versionStr) {
+		match := _golang.VersionExtractRegex.FindStringSubmatchIndex(field)
+		if match != nil && match[0] == 0 {
+			return field[match[2]:match[3]], nil
+		}
 	}
 
-	return version, nil
+	return "", fmt.Errorf("could not extract version from 'go version' output: %s", versionStr)
 }
@@ -1,17 +1,32 @@
 package manager
 
 import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"errors"
 	"fmt"
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"reflect"
 	"runtime"
+	"slices"
 	"strings"
+	"syscall"
 	"testing"
+	"time"
 
 	_config "github.com/justjundana/govman/internal/config"
 	_downloader "github.com/justjundana/govman/internal/downloader"
 	_golang "github.com/justjundana/govman/internal/golang"
+	_shell "github.com/justjundana/govman/internal/shell"
+	_util "github.com/justjundana/govman/internal/util"
 )
 
 // mockShell implements Shell interface for testing
@@ -22,6 +37,7 @@ type mockShell struct {
 	pathCommand  string
 	setupCommand []string
 	available    bool
+	executed     []string
 }
 
 func (m *mockShell) Name() string {
@@ -49,6 +65,7 @@ func (m *mockShell) IsAvailable() bool {
 }
 
 func (m *mockShell) ExecutePathCommand(path string) error {
+	m.executed = append(m.executed, path)
 	fmt.Printf(`export PATH="%s:$PATH"`+"\n", path)
 	return nil
 }
@@ -77,6 +94,7 @@ func createTestConfig(t *testing.T) *_config.Config {
 		AutoSwitch: _config.AutoSwitchConfig{
 			ProjectFile: filepath.Join(tempDir, ".govman-goversion"),
 		},
+		Shell: _config.ShellConfig{PinToolchain: true},
 	}
 
 	// Create directories
@@ -91,6 +109,10 @@ func createTestConfig(t *testing.T) *_config.Config {
 }
 
 func createTestManager(t *testing.T, config *_config.Config) *Manager {
+	// Tests swap fake go binaries on PATH, so never reuse a result memoized by an earlier test
+	resetSessionVersionCache()
+	t.Cleanup(resetSessionVersionCache)
+
 	return &Manager{
 		config:     config,
 		downloader: _downloader.New(config),
@@ -223,6 +245,17 @@ func TestManager_ListInstalled(t *testing.T) {
 			want:    []string{"1.21.0", "1.20.0", "1.19.0"},
 			wantErr: false,
 		},
+		{
+			name: "stable and prerelease versions installed",
+			setup: func(c *_config.Config) {
+				versions := []string{"1.22rc2", "1.21.13", "1.22.0", "1.22beta1", "1.22rc10"}
+				for _, v := range versions {
+					os.MkdirAll(c.GetVersionDir(v), 0755)
+				}
+			},
+			want:    []string{"1.22.0", "1.22rc10", "1.22rc2", "1.22beta1", "1.21.13"},
+			wantErr: false,
+		},
 		{
 			name: "install directory read error",
 			setup: func(c *_config.Config) {
@@ -245,6 +278,16 @@ func TestManager_ListInstalled(t *testing.T) {
 			want:    []string{"1.20.0"},
 			wantErr: false,
 		},
+		{
+			name: "non-version directories starting with go",
+			setup: func(c *_config.Config) {
+				for _, name := range []string{"go1.21.0", "golang-misc", "gopls-cache", "go", "go1", "go1.20.0-old"} {
+					os.MkdirAll(filepath.Join(c.InstallDir, name), 0755)
+				}
+			},
+			want:    []string{"1.21.0"},
+			wantErr: false,
+		},
 	}
 
 	for _, tt := range tests {
@@ -481,6 +524,41 @@ func TestManager_CurrentGlobal(t *testing.T) {
 			want:    "",
 			wantErr: true,
 		},
+		{
+			name: "copied binary with active version file",
+			setup: func(c *_config.Config) {
+				version := "1.20.0"
+				versionDir := c.GetVersionDir(version)
+				os.MkdirAll(filepath.Join(versionDir, "bin"), 0755)
+
+				goPath := filepath.Join(versionDir, "bin", "go")
+				symlinkPath := c.GetCurrentSymlink()
+				if runtime.GOOS == "windows" {
+					goPath += ".exe"
+					symlinkPath += ".exe"
+				}
+				os.WriteFile(goPath, []byte("binary"), 0755)
+
+				// Copy fallback used when symlinks are unavailable
+				os.WriteFile(symlinkPath, []byte("binary"), 0755)
+				os.WriteFile(filepath.Join(c.GetBinPath(), activeVersionFile), []byte(version+"\n"), 0644)
+			},
+			want:    "1.20.0",
+			wantErr: false,
+		},
+		{
+			name: "copied binary with active version file for removed version",
+			setup: func(c *_config.Config) {
+				symlinkPath := c.GetCurrentSymlink()
+				if runtime.GOOS == "windows" {
+					symlinkPath += ".exe"
+				}
+				os.WriteFile(symlinkPath, []byte("binary"), 0755)
+				os.WriteFile(filepath.Join(c.GetBinPath(), activeVersionFile), []byte("1.20.0\n"), 0644)
+			},
+			want:    "",
+			wantErr: true,
+		},
 		{
 			name: "symlink target format invalid",
 			setup: func(c *_config.Config) {
@@ -566,6 +644,521 @@ func TestManager_CurrentGlobal(t *testing.T) {
 	}
 }
 
+func TestManager_CreateSymlink_ClearsActiveVersionFile(t *testing.T) {
+	config := createTestConfig(t)
+	manager := createTestManager(t, config)
+
+	version := "1.20.0"
+	goPath := filepath.Join(config.GetVersionDir(version), "bin", "go")
+	if runtime.GOOS == "windows" {
+		goPath += ".exe"
+	}
+	os.MkdirAll(filepath.Dir(goPath), 0755)
+	os.WriteFile(goPath, []byte("binary"), 0755)
+
+	// Left over from an earlier activation that had to copy the binary
+	activeFile := filepath.Join(config.GetBinPath(), activeVersionFile)
+	os.MkdirAll(config.GetBinPath(), 0755)
+	os.WriteFile(activeFile, []byte("1.19.0\n"), 0644)
+
+	if err := manager.createSymlink(version); err != nil {
+		t.Fatalf("createSymlink() error = %v", err)
+	}
+	if _, err := os.Stat(activeFile); !os.IsNotExist(err) {
+		t.Errorf("expected %s to be removed after creating a real symlink", activeVersionFile)
+	}
+
+	got, err := manager.CurrentGlobal()
+	if err != nil {
+		t.Fatalf("CurrentGlobal() error = %v", err)
+	}
+	if got != version {
+		t.Errorf("CurrentGlobal() = %v, want %v", got, version)
+	}
+}
+
+func TestManager_CurrentGlobal_RelocatedHome(t *testing.T) {
+	config := createTestConfig(t)
+	manager := createTestManager(t, config)
+	oldHome := os.Getenv("HOME")
+
+	version := "1.22.0"
+	goPath := filepath.Join(config.GetVersionDir(version), "bin", "go")
+	if runtime.GOOS == "windows" {
+		goPath += ".exe"
+	}
+	os.MkdirAll(filepath.Dir(goPath), 0755)
+	os.WriteFile(goPath, []byte("binary"), 0755)
+
+	if err := manager.createSymlink(version); err != nil {
+		t.Fatalf("createSymlink() error = %v", err)
+	}
+
+	symlinkPath := config.GetCurrentSymlink()
+	if runtime.GOOS == "windows" {
+		symlinkPath += ".exe"
+	}
+	target, err := os.Readlink(symlinkPath)
+	if err != nil {
+		t.Fatalf("os.Readlink() error = %v", err)
+	}
+	if filepath.IsAbs(target) {
+		t.Errorf("expected a relative symlink target, got %q", target)
+	}
+	if m := _golang.VersionExtractRegex.FindStringSubmatch(target); len(m) < 2 || m[1] != version {
+		t.Errorf("VersionExtractRegex did not extract %s from %q", version, target)
+	}
+
+	// Move the whole govman home somewhere else
+	newHome := filepath.Join(t.TempDir(), "moved")
+	if err := os.Rename(oldHome, newHome); err != nil {
+		t.Fatalf("failed to move home: %v", err)
+	}
+	t.Setenv("HOME", newHome)
+	config.InstallDir = filepath.Join(newHome, "versions")
+
+	got, err := manager.CurrentGlobal()
+	if err != nil {
+		t.Fatalf("CurrentGlobal() after relocation error = %v", err)
+	}
+	if got != version {
+		t.Errorf("CurrentGlobal() = %v, want %v", got, version)
+	}
+}
+
+func TestManager_CurrentGlobal_CustomBinaryName(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses shell scripts as fake go binaries")
+	}
+
+	config := createTestConfig(t)
+	config.BinaryName = "go2"
+	manager := createTestManager(t, config)
+
+	for _, version := range []string{"1.21.0", "1.22.0"} {
+		writeFakeGo(t, filepath.Join(config.GetVersionDir(version), "bin"), version)
+	}
+
+	for _, version := range []string{"1.21.0", "1.22.0"} {
+		if err := manager.createSymlink(version); err != nil {
+			t.Fatalf("createSymlink(%s) error = %v", version, err)
+		}
+
+		linkPath := filepath.Join(config.GetBinPath(), "go2")
+		target, err := os.Readlink(linkPath)
+		if err != nil {
+			t.Fatalf("os.Readlink(%s) error = %v", linkPath, err)
+		}
+		if filepath.Base(target) != "go" {
+			t.Errorf("go2 link target = %q, want the version's bin/go", target)
+		}
+
+		got, err := manager.CurrentGlobal()
+		if err != nil {
+			t.Fatalf("CurrentGlobal() error = %v", err)
+		}
+		if got != version {
+			t.Errorf("CurrentGlobal() = %s, want %s", got, version)
+		}
+	}
+
+	if _, err := os.Lstat(filepath.Join(config.GetBinPath(), "go")); !os.IsNotExist(err) {
+		t.Errorf("a go link should not exist beside go2: %v", err)
+	}
+
+	// A system go earlier on PATH does not hide the go2 command
+	systemGo := writeFakeGo(t, filepath.Join(t.TempDir(), "system"), "1.20.0")
+	t.Setenv("PATH", filepath.Dir(systemGo)+string(os.PathListSeparator)+config.GetBinPath())
+	goPath, managed, err := manager.GoOnPath()
+	if err != nil || !managed || goPath != filepath.Join(config.GetBinPath(), "go2") {
+		t.Errorf("GoOnPath() = %q, %v, %v, want the go2 link", goPath, managed, err)
+	}
+	if current, err := manager.CurrentUncached(); err != nil || current != "1.22.0" {
+		t.Errorf("CurrentUncached() = %q, %v, want 1.22.0 from go2", current, err)
+	}
+}
+
+func TestManager_createSymlink_RenamedBinary(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses shell scripts as fake go binaries")
+	}
+
+	config := createTestConfig(t)
+	manager := createTestManager(t, config)
+	binDir := config.GetBinPath()
+	writeFakeGo(t, filepath.Join(config.GetVersionDir("1.22.0"), "bin"), "1.22.0")
+
+	exists := func(name string) bool {
+		_, err := os.Lstat(filepath.Join(binDir, name))
+		return err == nil
+	}
+
+	if err := manager.createSymlink("1.22.0"); err != nil {
+		t.Fatalf("createSymlink() error = %v", err)
+	}
+	config.BinaryName = "go2"
+	if err := manager.createSymlink("1.22.0"); err != nil {
+		t.Fatalf("createSymlink() as go2 error = %v", err)
+	}
+	if exists("go") || !exists("go2") {
+		t.Errorf("after renaming to go2: go exists = %v, go2 exists = %v; want only go2", exists("go"), exists("go2"))
+	}
+
+	config.BinaryName = ""
+	if err := manager.createSymlink("1.22.0"); err != nil {
+		t.Fatalf("createSymlink() as go error = %v", err)
+	}
+	if !exists("go") || exists("go2") {
+		t.Errorf("after renaming back: go exists = %v, go2 exists = %v; want only go", exists("go"), exists("go2"))
+	}
+
+	// A go link from before the name was recorded is removed only when it points into a version directory
+	os.Remove(filepath.Join(binDir, globalLinkFile))
+	config.BinaryName = "go2"
+	if err := manager.createSymlink("1.22.0"); err != nil {
+		t.Fatalf("createSymlink() without a record error = %v", err)
+	}
+	if exists("go") {
+		t.Error("an unrecorded go link into a version directory should be removed")
+	}
+
+	os.WriteFile(filepath.Join(binDir, "go"), []byte("#!/bin/sh\n"), 0755)
+	config.BinaryName = "go3"
+	if err := manager.createSymlink("1.22.0"); err != nil {
+		t.Fatalf("createSymlink() as go3 error = %v", err)
+	}
+	if !exists("go") {
+		t.Error("a go file govman did not create should be left alone")
+	}
+	if exists("go2") {
+		t.Error("the recorded go2 link should be removed after renaming to go3")
+	}
+}
+
+func TestManager_createSymlink_Companions(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses shell scripts as fake go binaries")
+	}
+
+	config := createTestConfig(t)
+	manager := createTestManager(t, config)
+	binDir := config.GetBinPath()
+
+	writeBinary := func(version, name string) {
+		t.Helper()
+		path := filepath.Join(config.GetVersionDir(version), "bin", name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte("#!/bin/sh\n"), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, name := range []string{"go", "gofmt", "godoc", "gotype"} {
+		writeBinary("1.21.0", name)
+	}
+	for _, name := range []string{"go", "gofmt", "gotype"} {
+		writeBinary("1.22.0", name)
+	}
+	// Not executable, so not a binary to link
+	os.WriteFile(filepath.Join(config.GetVersionDir("1.22.0"), "bin", "README"), []byte("notes\n"), 0644)
+
+	// A file govman did not create is never replaced
+	if err := os.WriteFile(filepath.Join(binDir, "gotype"), []byte("user file\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	assertLinks := func(version string, names ...string) {
+		t.Helper()
+		for _, name := range names {
+			target, err := os.Readlink(filepath.Join(binDir, name))
+			if err != nil {
+				t.Errorf("%s link: %v", name, err)
+				continue
+			}
+			if !filepath.IsAbs(target) {
+				target = filepath.Join(binDir, target)
+			}
+			if want := filepath.Join(config.GetVersionDir(version), "bin", name); filepath.Clean(target) != want {
+				t.Errorf("%s link points to %s, want %s", name, target, want)
+			}
+		}
+	}
+
+	if err := manager.createSymlink("1.21.0"); err != nil {
+		t.Fatalf("createSymlink(1.21.0) error = %v", err)
+	}
+	assertLinks("1.21.0", "go", "gofmt", "godoc")
+
+	if err := manager.createSymlink("1.22.0"); err != nil {
+		t.Fatalf("createSymlink(1.22.0) error = %v", err)
+	}
+	assertLinks("1.22.0", "go", "gofmt")
+	if _, err := os.Lstat(filepath.Join(binDir, "godoc")); !os.IsNotExist(err) {
+		t.Errorf("stale godoc link from Go 1.21.0 should be removed: %v", err)
+	}
+	if _, err := os.Lstat(filepath.Join(binDir, "README")); !os.IsNotExist(err) {
+		t.Errorf("a non-executable file should not be linked: %v", err)
+	}
+	if data, _ := os.ReadFile(filepath.Join(binDir, "gotype")); string(data) != "user file\n" {
+		t.Errorf("createSymlink() replaced a file it did not create: %q", data)
+	}
+
+	// With a custom binary_name, companions would shadow another installation's tools
+	config.BinaryName = "go2"
+	if err := manager.createSymlink("1.22.0"); err != nil {
+		t.Fatalf("createSymlink(1.22.0) as go2 error = %v", err)
+	}
+	for _, name := range []string{"gofmt", companionLinksFile} {
+		if _, err := os.Lstat(filepath.Join(binDir, name)); !os.IsNotExist(err) {
+			t.Errorf("%s should not exist with a custom binary_name: %v", name, err)
+		}
+	}
+	config.BinaryName = ""
+	if err := manager.createSymlink("1.22.0"); err != nil {
+		t.Fatalf("createSymlink(1.22.0) error = %v", err)
+	}
+
+	if err := manager.removeGlobalLink(); err != nil {
+		t.Fatalf("removeGlobalLink() error = %v", err)
+	}
+	for _, name := range []string{"go", "gofmt", companionLinksFile} {
+		if _, err := os.Lstat(filepath.Join(binDir, name)); !os.IsNotExist(err) {
+			t.Errorf("%s should be removed with the global link: %v", name, err)
+		}
+	}
+}
+
+func TestManager_VerifyDefault_Reconcile(t *testing.T) {
+	installGo := func(c *_config.Config, version string) {
+		goPath := filepath.Join(c.GetVersionDir(version), "bin", "go")
+		if runtime.GOOS == "windows" {
+			goPath += ".exe"
+		}
+		os.MkdirAll(filepath.Dir(goPath), 0755)
+		os.WriteFile(goPath, []byte("binary"), 0755)
+	}
+
+	tests := []struct {
+		name          string
+		setup         func(*Manager, *_config.Config)
+		wantVerifyErr bool
+		wantRelinked  bool
+		wantErr       bool
+	}{
+		{
+			name:  "no default version configured",
+			setup: func(m *Manager, c *_config.Config) {},
+		},
+		{
+			name: "link matches default",
+			setup: func(m *Manager, c *_config.Config) {
+				installGo(c, "1.20.0")
+				m.createSymlink("1.20.0")
+				c.DefaultVersion = "1.20.0"
+			},
+		},
+		{
+			name: "default version installed but symlink missing",
+			setup: func(m *Manager, c *_config.Config) {
+				installGo(c, "1.20.0")
+				c.DefaultVersion = "1.20.0"
+			},
+			wantVerifyErr: true,
+			wantRelinked:  true,
+		},
+		{
+			name: "link points to another version",
+			setup: func(m *Manager, c *_config.Config) {
+				installGo(c, "1.20.0")
+				installGo(c, "1.21.0")
+				m.createSymlink("1.21.0")
+				c.DefaultVersion = "1.20.0"
+			},
+			wantVerifyErr: true,
+			wantRelinked:  true,
+		},
+		{
+			name: "default version not installed",
+			setup: func(m *Manager, c *_config.Config) {
+				c.DefaultVersion = "1.20.0"
+			},
+			wantVerifyErr: true,
+			wantErr:       true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := createTestConfig(t)
+			manager := createTestManager(t, config)
+			tt.setup(manager, config)
+
+			if err := manager.VerifyDefault(); (err != nil) != tt.wantVerifyErr {
+				t.Fatalf("VerifyDefault() error = %v, wantErr %v", err, tt.wantVerifyErr)
+			}
+
+			relinked, err := manager.Reconcile()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Reconcile() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if relinked != tt.wantRelinked {
+				t.Errorf("Reconcile() relinked = %v, want %v", relinked, tt.wantRelinked)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			if err := manager.VerifyDefault(); err != nil {
+				t.Errorf("VerifyDefault() after Reconcile() error = %v", err)
+			}
+			if config.DefaultVersion != "" {
+				if got, err := manager.CurrentGlobal(); err != nil || got != config.DefaultVersion {
+					t.Errorf("CurrentGlobal() = %v, %v; want %v", got, err, config.DefaultVersion)
+				}
+			}
+		})
+	}
+}
+
+func TestManager_MoveInstallDir(t *testing.T) {
+	tests := []struct {
+		name       string
+		crossFS    bool
+		interrupts bool
+	}{
+		{name: "same filesystem rename"},
+		{name: "cross filesystem copy", crossFS: true},
+		{name: "resume after an interrupted copy", crossFS: true, interrupts: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			createTestConfig(t)
+			home := os.Getenv("HOME")
+			configPath := filepath.Join(home, "config.yaml")
+			config, err := _config.Load(configPath)
+			if err != nil {
+				t.Fatalf("Load() error = %v", err)
+			}
+			manager := createTestManager(t, config)
+			oldDir := config.InstallDir
+
+			// Two installed versions with an executable, a symlink, and a tag
+			for _, version := range []string{"1.21.0", "1.22.0"} {
+				binDir := filepath.Join(config.GetVersionDir(version), "bin")
+				os.MkdirAll(binDir, 0755)
+				os.WriteFile(filepath.Join(binDir, "go"), []byte("binary "+version), 0755)
+				os.Symlink("go", filepath.Join(binDir, "go-link"))
+			}
+			manager.SetTag("1.21.0", "legacy")
+			if err := manager.SetDefault("1.22.0"); err != nil {
+				t.Fatalf("SetDefault() error = %v", err)
+			}
+
+			if tt.crossFS {
+				original := renameDir
+				renameDir = func(string, string) error {
+					return &os.LinkError{Op: "rename", Err: errors.New("invalid cross-device link")}
+				}
+				t.Cleanup(func() { renameDir = original })
+			}
+
+			newDir := filepath.Join(t.TempDir(), "moved", "versions")
+
+			if tt.interrupts {
+				// A previous run finished 1.21.0 without removing the source, and left a partial staging copy of 1.22.0
+				copyTree(config.GetVersionDir("1.21.0"), filepath.Join(newDir, "go1.21.0"))
+				os.WriteFile(filepath.Join(newDir, "go1.21.0", moveCompleteMarker), nil, 0644)
+				os.MkdirAll(filepath.Join(newDir, moveStagingPrefix+"go1.22.0", "bin"), 0755)
+			}
+
+			if err := manager.MoveInstallDir(newDir); err != nil {
+				t.Fatalf("MoveInstallDir() error = %v", err)
+			}
+
+			if config.InstallDir != newDir {
+				t.Errorf("InstallDir = %s, want %s", config.InstallDir, newDir)
+			}
+			for _, version := range []string{"1.21.0", "1.22.0"} {
+				goPath := filepath.Join(newDir, "go"+version, "bin", "go")
+				data, err := os.ReadFile(goPath)
+				if err != nil || string(data) != "binary "+version {
+					t.Errorf("Go %s binary not moved: %q, %v", version, data, err)
+				}
+				if info, err := os.Stat(goPath); err == nil && info.Mode().Perm()&0100 == 0 {
+					t.Errorf("Go %s binary lost its executable bit", version)
+				}
+				if target, err := os.Readlink(filepath.Join(newDir, "go"+version, "bin", "go-link")); err != nil || target != "go" {
+					t.Errorf("Go %s symlink not preserved: %q, %v", version, target, err)
+				}
+				if _, err := os.Stat(filepath.Join(newDir, "go"+version, moveCompleteMarker)); !os.IsNotExist(err) {
+					t.Errorf("Go %s should not keep the move marker", version)
+				}
+			}
+			if got := manager.Tag("1.21.0"); got != "legacy" {
+				t.Errorf("Tag(1.21.0) = %q, want the tag to move with the version", got)
+			}
+			if _, err := os.Stat(filepath.Join(newDir, moveStagingPrefix+"go1.22.0")); !os.IsNotExist(err) {
+				t.Error("staging directory should not be left behind")
+			}
+			if _, err := os.Stat(oldDir); !os.IsNotExist(err) {
+				t.Errorf("empty old install directory %s should be removed", oldDir)
+			}
+
+			if got, err := manager.CurrentGlobal(); err != nil || got != "1.22.0" {
+				t.Errorf("CurrentGlobal() = %v, %v; want the symlink to follow 1.22.0", got, err)
+			}
+
+			reloaded, err := _config.Load(configPath)
+			if err != nil {
+				t.Fatalf("Load() after move error = %v", err)
+			}
+			if reloaded.InstallDir != newDir {
+				t.Errorf("saved install_dir = %s, want %s", reloaded.InstallDir, newDir)
+			}
+		})
+	}
+}
+
+func TestManager_MoveInstallDir_Failure(t *testing.T) {
+	createTestConfig(t)
+	config, err := _config.Load(filepath.Join(os.Getenv("HOME"), "config.yaml"))
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	manager := createTestManager(t, config)
+	oldDir := config.InstallDir
+	os.MkdirAll(filepath.Join(config.GetVersionDir("1.22.0"), "bin"), 0755)
+
+	if err := manager.MoveInstallDir("relative/path"); err == nil {
+		t.Error("MoveInstallDir() should reject a relative path")
+	}
+
+	// The target's parent is a file, so nothing can be created there
+	blocker := filepath.Join(t.TempDir(), "file")
+	os.WriteFile(blocker, nil, 0644)
+	if err := manager.MoveInstallDir(filepath.Join(blocker, "versions")); err == nil {
+		t.Error("MoveInstallDir() should fail when the target cannot be created")
+	}
+
+	// An unrelated directory already sits where the version would go
+	target := t.TempDir()
+	name := filepath.Base(config.GetVersionDir("1.22.0"))
+	os.MkdirAll(filepath.Join(target, name), 0755)
+	os.WriteFile(filepath.Join(target, name, "VERSION"), []byte("go1.21.0"), 0644)
+	if err := manager.MoveInstallDir(target); err == nil {
+		t.Error("MoveInstallDir() should fail when the target holds a different directory")
+	}
+
+	if config.InstallDir != oldDir {
+		t.Errorf("InstallDir = %s after failures, want it unchanged at %s", config.InstallDir, oldDir)
+	}
+	if !manager.IsInstalled("1.22.0") {
+		t.Error("versions should stay in place when the move fails")
+	}
+}
+
 func TestManager_Use(t *testing.T) {
 	tests := []struct {
 		name       string
@@ -697,7 +1290,7 @@ func TestManager_Use(t *testing.T) {
 				os.Chmod(config.GetBinPath(), 0755)
 			})
 
-			err := manager.Use(tt.version, tt.setDefault, tt.setLocal)
+			err := manager.Use(tt.version, tt.setDefault, tt.setLocal, false)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("Use() error = %v, wantErr %v", err, tt.wantErr)
 			}
@@ -713,33 +1306,193 @@ func TestManager_Use(t *testing.T) {
 	}
 }
 
-func TestManager_Install(t *testing.T) {
-	tests := []struct {
-		name    string
-		version string
-		setup   func(*_config.Config)
-		wantErr bool
-	}{
-		{
-			name:    "install new version (network failure expected)",
-			version: "1.20.0",
-			setup:   func(c *_config.Config) {},
-			wantErr: true, // Will fail because no actual download URL available in test
-		},
-		{
-			name:    "install already installed version",
-			version: "1.20.0",
-			setup: func(c *_config.Config) {
-				versionDir := c.GetVersionDir("1.20.0")
-				os.MkdirAll(versionDir, 0755)
-			},
-			wantErr: true,
-		},
-		{
-			name:    "install with resolveVersion failure",
-			version: "latest",
-			setup: func(c *_config.Config) {
-				c.GoReleases.APIURL = "invalid://url"
+func TestManager_PreviousVersion(t *testing.T) {
+	config := createTestConfig(t)
+	manager := createTestManager(t, config)
+	t.Setenv(_shell.SessionPIDEnv, "4242")
+
+	original := sessionProcessAlive
+	sessionProcessAlive = func(pid int) bool { return pid == 4242 }
+	t.Cleanup(func() { sessionProcessAlive = original })
+
+	for _, version := range []string{"1.24.0", "1.25.1"} {
+		goPath := filepath.Join(config.GetVersionDir(version), "bin", "go")
+		if runtime.GOOS == "windows" {
+			goPath += ".exe"
+		}
+		os.MkdirAll(filepath.Dir(goPath), 0755)
+		os.WriteFile(goPath, []byte("binary"), 0755)
+	}
+
+	if _, err := manager.PreviousVersion(false); !errors.Is(err, ErrNoPreviousVersion) {
+		t.Errorf("PreviousVersion() before any switch error = %v, want ErrNoPreviousVersion", err)
+	}
+
+	// A stale entry from a shell that has exited is dropped on the next write
+	os.WriteFile(filepath.Join(filepath.Dir(config.GetBinPath()), previousVersionsFile), []byte(`{"sessions":{"99":"1.25.1"}}`), 0644)
+
+	manager.SetSessionVersion("1.24.0")
+	if err := manager.Use("1.25.1", false, false, false); err != nil {
+		t.Fatalf("Use() error = %v", err)
+	}
+	if got, err := manager.PreviousVersion(false); err != nil || got != "1.24.0" {
+		t.Errorf("PreviousVersion(session) = %q, %v; want 1.24.0", got, err)
+	}
+	if got := manager.loadPreviousVersions().Sessions; len(got) != 1 {
+		t.Errorf("session entries = %v, want only the running shell's", got)
+	}
+
+	if err := manager.Use("1.24.0", true, false, false); err != nil {
+		t.Fatalf("Use(default) error = %v", err)
+	}
+	if _, err := manager.PreviousVersion(true); !errors.Is(err, ErrNoPreviousVersion) {
+		t.Errorf("PreviousVersion(default) after the first default error = %v, want ErrNoPreviousVersion", err)
+	}
+	if err := manager.Use("1.25.1", true, false, false); err != nil {
+		t.Fatalf("Use(default) error = %v", err)
+	}
+	if got, err := manager.PreviousVersion(true); err != nil || got != "1.24.0" {
+		t.Errorf("PreviousVersion(default) = %q, %v; want 1.24.0", got, err)
+	}
+	if got, _ := manager.PreviousVersion(false); got != "1.24.0" {
+		t.Errorf("PreviousVersion(session) = %q, want 1.24.0 untouched by the default switch", got)
+	}
+
+	// Another terminal has its own history
+	t.Setenv(_shell.SessionPIDEnv, "100")
+	if _, err := manager.PreviousVersion(false); !errors.Is(err, ErrNoPreviousVersion) {
+		t.Errorf("PreviousVersion() in another terminal error = %v, want ErrNoPreviousVersion", err)
+	}
+	t.Setenv(_shell.SessionPIDEnv, "4242")
+
+	os.RemoveAll(config.GetVersionDir("1.24.0"))
+	if got, err := manager.PreviousVersion(true); !errors.Is(err, ErrNotInstalled) || got != "1.24.0" {
+		t.Errorf("PreviousVersion() of an uninstalled version = %q, %v; want 1.24.0 and ErrNotInstalled", got, err)
+	}
+}
+
+func TestManager_Use_DriftedDefault(t *testing.T) {
+	config := createTestConfig(t)
+	manager := createTestManager(t, config)
+	shell := manager.shell.(*mockShell)
+
+	for _, version := range []string{"1.20.0", "1.21.0"} {
+		goPath := filepath.Join(config.GetVersionDir(version), "bin", "go")
+		if runtime.GOOS == "windows" {
+			goPath += ".exe"
+		}
+		os.MkdirAll(filepath.Dir(goPath), 0755)
+		os.WriteFile(goPath, []byte("binary"), 0755)
+	}
+
+	// The default is recorded but its link was deleted by hand
+	config.DefaultVersion = "1.20.0"
+
+	t.Run("session-only use leaves the link alone", func(t *testing.T) {
+		if err := manager.Use("1.21.0", false, false, false); err != nil {
+			t.Fatalf("Use(1.21.0) error = %v", err)
+		}
+		if _, err := manager.CurrentGlobal(); err == nil {
+			t.Error("session-only Use() should not recreate the global link")
+		}
+	})
+
+	t.Run("use default activates the configured default without relinking", func(t *testing.T) {
+		if err := manager.Use("default", false, false, false); err != nil {
+			t.Fatalf("Use(default) error = %v", err)
+		}
+		want := filepath.Join(config.GetVersionDir("1.20.0"), "bin")
+		if len(shell.executed) == 0 || shell.executed[len(shell.executed)-1] != want {
+			t.Errorf("Use(default) activated %v, want %s", shell.executed, want)
+		}
+		if _, err := manager.CurrentGlobal(); err == nil {
+			t.Error("Use(default) should not recreate the global link")
+		}
+	})
+
+	t.Run("use --default relinks", func(t *testing.T) {
+		if err := manager.Use("1.20.0", true, false, false); err != nil {
+			t.Fatalf("Use(1.20.0, default) error = %v", err)
+		}
+		if got, err := manager.CurrentGlobal(); err != nil || got != "1.20.0" {
+			t.Errorf("CurrentGlobal() = %v, %v; want 1.20.0", got, err)
+		}
+	})
+}
+
+func TestManager_Use_SkipSession(t *testing.T) {
+	config := createTestConfig(t)
+	manager := createTestManager(t, config)
+	shell := manager.shell.(*mockShell)
+
+	goPath := filepath.Join(config.GetVersionDir("1.25.1"), "bin", "go")
+	if runtime.GOOS == "windows" {
+		goPath += ".exe"
+	}
+	os.MkdirAll(filepath.Dir(goPath), 0755)
+	os.WriteFile(goPath, []byte("binary"), 0755)
+
+	t.Setenv("PATH", "/nonexistent/path")
+	if err := manager.Use("1.25.1", true, false, true); err != nil {
+		t.Fatalf("Use(default, skipSession) error = %v", err)
+	}
+	if len(shell.executed) != 0 {
+		t.Errorf("ExecutePathCommand called with %v, want no session PATH update without an active version", shell.executed)
+	}
+	if got, err := manager.CurrentGlobal(); err != nil || got != "1.25.1" {
+		t.Errorf("CurrentGlobal() = %q, %v; want the new default 1.25.1", got, err)
+	}
+
+	if err := manager.Use("1.25.1", true, false, false); err != nil {
+		t.Fatalf("Use(default) error = %v", err)
+	}
+	if len(shell.executed) != 1 {
+		t.Errorf("ExecutePathCommand calls = %v, want one session PATH update", shell.executed)
+	}
+
+	if runtime.GOOS == "windows" {
+		return
+	}
+	// A terminal running 1.24.0 stays on it rather than following the relinked default
+	oldBin := filepath.Join(config.GetVersionDir("1.24.0"), "bin")
+	writeFakeGo(t, oldBin, "1.24.0")
+	t.Setenv("PATH", oldBin)
+	shell.executed = nil
+	if err := manager.Use("1.25.1", true, false, true); err != nil {
+		t.Fatalf("Use(default, skipSession) error = %v", err)
+	}
+	if len(shell.executed) != 1 || shell.executed[0] != oldBin {
+		t.Errorf("ExecutePathCommand calls = %v, want this terminal pinned to %s", shell.executed, oldBin)
+	}
+}
+
+func TestManager_Install(t *testing.T) {
+	tests := []struct {
+		name    string
+		version string
+		setup   func(*_config.Config)
+		wantErr bool
+	}{
+		{
+			name:    "install new version (network failure expected)",
+			version: "1.20.0",
+			setup:   func(c *_config.Config) {},
+			wantErr: true, // Will fail because no actual download URL available in test
+		},
+		{
+			name:    "install already installed version",
+			version: "1.20.0",
+			setup: func(c *_config.Config) {
+				versionDir := c.GetVersionDir("1.20.0")
+				os.MkdirAll(versionDir, 0755)
+			},
+			wantErr: true,
+		},
+		{
+			name:    "install with resolveVersion failure",
+			version: "latest",
+			setup: func(c *_config.Config) {
+				c.GoReleases.APIURL = "invalid://url"
 			},
 			wantErr: true,
 		},
@@ -772,6 +1525,796 @@ func TestManager_Install(t *testing.T) {
 	}
 }
 
+func TestManager_InstallMany(t *testing.T) {
+	config := createTestConfig(t)
+	manager := createTestManager(t, config)
+
+	os.MkdirAll(config.GetVersionDir("1.20.0"), 0755)
+
+	successful, failures := manager.InstallMany([]string{"1.20.0", "not-a-version"})
+	if len(successful) != 0 {
+		t.Errorf("Expected no successful installs, got %v", successful)
+	}
+	if len(failures) != 2 {
+		t.Fatalf("Expected 2 failures, got %d: %v", len(failures), failures)
+	}
+
+	if failures[0].Version != "1.20.0" || failures[0].Kind != InstallErrorAlreadyInstalled {
+		t.Errorf("Unexpected first failure: %+v", failures[0])
+	}
+	if !errors.Is(failures[0], ErrAlreadyInstalled) {
+		t.Errorf("Expected failure to wrap ErrAlreadyInstalled, got %v", failures[0].Err)
+	}
+	if failures[1].Version != "not-a-version" || failures[1].Kind != InstallErrorUnknown {
+		t.Errorf("Unexpected second failure: %+v", failures[1])
+	}
+	if !strings.HasPrefix(failures[1].Error(), "Go not-a-version: ") {
+		t.Errorf("Unexpected error message: %s", failures[1].Error())
+	}
+}
+
+func TestManager_InstallMany_ReturnsResolvedVersions(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("official Windows archives are zip files")
+	}
+	_golang.ClearReleasesCache()
+	t.Cleanup(_golang.ClearReleasesCache)
+
+	archivePath := filepath.Join(t.TempDir(), _golang.ArchiveName("1.24.7"))
+	writeToolchainArchive(t, archivePath, "1.24.7")
+	archive, err := os.ReadFile(archivePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, ".tar.gz") {
+			w.Write(archive)
+			return
+		}
+		fmt.Fprintf(w, `[{"version": "go1.24.7", "stable": true, "files": [
+			{"filename": %q, "os": %q, "arch": %q, "version": "go1.24.7", "sha256": "%x", "size": %d, "kind": "archive"}
+		]}]`, filepath.Base(archivePath), runtime.GOOS, runtime.GOARCH, sha256.Sum256(archive), len(archive))
+	}))
+	defer server.Close()
+
+	config := createTestConfig(t)
+	config.GoReleases.APIURL = server.URL
+	config.GoReleases.DownloadURL = server.URL + "/%s"
+	manager := createTestManager(t, config)
+
+	successful, failures := manager.InstallMany([]string{"1.24"})
+	if len(failures) != 0 || !reflect.DeepEqual(successful, []string{"1.24.7"}) {
+		t.Errorf("InstallMany(1.24) = %v, %v; want the resolved release 1.24.7", successful, failures)
+	}
+}
+
+func TestManager_VerifyInstall(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("fake go binary is a shell script")
+	}
+	_golang.ClearReleasesCache()
+	t.Cleanup(_golang.ClearReleasesCache)
+
+	archive := []byte("archive")
+	filename := fmt.Sprintf("go1.25.1.%s-%s.tar.gz", runtime.GOOS, runtime.GOARCH)
+	releasesServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `[{"version": "go1.25.1", "stable": true, "files": [
+			{"filename": %q, "os": %q, "arch": %q, "version": "go1.25.1", "sha256": "%x", "size": %d, "kind": "archive"}
+		]}]`, filename, runtime.GOOS, runtime.GOARCH, sha256.Sum256(archive), len(archive))
+	}))
+	defer releasesServer.Close()
+
+	config := createTestConfig(t)
+	config.GoReleases.APIURL = releasesServer.URL
+	manager := createTestManager(t, config)
+
+	if _, err := manager.VerifyInstall(context.Background(), "1.25.1"); !errors.Is(err, ErrNotInstalled) {
+		t.Fatalf("VerifyInstall() for a missing version error = %v, want ErrNotInstalled", err)
+	}
+
+	versionDir := config.GetVersionDir("1.25.1")
+	writeFakeGo(t, filepath.Join(versionDir, "bin"), "1.25.1")
+	os.MkdirAll(filepath.Join(versionDir, "pkg"), 0755)
+	os.WriteFile(filepath.Join(versionDir, "VERSION"), []byte("go1.25.1\ntime 2025-09-03\n"), 0644)
+
+	verify := func() map[string]VerifyCheck {
+		t.Helper()
+		result, err := manager.VerifyInstall(context.Background(), "1.25.1")
+		if err != nil {
+			t.Fatalf("VerifyInstall() error = %v", err)
+		}
+		checks := map[string]VerifyCheck{}
+		for _, check := range result.Checks {
+			checks[check.Name] = check
+		}
+		return checks
+	}
+
+	checks := verify()
+	for _, name := range []string{"go binary", "install tree"} {
+		if checks[name].Err != nil || checks[name].Skipped {
+			t.Errorf("%s check = %+v, want a pass", name, checks[name])
+		}
+	}
+	if !checks["archive checksum"].Skipped {
+		t.Errorf("archive check without a cached archive = %+v, want it skipped", checks["archive checksum"])
+	}
+
+	cachePath := filepath.Join(config.CacheDir, filename)
+	os.WriteFile(cachePath, archive, 0644)
+	if check := verify()["archive checksum"]; check.Err != nil || check.Skipped {
+		t.Errorf("archive check with an intact archive = %+v, want a pass", check)
+	}
+
+	os.WriteFile(cachePath, []byte("ARCHIVE"), 0644)
+	if check := verify()["archive checksum"]; check.Err == nil {
+		t.Error("archive check with a corrupted archive should fail")
+	}
+
+	writeFakeGo(t, filepath.Join(versionDir, "bin"), "1.24.0")
+	os.Remove(filepath.Join(versionDir, "VERSION"))
+	checks = verify()
+	if checks["go binary"].Err == nil {
+		t.Error("binary check should fail when bin/go reports another version")
+	}
+	if checks["install tree"].Err == nil {
+		t.Error("install tree check should fail without a VERSION file")
+	}
+}
+
+func TestManager_PlanInstall(t *testing.T) {
+	_golang.ClearReleasesCache()
+	t.Cleanup(_golang.ClearReleasesCache)
+
+	releasesServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `[
+			{"version": "go1.24.7", "stable": true, "files": [
+				{"filename": "go1.24.7.%[1]s-%[2]s.tar.gz", "os": "%[1]s", "arch": "%[2]s", "version": "go1.24.7", "kind": "archive"}
+			]},
+			{"version": "go1.23.12", "stable": true, "files": []}
+		]`, runtime.GOOS, runtime.GOARCH)
+	}))
+	defer releasesServer.Close()
+
+	config := createTestConfig(t)
+	config.GoReleases.APIURL = releasesServer.URL
+	config.GoReleases.DownloadURL = "https://dl.example.com/%s"
+	manager := createTestManager(t, config)
+
+	plan, err := manager.PlanInstall("1.24")
+	if err != nil {
+		t.Fatalf("PlanInstall() error = %v", err)
+	}
+	wantURL := fmt.Sprintf("https://dl.example.com/go1.24.7.%s-%s.tar.gz", runtime.GOOS, runtime.GOARCH)
+	if plan.Requested != "1.24" || plan.Version != "1.24.7" || plan.URL != wantURL ||
+		plan.InstallDir != config.GetVersionDir("1.24.7") || plan.Installed {
+		t.Errorf("PlanInstall() = %+v", plan)
+	}
+	if _, err := os.Stat(plan.InstallDir); !os.IsNotExist(err) {
+		t.Errorf("PlanInstall() must not create %s", plan.InstallDir)
+	}
+
+	os.MkdirAll(config.GetVersionDir("1.24.7"), 0755)
+	plans, failures := manager.PlanInstallMany([]string{"1.24.7", "1.23.12", "1.99.0", "bad version"})
+	if len(plans) != 1 || !plans[0].Installed {
+		t.Errorf("PlanInstallMany() plans = %+v, want 1.24.7 marked installed", plans)
+	}
+	if len(failures) != 3 || failures[0].Kind != InstallErrorNotFound || failures[1].Kind != InstallErrorNotFound ||
+		failures[2].Kind != InstallErrorUnknown {
+		t.Fatalf("PlanInstallMany() failures = %+v, want not-found, not-found, then unknown", failures)
+	}
+
+	// A release without an archive for this platform is not the same as a release that does not exist
+	if errors.Is(failures[0], _golang.ErrVersionNotFound) || !errors.Is(failures[0], _golang.ErrNoDownload) {
+		t.Errorf("Go 1.23.12 failure = %v, want ErrNoDownload", failures[0].Err)
+	}
+	if !errors.Is(failures[1], _golang.ErrVersionNotFound) {
+		t.Errorf("Go 1.99.0 failure = %v, want ErrVersionNotFound", failures[1].Err)
+	}
+	if strings.Contains(failures[1].Error(), "download URL") {
+		t.Errorf("Go 1.99.0 failure should not blame the download: %v", failures[1].Err)
+	}
+}
+
+func TestClassifyInstallError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want InstallErrorKind
+	}{
+		{
+			name: "already installed",
+			err:  fmt.Errorf("go version 1.20.0 is %w", ErrAlreadyInstalled),
+			want: InstallErrorAlreadyInstalled,
+		},
+		{
+			name: "unresolvable version",
+			err:  fmt.Errorf("failed to resolve version 1.99: %w", ErrVersionNotFound),
+			want: InstallErrorNotFound,
+		},
+		{
+			name: "archive not cached in offline mode",
+			err:  fmt.Errorf("failed to get download URL: %w: Go 1.25.1 has no verified archive in the download cache", _golang.ErrOffline),
+			want: InstallErrorOffline,
+		},
+		{
+			name: "unknown release",
+			err:  fmt.Errorf("go version 1.99.0 is %w", _golang.ErrVersionNotFound),
+			want: InstallErrorNotFound,
+		},
+		{
+			name: "no download for platform",
+			err:  fmt.Errorf("failed to get download URL: %w", _golang.ErrNoDownload),
+			want: InstallErrorNotFound,
+		},
+		{
+			name: "no file info for platform",
+			err:  fmt.Errorf("failed to get file info: %w", _golang.ErrNoFileInfo),
+			want: InstallErrorNotFound,
+		},
+		{
+			name: "HTTP 404",
+			err:  fmt.Errorf("failed to download: %w", &_downloader.StatusError{StatusCode: 404, Status: "404 Not Found"}),
+			want: InstallErrorNotFound,
+		},
+		{
+			name: "interrupted download",
+			err:  fmt.Errorf("failed to download: %w", fmt.Errorf("download interrupted: %w", context.Canceled)),
+			want: InstallErrorInterrupted,
+		},
+		{
+			name: "HTTP 503",
+			err:  fmt.Errorf("failed to download: %w", &_downloader.StatusError{StatusCode: 503, Status: "503 Service Unavailable"}),
+			want: InstallErrorNetwork,
+		},
+		{
+			name: "connection error",
+			err:  fmt.Errorf("failed to download: %w", &url.Error{Op: "Get", URL: "https://go.dev", Err: errors.New("connection refused")}),
+			want: InstallErrorNetwork,
+		},
+		{
+			name: "disk full",
+			err:  fmt.Errorf("failed to write file: %w", syscall.ENOSPC),
+			want: InstallErrorDisk,
+		},
+		{
+			name: "permission denied",
+			err:  fmt.Errorf("failed to create install directory: %w", &fs.PathError{Op: "mkdir", Path: "/x", Err: fs.ErrPermission}),
+			want: InstallErrorDisk,
+		},
+		{
+			name: "strict hook that cannot start",
+			err:  fmt.Errorf("go 1.25.1 was installed, but %w: gopls: %w", ErrHookFailed, &fs.PathError{Op: "fork/exec", Path: "gopls", Err: fs.ErrNotExist}),
+			want: InstallErrorHook,
+		},
+		{
+			name: "other",
+			err:  errors.New("invalid version format: x"),
+			want: InstallErrorUnknown,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := classifyInstallError(tt.err); got != tt.want {
+				t.Errorf("classifyInstallError() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestManager_runPostInstallHooks(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses shell scripts as fake go binaries")
+	}
+
+	config := createTestConfig(t)
+	manager := createTestManager(t, config)
+
+	// The fake go records its arguments and the environment the hook sees
+	versionDir := config.GetVersionDir("1.25.1")
+	logFile := filepath.Join(t.TempDir(), "hook.log")
+	script := fmt.Sprintf("#!/bin/sh\necho \"$@|$GOROOT|$GOTOOLCHAIN|${PATH%%%%:*}\" >> %q\n", logFile)
+	if err := os.MkdirAll(filepath.Join(versionDir, "bin"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(versionDir, "bin", "go"), []byte(script), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("runs commands with the new version first on PATH", func(t *testing.T) {
+		os.Remove(logFile)
+		config.Hooks = _config.HooksConfig{PostInstall: []string{
+			"go install golang.org/x/tools/gopls@latest",
+			`go env -w "GOFLAGS=-mod=mod -trimpath"`,
+		}}
+
+		if err := manager.runPostInstallHooks(context.Background(), "1.25.1"); err != nil {
+			t.Fatalf("runPostInstallHooks() error = %v", err)
+		}
+
+		data, err := os.ReadFile(logFile)
+		if err != nil {
+			t.Fatalf("hooks did not run the version's go: %v", err)
+		}
+		binDir := filepath.Join(versionDir, "bin")
+		want := "install golang.org/x/tools/gopls@latest|" + versionDir + "|local|" + binDir + "\n" +
+			"env -w GOFLAGS=-mod=mod -trimpath|" + versionDir + "|local|" + binDir + "\n"
+		if string(data) != want {
+			t.Errorf("hook log = %q, want %q", data, want)
+		}
+	})
+
+	t.Run("shell syntax is not interpreted", func(t *testing.T) {
+		marker := filepath.Join(t.TempDir(), "injected")
+		config.Hooks = _config.HooksConfig{PostInstall: []string{"go version; touch " + marker}}
+
+		if err := manager.runPostInstallHooks(context.Background(), "1.25.1"); err != nil {
+			t.Fatalf("runPostInstallHooks() error = %v", err)
+		}
+		if _, err := os.Stat(marker); !os.IsNotExist(err) {
+			t.Errorf("hook command was run through a shell; %s exists", marker)
+		}
+	})
+
+	failing := []string{"govman-test-missing-hook-binary", "go version"}
+
+	t.Run("failures warn and continue by default", func(t *testing.T) {
+		os.Remove(logFile)
+		config.Hooks = _config.HooksConfig{PostInstall: failing}
+
+		if err := manager.runPostInstallHooks(context.Background(), "1.25.1"); err != nil {
+			t.Fatalf("runPostInstallHooks() error = %v, want nil without hooks.strict", err)
+		}
+		if _, err := os.Stat(logFile); err != nil {
+			t.Errorf("hooks after a failure should still run: %v", err)
+		}
+	})
+
+	t.Run("strict mode stops at the first failure", func(t *testing.T) {
+		os.Remove(logFile)
+		config.Hooks = _config.HooksConfig{PostInstall: failing, Strict: true}
+
+		err := manager.runPostInstallHooks(context.Background(), "1.25.1")
+		if !errors.Is(err, ErrHookFailed) {
+			t.Fatalf("runPostInstallHooks() error = %v, want ErrHookFailed", err)
+		}
+		if classifyInstallError(err) != InstallErrorHook {
+			t.Errorf("classifyInstallError() = %s, want %s", classifyInstallError(err), InstallErrorHook)
+		}
+		if _, err := os.Stat(logFile); !os.IsNotExist(err) {
+			t.Error("hooks after a strict failure should not run")
+		}
+	})
+
+	t.Run("SkipHooks runs nothing", func(t *testing.T) {
+		os.Remove(logFile)
+		config.Hooks = _config.HooksConfig{PostInstall: []string{"go version"}, Strict: true}
+		manager.SkipHooks = true
+		defer func() { manager.SkipHooks = false }()
+
+		if err := manager.runPostInstallHooks(context.Background(), "1.25.1"); err != nil {
+			t.Fatalf("runPostInstallHooks() error = %v", err)
+		}
+		if _, err := os.Stat(logFile); !os.IsNotExist(err) {
+			t.Error("hooks should not run with SkipHooks")
+		}
+		if len(config.Hooks.PostInstall) != 1 {
+			t.Errorf("hooks.post_install = %v, want it unchanged", config.Hooks.PostInstall)
+		}
+	})
+
+	t.Run("canceled context skips hooks", func(t *testing.T) {
+		os.Remove(logFile)
+		config.Hooks = _config.HooksConfig{PostInstall: []string{"go version"}, Strict: true}
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		if err := manager.runPostInstallHooks(ctx, "1.25.1"); err != nil {
+			t.Fatalf("runPostInstallHooks() error = %v", err)
+		}
+		if _, err := os.Stat(logFile); !os.IsNotExist(err) {
+			t.Error("hooks should not run after an interrupt")
+		}
+	})
+}
+
+func TestManager_Command(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses shell scripts as fake go binaries")
+	}
+
+	config := createTestConfig(t)
+	manager := createTestManager(t, config)
+
+	t.Run("not installed", func(t *testing.T) {
+		if _, err := manager.Command(context.Background(), "1.99.0", []string{"go", "version"}); err == nil {
+			t.Error("Command() should fail for a version that is not installed")
+		}
+	})
+
+	versionDir := config.GetVersionDir("1.25.1")
+	goPath := writeFakeGo(t, filepath.Join(versionDir, "bin"), "1.25.1")
+
+	t.Run("empty command", func(t *testing.T) {
+		if _, err := manager.Command(context.Background(), "1.25.1", nil); err == nil {
+			t.Error("Command() should fail without a program")
+		}
+	})
+
+	t.Run("runs the version's go without activating it", func(t *testing.T) {
+		defaultVersion := config.DefaultVersion
+		cmd, err := manager.Command(context.Background(), "1.25.1", []string{"go", "version"})
+		if err != nil {
+			t.Fatalf("Command() error = %v", err)
+		}
+		if cmd.Path != goPath {
+			t.Errorf("Command() path = %s, want %s", cmd.Path, goPath)
+		}
+		if !slices.Contains(cmd.Env, "GOROOT="+versionDir) || !slices.Contains(cmd.Env, "GOTOOLCHAIN=local") {
+			t.Errorf("Command() env is missing GOROOT or GOTOOLCHAIN: %v", cmd.Env)
+		}
+
+		output, err := cmd.Output()
+		if err != nil {
+			t.Fatalf("running command: %v", err)
+		}
+		if !strings.Contains(string(output), "go1.25.1") {
+			t.Errorf("output = %q, want go1.25.1", output)
+		}
+
+		if config.DefaultVersion != defaultVersion {
+			t.Errorf("Command() changed the default version to %s", config.DefaultVersion)
+		}
+		if _, err := os.Lstat(manager.globalSymlinkPath()); !os.IsNotExist(err) {
+			t.Error("Command() should not create the global symlink")
+		}
+	})
+
+	t.Run("leaves GOTOOLCHAIN alone without pin_toolchain", func(t *testing.T) {
+		config.Shell.PinToolchain = false
+		defer func() { config.Shell.PinToolchain = true }()
+		t.Setenv("GOTOOLCHAIN", "auto")
+
+		cmd, err := manager.Command(context.Background(), "1.25.1", []string{"go", "version"})
+		if err != nil {
+			t.Fatalf("Command() error = %v", err)
+		}
+		if slices.Contains(cmd.Env, "GOTOOLCHAIN=local") {
+			t.Errorf("Command() env sets GOTOOLCHAIN with pin_toolchain off: %v", cmd.Env)
+		}
+	})
+}
+
+func TestManager_ShellCommand(t *testing.T) {
+	config := createTestConfig(t)
+	manager := createTestManager(t, config)
+
+	if _, err := manager.ShellCommand(context.Background(), "1.99.0", "/bin/sh"); err == nil {
+		t.Error("ShellCommand() should fail for a version that is not installed")
+	}
+
+	versionDir := config.GetVersionDir("1.25.1")
+	os.MkdirAll(filepath.Join(versionDir, "bin"), 0755)
+
+	// An outer subshell's marker must be overridden by the inner one
+	t.Setenv(_shell.SubshellVersionEnv, "1.24.0")
+
+	cmd, err := manager.ShellCommand(context.Background(), "1.25.1", "/bin/sh")
+	if err != nil {
+		t.Fatalf("ShellCommand() error = %v", err)
+	}
+	if len(cmd.Args) != 1 || cmd.Args[0] != "/bin/sh" {
+		t.Errorf("ShellCommand() args = %v, want just the shell", cmd.Args)
+	}
+
+	env := map[string]string{}
+	for _, kv := range cmd.Env {
+		// Later entries win, as they do when the command runs
+		if key, value, ok := strings.Cut(kv, "="); ok {
+			env[key] = value
+		}
+	}
+	if env[_shell.SubshellVersionEnv] != "1.25.1" {
+		t.Errorf("%s = %q, want 1.25.1", _shell.SubshellVersionEnv, env[_shell.SubshellVersionEnv])
+	}
+	if env["GOROOT"] != versionDir {
+		t.Errorf("GOROOT = %q, want %s", env["GOROOT"], versionDir)
+	}
+	if !strings.HasPrefix(env["PATH"], filepath.Join(versionDir, "bin")+string(os.PathListSeparator)) {
+		t.Errorf("PATH = %q, want the version's bin directory first", env["PATH"])
+	}
+}
+
+func TestManager_SessionVersion(t *testing.T) {
+	config := createTestConfig(t)
+	manager := createTestManager(t, config)
+	t.Setenv(_shell.SessionPIDEnv, "4242")
+
+	alive := map[int]bool{4242: true}
+	original := sessionProcessAlive
+	sessionProcessAlive = func(pid int) bool { return alive[pid] }
+	t.Cleanup(func() { sessionProcessAlive = original })
+
+	if err := manager.SetSessionVersion("1.25.1"); !errors.Is(err, ErrNotInstalled) {
+		t.Errorf("SetSessionVersion() for a missing version error = %v, want ErrNotInstalled", err)
+	}
+
+	os.MkdirAll(filepath.Join(config.GetVersionDir("1.25.1"), "bin"), 0755)
+	os.MkdirAll(filepath.Join(config.GetVersionDir("1.24.0"), "bin"), 0755)
+	manager.setLocalVersion("1.24.0")
+
+	// A shell that exited without cleaning up, and one that is still open
+	os.WriteFile(config.GetSessionFile(99), []byte("1.24.0\n"), 0644)
+	os.WriteFile(config.GetSessionFile(100), []byte("1.24.0\n"), 0644)
+	alive[100] = true
+
+	if err := manager.SetSessionVersion("1.25.1"); err != nil {
+		t.Fatalf("SetSessionVersion() error = %v", err)
+	}
+	if got := manager.SessionVersion(); got != "1.25.1" {
+		t.Errorf("SessionVersion() = %q, want 1.25.1", got)
+	}
+	if got, err := manager.Current(); err != nil || got != "1.25.1" {
+		t.Errorf("Current() = %q, %v, want the session version ahead of the local one", got, err)
+	}
+	if got := manager.CurrentActivationMethod(); got != "session-only" {
+		t.Errorf("CurrentActivationMethod() = %q, want session-only", got)
+	}
+	if _, err := os.Stat(config.GetSessionFile(99)); !os.IsNotExist(err) {
+		t.Error("the session file of an exited shell should be removed")
+	}
+	if _, err := os.Stat(config.GetSessionFile(100)); err != nil {
+		t.Errorf("the session file of a running shell should be kept: %v", err)
+	}
+
+	// Another terminal has its own session
+	t.Setenv(_shell.SessionPIDEnv, "100")
+	if got := manager.SessionVersion(); got != "1.24.0" {
+		t.Errorf("SessionVersion() in another terminal = %q, want 1.24.0", got)
+	}
+
+	t.Setenv(_shell.SessionPIDEnv, "4242")
+	if err := manager.ClearSessionVersion(); err != nil {
+		t.Fatalf("ClearSessionVersion() error = %v", err)
+	}
+	if got := manager.SessionVersion(); got != "" {
+		t.Errorf("SessionVersion() after clearing = %q, want none", got)
+	}
+	if err := manager.ClearSessionVersion(); err != nil {
+		t.Errorf("ClearSessionVersion() without a session error = %v", err)
+	}
+}
+
+func TestManager_PermissionHints(t *testing.T) {
+	if runtime.GOOS == "windows" || os.Geteuid() == 0 {
+		t.Skip("needs Unix permissions that apply to the current user")
+	}
+
+	tests := []struct {
+		name    string
+		lock    func(*_config.Config) string
+		run     func(*Manager) error
+		wantDir func(*_config.Config) string
+	}{
+		{
+			name: "createSymlink",
+			lock: func(c *_config.Config) string {
+				os.MkdirAll(c.GetBinPath(), 0755)
+				return c.GetBinPath()
+			},
+			run:     func(m *Manager) error { return m.createSymlink("1.20.0") },
+			wantDir: func(c *_config.Config) string { return c.GetBinPath() },
+		},
+		{
+			name:    "setLocalVersion",
+			lock:    func(c *_config.Config) string { return filepath.Dir(c.AutoSwitch.ProjectFile) },
+			run:     func(m *Manager) error { return m.setLocalVersion("1.20.0") },
+			wantDir: func(c *_config.Config) string { return filepath.Dir(c.AutoSwitch.ProjectFile) },
+		},
+		{
+			name: "Clean",
+			lock: func(c *_config.Config) string {
+				os.WriteFile(filepath.Join(c.CacheDir, "go1.20.0.tar.gz"), []byte("data"), 0644)
+				return c.CacheDir
+			},
+			run:     func(m *Manager) error { return m.Clean() },
+			wantDir: func(c *_config.Config) string { return c.CacheDir },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := createTestConfig(t)
+			manager := createTestManager(t, config)
+			os.MkdirAll(filepath.Join(config.GetVersionDir("1.20.0"), "bin"), 0755)
+
+			locked := tt.lock(config)
+			os.Chmod(locked, 0555)
+			t.Cleanup(func() { os.Chmod(locked, 0755) })
+
+			err := tt.run(manager)
+			if !errors.Is(err, fs.ErrPermission) {
+				t.Fatalf("error = %v, want a permission error", err)
+			}
+			var permErr *_util.PermissionError
+			if !errors.As(err, &permErr) {
+				t.Fatalf("error = %v, want a *PermissionError with advice", err)
+			}
+			if want := tt.wantDir(config); permErr.Dir != want {
+				t.Errorf("advice names %s, want %s", permErr.Dir, want)
+			}
+			if !strings.Contains(err.Error(), "check the ownership and permissions of") {
+				t.Errorf("error = %q, want advice on fixing permissions", err)
+			}
+		})
+	}
+}
+
+func TestManager_Export(t *testing.T) {
+	config := createTestConfig(t)
+	manager := createTestManager(t, config)
+
+	manifest, err := manager.Export()
+	if err != nil {
+		t.Fatalf("Export() error = %v", err)
+	}
+	if manifest.SchemaVersion != ManifestSchemaVersion {
+		t.Errorf("Export() schema version = %d, want %d", manifest.SchemaVersion, ManifestSchemaVersion)
+	}
+	if manifest.Versions == nil || len(manifest.Versions) != 0 {
+		t.Errorf("Expected empty, non-nil versions, got %#v", manifest.Versions)
+	}
+
+	os.MkdirAll(config.GetVersionDir("1.20.0"), 0755)
+	os.MkdirAll(config.GetVersionDir("1.21.0"), 0755)
+	config.DefaultVersion = "1.21.0"
+
+	manifest, err = manager.Export()
+	if err != nil {
+		t.Fatalf("Export() error = %v", err)
+	}
+	if len(manifest.Versions) != 2 {
+		t.Errorf("Expected 2 versions, got %v", manifest.Versions)
+	}
+	if manifest.Default != "1.21.0" {
+		t.Errorf("Export() default = %s, want 1.21.0", manifest.Default)
+	}
+	if manifest.Tags != nil {
+		t.Errorf("Export() tags = %v, want none", manifest.Tags)
+	}
+
+	manager.SetTag("1.20.0", "legacy service")
+	manifest, err = manager.Export()
+	if err != nil {
+		t.Fatalf("Export() error = %v", err)
+	}
+	if len(manifest.Tags) != 1 || manifest.Tags["1.20.0"] != "legacy service" {
+		t.Errorf("Export() tags = %v, want 1.20.0 tagged", manifest.Tags)
+	}
+}
+
+func TestParseManifest(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		want    []string
+		wantErr string
+	}{
+		{
+			name: "valid manifest",
+			data: `{"schema_version": 1, "versions": ["1.21.0", "1.20.0"], "default": "1.21.0"}`,
+			want: []string{"1.21.0", "1.20.0"},
+		},
+		{
+			name:    "malformed JSON",
+			data:    `{"schema_version": `,
+			wantErr: "failed to parse manifest",
+		},
+		{
+			name:    "missing schema version",
+			data:    `{"versions": ["1.21.0"]}`,
+			wantErr: "missing schema_version",
+		},
+		{
+			name:    "future schema version",
+			data:    `{"schema_version": 99, "versions": []}`,
+			wantErr: "unsupported manifest schema version 99",
+		},
+		{
+			name:    "invalid version",
+			data:    `{"schema_version": 1, "versions": ["1.21.0; rm -rf /"]}`,
+			wantErr: "invalid version in manifest",
+		},
+		{
+			name: "tags for listed versions",
+			data: `{"schema_version": 1, "versions": ["1.21.0"], "tags": {"1.21.0": "work"}}`,
+			want: []string{"1.21.0"},
+		},
+		{
+			name:    "tag for unlisted version",
+			data:    `{"schema_version": 1, "versions": ["1.21.0"], "tags": {"1.20.0": "work"}}`,
+			wantErr: "does not list",
+		},
+		{
+			name:    "multi-line tag",
+			data:    `{"schema_version": 1, "versions": ["1.21.0"], "tags": {"1.21.0": "a\nb"}}`,
+			wantErr: "invalid tag",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			manifest, err := ParseManifest([]byte(tt.data))
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("ParseManifest() error = %v, want containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseManifest() error = %v", err)
+			}
+			if strings.Join(manifest.Versions, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("ParseManifest() versions = %v, want %v", manifest.Versions, tt.want)
+			}
+		})
+	}
+}
+
+func TestManager_Import(t *testing.T) {
+	config := createTestConfig(t)
+	manager := createTestManager(t, config)
+
+	os.MkdirAll(filepath.Join(config.GetVersionDir("1.20.0"), "bin"), 0755)
+	os.MkdirAll(filepath.Join(config.GetVersionDir("1.21.0"), "bin"), 0755)
+
+	result := manager.Import(&Manifest{
+		SchemaVersion: ManifestSchemaVersion,
+		Versions:      []string{"1.20.0", "1.21.0"},
+		Default:       "1.21.0",
+	})
+
+	if len(result.Added) != 0 || len(result.Failures) != 0 {
+		t.Errorf("Expected nothing to install, got added=%v failures=%v", result.Added, result.Failures)
+	}
+	if len(result.Skipped) != 2 {
+		t.Errorf("Expected 2 skipped versions, got %v", result.Skipped)
+	}
+	if !result.DefaultSet || manager.DefaultVersion() != "1.21.0" {
+		t.Errorf("Expected default to be restored to 1.21.0, got %q (set=%v)", manager.DefaultVersion(), result.DefaultSet)
+	}
+
+	t.Run("tags are restored without overwriting local ones", func(t *testing.T) {
+		manager.SetTag("1.21.0", "mine")
+		manager.Import(&Manifest{
+			SchemaVersion: ManifestSchemaVersion,
+			Versions:      []string{"1.20.0", "1.21.0"},
+			Tags:          map[string]string{"1.20.0": "imported", "1.21.0": "theirs"},
+		})
+		if got := manager.Tag("1.20.0"); got != "imported" {
+			t.Errorf("Tag(1.20.0) = %q, want imported", got)
+		}
+		if got := manager.Tag("1.21.0"); got != "mine" {
+			t.Errorf("Tag(1.21.0) = %q, want mine", got)
+		}
+	})
+
+	t.Run("missing default is reported but not fatal", func(t *testing.T) {
+		result := manager.Import(&Manifest{
+			SchemaVersion: ManifestSchemaVersion,
+			Versions:      []string{"1.20.0"},
+			Default:       "1.19.0",
+		})
+		if result.DefaultSet {
+			t.Error("Expected default not to be set for a version that is not installed")
+		}
+		if manager.DefaultVersion() != "1.21.0" {
+			t.Errorf("Default changed unexpectedly to %s", manager.DefaultVersion())
+		}
+	})
+}
+
 func TestManager_Uninstall(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -822,18 +2365,463 @@ func TestManager_Uninstall(t *testing.T) {
 			config := createTestConfig(t)
 			manager := createTestManager(t, config)
 
-			// Clean up
-			versionDir := config.GetVersionDir(tt.version)
-			os.RemoveAll(versionDir)
+			// Clean up
+			versionDir := config.GetVersionDir(tt.version)
+			os.RemoveAll(versionDir)
+
+			tt.setup(config)
+
+			err := manager.Uninstall(tt.version)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Uninstall() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestManager_ReplaceActive(t *testing.T) {
+	config := createTestConfig(t)
+	manager := createTestManager(t, config)
+	t.Setenv(_shell.SessionPIDEnv, "4242")
+
+	original := sessionProcessAlive
+	sessionProcessAlive = func(pid int) bool { return pid == 4242 }
+	t.Cleanup(func() { sessionProcessAlive = original })
+
+	for _, version := range []string{"1.20.0", "1.21.0", "1.22.0"} {
+		binDir := filepath.Join(config.GetVersionDir(version), "bin")
+		os.MkdirAll(binDir, 0755)
+		os.WriteFile(filepath.Join(binDir, "go"), []byte("#!/bin/sh\n"), 0755)
+	}
+
+	if _, err := manager.ReplaceActive("1.20.0", "1.99.0"); !errors.Is(err, ErrNotInstalled) {
+		t.Errorf("ReplaceActive() to a missing version error = %v, want ErrNotInstalled", err)
+	}
+
+	// Nothing refers to 1.20.0 yet
+	if moved, err := manager.ReplaceActive("1.20.0", "1.21.0"); err != nil || len(moved) != 0 {
+		t.Errorf("ReplaceActive() with nothing to move = %v, %v, want no scopes", moved, err)
+	}
+
+	if err := manager.SetDefault("1.20.0"); err != nil {
+		t.Fatalf("SetDefault() error = %v", err)
+	}
+	if err := manager.SetSessionVersion("1.20.0"); err != nil {
+		t.Fatalf("SetSessionVersion() error = %v", err)
+	}
+
+	moved, err := manager.ReplaceActive("1.20.0", "1.21.0")
+	if err != nil {
+		t.Fatalf("ReplaceActive() error = %v", err)
+	}
+	if strings.Join(moved, ",") != "default,session" {
+		t.Errorf("ReplaceActive() moved = %v, want default and session", moved)
+	}
+	if config.DefaultVersion != "1.21.0" || manager.globalLinkVersion() != "1.21.0" {
+		t.Errorf("default = %q, link = %q, want both 1.21.0", config.DefaultVersion, manager.globalLinkVersion())
+	}
+	if got := manager.SessionVersion(); got != "1.21.0" {
+		t.Errorf("SessionVersion() = %q, want 1.21.0", got)
+	}
+
+	// Only the scopes that selected the version move
+	manager.ClearSessionVersion()
+	manager.SetSessionVersion("1.22.0")
+	if moved, err := manager.ReplaceActive("1.21.0", "1.20.0"); err != nil || strings.Join(moved, ",") != "default" {
+		t.Errorf("ReplaceActive() = %v, %v, want only the default moved", moved, err)
+	}
+	if got := manager.SessionVersion(); got != "1.22.0" {
+		t.Errorf("SessionVersion() = %q, want 1.22.0 left alone", got)
+	}
+}
+
+func TestManager_ForceUninstall(t *testing.T) {
+	tests := []struct {
+		name        string
+		linked      bool
+		isDefault   bool
+		wantErr     bool
+		wantLinkErr string
+	}{
+		{
+			name:        "active default version clears symlink and default",
+			linked:      true,
+			isDefault:   true,
+			wantLinkErr: "no Go version is currently active",
+		},
+		{
+			name:   "inactive version leaves other link alone",
+			linked: false,
+		},
+		{
+			name:    "not installed",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := createTestConfig(t)
+			manager := createTestManager(t, config)
+
+			if !tt.wantErr {
+				for _, version := range []string{"1.20.0", "1.21.0"} {
+					binDir := filepath.Join(config.GetVersionDir(version), "bin")
+					os.MkdirAll(binDir, 0755)
+					os.WriteFile(filepath.Join(binDir, "go"), []byte("#!/bin/sh\n"), 0755)
+				}
+
+				linkTo := "1.21.0"
+				if tt.linked {
+					linkTo = "1.20.0"
+				}
+				if err := manager.createSymlink(linkTo); err != nil {
+					t.Fatalf("createSymlink() error = %v", err)
+				}
+				if tt.isDefault {
+					config.DefaultVersion = "1.20.0"
+				}
+			}
+
+			err := manager.ForceUninstall("1.20.0")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ForceUninstall() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			if manager.IsInstalled("1.20.0") {
+				t.Error("ForceUninstall() left the version installed")
+			}
+			if config.DefaultVersion != "" {
+				t.Errorf("DefaultVersion = %q, want cleared", config.DefaultVersion)
+			}
+
+			global, err := manager.CurrentGlobal()
+			if tt.wantLinkErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantLinkErr) {
+					t.Errorf("CurrentGlobal() error = %v, want containing %q", err, tt.wantLinkErr)
+				}
+				return
+			}
+			if err != nil || global != "1.21.0" {
+				t.Errorf("CurrentGlobal() = %q, %v; want 1.21.0", global, err)
+			}
+		})
+	}
+}
+
+func TestManager_Prune(t *testing.T) {
+	installed := []string{"1.20.0", "1.21.0", "1.22.0", "1.23.0"}
+
+	tests := []struct {
+		name          string
+		dryRun        bool
+		policy        PrunePolicy
+		setup         func(*testing.T, *_config.Config)
+		wantRemoved   []string
+		wantProtected map[string]string
+	}{
+		{
+			name:        "nothing protected removes everything",
+			wantRemoved: []string{"1.23.0", "1.22.0", "1.21.0", "1.20.0"},
+		},
+		{
+			name: "default version is protected",
+			setup: func(t *testing.T, c *_config.Config) {
+				c.DefaultVersion = "1.21.0"
+			},
+			wantRemoved:   []string{"1.23.0", "1.22.0", "1.20.0"},
+			wantProtected: map[string]string{"1.21.0": "system default"},
+		},
+		{
+			name: "active version is protected",
+			setup: func(t *testing.T, c *_config.Config) {
+				binDir := filepath.Join(c.GetVersionDir("1.22.0"), "bin")
+				os.WriteFile(filepath.Join(binDir, "go"), []byte("#!/bin/sh\necho 'go version go1.22.0 linux/amd64'"), 0755)
+				t.Setenv("PATH", binDir)
+			},
+			wantRemoved:   []string{"1.23.0", "1.21.0", "1.20.0"},
+			wantProtected: map[string]string{"1.22.0": "currently active"},
+		},
+		{
+			name: "local version is protected alongside a different session version",
+			setup: func(t *testing.T, c *_config.Config) {
+				binDir := filepath.Join(c.GetVersionDir("1.21.0"), "bin")
+				os.WriteFile(filepath.Join(binDir, "go"), []byte("#!/bin/sh\necho 'go version go1.21.0 linux/amd64'"), 0755)
+				t.Setenv("PATH", binDir)
+				os.WriteFile(c.AutoSwitch.ProjectFile, []byte("1.23.0"), 0644)
+			},
+			wantRemoved: []string{"1.22.0", "1.20.0"},
+			wantProtected: map[string]string{
+				"1.21.0": "currently active",
+				"1.23.0": "project-local",
+			},
+		},
+		{
+			name: "active, default, and local are all protected",
+			setup: func(t *testing.T, c *_config.Config) {
+				binDir := filepath.Join(c.GetVersionDir("1.22.0"), "bin")
+				os.WriteFile(filepath.Join(binDir, "go"), []byte("#!/bin/sh\necho 'go version go1.22.0 linux/amd64'"), 0755)
+				t.Setenv("PATH", binDir)
+				c.DefaultVersion = "1.21.0"
+				os.WriteFile(c.AutoSwitch.ProjectFile, []byte("1.20.0"), 0644)
+			},
+			wantRemoved: []string{"1.23.0"},
+			wantProtected: map[string]string{
+				"1.20.0": "project-local",
+				"1.21.0": "system default",
+				"1.22.0": "currently active",
+			},
+		},
+		{
+			name:   "dry run removes nothing",
+			dryRun: true,
+			setup: func(t *testing.T, c *_config.Config) {
+				c.DefaultVersion = "1.20.0"
+			},
+			wantRemoved:   []string{"1.23.0", "1.22.0", "1.21.0"},
+			wantProtected: map[string]string{"1.20.0": "system default"},
+		},
+		{
+			name:        "keep retains the newest versions",
+			policy:      PrunePolicy{Keep: 2},
+			wantRemoved: []string{"1.21.0", "1.20.0"},
+			wantProtected: map[string]string{
+				"1.23.0": RetainedReason,
+				"1.22.0": RetainedReason,
+			},
+		},
+		{
+			name:   "keep is in addition to versions in use",
+			policy: PrunePolicy{Keep: 1},
+			setup: func(t *testing.T, c *_config.Config) {
+				c.DefaultVersion = "1.20.0"
+			},
+			wantRemoved: []string{"1.22.0", "1.21.0"},
+			wantProtected: map[string]string{
+				"1.23.0": RetainedReason,
+				"1.20.0": "system default",
+			},
+		},
+		{
+			name:   "versions in use keep their own reason",
+			policy: PrunePolicy{Keep: 1},
+			setup: func(t *testing.T, c *_config.Config) {
+				c.DefaultVersion = "1.23.0"
+			},
+			wantRemoved:   []string{"1.22.0", "1.21.0", "1.20.0"},
+			wantProtected: map[string]string{"1.23.0": "system default"},
+		},
+		{
+			name:   "keep larger than the installed count removes nothing",
+			policy: PrunePolicy{Keep: 10},
+			wantProtected: map[string]string{
+				"1.23.0": RetainedReason,
+				"1.22.0": RetainedReason,
+				"1.21.0": RetainedReason,
+				"1.20.0": RetainedReason,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := createTestConfig(t)
+			manager := createTestManager(t, config)
+			t.Setenv("PATH", "/nonexistent/path")
+
+			for _, version := range installed {
+				binDir := filepath.Join(config.GetVersionDir(version), "bin")
+				os.MkdirAll(binDir, 0755)
+				os.WriteFile(filepath.Join(binDir, "gofmt"), []byte("0123456789"), 0755)
+			}
+
+			if tt.setup != nil {
+				tt.setup(t, config)
+			}
+
+			removed, protected, freed, err := manager.Prune(tt.dryRun, tt.policy)
+			if err != nil {
+				t.Fatalf("Prune() error = %v", err)
+			}
+
+			if !reflect.DeepEqual(removed, tt.wantRemoved) {
+				t.Errorf("Prune() removed = %v, want %v", removed, tt.wantRemoved)
+			}
+
+			if len(protected) != len(tt.wantProtected) {
+				t.Errorf("Prune() protected = %v, want %v", protected, tt.wantProtected)
+			}
+			for version, reason := range tt.wantProtected {
+				if !strings.HasPrefix(protected[version], reason) {
+					t.Errorf("Prune() protected[%s] = %q, want prefix %q", version, protected[version], reason)
+				}
+			}
+
+			if freed < int64(10*len(tt.wantRemoved)) {
+				t.Errorf("Prune() freed = %d, want at least %d", freed, 10*len(tt.wantRemoved))
+			}
+
+			for _, version := range installed {
+				_, isProtected := protected[version]
+				wantInstalled := tt.dryRun || isProtected
+				if got := manager.IsInstalled(version); got != wantInstalled {
+					t.Errorf("IsInstalled(%s) = %v after Prune(), want %v", version, got, wantInstalled)
+				}
+			}
+		})
+	}
+}
+
+func TestManager_Prune_LocalVersionMatching(t *testing.T) {
+	tests := []struct {
+		name          string
+		installed     []string
+		local         string
+		wantProtected []string
+	}{
+		{
+			name:          "1.2 does not protect 1.20 or 1.22",
+			installed:     []string{"1.2", "1.20.0", "1.20.5", "1.22.1"},
+			local:         "1.2",
+			wantProtected: []string{"1.2"},
+		},
+		{
+			name:          "1.2 with no 1.2 install protects nothing",
+			installed:     []string{"1.20.0", "1.22.1"},
+			local:         "1.2",
+			wantProtected: nil,
+		},
+		{
+			name:          "partial version protects only the best match",
+			installed:     []string{"1.22.0", "1.22.3", "1.23.0"},
+			local:         "1.22",
+			wantProtected: []string{"1.22.3"},
+		},
+		{
+			name:          "exact installed version is protected alongside the best match",
+			installed:     []string{"1.22.0", "1.22.3"},
+			local:         "1.22.0",
+			wantProtected: []string{"1.22.0", "1.22.3"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := createTestConfig(t)
+			manager := createTestManager(t, config)
+			t.Setenv("PATH", "/nonexistent/path")
+
+			for _, version := range tt.installed {
+				os.MkdirAll(filepath.Join(config.GetVersionDir(version), "bin"), 0755)
+			}
+			os.WriteFile(config.AutoSwitch.ProjectFile, []byte(tt.local), 0644)
 
-			tt.setup(config)
+			protected := manager.protectedVersions(tt.installed)
 
-			err := manager.Uninstall(tt.version)
-			if (err != nil) != tt.wantErr {
-				t.Errorf("Uninstall() error = %v, wantErr %v", err, tt.wantErr)
+			var got []string
+			for version := range protected {
+				got = append(got, version)
+			}
+			slices.Sort(got)
+
+			if !reflect.DeepEqual(got, tt.wantProtected) {
+				t.Errorf("protectedVersions() = %v, want %v", protected, tt.wantProtected)
+			}
+		})
+	}
+}
+
+func TestRetainedVersions(t *testing.T) {
+	installed := []string{"1.22.0", "1.23.1", "1.22.10", "1.23rc1", "1.22.2", "1.21.13", "1.23.0"}
+
+	tests := []struct {
+		name   string
+		policy PrunePolicy
+		want   []string
+	}{
+		{name: "zero keeps nothing", policy: PrunePolicy{}, want: nil},
+		{name: "newest overall", policy: PrunePolicy{Keep: 3}, want: []string{"1.23rc1", "1.23.0", "1.23.1"}},
+		{name: "per minor", policy: PrunePolicy{Keep: 1, PerMinor: true}, want: []string{"1.21.13", "1.22.10", "1.23.1"}},
+		{name: "per minor counts prereleases on their line", policy: PrunePolicy{Keep: 3, PerMinor: true}, want: []string{"1.21.13", "1.22.10", "1.22.2", "1.22.0", "1.23.1", "1.23.0", "1.23rc1"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			retained := retainedVersions(installed, tt.policy)
+
+			var got []string
+			for version, reason := range retained {
+				if !strings.HasPrefix(reason, RetainedReason) {
+					t.Errorf("retainedVersions()[%s] = %q, want prefix %q", version, reason, RetainedReason)
+				}
+				got = append(got, version)
+			}
+			slices.Sort(got)
+			want := slices.Clone(tt.want)
+			slices.Sort(want)
+
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("retainedVersions() = %v, want %v", got, want)
 			}
 		})
 	}
+
+	if reason := retainedVersions(installed, PrunePolicy{Keep: 2, PerMinor: true})["1.22.10"]; !strings.Contains(reason, "Go 1.22") {
+		t.Errorf("per-minor reason = %q, want it to name the line", reason)
+	}
+}
+
+func TestManager_Prune_InvalidPolicy(t *testing.T) {
+	config := createTestConfig(t)
+	manager := createTestManager(t, config)
+
+	if _, _, _, err := manager.Prune(true, PrunePolicy{Keep: -1}); err == nil {
+		t.Error("Prune() should reject a negative retention count")
+	}
+}
+
+func TestManager_Prune_NoVersions(t *testing.T) {
+	config := createTestConfig(t)
+	manager := createTestManager(t, config)
+
+	removed, protected, freed, err := manager.Prune(false, PrunePolicy{Keep: 2})
+	if err != nil {
+		t.Fatalf("Prune() error = %v", err)
+	}
+	if len(removed) != 0 || len(protected) != 0 || freed != 0 {
+		t.Errorf("Prune() = (%v, %v, %d), want nothing removed or protected", removed, protected, freed)
+	}
+}
+
+func TestManager_PlanPrune(t *testing.T) {
+	config := createTestConfig(t)
+	config.DefaultVersion = "1.20.0"
+	manager := createTestManager(t, config)
+	for _, version := range []string{"1.22.1", "1.21.5"} {
+		os.MkdirAll(filepath.Join(config.GetVersionDir(version), "bin"), 0755)
+	}
+
+	plan, err := manager.PlanPrune(PrunePolicy{Keep: 1})
+	if err != nil {
+		t.Fatalf("PlanPrune() error = %v", err)
+	}
+	if len(plan.Installed) != 2 || !reflect.DeepEqual(plan.Remove, []string{"1.21.5"}) {
+		t.Errorf("PlanPrune() installed %v, remove %v; want 2 installed and [1.21.5] removed", plan.Installed, plan.Remove)
+	}
+	if _, ok := plan.Protected["1.20.0"]; ok {
+		t.Errorf("PlanPrune() protected = %v, want no entry for the default that is not installed", plan.Protected)
+	}
+
+	removed, _, err := manager.ApplyPrune(plan)
+	if err != nil || !reflect.DeepEqual(removed, []string{"1.21.5"}) {
+		t.Errorf("ApplyPrune() = %v, %v; want [1.21.5]", removed, err)
+	}
+	if !manager.IsInstalled("1.22.1") || manager.IsInstalled("1.21.5") {
+		t.Error("ApplyPrune() should remove only the planned version")
+	}
 }
 
 func TestManager_Clean(t *testing.T) {
@@ -903,6 +2891,112 @@ func TestManager_Clean(t *testing.T) {
 	}
 }
 
+func TestManager_CacheEntries(t *testing.T) {
+	config := createTestConfig(t)
+	manager := createTestManager(t, config)
+
+	now := time.Now()
+	os.WriteFile(filepath.Join(config.CacheDir, "go1.21.0.linux-amd64.tar.gz"), []byte("0123456789"), 0644)
+	os.MkdirAll(filepath.Join(config.CacheDir, "extract-tmp"), 0755)
+	os.WriteFile(filepath.Join(config.CacheDir, "extract-tmp", "file"), []byte("01234"), 0644)
+	os.Chtimes(filepath.Join(config.CacheDir, "extract-tmp"), now.Add(-48*time.Hour), now.Add(-48*time.Hour))
+
+	entries, err := manager.CacheEntries()
+	if err != nil {
+		t.Fatalf("CacheEntries() error = %v", err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("Expected 2 entries, got %d: %v", len(entries), entries)
+	}
+	if entries[0].Name != "extract-tmp" || entries[0].Size != 5 {
+		t.Errorf("Expected oldest entry extract-tmp with size 5, got %s with size %d", entries[0].Name, entries[0].Size)
+	}
+	if entries[1].Name != "go1.21.0.linux-amd64.tar.gz" || entries[1].Size != 10 {
+		t.Errorf("Expected archive with size 10, got %s with size %d", entries[1].Name, entries[1].Size)
+	}
+
+	t.Run("missing cache directory", func(t *testing.T) {
+		os.RemoveAll(config.CacheDir)
+		entries, err := manager.CacheEntries()
+		if err != nil || len(entries) != 0 {
+			t.Errorf("Expected no entries and no error, got %v, %v", entries, err)
+		}
+	})
+}
+
+func TestManager_CleanSelective(t *testing.T) {
+	tests := []struct {
+		name          string
+		olderThan     time.Duration
+		dryRun        bool
+		expectMatched []string
+		expectFreed   int64
+		expectKept    []string
+	}{
+		{
+			name:          "older than removes only stale entries",
+			olderThan:     7 * 24 * time.Hour,
+			expectMatched: []string{"old.tar.gz"},
+			expectFreed:   10,
+			expectKept:    []string{"recent.tar.gz"},
+		},
+		{
+			name:          "zero duration removes everything",
+			expectMatched: []string{"old.tar.gz", "recent.tar.gz"},
+			expectFreed:   15,
+		},
+		{
+			name:          "dry run keeps files",
+			olderThan:     7 * 24 * time.Hour,
+			dryRun:        true,
+			expectMatched: []string{"old.tar.gz"},
+			expectFreed:   10,
+			expectKept:    []string{"old.tar.gz", "recent.tar.gz"},
+		},
+		{
+			name:       "nothing old enough",
+			olderThan:  60 * 24 * time.Hour,
+			expectKept: []string{"old.tar.gz", "recent.tar.gz"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := createTestConfig(t)
+			manager := createTestManager(t, config)
+
+			old := time.Now().Add(-30 * 24 * time.Hour)
+			oldPath := filepath.Join(config.CacheDir, "old.tar.gz")
+			os.WriteFile(oldPath, []byte("0123456789"), 0644)
+			os.Chtimes(oldPath, old, old)
+			os.WriteFile(filepath.Join(config.CacheDir, "recent.tar.gz"), []byte("01234"), 0644)
+
+			matched, freed, err := manager.CleanSelective(tt.olderThan, tt.dryRun)
+			if err != nil {
+				t.Fatalf("CleanSelective() error = %v", err)
+			}
+
+			var names []string
+			for _, entry := range matched {
+				names = append(names, entry.Name)
+			}
+			if !slices.Equal(names, tt.expectMatched) {
+				t.Errorf("Expected matched %v, got %v", tt.expectMatched, names)
+			}
+			if freed != tt.expectFreed {
+				t.Errorf("Expected %d bytes freed, got %d", tt.expectFreed, freed)
+			}
+
+			for _, name := range []string{"old.tar.gz", "recent.tar.gz"} {
+				_, statErr := os.Stat(filepath.Join(config.CacheDir, name))
+				if kept := slices.Contains(tt.expectKept, name); kept != (statErr == nil) {
+					t.Errorf("Expected %s kept=%v, stat error %v", name, kept, statErr)
+				}
+			}
+		})
+	}
+}
+
 func TestManager_DefaultVersion(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -937,72 +3031,406 @@ func TestManager_DefaultVersion(t *testing.T) {
 			config := tt.setup()
 			manager := createTestManager(t, config)
 
-			got := manager.DefaultVersion()
-			if got != tt.want {
-				t.Errorf("DefaultVersion() = %v, want %v", got, tt.want)
-			}
-		})
+			got := manager.DefaultVersion()
+			if got != tt.want {
+				t.Errorf("DefaultVersion() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestManager_setLocalVersion(t *testing.T) {
+	tests := []struct {
+		name    string
+		version string
+		setup   func(*_config.Config)
+		wantErr bool
+	}{
+		{
+			name:    "set local version successfully",
+			version: "1.20.0",
+			setup:   func(c *_config.Config) {},
+			wantErr: false,
+		},
+		{
+			name:    "set local version with write permission failure",
+			version: "1.20.0",
+			setup: func(c *_config.Config) {
+				// Make directory read-only
+				projectDir := filepath.Dir(c.AutoSwitch.ProjectFile)
+				os.Chmod(projectDir, 0444)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := createTestConfig(t)
+			manager := createTestManager(t, config)
+
+			tt.setup(config)
+
+			// Cleanup permissions after test
+			t.Cleanup(func() {
+				os.Chmod(filepath.Dir(config.AutoSwitch.ProjectFile), 0755)
+			})
+
+			err := manager.setLocalVersion(tt.version)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("setLocalVersion() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			// Check that file was created with correct content
+			if !tt.wantErr {
+				data, err := os.ReadFile(config.AutoSwitch.ProjectFile)
+				if err != nil {
+					t.Errorf("Failed to read local version file: %v", err)
+				}
+
+				if strings.TrimSpace(string(data)) != tt.version {
+					t.Errorf("File content = %s, want %s", string(data), tt.version)
+				}
+			}
+		})
+	}
+}
+
+func TestManager_Pin(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("fake go binary is a shell script")
+	}
+
+	tests := []struct {
+		name    string
+		active  string
+		minor   bool
+		want    string
+		wantErr bool
+	}{
+		{name: "exact patch", active: "1.22.5", want: "1.22.5"},
+		{name: "minor line", active: "1.22.5", minor: true, want: "1.22"},
+		{name: "pre-release minor line", active: "1.23rc1", minor: true, want: "1.23"},
+		{name: "no active version", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := createTestConfig(t)
+			manager := createTestManager(t, config)
+
+			t.Setenv("PATH", "/nonexistent/path")
+			if tt.active != "" {
+				binDir := filepath.Join(config.GetVersionDir(tt.active), "bin")
+				writeFakeGo(t, binDir, tt.active)
+				t.Setenv("PATH", binDir)
+			}
+
+			got, err := manager.Pin(tt.minor)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Pin() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				if _, err := os.Stat(config.AutoSwitch.ProjectFile); !os.IsNotExist(err) {
+					t.Error("Pin() should not write the project file when it fails")
+				}
+				return
+			}
+
+			data, _ := os.ReadFile(config.AutoSwitch.ProjectFile)
+			if got != tt.want || string(data) != tt.want {
+				t.Errorf("Pin() = %q, file holds %q, want %q", got, data, tt.want)
+			}
+		})
+	}
+}
+
+func TestManager_FindProjectVersionFile(t *testing.T) {
+	tests := []struct {
+		name     string
+		files    map[string]string
+		wantFile string
+		want     string
+	}{
+		{
+			name:  "no version files",
+			files: map[string]string{},
+		},
+		{
+			name:     "govman file takes precedence",
+			files:    map[string]string{".govman-goversion": "1.21.0\n", ".go-version": "1.20.0", "go.mod": "module x\n\ngo 1.19\n"},
+			wantFile: ".govman-goversion",
+			want:     "1.21.0",
+		},
+		{
+			name:     "legacy file read when the project file is absent",
+			files:    map[string]string{".govman-version": "1.18.0\n", ".go-version": "1.20.0"},
+			wantFile: ".govman-version",
+			want:     "1.18.0",
+		},
+		{
+			name:     "project file takes precedence over the legacy file",
+			files:    map[string]string{".govman-goversion": "1.21.0", ".govman-version": "1.18.0"},
+			wantFile: ".govman-goversion",
+			want:     "1.21.0",
+		},
+		{
+			name:     "falls back to .go-version",
+			files:    map[string]string{".go-version": " 1.20.5 ", "go.mod": "module x\n\ngo 1.19\n"},
+			wantFile: ".go-version",
+			want:     "1.20.5",
+		},
+		{
+			name:     "empty file is skipped",
+			files:    map[string]string{".govman-goversion": "\n", "go.mod": "module x\n\ngo 1.19\n"},
+			wantFile: "go.mod",
+			want:     "1.19",
+		},
+		{
+			name:     "go.mod directive is reduced to major.minor",
+			files:    map[string]string{"go.mod": "module x\n\ngo 1.22.3 // minimum\n\ntoolchain go1.22.5\n"},
+			wantFile: "go.mod",
+			want:     "1.22",
+		},
+		{
+			name:  "go.mod without go directive",
+			files: map[string]string{"go.mod": "module x\n"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := createTestConfig(t)
+			manager := createTestManager(t, config)
+
+			dir := t.TempDir()
+			config.AutoSwitch.ProjectFile = filepath.Join(dir, ".govman-goversion")
+			config.AutoSwitch.ProjectFiles = []string{
+				filepath.Join(dir, ".govman-goversion"),
+				filepath.Join(dir, ".go-version"),
+				filepath.Join(dir, "go.mod"),
+			}
+			for name, content := range tt.files {
+				os.WriteFile(filepath.Join(dir, name), []byte(content), 0644)
+			}
+
+			gotFile, got := manager.FindProjectVersionFile()
+			wantFile := ""
+			if tt.wantFile != "" {
+				wantFile = filepath.Join(dir, tt.wantFile)
+			}
+			if gotFile != wantFile || got != tt.want {
+				t.Errorf("FindProjectVersionFile() = (%q, %q), want (%q, %q)", gotFile, got, wantFile, tt.want)
+			}
+		})
+	}
+}
+
+func TestManager_MigrateLegacyProjectFile(t *testing.T) {
+	config := createTestConfig(t)
+	manager := createTestManager(t, config)
+	dir := filepath.Dir(config.AutoSwitch.ProjectFile)
+	legacy := filepath.Join(dir, _config.LegacyProjectFile)
+
+	if got, err := manager.MigrateLegacyProjectFile(); got != "" || err != nil {
+		t.Errorf("MigrateLegacyProjectFile() without a legacy file = %q, %v; want nothing to do", got, err)
+	}
+
+	// Only the legacy file: it is renamed and keeps its version
+	os.WriteFile(legacy, []byte("1.18.0\n"), 0644)
+	if got := manager.LegacyProjectFile(); got != legacy {
+		t.Errorf("LegacyProjectFile() = %q, want %q", got, legacy)
+	}
+	if got, err := manager.MigrateLegacyProjectFile(); got != legacy || err != nil {
+		t.Fatalf("MigrateLegacyProjectFile() = %q, %v; want %q", got, err, legacy)
+	}
+	if data, _ := os.ReadFile(config.AutoSwitch.ProjectFile); string(data) != "1.18.0\n" {
+		t.Errorf("project file = %q after migration, want the legacy version", data)
+	}
+	if manager.LegacyProjectFile() != "" {
+		t.Error("the legacy file should be gone after migration")
+	}
+
+	// Both files: the project file wins, so the legacy one is removed without touching it
+	os.WriteFile(legacy, []byte("1.17.0"), 0644)
+	if _, err := manager.MigrateLegacyProjectFile(); err != nil {
+		t.Fatalf("MigrateLegacyProjectFile() with both files error = %v", err)
+	}
+	if _, err := os.Stat(legacy); !os.IsNotExist(err) {
+		t.Error("the legacy file should be removed when the project file exists")
+	}
+	if data, _ := os.ReadFile(config.AutoSwitch.ProjectFile); string(data) != "1.18.0\n" {
+		t.Errorf("project file = %q, want it left alone", data)
+	}
+}
+
+func TestManager_FindProjectVersionFile_Traversal(t *testing.T) {
+	base, err := filepath.EvalSymlinks(t.TempDir())
+	if err != nil {
+		t.Fatalf("EvalSymlinks() error = %v", err)
+	}
+	home := filepath.Join(base, "home")
+	project := filepath.Join(home, "project")
+	subdir := filepath.Join(project, "cmd", "tool")
+	os.MkdirAll(subdir, 0755)
+
+	config := createTestConfig(t)
+	manager := createTestManager(t, config)
+	t.Setenv("HOME", home)
+	config.AutoSwitch.ProjectFile = ".govman-goversion"
+	config.AutoSwitch.ProjectFiles = []string{".govman-goversion", ".go-version", "go.mod"}
+
+	t.Run("file above home is ignored", func(t *testing.T) {
+		os.WriteFile(filepath.Join(base, ".govman-goversion"), []byte("1.19.0"), 0644)
+		t.Cleanup(func() { os.Remove(filepath.Join(base, ".govman-goversion")) })
+		t.Chdir(subdir)
+
+		if path, version := manager.FindProjectVersionFile(); path != "" || version != "" {
+			t.Errorf("FindProjectVersionFile() = (%q, %q), want no match", path, version)
+		}
+	})
+
+	t.Run("file in home is found", func(t *testing.T) {
+		homeFile := filepath.Join(home, ".govman-goversion")
+		os.WriteFile(homeFile, []byte("1.20.0"), 0644)
+		t.Cleanup(func() { os.Remove(homeFile) })
+		t.Chdir(subdir)
+
+		if path, version := manager.FindProjectVersionFile(); path != homeFile || version != "1.20.0" {
+			t.Errorf("FindProjectVersionFile() = (%q, %q), want (%q, %q)", path, version, homeFile, "1.20.0")
+		}
+	})
+
+	projectFile := filepath.Join(project, ".govman-goversion")
+	os.WriteFile(projectFile, []byte("1.21.0"), 0644)
+
+	t.Run("file in parent directory is found", func(t *testing.T) {
+		t.Chdir(subdir)
+
+		if path, version := manager.FindProjectVersionFile(); path != projectFile || version != "1.21.0" {
+			t.Errorf("FindProjectVersionFile() = (%q, %q), want (%q, %q)", path, version, projectFile, "1.21.0")
+		}
+	})
+
+	t.Run("nearest directory wins over file precedence", func(t *testing.T) {
+		nearer := filepath.Join(subdir, ".go-version")
+		os.WriteFile(nearer, []byte("1.22.0"), 0644)
+		t.Cleanup(func() { os.Remove(nearer) })
+		t.Chdir(subdir)
+
+		if path, version := manager.FindProjectVersionFile(); path != nearer || version != "1.22.0" {
+			t.Errorf("FindProjectVersionFile() = (%q, %q), want (%q, %q)", path, version, nearer, "1.22.0")
+		}
+	})
+
+	t.Run("symlinked directory resolves to its target's parents", func(t *testing.T) {
+		link := filepath.Join(home, "link")
+		if err := os.Symlink(subdir, link); err != nil {
+			t.Skipf("symlinks not supported: %v", err)
+		}
+		t.Chdir(link)
+
+		if path, version := manager.FindProjectVersionFile(); path != projectFile || version != "1.21.0" {
+			t.Errorf("FindProjectVersionFile() = (%q, %q), want (%q, %q)", path, version, projectFile, "1.21.0")
+		}
+	})
+
+	t.Run("Current uses the discovered file", func(t *testing.T) {
+		t.Chdir(subdir)
+		os.MkdirAll(filepath.Join(config.GetVersionDir("1.21.0"), "bin"), 0755)
+
+		if got := manager.getLocalVersion(); got != "1.21.0" {
+			t.Errorf("getLocalVersion() = %q, want 1.21.0", got)
+		}
+	})
+}
+
+func TestManager_AutoSwitchVersion(t *testing.T) {
+	base, err := filepath.EvalSymlinks(t.TempDir())
+	if err != nil {
+		t.Fatalf("EvalSymlinks() error = %v", err)
+	}
+	project := filepath.Join(base, "project")
+	os.MkdirAll(project, 0755)
+
+	config := createTestConfig(t)
+	manager := createTestManager(t, config)
+	t.Setenv("HOME", base)
+	config.AutoSwitch.ProjectFile = ".govman-goversion"
+	config.AutoSwitch.ProjectFiles = []string{".govman-goversion"}
+
+	for _, version := range []string{"1.21.3", "1.21.10", "1.22.0"} {
+		os.MkdirAll(filepath.Join(config.GetVersionDir(version), "bin"), 0755)
 	}
-}
 
-func TestManager_setLocalVersion(t *testing.T) {
 	tests := []struct {
-		name    string
-		version string
-		setup   func(*_config.Config)
-		wantErr bool
+		name        string
+		content     string
+		wantVersion string
+		wantErr     bool
 	}{
-		{
-			name:    "set local version successfully",
-			version: "1.20.0",
-			setup:   func(c *_config.Config) {},
-			wantErr: false,
-		},
-		{
-			name:    "set local version with write permission failure",
-			version: "1.20.0",
-			setup: func(c *_config.Config) {
-				// Make directory read-only
-				projectDir := filepath.Dir(c.AutoSwitch.ProjectFile)
-				os.Chmod(projectDir, 0444)
-			},
-			wantErr: true,
-		},
+		{name: "no project file", content: "", wantVersion: ""},
+		{name: "exact installed version", content: "1.21.3", wantVersion: "1.21.3"},
+		{name: "partial version matches newest patch", content: "1.21", wantVersion: "1.21.10"},
+		{name: "missing version", content: "1.23", wantErr: true},
+		{name: "invalid version", content: "not-a-version", wantErr: true},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			config := createTestConfig(t)
-			manager := createTestManager(t, config)
-
-			tt.setup(config)
-
-			// Cleanup permissions after test
-			t.Cleanup(func() {
-				os.Chmod(filepath.Dir(config.AutoSwitch.ProjectFile), 0755)
-			})
+			projectFile := filepath.Join(project, ".govman-goversion")
+			os.Remove(projectFile)
+			if tt.content != "" {
+				os.WriteFile(projectFile, []byte(tt.content), 0644)
+			}
+			t.Chdir(project)
 
-			err := manager.setLocalVersion(tt.version)
+			file, version, err := manager.AutoSwitchVersion()
 			if (err != nil) != tt.wantErr {
-				t.Errorf("setLocalVersion() error = %v, wantErr %v", err, tt.wantErr)
-				return
+				t.Fatalf("AutoSwitchVersion() error = %v, wantErr %v", err, tt.wantErr)
 			}
-
-			// Check that file was created with correct content
-			if !tt.wantErr {
-				data, err := os.ReadFile(config.AutoSwitch.ProjectFile)
-				if err != nil {
-					t.Errorf("Failed to read local version file: %v", err)
-				}
-
-				if strings.TrimSpace(string(data)) != tt.version {
-					t.Errorf("File content = %s, want %s", string(data), tt.version)
-				}
+			if version != tt.wantVersion {
+				t.Errorf("AutoSwitchVersion() version = %q, want %q", version, tt.wantVersion)
+			}
+			if tt.content == "" && file != "" {
+				t.Errorf("AutoSwitchVersion() file = %q, want none", file)
+			}
+			if tt.content != "" && file != projectFile {
+				t.Errorf("AutoSwitchVersion() file = %q, want %q", file, projectFile)
 			}
 		})
 	}
 }
 
+func TestManager_UnsetLocal(t *testing.T) {
+	config := createTestConfig(t)
+	manager := createTestManager(t, config)
+
+	removed, err := manager.UnsetLocal()
+	if err != nil || removed != "" {
+		t.Errorf("UnsetLocal() with no file = (%q, %v), want (\"\", nil)", removed, err)
+	}
+
+	if err := manager.setLocalVersion("1.21.0"); err != nil {
+		t.Fatalf("setLocalVersion() error = %v", err)
+	}
+
+	removed, err = manager.UnsetLocal()
+	if err != nil {
+		t.Fatalf("UnsetLocal() error = %v", err)
+	}
+	if removed != config.AutoSwitch.ProjectFile {
+		t.Errorf("UnsetLocal() removed %q, want %q", removed, config.AutoSwitch.ProjectFile)
+	}
+	if _, err := os.Stat(config.AutoSwitch.ProjectFile); !os.IsNotExist(err) {
+		t.Error("Expected project file to be removed")
+	}
+	if raw := manager.GetLocalVersionRaw(); raw != "" {
+		t.Errorf("GetLocalVersionRaw() after unset = %q, want empty", raw)
+	}
+}
+
 func TestManager_getLocalVersion(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -1074,6 +3502,65 @@ func TestManager_getLocalVersion(t *testing.T) {
 	}
 }
 
+func TestManager_CurrentDetailed(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses a shell script as the go binary")
+	}
+
+	config := createTestConfig(t)
+	manager := createTestManager(t, config)
+
+	for _, version := range []string{"1.20.0", "1.21.0"} {
+		os.MkdirAll(filepath.Join(config.GetVersionDir(version), "bin"), 0755)
+	}
+	os.WriteFile(filepath.Join(config.GetVersionDir("1.20.0"), "bin", "go"), []byte("#!/bin/sh\necho 'go version go1.20.0 linux/amd64'\n"), 0755)
+	os.Symlink(filepath.Join(config.GetVersionDir("1.20.0"), "bin", "go"), config.GetCurrentSymlink())
+	config.DefaultVersion = "1.20.0"
+	os.WriteFile(config.AutoSwitch.ProjectFile, []byte("1.21"), 0644)
+
+	// The go on PATH counts its runs, so the snapshot is shown to need only one
+	runs := filepath.Join(t.TempDir(), "runs")
+	pathDir := t.TempDir()
+	os.WriteFile(filepath.Join(pathDir, "go"), []byte("#!/bin/sh\necho run >> '"+runs+"'\necho 'go version go1.21.0 linux/amd64'\n"), 0755)
+	t.Setenv("PATH", pathDir)
+
+	state, err := manager.CurrentDetailed()
+	if err != nil {
+		t.Fatalf("CurrentDetailed() error = %v", err)
+	}
+	want := CurrentState{
+		Version:     "1.21.0",
+		Method:      "project-local",
+		PathVersion: "1.21.0",
+		LocalFile:   config.AutoSwitch.ProjectFile,
+		LocalRaw:    "1.21",
+		Local:       "1.21.0",
+		Global:      "1.20.0",
+		Default:     "1.20.0",
+	}
+	if state != want {
+		t.Errorf("CurrentDetailed() = %+v, want %+v", state, want)
+	}
+	if data, _ := os.ReadFile(runs); strings.Count(string(data), "run") != 1 {
+		t.Errorf("go ran %d times, want once", strings.Count(string(data), "run"))
+	}
+
+	// Without a go on PATH or a project file, the global link decides
+	os.Remove(config.AutoSwitch.ProjectFile)
+	t.Setenv("PATH", "/nonexistent/path")
+	state, err = manager.CurrentDetailed()
+	if err != nil || state.Version != "1.20.0" || state.Method != "system-default" || state.PathVersion != "" {
+		t.Errorf("CurrentDetailed() without go on PATH = %+v, %v; want 1.20.0 from the system default", state, err)
+	}
+
+	// A project version with no installed match is an error, but the rest of the snapshot is still filled in
+	os.WriteFile(config.AutoSwitch.ProjectFile, []byte("1.30"), 0644)
+	state, err = manager.CurrentDetailed()
+	if !errors.Is(err, ErrNotInstalled) || state.Version != "" || state.LocalRaw != "1.30" || state.Global != "1.20.0" {
+		t.Errorf("CurrentDetailed() with an uninstalled project version = %+v, %v; want ErrNotInstalled", state, err)
+	}
+}
+
 func TestManager_CurrentActivationMethod(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -1200,63 +3687,658 @@ func TestManager_Info(t *testing.T) {
 			config := createTestConfig(t)
 			manager := createTestManager(t, config)
 
-			tt.setup(config)
+			tt.setup(config)
+
+			got, err := manager.Info(tt.version)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Info() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if !tt.wantErr && got == nil {
+				t.Error("Expected VersionInfo but got nil")
+			}
+		})
+	}
+}
+
+func TestManager_Stat(t *testing.T) {
+	config := createTestConfig(t)
+	manager := createTestManager(t, config)
+
+	if _, err := manager.Stat("1.20.0"); err == nil {
+		t.Error("Expected error for version that is not installed")
+	}
+
+	versionDir := config.GetVersionDir("1.20.0")
+	os.MkdirAll(filepath.Join(versionDir, "bin"), 0755)
+	os.WriteFile(filepath.Join(versionDir, "bin", "go"), []byte("binary"), 0755)
+
+	got, err := manager.Stat("1.20.0")
+	if err != nil {
+		t.Fatalf("Stat() error = %v", err)
+	}
+	if got.Version != "1.20.0" {
+		t.Errorf("Stat() version = %s, want 1.20.0", got.Version)
+	}
+	if got.Size != 0 {
+		t.Errorf("Stat() size = %d, want 0", got.Size)
+	}
+}
+
+func TestManager_VersionSizes(t *testing.T) {
+	config := createTestConfig(t)
+	manager := createTestManager(t, config)
+
+	for _, version := range []string{"1.20.0", "1.21.0"} {
+		versionDir := config.GetVersionDir(version)
+		os.MkdirAll(filepath.Join(versionDir, "bin"), 0755)
+		os.WriteFile(filepath.Join(versionDir, "bin", "go"), []byte("0123456789"), 0755)
+	}
+
+	sizes := manager.VersionSizes([]string{"1.20.0", "1.21.0", "1.22.0"})
+	if len(sizes) != 2 {
+		t.Fatalf("Expected 2 sizes, got %d: %v", len(sizes), sizes)
+	}
+	if sizes["1.20.0"] != 10 || sizes["1.21.0"] != 10 {
+		t.Errorf("Unexpected sizes: %v", sizes)
+	}
+
+	cachePath := filepath.Join(config.CacheDir, sizeCacheFile)
+	if _, err := os.Stat(cachePath); err != nil {
+		t.Fatalf("Expected size cache to be written: %v", err)
+	}
+
+	t.Run("cached size is reused while modtime is unchanged", func(t *testing.T) {
+		cache := loadSizeCache(cachePath)
+		entry := cache["1.20.0"]
+		entry.Size = 42
+		cache["1.20.0"] = entry
+		if err := saveSizeCache(cachePath, cache); err != nil {
+			t.Fatalf("saveSizeCache() error = %v", err)
+		}
+
+		sizes := manager.VersionSizes([]string{"1.20.0"})
+		if sizes["1.20.0"] != 42 {
+			t.Errorf("Expected cached size 42, got %d", sizes["1.20.0"])
+		}
+	})
+
+	t.Run("stale cache entry is recomputed", func(t *testing.T) {
+		versionDir := config.GetVersionDir("1.20.0")
+		later := time.Now().Add(time.Hour)
+		if err := os.Chtimes(versionDir, later, later); err != nil {
+			t.Fatalf("Chtimes() error = %v", err)
+		}
+
+		sizes := manager.VersionSizes([]string{"1.20.0"})
+		if sizes["1.20.0"] != 10 {
+			t.Errorf("Expected recomputed size 10, got %d", sizes["1.20.0"])
+		}
+	})
+
+	t.Run("corrupt cache is ignored", func(t *testing.T) {
+		os.WriteFile(cachePath, []byte("not json"), 0644)
+
+		sizes := manager.VersionSizes([]string{"1.21.0"})
+		if sizes["1.21.0"] != 10 {
+			t.Errorf("Expected size 10, got %d", sizes["1.21.0"])
+		}
+	})
+}
+
+func TestVersionSizer(t *testing.T) {
+	config := createTestConfig(t)
+	manager := createTestManager(t, config)
+
+	versionDir := config.GetVersionDir("1.21.0")
+	os.MkdirAll(filepath.Join(versionDir, "bin"), 0755)
+	os.WriteFile(filepath.Join(versionDir, "bin", "go"), []byte("0123456789"), 0755)
+
+	cachePath := filepath.Join(config.CacheDir, sizeCacheFile)
+	sizer := manager.NewVersionSizer()
+	if size, ok := sizer.Size("1.21.0"); !ok || size != 10 {
+		t.Errorf("Size(1.21.0) = %d, %v; want 10", size, ok)
+	}
+	if _, ok := sizer.Size("1.22.0"); ok {
+		t.Error("Size() of a version that is not installed should fail")
+	}
+	if _, err := os.Stat(cachePath); !os.IsNotExist(err) {
+		t.Errorf("Size() should not write the cache before Save: %v", err)
+	}
+
+	sizer.Save()
+	if entry, ok := loadSizeCache(cachePath)["1.21.0"]; !ok || entry.Size != 10 {
+		t.Errorf("size cache after Save() = %+v, %v; want 10", entry, ok)
+	}
+}
+func TestManager_SetTag(t *testing.T) {
+	config := createTestConfig(t)
+	manager := createTestManager(t, config)
+
+	os.MkdirAll(config.GetVersionDir("1.20.0"), 0755)
+	os.MkdirAll(config.GetVersionDir("1.21.0"), 0755)
+
+	tests := []struct {
+		name    string
+		version string
+		tag     string
+		want    string
+		wantErr bool
+	}{
+		{name: "set tag", version: "1.20.0", tag: "  work project  ", want: "work project"},
+		{name: "replace tag", version: "1.20.0", tag: "side project", want: "side project"},
+		{name: "multi-line tag", version: "1.20.0", tag: "a\nb", want: "side project", wantErr: true},
+		{name: "too long", version: "1.20.0", tag: strings.Repeat("x", maxTagLength+1), want: "side project", wantErr: true},
+		{name: "remove tag", version: "1.20.0", tag: "", want: ""},
+		{name: "not installed", version: "1.19.0", tag: "old", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := manager.SetTag(tt.version, tt.tag)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("SetTag() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got := manager.Tag(tt.version); got != tt.want {
+				t.Errorf("Tag() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	manager.SetTag("1.21.0", "current")
+	installed, err := manager.ListInstalledWithMeta()
+	if err != nil {
+		t.Fatalf("ListInstalledWithMeta() error = %v", err)
+	}
+	want := []InstalledVersion{{Version: "1.21.0", Tag: "current"}, {Version: "1.20.0"}}
+	if !reflect.DeepEqual(installed, want) {
+		t.Errorf("ListInstalledWithMeta() = %v, want %v", installed, want)
+	}
+
+	versions, _ := manager.ListInstalled()
+	if !reflect.DeepEqual(versions, []string{"1.21.0", "1.20.0"}) {
+		t.Errorf("ListInstalled() = %v, tag files must not appear as versions", versions)
+	}
+}
+
+func TestManager_ListRemote(t *testing.T) {
+	tests := []struct {
+		name       string
+		forceCheck bool
+		setup      func(*_config.Config)
+		wantErr    bool
+	}{
+		{
+			name:       "list remote with invalid API URL",
+			forceCheck: false,
+			setup: func(c *_config.Config) {
+				c.GoReleases.APIURL = "invalid://url"
+			},
+			wantErr: true,
+		},
+		{
+			name:       "list remote with force check and invalid URL",
+			forceCheck: true,
+			setup: func(c *_config.Config) {
+				c.GoReleases.APIURL = "invalid://url"
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := createTestConfig(t)
+			manager := createTestManager(t, config)
+
+			tt.setup(config)
+
+			_, err := manager.ListRemote(tt.forceCheck)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ListRemote() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// fakeReleaseSource implements _golang.ReleaseSource from an in-memory release list for testing
+type fakeReleaseSource struct {
+	versions []string // newest first
+	unstable map[string]bool
+	calls    int
+}
+
+func (f *fakeReleaseSource) AvailableVersions(ctx context.Context, includeUnstable bool) ([]string, error) {
+	f.calls++
+	var versions []string
+	for _, v := range f.versions {
+		if includeUnstable || !f.unstable[v] {
+			versions = append(versions, v)
+		}
+	}
+	return versions, nil
+}
+
+func (f *fakeReleaseSource) DownloadURL(ctx context.Context, version string) (string, error) {
+	file, err := f.FileInfo(ctx, version)
+	if err != nil {
+		return "", err
+	}
+	return "http://127.0.0.1:1/mirror/" + file.Filename, nil
+}
+
+func (f *fakeReleaseSource) FileInfo(ctx context.Context, version string) (*_golang.File, error) {
+	if !slices.Contains(f.versions, version) {
+		return nil, fmt.Errorf("go version %s is %w", version, _golang.ErrVersionNotFound)
+	}
+	return &_golang.File{
+		Filename: fmt.Sprintf("go%s.%s-%s.tar.gz", version, runtime.GOOS, runtime.GOARCH),
+		Version:  "go" + version,
+		Sha256:   strings.Repeat("0", 64),
+		Kind:     "archive",
+	}, nil
+}
+
+// mirroredReleaseSource is a fakeReleaseSource whose archives are published under several mirror bases.
+type mirroredReleaseSource struct {
+	*fakeReleaseSource
+	bases []string
+}
+
+func (f *mirroredReleaseSource) DownloadURL(ctx context.Context, version string) (string, error) {
+	urls, err := f.ArchiveURLs(ctx, version)
+	if err != nil {
+		return "", err
+	}
+	return urls[0], nil
+}
+
+func (f *mirroredReleaseSource) ArchiveURLs(ctx context.Context, version string) ([]string, error) {
+	file, err := f.FileInfo(ctx, version)
+	if err != nil {
+		return nil, err
+	}
+	var urls []string
+	for _, base := range f.bases {
+		urls = append(urls, base+"/"+file.Filename)
+	}
+	return urls, nil
+}
+
+func TestManager_Install_TriesEveryMirror(t *testing.T) {
+	var downHits, corruptHits, lastHits int
+	down := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		downHits++
+		http.Error(w, "unavailable", http.StatusServiceUnavailable)
+	}))
+	defer down.Close()
+	corrupt := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		corruptHits++
+		fmt.Fprint(w, "not the archive")
+	}))
+	defer corrupt.Close()
+	last := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lastHits++
+		http.Error(w, "unavailable", http.StatusServiceUnavailable)
+	}))
+	defer last.Close()
+
+	config := createTestConfig(t)
+	config.Download.RetryCount = 1
+	source := &mirroredReleaseSource{fakeReleaseSource: &fakeReleaseSource{versions: []string{"1.24.7"}}, bases: []string{down.URL, corrupt.URL, last.URL}}
+	manager := NewWithSource(config, source)
+
+	err := manager.InstallContext(context.Background(), "1.24.7")
+	if err == nil || !strings.Contains(err.Error(), "checksum") {
+		t.Fatalf("InstallContext() error = %v, want the second mirror's checksum failure", err)
+	}
+	if downHits != 1 || corruptHits != 1 {
+		t.Errorf("mirror requests = %d, %d; want one each before the archive is fetched", downHits, corruptHits)
+	}
+	if lastHits != 0 {
+		t.Errorf("last mirror requests = %d, want none after a fetched archive fails verification", lastHits)
+	}
+}
+
+func TestManager_NewWithSource(t *testing.T) {
+	config := createTestConfig(t)
+	// Any request to the configured release API would fail the test through a resolution error
+	config.GoReleases.APIURL = "http://127.0.0.1:1/releases"
+	source := &fakeReleaseSource{
+		versions: []string{"1.25rc1", "1.24.7", "1.24.6", "1.23.12"},
+		unstable: map[string]bool{"1.25rc1": true},
+	}
+	manager := NewWithSource(config, source)
+
+	versions, err := manager.ListRemote(false)
+	if err != nil || !reflect.DeepEqual(versions, []string{"1.24.7", "1.24.6", "1.23.12"}) {
+		t.Errorf("ListRemote(false) = %v, %v", versions, err)
+	}
+	versions, err = manager.ListRemoteAll(true)
+	if err != nil || len(versions) != 4 {
+		t.Errorf("ListRemoteAll(true) = %v, %v; want every release from a source without pagination", versions, err)
+	}
+
+	for input, want := range map[string]string{"latest": "1.24.7", "1.24": "1.24.7", "~1.23": "1.23.12"} {
+		if got, err := manager.ResolveVersion(input); err != nil || got != want {
+			t.Errorf("ResolveVersion(%q) = %q, %v; want %q", input, got, err, want)
+		}
+	}
+
+	plan, err := manager.PlanInstall("1.24.6")
+	if err != nil {
+		t.Fatalf("PlanInstall() error = %v", err)
+	}
+	if want := fmt.Sprintf("http://127.0.0.1:1/mirror/go1.24.6.%s-%s.tar.gz", runtime.GOOS, runtime.GOARCH); plan.URL != want {
+		t.Errorf("PlanInstall() URL = %q, want %q", plan.URL, want)
+	}
+	if _, err := manager.PlanInstall("1.99.0"); !errors.Is(err, _golang.ErrVersionNotFound) {
+		t.Errorf("PlanInstall() of an unknown release error = %v, want ErrVersionNotFound", err)
+	}
+
+	info, err := manager.RemoteInfo("1.24.6")
+	if err != nil {
+		t.Fatalf("RemoteInfo() error = %v", err)
+	}
+	if info.Version != "1.24.6" || !info.Stable || info.Sha256 != strings.Repeat("0", 64) {
+		t.Errorf("RemoteInfo() = %+v, want stable 1.24.6 from the source", info)
+	}
+
+	if source.calls == 0 {
+		t.Error("expected the manager to list releases through the injected source")
+	}
+}
+
+// flakyReleaseSource fails AvailableVersions with err for the first failures calls, then serves fakeReleaseSource
+type flakyReleaseSource struct {
+	*fakeReleaseSource
+	failures int
+	err      error
+}
+
+func (f *flakyReleaseSource) AvailableVersions(ctx context.Context, includeUnstable bool) ([]string, error) {
+	if f.failures > 0 {
+		f.failures--
+		f.calls++
+		return nil, f.err
+	}
+	return f.fakeReleaseSource.AvailableVersions(ctx, includeUnstable)
+}
+
+func TestManager_ResolveVersion_Resilient(t *testing.T) {
+	networkErr := fmt.Errorf("failed to fetch releases: %w", _golang.ErrNetwork)
+
+	t.Run("Retries transient failures", func(t *testing.T) {
+		config := createTestConfig(t)
+		config.Download.RetryCount = 3
+		source := &flakyReleaseSource{fakeReleaseSource: &fakeReleaseSource{versions: []string{"1.24.7"}}, failures: 2, err: networkErr}
+		manager := NewWithSource(config, source)
+
+		if got, err := manager.ResolveVersion("latest"); err != nil || got != "1.24.7" {
+			t.Errorf("ResolveVersion(latest) = %q, %v; want 1.24.7", got, err)
+		}
+		if source.calls != 3 {
+			t.Errorf("source calls = %d, want 3", source.calls)
+		}
+	})
+
+	t.Run("Does not retry rate limits", func(t *testing.T) {
+		config := createTestConfig(t)
+		config.Download.RetryCount = 3
+		source := &flakyReleaseSource{fakeReleaseSource: &fakeReleaseSource{}, failures: 3, err: &_golang.RateLimitError{StatusCode: http.StatusForbidden}}
+		manager := NewWithSource(config, source)
+
+		if _, err := manager.ResolveVersion("latest"); !errors.Is(err, _golang.ErrNetwork) {
+			t.Errorf("ResolveVersion(latest) error = %v, want ErrNetwork", err)
+		}
+		if source.calls != 1 {
+			t.Errorf("source calls = %d, want 1", source.calls)
+		}
+	})
+
+	t.Run("Falls back to a stale cache", func(t *testing.T) {
+		config := createTestConfig(t)
+		config.Download.RetryCount = 2
+		stale := remoteVersionsCache{FetchedAt: time.Now().AddDate(0, -1, 0), Versions: []string{"1.25rc1", "1.24.6", "1.23.12"}}
+		if err := saveRemoteCache(filepath.Join(config.CacheDir, remoteCacheFile), stale); err != nil {
+			t.Fatalf("saveRemoteCache() error = %v", err)
+		}
+		source := &flakyReleaseSource{fakeReleaseSource: &fakeReleaseSource{}, failures: 2, err: networkErr}
+		manager := NewWithSource(config, source)
+
+		if got, err := manager.ResolveVersion("latest"); err != nil || got != "1.24.6" {
+			t.Errorf("ResolveVersion(latest) = %q, %v; want the newest stable cached release 1.24.6", got, err)
+		}
+		if source.calls != 2 {
+			t.Errorf("source calls = %d, want 2", source.calls)
+		}
+	})
+
+	t.Run("Falls back to the built-in list", func(t *testing.T) {
+		config := createTestConfig(t)
+		config.Download.RetryCount = 1
+		primary := &flakyReleaseSource{fakeReleaseSource: &fakeReleaseSource{}, failures: 1, err: networkErr}
+		manager := NewWithSource(config, &_golang.FallbackSource{Primary: primary, Fallback: &_golang.EmbeddedSource{}})
+
+		embedded, _ := _golang.EmbeddedVersions()
+		if got, err := manager.ResolveVersion("latest"); err != nil || got != embedded[0] {
+			t.Errorf("ResolveVersion(latest) = %q, %v; want the newest built-in release %s", got, err, embedded[0])
+		}
+	})
+
+	t.Run("Fails without a cache", func(t *testing.T) {
+		config := createTestConfig(t)
+		config.Download.RetryCount = 1
+		source := &flakyReleaseSource{fakeReleaseSource: &fakeReleaseSource{}, failures: 1, err: networkErr}
+		manager := NewWithSource(config, source)
+
+		if _, err := manager.ResolveVersion("1.24"); !errors.Is(err, _golang.ErrNetwork) {
+			t.Errorf("ResolveVersion(1.24) error = %v, want ErrNetwork", err)
+		}
+	})
+}
+
+func TestManager_Offline(t *testing.T) {
+	config := createTestConfig(t)
+	config.Offline = true
+	manager := createTestManager(t, config)
+	for _, v := range []string{"1.24.7", "1.24.6", "1.25rc1", "1.23.12"} {
+		os.MkdirAll(config.GetVersionDir(v), 0755)
+	}
+
+	for input, want := range map[string]string{"latest": "1.24.7", "1": "1.24.7", "1.24": "1.24.7", "1.23": "1.23.12", "^1.23": "1.24.7"} {
+		if got, err := manager.ResolveVersion(input); err != nil || got != want {
+			t.Errorf("ResolveVersion(%q) offline = %q, %v; want %q from installed versions", input, got, err, want)
+		}
+	}
+	if _, err := manager.ResolveVersion("1.22"); !errors.Is(err, ErrVersionNotFound) || !strings.Contains(err.Error(), "offline") {
+		t.Errorf("ResolveVersion(1.22) offline error = %v, want a not-found error mentioning offline mode", err)
+	}
+
+	if _, err := manager.ListRemote(false); !errors.Is(err, _golang.ErrOffline) {
+		t.Errorf("ListRemote() offline error = %v, want ErrOffline", err)
+	}
+	if err := manager.Install("1.22.5"); !errors.Is(err, _golang.ErrOffline) || classifyInstallError(err) != InstallErrorOffline {
+		t.Errorf("Install() of an uncached archive offline error = %v, want ErrOffline", err)
+	}
+}
 
-			got, err := manager.Info(tt.version)
-			if (err != nil) != tt.wantErr {
-				t.Errorf("Info() error = %v, wantErr %v", err, tt.wantErr)
-				return
-			}
+// writeToolchainArchive writes a .tar.gz holding a minimal Go toolchain for version to path.
+func writeToolchainArchive(t *testing.T, path, version string) {
+	t.Helper()
 
-			if !tt.wantErr && got == nil {
-				t.Error("Expected VersionInfo but got nil")
-			}
-		})
+	file, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	gzWriter := gzip.NewWriter(file)
+	tarWriter := tar.NewWriter(gzWriter)
+	for name, body := range map[string]string{"go/bin/go": "binary", "go/pkg/": "", "go/VERSION": "go" + version + "\n"} {
+		header := &tar.Header{Name: name, Size: int64(len(body)), Mode: 0755, Typeflag: tar.TypeReg}
+		if strings.HasSuffix(name, "/") {
+			header.Typeflag = tar.TypeDir
+		}
+		if err := tarWriter.WriteHeader(header); err != nil {
+			t.Fatal(err)
+		}
+		tarWriter.Write([]byte(body))
 	}
+	tarWriter.Close()
+	gzWriter.Close()
 }
 
-func TestManager_ListRemote(t *testing.T) {
-	tests := []struct {
-		name       string
-		forceCheck bool
-		setup      func(*_config.Config)
-		wantErr    bool
-	}{
-		{
-			name:       "list remote with invalid API URL",
-			forceCheck: false,
-			setup: func(c *_config.Config) {
-				c.GoReleases.APIURL = "invalid://url"
-			},
-			wantErr: true,
-		},
-		{
-			name:       "list remote with force check and invalid URL",
-			forceCheck: true,
-			setup: func(c *_config.Config) {
-				c.GoReleases.APIURL = "invalid://url"
-			},
-			wantErr: true,
-		},
+func TestManager_InstallFromFile(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("official Windows archives are zip files")
+	}
+	config := createTestConfig(t)
+	config.Offline = true
+	manager := createTestManager(t, config)
+	dir := t.TempDir()
+
+	named := filepath.Join(dir, _golang.ArchiveName("1.24.7"))
+	writeToolchainArchive(t, named, "1.24.7")
+	version, err := manager.InstallFromFile(context.Background(), named, "")
+	if err != nil || version != "1.24.7" || !manager.IsInstalled("1.24.7") {
+		t.Fatalf("InstallFromFile(official name) = %q, %v; want Go 1.24.7 installed", version, err)
+	}
+	if _, err := manager.InstallFromFile(context.Background(), named, ""); !errors.Is(err, ErrAlreadyInstalled) {
+		t.Errorf("InstallFromFile() again error = %v, want ErrAlreadyInstalled", err)
 	}
 
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			config := createTestConfig(t)
-			manager := createTestManager(t, config)
+	renamed := filepath.Join(dir, "go.tar.gz")
+	writeToolchainArchive(t, renamed, "1.23.12")
+	if _, err := manager.InstallFromFile(context.Background(), renamed, ""); err == nil {
+		t.Error("InstallFromFile() of a renamed archive without a version should fail")
+	}
+	if _, err := manager.InstallFromFile(context.Background(), renamed, "1.23.11"); err == nil || manager.IsInstalled("1.23.11") {
+		t.Errorf("InstallFromFile() with the wrong version error = %v, want a toolchain mismatch", err)
+	}
+	if version, err := manager.InstallFromFile(context.Background(), renamed, "1.23.12"); err != nil || version != "1.23.12" {
+		t.Errorf("InstallFromFile(renamed, 1.23.12) = %q, %v", version, err)
+	}
 
-			tt.setup(config)
+	// A release downloaded before must match the checksum recorded then
+	tampered := filepath.Join(dir, "tampered", _golang.ArchiveName("1.22.5"))
+	os.MkdirAll(filepath.Dir(tampered), 0755)
+	writeToolchainArchive(t, tampered, "1.22.5")
+	_golang.RecordArchiveChecksum(config.CacheDir, _golang.ArchiveName("1.22.5"), strings.Repeat("0", 64))
+	if _, err := manager.InstallFromFile(context.Background(), tampered, ""); err == nil || !strings.Contains(err.Error(), "checksum mismatch") || manager.IsInstalled("1.22.5") {
+		t.Errorf("InstallFromFile() of an archive not matching the recorded checksum error = %v", err)
+	}
 
-			_, err := manager.ListRemote(tt.forceCheck)
-			if (err != nil) != tt.wantErr {
-				t.Errorf("ListRemote() error = %v, wantErr %v", err, tt.wantErr)
-			}
-		})
+	foreign := filepath.Join(dir, "go1.22.5.plan9-386.tar.gz")
+	writeToolchainArchive(t, foreign, "1.22.5")
+	if _, err := manager.InstallFromFile(context.Background(), foreign, ""); err == nil || !strings.Contains(err.Error(), "not this platform") {
+		t.Errorf("InstallFromFile() of another platform's archive error = %v", err)
+	}
+	if _, err := manager.InstallFromFile(context.Background(), named, "1.22.5"); err == nil || !strings.Contains(err.Error(), "not Go 1.22.5") {
+		t.Errorf("InstallFromFile() with a version contradicting the file name error = %v", err)
 	}
 }
 
+func TestManager_CachedRemoteVersions(t *testing.T) {
+	_golang.ClearReleasesCache()
+	t.Cleanup(_golang.ClearReleasesCache)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[{"version": "go1.25.1", "stable": true}, {"version": "go1.24.7", "stable": true}]`)
+	}))
+	defer server.Close()
+
+	t.Run("empty before any fetch", func(t *testing.T) {
+		config := createTestConfig(t)
+		manager := createTestManager(t, config)
+
+		if got := manager.CachedRemoteVersions(); got != nil {
+			t.Errorf("CachedRemoteVersions() = %v, want nil", got)
+		}
+	})
+
+	t.Run("returns versions saved by ListRemote", func(t *testing.T) {
+		_golang.ClearReleasesCache()
+		config := createTestConfig(t)
+		config.GoReleases.APIURL = server.URL
+		config.GoReleases.CacheExpiry = time.Hour
+		manager := createTestManager(t, config)
+
+		if _, err := manager.ListRemote(false); err != nil {
+			t.Fatalf("ListRemote() error = %v", err)
+		}
+
+		// Point at an unreachable API to prove no network call is made
+		config.GoReleases.APIURL = "invalid://url"
+		want := []string{"1.25.1", "1.24.7"}
+		if got := manager.CachedRemoteVersions(); !reflect.DeepEqual(got, want) {
+			t.Errorf("CachedRemoteVersions() = %v, want %v", got, want)
+		}
+	})
+
+	t.Run("keeps prereleases after a stable-only listing", func(t *testing.T) {
+		config := createTestConfig(t)
+		config.GoReleases.CacheExpiry = time.Hour
+		source := &fakeReleaseSource{versions: []string{"1.25rc1", "1.24.7"}, unstable: map[string]bool{"1.25rc1": true}}
+		manager := NewWithSource(config, source)
+
+		if got, err := manager.ListRemote(false); err != nil || !reflect.DeepEqual(got, []string{"1.24.7"}) {
+			t.Fatalf("ListRemote(false) = %v, %v; want [1.24.7]", got, err)
+		}
+		want := []string{"1.25rc1", "1.24.7"}
+		if got := manager.CachedRemoteVersions(); !reflect.DeepEqual(got, want) {
+			t.Errorf("CachedRemoteVersions() = %v, want %v", got, want)
+		}
+	})
+
+	t.Run("stale cache is ignored", func(t *testing.T) {
+		config := createTestConfig(t)
+		config.GoReleases.CacheExpiry = time.Hour
+		manager := createTestManager(t, config)
+
+		cachePath := filepath.Join(config.CacheDir, remoteCacheFile)
+		stale := remoteVersionsCache{FetchedAt: time.Now().Add(-2 * config.GoReleases.CacheExpiry), Versions: []string{"1.25.1"}}
+		if err := saveRemoteCache(cachePath, stale); err != nil {
+			t.Fatalf("saveRemoteCache() error = %v", err)
+		}
+
+		if got := manager.CachedRemoteVersions(); got != nil {
+			t.Errorf("CachedRemoteVersions() = %v, want nil for stale cache", got)
+		}
+	})
+
+	t.Run("corrupt cache is ignored", func(t *testing.T) {
+		config := createTestConfig(t)
+		manager := createTestManager(t, config)
+
+		os.MkdirAll(config.CacheDir, 0755)
+		os.WriteFile(filepath.Join(config.CacheDir, remoteCacheFile), []byte("not json"), 0644)
+
+		if got := manager.CachedRemoteVersions(); got != nil {
+			t.Errorf("CachedRemoteVersions() = %v, want nil for corrupt cache", got)
+		}
+	})
+}
+
 func TestManager_ResolveVersion(t *testing.T) {
 	_golang.ClearReleasesCache()
+	t.Cleanup(_golang.ClearReleasesCache)
+
+	releasesServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[
+			{"version": "go20.1.0", "stable": true},
+			{"version": "go3.0rc1", "stable": false},
+			{"version": "go1.25rc1", "stable": false},
+			{"version": "go1.24.7", "stable": true},
+			{"version": "go1.23.12", "stable": true}
+		]`)
+	}))
+	defer releasesServer.Close()
+
+	emptyServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[]`)
+	}))
+	defer emptyServer.Close()
 
 	tests := []struct {
 		name    string
@@ -1273,13 +4355,13 @@ func TestManager_ResolveVersion(t *testing.T) {
 			wantErr: false,
 		},
 		{
-			name:  "resolve latest with API failure",
+			name:  "resolve latest with API failure uses the built-in list",
 			input: "latest",
 			setup: func(c *_config.Config) {
 				c.GoReleases.APIURL = "invalid://url"
 			},
 			want:    "",
-			wantErr: true,
+			wantErr: false,
 		},
 		{
 			name:  "resolve partial version with API failure",
@@ -1291,10 +4373,48 @@ func TestManager_ResolveVersion(t *testing.T) {
 			wantErr: true,
 		},
 		{
-			name:    "resolve version with no dots",
-			input:   "1",
-			setup:   func(c *_config.Config) {},
-			want:    "1",
+			name:  "resolve bare major with API failure uses the built-in list",
+			input: "1",
+			setup: func(c *_config.Config) {
+				c.GoReleases.APIURL = "invalid://url"
+			},
+			want:    "",
+			wantErr: false,
+		},
+		{
+			name:  "resolve bare major to newest stable release",
+			input: "1",
+			setup: func(c *_config.Config) {
+				c.GoReleases.APIURL = releasesServer.URL
+			},
+			want:    "1.24.7",
+			wantErr: false,
+		},
+		{
+			name:  "resolve bare major does not match longer majors",
+			input: "2",
+			setup: func(c *_config.Config) {
+				c.GoReleases.APIURL = releasesServer.URL
+			},
+			want:    "",
+			wantErr: true,
+		},
+		{
+			name:  "resolve bare major with only prerelease",
+			input: "3",
+			setup: func(c *_config.Config) {
+				c.GoReleases.APIURL = releasesServer.URL
+			},
+			want:    "",
+			wantErr: true,
+		},
+		{
+			name:  "latest still resolves to newest stable",
+			input: "latest",
+			setup: func(c *_config.Config) {
+				c.GoReleases.APIURL = releasesServer.URL
+			},
+			want:    "20.1.0",
 			wantErr: false,
 		},
 		{
@@ -1310,7 +4430,7 @@ func TestManager_ResolveVersion(t *testing.T) {
 			name:  "resolve latest with empty versions list",
 			input: "latest",
 			setup: func(c *_config.Config) {
-				c.GoReleases.APIURL = "invalid://url"
+				c.GoReleases.APIURL = emptyServer.URL
 			},
 			want:    "",
 			wantErr: true,
@@ -1326,6 +4446,7 @@ func TestManager_ResolveVersion(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
+			_golang.ClearReleasesCache()
 			config := createTestConfig(t)
 			manager := createTestManager(t, config)
 
@@ -1344,6 +4465,129 @@ func TestManager_ResolveVersion(t *testing.T) {
 	}
 }
 
+func TestManager_ResolveVersion_Constraint(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `[
+			{"version": "go1.25rc1", "stable": false},
+			{"version": "go1.24.7", "stable": true},
+			{"version": "go1.23.12", "stable": true},
+			{"version": "go1.22.10", "stable": true},
+			{"version": "go1.21.13", "stable": true}
+		]`)
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name     string
+		input    string
+		want     string
+		wantErr  bool
+		notFound bool
+	}{
+		{name: "caret", input: "^1.22", want: "1.24.7"},
+		{name: "tilde", input: "~1.22.3", want: "1.22.10"},
+		{name: "range", input: ">=1.21 <1.23", want: "1.22.10"},
+		{name: "no match", input: "^2.0", wantErr: true, notFound: true},
+		{name: "invalid constraint", input: "^1.x", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_golang.ClearReleasesCache()
+			config := createTestConfig(t)
+			config.GoReleases.APIURL = server.URL
+			manager := createTestManager(t, config)
+
+			got, err := manager.ResolveVersion(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ResolveVersion(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ResolveVersion(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+			if tt.notFound && !errors.Is(err, ErrVersionNotFound) {
+				t.Errorf("ResolveVersion(%q) error = %v, want ErrVersionNotFound", tt.input, err)
+			}
+		})
+	}
+	_golang.ClearReleasesCache()
+}
+
+func TestReleaseSpellings(t *testing.T) {
+	tests := []struct {
+		version       string
+		wantAlternate string
+		wantCanonical string
+	}{
+		{version: "1.22", wantAlternate: "1.22.0", wantCanonical: "1.22.0"},
+		{version: "1.22.0", wantAlternate: "1.22", wantCanonical: "1.22.0"},
+		{version: "1.21", wantAlternate: "1.21.0", wantCanonical: "1.21.0"},
+		{version: "1.20", wantAlternate: "1.20.0", wantCanonical: "1.20"},
+		{version: "1.20.0", wantAlternate: "1.20", wantCanonical: "1.20"},
+		{version: "1.22.1", wantAlternate: "", wantCanonical: ""},
+		{version: "1.22rc1", wantAlternate: "", wantCanonical: ""},
+		{version: "1", wantAlternate: "", wantCanonical: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			alternate, canonical := releaseSpellings(tt.version)
+			if alternate != tt.wantAlternate || canonical != tt.wantCanonical {
+				t.Errorf("releaseSpellings(%q) = %q, %q; want %q, %q",
+					tt.version, alternate, canonical, tt.wantAlternate, tt.wantCanonical)
+			}
+		})
+	}
+}
+
+func TestManager_ResolveVersion_EquivalentInstall(t *testing.T) {
+	tests := []struct {
+		name      string
+		installed []string
+		input     string
+		want      string
+	}{
+		{name: "1.22.0 resolves to an existing 1.22", installed: []string{"1.22"}, input: "1.22.0", want: "1.22"},
+		{name: "1.20.0 resolves to an existing 1.20", installed: []string{"1.20"}, input: "1.20.0", want: "1.20"},
+		{name: "Not installed keeps the requested spelling", input: "1.22.0", want: "1.22.0"},
+		{name: "Later patch is never merged with the first release", installed: []string{"1.22"}, input: "1.22.1", want: "1.22.1"},
+		{name: "Both installed picks the canonical directory", installed: []string{"1.22", "1.22.0"}, input: "1.22.0", want: "1.22.0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := createTestConfig(t)
+			manager := createTestManager(t, config)
+			for _, version := range tt.installed {
+				os.MkdirAll(filepath.Join(config.GetVersionDir(version), "bin"), 0755)
+			}
+
+			got, err := manager.ResolveVersion(tt.input)
+			if err != nil {
+				t.Fatalf("ResolveVersion(%q) error = %v", tt.input, err)
+			}
+			if got != tt.want {
+				t.Errorf("ResolveVersion(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestManager_Install_EquivalentAlreadyInstalled(t *testing.T) {
+	config := createTestConfig(t)
+	manager := createTestManager(t, config)
+	os.MkdirAll(filepath.Join(config.GetVersionDir("1.22"), "bin"), 0755)
+
+	err := manager.Install("1.22.0")
+	if !errors.Is(err, ErrAlreadyInstalled) {
+		t.Fatalf("Install(1.22.0) error = %v, want ErrAlreadyInstalled", err)
+	}
+	if _, err := os.Stat(config.GetVersionDir("1.22.0")); !os.IsNotExist(err) {
+		t.Error("Install(1.22.0) should not create a second directory next to go1.22")
+	}
+}
+
 func TestManager_createSymlink(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -1493,6 +4737,71 @@ func TestManager_getCurrentSessionVersion(t *testing.T) {
 			want:    "",
 			wantErr: true,
 		},
+		{
+			name: "get current session version from devel build",
+			setup: func(c *_config.Config) {
+				binDir := filepath.Join(c.GetBinPath(), "fakego")
+				os.MkdirAll(binDir, 0755)
+				goPath := filepath.Join(binDir, "go")
+				os.WriteFile(goPath, []byte("#!/bin/bash\necho 'go version devel go1.23-abcdef Tue Jan 2 15:04:05 2024 +0000 linux/amd64'"), 0755)
+				originalPath := os.Getenv("PATH")
+				os.Setenv("PATH", binDir+string(os.PathListSeparator)+originalPath)
+			},
+			want:    "1.23",
+			wantErr: false,
+		},
+		{
+			name: "get current session version from beta release",
+			setup: func(c *_config.Config) {
+				binDir := filepath.Join(c.GetBinPath(), "fakego")
+				os.MkdirAll(binDir, 0755)
+				goPath := filepath.Join(binDir, "go")
+				os.WriteFile(goPath, []byte("#!/bin/bash\necho 'go version go1.22beta1 linux/amd64'"), 0755)
+				originalPath := os.Getenv("PATH")
+				os.Setenv("PATH", binDir+string(os.PathListSeparator)+originalPath)
+			},
+			want:    "1.22beta1",
+			wantErr: false,
+		},
+		{
+			name: "get current session version from release candidate",
+			setup: func(c *_config.Config) {
+				binDir := filepath.Join(c.GetBinPath(), "fakego")
+				os.MkdirAll(binDir, 0755)
+				goPath := filepath.Join(binDir, "go")
+				os.WriteFile(goPath, []byte("#!/bin/bash\necho 'go version go1.21rc2 darwin/arm64'"), 0755)
+				originalPath := os.Getenv("PATH")
+				os.Setenv("PATH", binDir+string(os.PathListSeparator)+originalPath)
+			},
+			want:    "1.21rc2",
+			wantErr: false,
+		},
+		{
+			name: "get current session version from multiple spaces",
+			setup: func(c *_config.Config) {
+				binDir := filepath.Join(c.GetBinPath(), "fakego")
+				os.MkdirAll(binDir, 0755)
+				goPath := filepath.Join(binDir, "go")
+				os.WriteFile(goPath, []byte("#!/bin/bash\necho 'go   version    go1.21.5     linux/amd64'"), 0755)
+				originalPath := os.Getenv("PATH")
+				os.Setenv("PATH", binDir+string(os.PathListSeparator)+originalPath)
+			},
+			want:    "1.21.5",
+			wantErr: false,
+		},
+		{
+			name: "get current session version from devel build without version",
+			setup: func(c *_config.Config) {
+				binDir := filepath.Join(c.GetBinPath(), "fakego")
+				os.MkdirAll(binDir, 0755)
+				goPath := filepath.Join(binDir, "go")
+				os.WriteFile(goPath, []byte("#!/bin/bash\necho 'go version devel +b0c1d2e3 Tue Jan 2 15:04:05 2024 +0000 linux/amd64'"), 0755)
+				originalPath := os.Getenv("PATH")
+				os.Setenv("PATH", binDir+string(os.PathListSeparator)+originalPath)
+			},
+			want:    "",
+			wantErr: true,
+		},
 		{
 			name: "get current session version with empty version string",
 			setup: func(c *_config.Config) {
@@ -1523,7 +4832,7 @@ func TestManager_getCurrentSessionVersion(t *testing.T) {
 
 			tt.setup(config)
 
-			got, err := manager.getCurrentSessionVersion()
+			got, err := manager.getCurrentSessionVersion(false)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("getCurrentSessionVersion() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -1535,3 +4844,145 @@ func TestManager_getCurrentSessionVersion(t *testing.T) {
 		})
 	}
 }
+
+// writeFakeGo writes a go script into dir that reports version and returns its path.
+func writeFakeGo(t *testing.T, dir, version string) string {
+	t.Helper()
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	goPath := filepath.Join(dir, "go")
+	script := fmt.Sprintf("#!/bin/sh\necho 'go version go%s linux/amd64'\n", version)
+	if err := os.WriteFile(goPath, []byte(script), 0755); err != nil {
+		t.Fatal(err)
+	}
+	return goPath
+}
+
+func TestManager_getCurrentSessionVersion_Memoized(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses shell scripts as fake go binaries")
+	}
+
+	config := createTestConfig(t)
+	manager := createTestManager(t, config)
+
+	root := t.TempDir()
+	writeFakeGo(t, filepath.Join(root, "go1.21.0"), "1.21.0")
+	writeFakeGo(t, filepath.Join(root, "go1.22.0"), "1.22.0")
+	binDir := filepath.Join(root, "bin")
+	if err := os.MkdirAll(binDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	link := filepath.Join(binDir, "go")
+	if err := os.Symlink(filepath.Join(root, "go1.21.0", "go"), link); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", binDir)
+
+	assertVersion := func(useCache bool, want string) {
+		t.Helper()
+		got, err := manager.getCurrentSessionVersion(useCache)
+		if err != nil {
+			t.Fatalf("getCurrentSessionVersion(%v) error = %v", useCache, err)
+		}
+		if got != want {
+			t.Errorf("getCurrentSessionVersion(%v) = %q, want %q", useCache, got, want)
+		}
+	}
+
+	assertVersion(true, "1.21.0")
+
+	// Rewrite the binary in place with the same size and modtime: a memoized call must not execute it again
+	target := filepath.Join(root, "go1.21.0", "go")
+	info, err := os.Stat(target)
+	if err != nil {
+		t.Fatal(err)
+	}
+	writeFakeGo(t, filepath.Join(root, "go1.21.0"), "1.21.9")
+	if err := os.Chtimes(target, info.ModTime(), info.ModTime()); err != nil {
+		t.Fatal(err)
+	}
+	assertVersion(true, "1.21.0")
+	assertVersion(false, "1.21.9")
+
+	// Repointing the symlink changes the resolved binary and invalidates the memoized result
+	if err := os.Remove(link); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink(filepath.Join(root, "go1.22.0", "go"), link); err != nil {
+		t.Fatal(err)
+	}
+	assertVersion(true, "1.22.0")
+}
+
+func TestManager_GoOnPath(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses shell scripts as fake go binaries")
+	}
+
+	config := createTestConfig(t)
+	manager := createTestManager(t, config)
+
+	versionBin := filepath.Join(config.GetVersionDir("1.25.1"), "bin")
+	writeFakeGo(t, versionBin, "1.25.1")
+	if err := manager.createSymlink("1.25.1"); err != nil {
+		t.Fatalf("createSymlink() error = %v", err)
+	}
+	binDir := config.GetBinPath()
+	competing := writeFakeGo(t, filepath.Join(t.TempDir(), "usr", "local", "go", "bin"), "1.20.0")
+	competingDir := filepath.Dir(competing)
+
+	tests := []struct {
+		name        string
+		path        []string
+		wantPath    string
+		wantManaged bool
+		wantErr     bool
+	}{
+		{name: "govman bin directory first", path: []string{binDir, competingDir}, wantPath: filepath.Join(binDir, "go"), wantManaged: true},
+		{name: "session version directory first", path: []string{versionBin, competingDir}, wantPath: filepath.Join(versionBin, "go"), wantManaged: true},
+		{name: "competing go earlier on PATH", path: []string{competingDir, binDir}, wantPath: competing},
+		{name: "no go on PATH", path: []string{t.TempDir()}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("PATH", strings.Join(tt.path, string(os.PathListSeparator)))
+
+			goPath, managed, err := manager.GoOnPath()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GoOnPath() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if goPath != tt.wantPath || managed != tt.wantManaged {
+				t.Errorf("GoOnPath() = %q, %v, want %q, %v", goPath, managed, tt.wantPath, tt.wantManaged)
+			}
+		})
+	}
+}
+
+func BenchmarkManager_getCurrentSessionVersion(b *testing.B) {
+	if runtime.GOOS == "windows" {
+		b.Skip("uses shell scripts as fake go binaries")
+	}
+
+	dir := b.TempDir()
+	script := "#!/bin/sh\necho 'go version go1.21.0 linux/amd64'\n"
+	if err := os.WriteFile(filepath.Join(dir, "go"), []byte(script), 0755); err != nil {
+		b.Fatal(err)
+	}
+	b.Setenv("PATH", dir)
+	manager := &Manager{config: &_config.Config{}}
+
+	for _, useCache := range []bool{false, true} {
+		b.Run(fmt.Sprintf("cache=%v", useCache), func(b *testing.B) {
+			resetSessionVersionCache()
+			defer resetSessionVersionCache()
+			for b.Loop() {
+				if _, err := manager.getCurrentSessionVersion(useCache); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
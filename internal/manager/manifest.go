@@ -0,0 +1,126 @@
+package manager
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+
+	_logger "github.com/justjundana/govman/internal/logger"
+)
+
+// ManifestSchemaVersion is the manifest format written by Export.
+const ManifestSchemaVersion = 1
+
+// Manifest describes a portable set of installed Go versions.
+type Manifest struct {
+	SchemaVersion int               `json:"schema_version"`
+	Versions      []string          `json:"versions"`
+	Default       string            `json:"default,omitempty"`
+	Tags          map[string]string `json:"tags,omitempty"`
+}
+
+// ImportResult summarizes the outcome of Import.
+type ImportResult struct {
+	Added      []string
+	Skipped    []string
+	Failures   []InstallError
+	DefaultSet bool
+}
+
+// Export builds a manifest of all installed versions and the configured default.
+// Returns the manifest or an error if installed versions cannot be listed.
+func (m *Manager) Export() (*Manifest, error) {
+	installed, err := m.ListInstalledWithMeta()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list installed versions: %w", err)
+	}
+
+	manifest := &Manifest{
+		SchemaVersion: ManifestSchemaVersion,
+		Versions:      []string{},
+		Default:       m.DefaultVersion(),
+	}
+	for _, entry := range installed {
+		manifest.Versions = append(manifest.Versions, entry.Version)
+		if entry.Tag != "" {
+			if manifest.Tags == nil {
+				manifest.Tags = make(map[string]string)
+			}
+			manifest.Tags[entry.Version] = entry.Tag
+		}
+	}
+
+	return manifest, nil
+}
+
+// ParseManifest decodes a JSON manifest, migrating older schema versions when needed.
+// Returns an error if the data is malformed or uses an unsupported schema version.
+func ParseManifest(data []byte) (*Manifest, error) {
+	var manifest Manifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return nil, fmt.Errorf("failed to parse manifest: %w", err)
+	}
+
+	switch manifest.SchemaVersion {
+	case ManifestSchemaVersion:
+	case 0:
+		return nil, fmt.Errorf("manifest is missing schema_version")
+	default:
+		return nil, fmt.Errorf("unsupported manifest schema version %d (this govman supports %d)", manifest.SchemaVersion, ManifestSchemaVersion)
+	}
+
+	for _, version := range manifest.Versions {
+		if !VersionFormatRegex.MatchString(version) {
+			return nil, fmt.Errorf("invalid version in manifest: %s", version)
+		}
+	}
+
+	for version, tag := range manifest.Tags {
+		if !slices.Contains(manifest.Versions, version) {
+			return nil, fmt.Errorf("manifest tags version %s that it does not list", version)
+		}
+		if err := validateTag(tag); err != nil {
+			return nil, fmt.Errorf("invalid tag for %s in manifest: %w", version, err)
+		}
+	}
+
+	return &manifest, nil
+}
+
+// Import installs every manifest version that is not already installed and restores the default.
+// Returns an ImportResult describing added, skipped, and failed versions.
+func (m *Manager) Import(manifest *Manifest) *ImportResult {
+	result := &ImportResult{}
+
+	var missing []string
+	for _, version := range manifest.Versions {
+		if m.IsInstalled(version) {
+			result.Skipped = append(result.Skipped, version)
+			continue
+		}
+		missing = append(missing, version)
+	}
+
+	if len(missing) > 0 {
+		result.Added, result.Failures = m.InstallMany(missing)
+	}
+
+	for version, tag := range manifest.Tags {
+		if !m.IsInstalled(version) || m.Tag(version) != "" {
+			continue
+		}
+		if err := m.SetTag(version, tag); err != nil {
+			_logger.Warning("Failed to restore tag for Go %s: %v", version, err)
+		}
+	}
+
+	if manifest.Default != "" && manifest.Default != m.DefaultVersion() {
+		if err := m.SetDefault(manifest.Default); err != nil {
+			_logger.Warning("Failed to restore default version %s: %v", manifest.Default, err)
+		} else {
+			result.DefaultSet = true
+		}
+	}
+
+	return result
+}
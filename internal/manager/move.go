@@ -0,0 +1,205 @@
+package manager
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	_golang "github.com/justjundana/govman/internal/golang"
+	_logger "github.com/justjundana/govman/internal/logger"
+)
+
+// moveStagingPrefix names the temporary copy of a version directory while it is copied to a new install directory.
+const moveStagingPrefix = ".govman-move-"
+
+// moveCompleteMarker is written into a staged copy once it is complete, so a move resumed after an interruption
+// can tell it from an unrelated directory.
+const moveCompleteMarker = ".govman-move-complete"
+
+// renameDir moves a directory, failing when source and target are on different filesystems. Replaced in tests.
+var renameDir = os.Rename
+
+// MoveInstallDir moves every installed version to newDir, relinks the default, and saves newDir as install_dir;
+// rerunning after an interruption finishes the move. Returns an error, leaving install_dir unchanged, on failure.
+func (m *Manager) MoveInstallDir(newDir string) error {
+	oldDir := m.config.InstallDir
+	if err := m.config.Set("install_dir", newDir); err != nil {
+		return err
+	}
+	newDir = m.config.InstallDir
+	if filepath.Clean(oldDir) == filepath.Clean(newDir) {
+		return nil
+	}
+
+	// Move from the old directory while the config still points there
+	m.config.InstallDir = oldDir
+	versions, err := m.ListInstalled()
+	if err != nil {
+		return err
+	}
+	linked := m.globalLinkVersion()
+
+	if err := os.MkdirAll(newDir, 0755); err != nil {
+		return fmt.Errorf("failed to create install directory %s: %w", newDir, err)
+	}
+
+	for _, version := range versions {
+		name := filepath.Base(m.config.GetVersionDir(version))
+		_logger.Progress("Moving Go %s", version)
+		if err := moveVersionDir(filepath.Join(oldDir, name), filepath.Join(newDir, name)); err != nil {
+			return fmt.Errorf("failed to move Go %s to %s: %w", version, newDir, err)
+		}
+	}
+
+	m.config.InstallDir = newDir
+
+	if linked == "" {
+		linked = m.config.DefaultVersion
+	}
+	if linked != "" && m.IsInstalled(linked) {
+		if err := m.createSymlink(linked); err != nil {
+			return fmt.Errorf("versions were moved but the symlink for Go %s could not be recreated: %w", linked, err)
+		}
+	}
+
+	if err := m.config.Save(); err != nil {
+		return fmt.Errorf("versions were moved but install_dir could not be saved: %w", err)
+	}
+
+	// Only an empty directory is removed; anything that was not a version stays where it was
+	if err := os.Remove(oldDir); err != nil && !os.IsNotExist(err) {
+		_logger.Verbose("Left %s in place: %v", oldDir, err)
+	}
+
+	return nil
+}
+
+// moveVersionDir moves the directory src to dst, copying it under a staging name and then removing src when a
+// rename is not possible. A dst left by an interrupted move is trusted only if it is a complete copy of src.
+// Returns an error if dst holds something else, or the copy or removal fails.
+func moveVersionDir(src, dst string) error {
+	if _, err := os.Stat(dst); err == nil {
+		if !isCopyOf(src, dst) {
+			return fmt.Errorf("%s already exists and is not a copy of %s; move it aside and rerun", dst, src)
+		}
+		if err := os.RemoveAll(src); err != nil {
+			return err
+		}
+		return removeMoveMarker(dst)
+	}
+
+	if err := renameDir(src, dst); err == nil {
+		return nil
+	}
+
+	staging := filepath.Join(filepath.Dir(dst), moveStagingPrefix+filepath.Base(dst))
+	if err := os.RemoveAll(staging); err != nil {
+		return fmt.Errorf("failed to clear %s: %w", staging, err)
+	}
+	if err := copyTree(src, staging); err != nil {
+		os.RemoveAll(staging)
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(staging, moveCompleteMarker), nil, 0644); err != nil {
+		os.RemoveAll(staging)
+		return fmt.Errorf("failed to mark %s complete: %w", staging, err)
+	}
+	if err := os.Rename(staging, dst); err != nil {
+		return fmt.Errorf("failed to finish moving to %s: %w", dst, err)
+	}
+
+	if err := os.RemoveAll(src); err != nil {
+		return err
+	}
+	return removeMoveMarker(dst)
+}
+
+// isCopyOf reports whether dst is a complete copy of src: it carries the marker of a finished staged copy, or
+// its VERSION file and size match those of src.
+func isCopyOf(src, dst string) bool {
+	if _, err := os.Stat(filepath.Join(dst, moveCompleteMarker)); err == nil {
+		return true
+	}
+
+	srcVersion, err := os.ReadFile(filepath.Join(src, "VERSION"))
+	if err != nil {
+		return false
+	}
+	dstVersion, err := os.ReadFile(filepath.Join(dst, "VERSION"))
+	if err != nil || !bytes.Equal(srcVersion, dstVersion) {
+		return false
+	}
+
+	srcSize, err := _golang.DirSize(src)
+	if err != nil {
+		return false
+	}
+	dstSize, err := _golang.DirSize(dst)
+	return err == nil && srcSize == dstSize
+}
+
+// removeMoveMarker removes the marker of a finished staged copy from dir once its source is gone.
+func removeMoveMarker(dir string) error {
+	if err := os.Remove(filepath.Join(dir, moveCompleteMarker)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// copyTree copies the directory tree at src to dst, keeping file modes and symbolic links.
+// Returns an error if any entry cannot be copied.
+func copyTree(src, dst string) error {
+	return filepath.WalkDir(src, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dst, rel)
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+
+		switch {
+		case d.IsDir():
+			return os.MkdirAll(target, info.Mode().Perm()|0700)
+		case info.Mode()&os.ModeSymlink != 0:
+			link, err := os.Readlink(path)
+			if err != nil {
+				return err
+			}
+			return os.Symlink(link, target)
+		default:
+			return copyFile(path, target, info.Mode().Perm())
+		}
+	})
+}
+
+// copyFile copies the regular file src to dst, creating dst with the given permissions.
+func copyFile(src, dst string, perm os.FileMode) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.OpenFile(dst, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, perm)
+	if err != nil {
+		return err
+	}
+
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+
+	return out.Close()
+}
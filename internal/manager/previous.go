@@ -0,0 +1,87 @@
+package manager
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	_logger "github.com/justjundana/govman/internal/logger"
+)
+
+// previousVersionsFile is the name of the file in GovmanHome that records what Use switched away from, for 'govman use -'.
+const previousVersionsFile = "previous-versions.json"
+
+// previousVersions records the version active before the last switch, for the default and for each terminal session.
+type previousVersions struct {
+	Default  string            `json:"default,omitempty"`
+	Sessions map[string]string `json:"sessions,omitempty"` // keyed by SessionID
+}
+
+// PreviousVersion returns the version that was active before Use last switched the default (isDefault) or the
+// current terminal session. Returns ErrNoPreviousVersion if there is none, or an error wrapping ErrNotInstalled
+// together with the version if it has been uninstalled since.
+func (m *Manager) PreviousVersion(isDefault bool) (string, error) {
+	previous := m.loadPreviousVersions()
+	version := previous.Sessions[strconv.Itoa(SessionID())]
+	if isDefault {
+		version = previous.Default
+	}
+
+	if version == "" {
+		return "", ErrNoPreviousVersion
+	}
+	if !m.IsInstalled(version) {
+		return version, fmt.Errorf("previous go version %s is %w", version, ErrNotInstalled)
+	}
+	return version, nil
+}
+
+// previousVersionsPath returns the path of the file recording the versions Use switched away from.
+func (m *Manager) previousVersionsPath() string {
+	return filepath.Join(filepath.Dir(m.config.GetBinPath()), previousVersionsFile)
+}
+
+// loadPreviousVersions reads the versions Use switched away from, or an empty record if there are none or the
+// file is unreadable.
+func (m *Manager) loadPreviousVersions() previousVersions {
+	var previous previousVersions
+	if data, err := os.ReadFile(m.previousVersionsPath()); err == nil {
+		if err := json.Unmarshal(data, &previous); err != nil {
+			_logger.Verbose("Ignoring unreadable %s: %v", previousVersionsFile, err)
+			return previousVersions{}
+		}
+	}
+	return previous
+}
+
+// recordPreviousVersion records version as the one the default (isDefault) or the current terminal session was
+// switched away from, dropping the entries of shells that are no longer running. Returns an error if the file
+// cannot be written.
+func (m *Manager) recordPreviousVersion(isDefault bool, version string) error {
+	previous := m.loadPreviousVersions()
+	if isDefault {
+		previous.Default = version
+	} else {
+		for id := range previous.Sessions {
+			if pid, err := strconv.Atoi(id); err != nil || !sessionProcessAlive(pid) {
+				delete(previous.Sessions, id)
+			}
+		}
+		if previous.Sessions == nil {
+			previous.Sessions = make(map[string]string)
+		}
+		previous.Sessions[strconv.Itoa(SessionID())] = version
+	}
+
+	data, err := json.MarshalIndent(previous, "", "  ")
+	if err != nil {
+		return err
+	}
+	path := m.previousVersionsPath()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
@@ -0,0 +1,288 @@
+package manager
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+
+	_config "github.com/justjundana/govman/internal/config"
+	_logger "github.com/justjundana/govman/internal/logger"
+	_util "github.com/justjundana/govman/internal/util"
+)
+
+// legacyProjectFileNotice makes sure the deprecation notice for a legacy project file is logged once per run.
+var legacyProjectFileNotice sync.Once
+
+// Pin writes the active version to the project's version file, exactly or, with minor, as its major.minor line,
+// which matches the newest installed patch of that line. Returns the version written, or an error if no version
+// is active, its version cannot be pinned, or the file cannot be written.
+func (m *Manager) Pin(minor bool) (string, error) {
+	current, err := m.Current()
+	if err != nil {
+		return "", fmt.Errorf("failed to determine the active version: %w", err)
+	}
+	if !installedVersionRegex.MatchString(current) {
+		return "", fmt.Errorf("active version %q cannot be pinned", current)
+	}
+
+	version := current
+	if minor {
+		version = _util.ReleaseLine(current)
+	}
+
+	if err := m.setLocalVersion(version); err != nil {
+		return "", fmt.Errorf("failed to set local version: %w", err)
+	}
+	return version, nil
+}
+
+// setLocalVersion writes the project's autoswitch file with the specified version.
+// Returns an error if the file write fails, with advice on the project directory if permission is denied.
+func (m *Manager) setLocalVersion(version string) error {
+	filename := m.config.AutoSwitch.ProjectFile
+	if err := os.WriteFile(filename, []byte(version), 0644); err != nil {
+		return _util.WithPermissionHint(err, filepath.Dir(filename))
+	}
+	return nil
+}
+
+// UnsetLocal removes the project version file (auto_switch.project_file) from the current directory.
+// Returns the removed path, or an empty string if no file was present; an error if removal fails.
+func (m *Manager) UnsetLocal() (string, error) {
+	filename := m.config.AutoSwitch.ProjectFile
+	if err := os.Remove(filename); err != nil {
+		if os.IsNotExist(err) {
+			return "", nil
+		}
+		return "", fmt.Errorf("failed to remove %s: %w", filename, err)
+	}
+
+	return filename, nil
+}
+
+// getLocalVersionRaw reads the project's version file and returns the raw version string.
+// Returns an empty string if no project version file provides a version.
+func (m *Manager) getLocalVersionRaw() string {
+	_, version := m.FindProjectVersionFile()
+	return version
+}
+
+// FindProjectVersionFile looks for a project version file, in precedence order, from the current directory up to
+// $HOME or the root; go.mod contributes its go directive. Returns the file path and raw version, or empty strings.
+func (m *Manager) FindProjectVersionFile() (string, string) {
+	candidates, legacy := m.projectFileCandidates()
+
+	var relative []string
+	for _, name := range candidates {
+		if filepath.IsAbs(name) {
+			if version := readProjectVersionFile(name); version != "" {
+				m.noteLegacyProjectFile(name, legacy)
+				return name, version
+			}
+			continue
+		}
+		relative = append(relative, name)
+	}
+
+	if len(relative) == 0 {
+		return "", ""
+	}
+
+	dir, err := os.Getwd()
+	if err != nil {
+		_logger.Verbose("Failed to get working directory: %v", err)
+		return "", ""
+	}
+	// Walk the physical directory tree, as git does, so a symlinked directory finds its target's project file
+	if resolved, err := filepath.EvalSymlinks(dir); err == nil {
+		dir = resolved
+	}
+
+	homeDir, _ := os.UserHomeDir()
+	if resolved, err := filepath.EvalSymlinks(homeDir); err == nil {
+		homeDir = resolved
+	}
+
+	for {
+		for _, name := range relative {
+			path := filepath.Join(dir, name)
+			if version := readProjectVersionFile(path); version != "" {
+				m.noteLegacyProjectFile(name, legacy)
+				return path, version
+			}
+		}
+
+		parent := filepath.Dir(dir)
+		if dir == homeDir || parent == dir {
+			return "", ""
+		}
+		dir = parent
+	}
+}
+
+// projectFileCandidates returns the project version file names to search, with the legacy name after
+// auto_switch.project_file unless configured. Returns the names and the inserted legacy name, or "".
+func (m *Manager) projectFileCandidates() ([]string, string) {
+	candidates := m.config.AutoSwitch.ProjectFileCandidates()
+	legacy := m.legacyProjectFileName()
+	if legacy == "" || slices.Contains(candidates, legacy) {
+		return candidates, ""
+	}
+	return slices.Insert(candidates, 1, legacy), legacy
+}
+
+// legacyProjectFileName returns the legacy project file name next to auto_switch.project_file, relative when
+// project_file is. Returns "" when project_file is unset or already uses the legacy name.
+func (m *Manager) legacyProjectFileName() string {
+	projectFile := m.config.AutoSwitch.ProjectFile
+	if projectFile == "" || filepath.Base(projectFile) == _config.LegacyProjectFile {
+		return ""
+	}
+	return filepath.Join(filepath.Dir(projectFile), _config.LegacyProjectFile)
+}
+
+// noteLegacyProjectFile logs, once per run, that name is the legacy project file a version was read from.
+func (m *Manager) noteLegacyProjectFile(name, legacy string) {
+	if legacy == "" || name != legacy {
+		return
+	}
+	legacyProjectFileNotice.Do(func() {
+		_logger.Warning("%s is deprecated; rename it to %s, or run 'govman refresh' in the project to migrate it",
+			_config.LegacyProjectFile, filepath.Base(m.config.AutoSwitch.ProjectFile))
+	})
+}
+
+// LegacyProjectFile returns the path of the legacy .govman-version file next to auto_switch.project_file, which is
+// the current directory unless project_file is absolute. Returns "" when there is no such file.
+func (m *Manager) LegacyProjectFile() string {
+	legacy := m.legacyProjectFileName()
+	if legacy == "" {
+		return ""
+	}
+	if info, err := os.Lstat(legacy); err != nil || !info.Mode().IsRegular() {
+		return ""
+	}
+	return legacy
+}
+
+// MigrateLegacyProjectFile renames the legacy project file to auto_switch.project_file, or removes it when that file
+// exists. Returns the legacy path, or "" when there was nothing to migrate, and an error.
+func (m *Manager) MigrateLegacyProjectFile() (string, error) {
+	legacy := m.LegacyProjectFile()
+	if legacy == "" {
+		return "", nil
+	}
+
+	projectFile := m.config.AutoSwitch.ProjectFile
+	if _, err := os.Lstat(projectFile); err == nil {
+		if err := os.Remove(legacy); err != nil {
+			return "", _util.WithPermissionHint(fmt.Errorf("failed to remove %s: %w", legacy, err), filepath.Dir(legacy))
+		}
+		return legacy, nil
+	}
+
+	if err := os.Rename(legacy, projectFile); err != nil {
+		return "", _util.WithPermissionHint(fmt.Errorf("failed to rename %s to %s: %w", legacy, projectFile, err), filepath.Dir(legacy))
+	}
+	return legacy, nil
+}
+
+// readProjectVersionFile reads a project version file and returns the version it specifies.
+// Returns an empty string if the file is missing, unreadable, or empty.
+func readProjectVersionFile(path string) string {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return ""
+	}
+
+	if filepath.Base(path) == "go.mod" {
+		return parseGoModVersion(data)
+	}
+
+	return strings.TrimSpace(string(data))
+}
+
+// parseGoModVersion extracts the go directive from go.mod contents as a major.minor version, e.g. "go 1.22.3" yields "1.22".
+// Returns an empty string if there is no go directive.
+func parseGoModVersion(data []byte) string {
+	for _, line := range strings.Split(string(data), "\n") {
+		if i := strings.Index(line, "//"); i >= 0 {
+			line = line[:i]
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) == 2 && fields[0] == "go" {
+			return _util.ExtractMajorMinor(fields[1])
+		}
+	}
+
+	return ""
+}
+
+// AutoSwitchVersion finds the nearest project version file and resolves it to an installed version,
+// matching partial versions such as "1.22" to the newest installed patch.
+// Returns empty strings and a nil error when no project file is found, or an error if the version is invalid or not installed.
+func (m *Manager) AutoSwitchVersion() (file, version string, err error) {
+	file, raw := m.FindProjectVersionFile()
+	if file == "" {
+		return "", "", nil
+	}
+
+	if !VersionFormatRegex.MatchString(raw) {
+		return file, "", fmt.Errorf("invalid version format in %s: %s", file, raw)
+	}
+
+	if m.IsInstalled(raw) {
+		return file, raw, nil
+	}
+
+	installed, err := m.ListInstalled()
+	if err != nil {
+		return file, "", err
+	}
+	if matched, err := _util.FindBestMatchingVersion(raw, installed); err == nil {
+		return file, matched, nil
+	}
+
+	return file, "", fmt.Errorf("go version %s required by %s is %w. Run 'govman install %s' first", raw, file, ErrNotInstalled, raw)
+}
+
+// GetLocalVersionRaw returns the raw version string from the project's autoswitch file.
+// Returns an empty string if the file does not exist or cannot be read.
+func (m *Manager) GetLocalVersionRaw() string {
+	return m.getLocalVersionRaw()
+}
+
+// getLocalVersion reads the project's autoswitch file and returns the best matching installed version.
+// It uses flexible version matching based on major.minor version (e.g., "1.25" matches "1.25.4").
+// Returns an empty string if the file does not exist or no matching version is installed.
+func (m *Manager) getLocalVersion() string {
+	rawVersion := m.getLocalVersionRaw()
+	if rawVersion == "" {
+		return ""
+	}
+
+	// Get all installed versions
+	installedVersions, err := m.ListInstalled()
+	if err != nil || len(installedVersions) == 0 {
+		return ""
+	}
+
+	// Find a matching version based on major.minor
+	matchedVersion, err := _util.FindBestMatchingVersion(rawVersion, installedVersions)
+	if err != nil {
+		// No matching version found, return empty string
+		return ""
+	}
+
+	return matchedVersion
+}
+
+// LocalVersion returns the installed version the project's version file selects, or "" when there is no project
+// file or no installed version matches it.
+func (m *Manager) LocalVersion() string {
+	return m.getLocalVersion()
+}
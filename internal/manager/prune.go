@@ -0,0 +1,179 @@
+package manager
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	_golang "github.com/justjundana/govman/internal/golang"
+	_logger "github.com/justjundana/govman/internal/logger"
+	_util "github.com/justjundana/govman/internal/util"
+)
+
+// RetainedReason starts the reason Prune gives for versions kept only by the retention policy.
+const RetainedReason = "retention policy"
+
+// PrunePolicy selects versions Prune keeps in addition to the active, default, and project-local versions.
+type PrunePolicy struct {
+	// Keep is how many of the highest installed versions to keep; 0 keeps none.
+	Keep int
+	// PerMinor applies Keep to each major.minor line instead of to all versions.
+	PerMinor bool
+}
+
+// Prune removes every installed version PlanPrune does not protect, or with dryRun only reports them. Returns the
+// removed versions, the protected ones with their reasons, the bytes freed, and an error.
+func (m *Manager) Prune(dryRun bool, policy PrunePolicy) (removed []string, protected map[string]string, freed int64, err error) {
+	plan, err := m.PlanPrune(policy)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+	if dryRun {
+		return plan.Remove, plan.Protected, plan.Reclaimable, nil
+	}
+
+	removed, freed, err = m.ApplyPrune(plan)
+	return removed, plan.Protected, freed, err
+}
+
+// PrunePlan is what a prune removes and keeps, computed once by PlanPrune and carried out by ApplyPrune.
+type PrunePlan struct {
+	Installed   []string          // every installed version
+	Remove      []string          // the versions to remove
+	Protected   map[string]string // the protected versions mapped to the reason they are kept
+	Reclaimable int64             // the bytes removing them frees
+
+	sizes map[string]int64
+}
+
+// PlanPrune works out which installed versions Prune would remove under policy, without deleting anything.
+// Returns an error if policy is invalid or installed versions cannot be listed.
+func (m *Manager) PlanPrune(policy PrunePolicy) (*PrunePlan, error) {
+	if policy.Keep < 0 {
+		return nil, fmt.Errorf("invalid retention count %d: must not be negative", policy.Keep)
+	}
+
+	installed, err := m.ListInstalled()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list installed versions: %w", err)
+	}
+
+	protected := m.protectedVersions(installed)
+	// Versions in use keep their more specific reason
+	for version, reason := range retainedVersions(installed, policy) {
+		if _, exists := protected[version]; !exists {
+			protected[version] = reason
+		}
+	}
+
+	// Only installed versions are kept; a default that is not installed has nothing to protect
+	for version := range protected {
+		if !slices.Contains(installed, version) {
+			delete(protected, version)
+		}
+	}
+
+	plan := &PrunePlan{Installed: installed, Protected: protected}
+	for _, version := range installed {
+		if _, isProtected := protected[version]; !isProtected {
+			plan.Remove = append(plan.Remove, version)
+		}
+	}
+
+	plan.sizes = m.VersionSizes(plan.Remove)
+	for _, version := range plan.Remove {
+		plan.Reclaimable += plan.sizes[version]
+	}
+	return plan, nil
+}
+
+// ApplyPrune uninstalls the versions plan removes, continuing past failures.
+// Returns the removed versions, the bytes freed, and an error listing every removal that failed.
+func (m *Manager) ApplyPrune(plan *PrunePlan) (removed []string, freed int64, err error) {
+	var failures []string
+	for i, version := range plan.Remove {
+		_logger.Info("[%d/%d] Removing Go %s...", i+1, len(plan.Remove), version)
+		if err := m.Uninstall(version); err != nil {
+			_logger.Warning("Failed to remove Go %s: %v", version, err)
+			failures = append(failures, fmt.Sprintf("Go %s: %v", version, err))
+			continue
+		}
+
+		removed = append(removed, version)
+		freed += plan.sizes[version]
+	}
+
+	if len(failures) > 0 {
+		return removed, freed, fmt.Errorf("failed to prune %d version(s): %s", len(failures), strings.Join(failures, "; "))
+	}
+
+	return removed, freed, nil
+}
+
+// protectedVersions returns the installed versions that prune must keep, mapped to the reason each is kept.
+func (m *Manager) protectedVersions(installed []string) map[string]string {
+	protected := make(map[string]string)
+
+	state, err := m.CurrentDetailed()
+
+	// Currently active version
+	if err == nil && state.Version != "" {
+		protected[state.Version] = "currently active"
+	}
+
+	// System default version
+	if state.Default != "" {
+		if _, exists := protected[state.Default]; !exists {
+			protected[state.Default] = "system default"
+		}
+	}
+
+	// Local project version (from .govman-goversion, .go-version, or go.mod)
+	if localVersion := state.LocalRaw; localVersion != "" {
+		reason := fmt.Sprintf("project-local (%s)", state.LocalFile)
+
+		// Protect the exact version if it is installed, and the version it resolves to on its major.minor line
+		if slices.Contains(installed, localVersion) {
+			if _, exists := protected[localVersion]; !exists {
+				protected[localVersion] = reason
+			}
+		}
+		if matched, err := _util.FindBestMatchingVersion(localVersion, installed); err == nil {
+			if _, exists := protected[matched]; !exists {
+				protected[matched] = reason
+			}
+		}
+	}
+
+	return protected
+}
+
+// retainedVersions returns the versions policy keeps: the Keep highest of installed, or of each major.minor line
+// with PerMinor, mapped to a reason starting with RetainedReason.
+func retainedVersions(installed []string, policy PrunePolicy) map[string]string {
+	retained := make(map[string]string)
+	if policy.Keep <= 0 {
+		return retained
+	}
+
+	sorted := slices.Clone(installed)
+	slices.SortFunc(sorted, func(a, b string) int {
+		return _golang.CompareVersions(b, a)
+	})
+
+	kept := make(map[string]int)
+	for _, version := range sorted {
+		line, reason := "", fmt.Sprintf("%s: newest %d", RetainedReason, policy.Keep)
+		if policy.PerMinor {
+			line = _util.ReleaseLine(version)
+			reason = fmt.Sprintf("%s: newest %d of Go %s", RetainedReason, policy.Keep, line)
+		}
+		if kept[line] >= policy.Keep {
+			continue
+		}
+		kept[line]++
+		retained[version] = reason
+	}
+
+	return retained
+}
@@ -0,0 +1,101 @@
+package manager
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	_config "github.com/justjundana/govman/internal/config"
+	_lock "github.com/justjundana/govman/internal/lock"
+	_logger "github.com/justjundana/govman/internal/logger"
+	_shell "github.com/justjundana/govman/internal/shell"
+	_util "github.com/justjundana/govman/internal/util"
+)
+
+// sessionProcessAlive reports whether the shell owning a session file still runs; replaced in tests.
+var sessionProcessAlive = _lock.ProcessAlive
+
+// SessionID returns the process ID of the shell whose session 'govman use --temp' records: $GOVMAN_SESSION_PID as
+// exported by the shell integration, or govman's parent process when it is unset.
+func SessionID() int {
+	if pid, err := strconv.Atoi(os.Getenv(_shell.SessionPIDEnv)); err == nil && pid > 0 {
+		return pid
+	}
+	return os.Getppid()
+}
+
+// SetSessionVersion records version as the Go version of the current terminal session, which Current and the shell
+// hook then prefer until the shell exits, and removes the files left by shells that are no longer running.
+// Returns an error if the version is not installed or the session file cannot be written.
+func (m *Manager) SetSessionVersion(version string) error {
+	version = m.installedSpelling(version)
+	if !m.IsInstalled(version) {
+		return fmt.Errorf("go version %s is %w", version, ErrNotInstalled)
+	}
+
+	if _, err := m.CleanStaleSessions(); err != nil {
+		_logger.Verbose("Could not remove stale session files: %v", err)
+	}
+
+	sessionFile := m.config.GetSessionFile(SessionID())
+	if err := os.MkdirAll(filepath.Dir(sessionFile), 0755); err != nil {
+		return _util.WithPermissionHint(fmt.Errorf("failed to create session directory: %w", err), filepath.Dir(sessionFile))
+	}
+	if err := os.WriteFile(sessionFile, []byte(version+"\n"), 0644); err != nil {
+		return _util.WithPermissionHint(fmt.Errorf("failed to write session file: %w", err), filepath.Dir(sessionFile))
+	}
+	return nil
+}
+
+// SessionVersion returns the version recorded for the current terminal session by SetSessionVersion,
+// or "" when none is recorded or the recorded version is no longer installed.
+func (m *Manager) SessionVersion() string {
+	data, err := os.ReadFile(m.config.GetSessionFile(SessionID()))
+	if err != nil {
+		return ""
+	}
+
+	version := strings.TrimSpace(string(data))
+	if version == "" || !m.IsInstalled(version) {
+		return ""
+	}
+	return version
+}
+
+// ClearSessionVersion removes the version recorded for the current terminal session, if any.
+// Returns an error if the session file exists but cannot be removed.
+func (m *Manager) ClearSessionVersion() error {
+	if err := os.Remove(m.config.GetSessionFile(SessionID())); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove session file: %w", err)
+	}
+	return nil
+}
+
+// CleanStaleSessions removes the session files of shells that are no longer running.
+// Returns the number of files removed, or an error if the govman home cannot be read.
+func (m *Manager) CleanStaleSessions() (int, error) {
+	sessionDir := filepath.Dir(m.config.GetSessionFile(0))
+	entries, err := os.ReadDir(sessionDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return 0, nil
+		}
+		return 0, fmt.Errorf("failed to read %s: %w", sessionDir, err)
+	}
+
+	removed := 0
+	for _, entry := range entries {
+		pid, err := strconv.Atoi(strings.TrimPrefix(entry.Name(), _config.SessionFilePrefix))
+		if entry.IsDir() || !strings.HasPrefix(entry.Name(), _config.SessionFilePrefix) || err != nil || sessionProcessAlive(pid) {
+			continue
+		}
+		if err := os.Remove(filepath.Join(sessionDir, entry.Name())); err != nil {
+			_logger.Verbose("Could not remove stale session file %s: %v", entry.Name(), err)
+			continue
+		}
+		removed++
+	}
+	return removed, nil
+}
@@ -0,0 +1,304 @@
+package manager
+
+import (
+	"context"
+	"debug/buildinfo"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+
+	_config "github.com/justjundana/govman/internal/config"
+	_logger "github.com/justjundana/govman/internal/logger"
+	_util "github.com/justjundana/govman/internal/util"
+)
+
+// shimManifestFile records the tool shims Rehash wrote to the bin directory, so it never touches files it did not create.
+const shimManifestFile = ".govman-shims.json"
+
+// Shim is a wrapper in the bin directory that runs a tool installed with 'go install', so the tool stays on PATH
+// when the active Go version changes.
+type Shim struct {
+	Name    string `json:"name"`              // command name, without .exe
+	Target  string `json:"target"`            // the tool binary the shim runs
+	Version string `json:"version,omitempty"` // installed Go version the tool was built with, or "" if unknown
+}
+
+// Shims returns the tool shims recorded in the bin directory, sorted by name.
+// Returns an error if the record exists but cannot be read.
+func (m *Manager) Shims() ([]Shim, error) {
+	data, err := os.ReadFile(filepath.Join(m.config.GetBinPath(), shimManifestFile))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read shim list: %w", err)
+	}
+
+	var shims []Shim
+	if err := json.Unmarshal(data, &shims); err != nil {
+		return nil, fmt.Errorf("failed to parse shim list: %w", err)
+	}
+	sort.Slice(shims, func(i, j int) bool { return shims[i].Name < shims[j].Name })
+	return shims, nil
+}
+
+// Rehash regenerates the shims in the bin directory for the executables in the active version's GOBIN, removing
+// those whose tool is gone. Returns the shims present and the removed names, or an error.
+func (m *Manager) Rehash(ctx context.Context) ([]Shim, []string, error) {
+	version, err := m.Current()
+	if err != nil {
+		return nil, nil, fmt.Errorf("no active Go version to find installed tools with: %w", err)
+	}
+
+	toolDir, err := m.toolBinDir(ctx, version)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	previous, err := m.Shims()
+	if err != nil {
+		return nil, nil, err
+	}
+	owned := make(map[string]Shim, len(previous))
+	for _, shim := range previous {
+		owned[shim.Name] = shim
+	}
+
+	binDir := m.config.GetBinPath()
+	if err := os.MkdirAll(binDir, 0755); err != nil {
+		return nil, nil, _util.WithPermissionHint(fmt.Errorf("failed to create bin directory: %w", err), filepath.Dir(binDir))
+	}
+
+	current := make(map[string]Shim)
+	for _, shim := range m.findTools(toolDir, version) {
+		if _, ok := owned[shim.Name]; !ok {
+			if _, err := os.Lstat(shimPath(binDir, shim.Name)); err == nil {
+				_logger.Verbose("Not shimming %s: %s already exists and was not created by govman", shim.Name, shimPath(binDir, shim.Name))
+				continue
+			}
+		}
+		if err := m.writeShim(binDir, shim); err != nil {
+			return nil, nil, err
+		}
+		current[shim.Name] = shim
+	}
+
+	// Tools found through another GOBIN keep their shims until the tool itself is removed
+	var removed []string
+	for _, shim := range previous {
+		if _, ok := current[shim.Name]; ok {
+			continue
+		}
+		if _, err := os.Stat(shim.Target); err == nil {
+			current[shim.Name] = shim
+			continue
+		}
+		if err := os.Remove(shimPath(binDir, shim.Name)); err != nil && !os.IsNotExist(err) {
+			return nil, nil, _util.WithPermissionHint(fmt.Errorf("failed to remove shim %s: %w", shim.Name, err), binDir)
+		}
+		removed = append(removed, shim.Name)
+	}
+
+	shims := make([]Shim, 0, len(current))
+	for _, shim := range current {
+		shims = append(shims, shim)
+	}
+	sort.Slice(shims, func(i, j int) bool { return shims[i].Name < shims[j].Name })
+
+	if err := m.saveShims(shims); err != nil {
+		return nil, nil, err
+	}
+	return shims, removed, nil
+}
+
+// toolBinDir returns the directory 'go install' writes to for version: GOBIN, or the bin directory of the first
+// GOPATH entry. Returns an error if 'go env' fails.
+func (m *Manager) toolBinDir(ctx context.Context, version string) (string, error) {
+	cmd, err := m.Command(ctx, version, []string{"go", "env", "GOBIN", "GOPATH"})
+	if err != nil {
+		return "", err
+	}
+	output, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to run 'go env' for Go %s: %w", version, err)
+	}
+
+	// An unset GOBIN prints an empty first line, so only trailing newlines may be trimmed
+	lines := strings.Split(strings.TrimRight(string(output), "\r\n"), "\n")
+	if len(lines) < 2 {
+		return "", fmt.Errorf("unexpected 'go env' output for Go %s: %q", version, output)
+	}
+	if gobin := strings.TrimSpace(lines[0]); gobin != "" {
+		return gobin, nil
+	}
+	gopath := filepath.SplitList(strings.TrimSpace(lines[1]))
+	if len(gopath) == 0 || gopath[0] == "" {
+		return "", fmt.Errorf("neither GOBIN nor GOPATH is set for Go %s", version)
+	}
+	return filepath.Join(gopath[0], "bin"), nil
+}
+
+// findTools returns a shim for each executable in toolDir, skipping names of the active version's own binaries
+// (such as go and gofmt) so a tool never shadows the toolchain. A missing toolDir has no tools.
+func (m *Manager) findTools(toolDir, version string) []Shim {
+	entries, err := os.ReadDir(toolDir)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			_logger.Verbose("Failed to read %s: %v", toolDir, err)
+		}
+		return nil
+	}
+
+	var shims []Shim
+	for _, entry := range entries {
+		name, ok := toolName(entry)
+		if !ok {
+			continue
+		}
+		if _, err := os.Stat(filepath.Join(m.config.GetVersionDir(version), "bin", entry.Name())); err == nil {
+			_logger.Verbose("Not shimming %s: Go %s ships a binary with that name", name, version)
+			continue
+		}
+
+		target := filepath.Join(toolDir, entry.Name())
+		shims = append(shims, Shim{Name: name, Target: target, Version: m.builtWith(target)})
+	}
+	return shims
+}
+
+// toolName returns the command name of an executable binary, without .exe on Windows.
+// Returns false for directories, hidden files, and files that are not executable.
+func toolName(entry os.DirEntry) (string, bool) {
+	if entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
+		return "", false
+	}
+	info, err := entry.Info()
+	if err != nil || !info.Mode().IsRegular() {
+		return "", false
+	}
+
+	if runtime.GOOS == "windows" {
+		if !strings.EqualFold(filepath.Ext(entry.Name()), ".exe") {
+			return "", false
+		}
+		return strings.TrimSuffix(entry.Name(), filepath.Ext(entry.Name())), true
+	}
+	return entry.Name(), info.Mode()&0111 != 0
+}
+
+// builtWith returns the installed version the Go binary at path was built with, read from its build information.
+// Returns "" if the binary carries none or that version is not installed.
+func (m *Manager) builtWith(path string) string {
+	info, err := buildinfo.ReadFile(path)
+	if err != nil {
+		return ""
+	}
+	// GoVersion may carry experiments after the release, as in "go1.25.1 X:nocoverageredesign"
+	fields := strings.Fields(info.GoVersion)
+	if len(fields) == 0 {
+		return ""
+	}
+	version := m.installedSpelling(strings.TrimPrefix(fields[0], "go"))
+	if !m.IsInstalled(version) {
+		return ""
+	}
+	return version
+}
+
+// shimPath returns the path of the shim for a tool: name in binDir, with .cmd on Windows.
+func shimPath(binDir, name string) string {
+	if runtime.GOOS == "windows" {
+		return filepath.Join(binDir, name+".cmd")
+	}
+	return filepath.Join(binDir, name)
+}
+
+// writeShim writes the script for shim into binDir, running the version the tool was built with when shims.version
+// is installed, else the active one. Returns an error if the script cannot be written.
+func (m *Manager) writeShim(binDir string, shim Shim) error {
+	var versionDir string
+	if shim.Version != "" && m.config.Shims.Version != _config.ShimVersionActive {
+		versionDir = m.config.GetVersionDir(shim.Version)
+	}
+
+	var script string
+	if runtime.GOOS == "windows" {
+		script = windowsShimScript(shim, versionDir, m.config.Shell.PinToolchain)
+	} else {
+		script = unixShimScript(shim, versionDir, m.config.Shell.PinToolchain)
+	}
+
+	if err := os.WriteFile(shimPath(binDir, shim.Name), []byte(script), 0755); err != nil {
+		return _util.WithPermissionHint(fmt.Errorf("failed to write shim %s: %w", shim.Name, err), binDir)
+	}
+	return nil
+}
+
+// unixShimScript returns a POSIX sh script that execs shim.Target, with versionDir activated when it is not empty.
+// pinToolchain also sets GOTOOLCHAIN=local there.
+func unixShimScript(shim Shim, versionDir string, pinToolchain bool) string {
+	var sb strings.Builder
+	sb.WriteString("#!/bin/sh\n")
+	fmt.Fprintf(&sb, "# govman shim for %s; regenerate with 'govman shim --rehash'\n", shim.Name)
+	if versionDir != "" {
+		fmt.Fprintf(&sb, "GOROOT=%s\n", shQuote(versionDir))
+		fmt.Fprintf(&sb, "PATH=%s:\"$PATH\"\n", shQuote(filepath.Join(versionDir, "bin")))
+		if pinToolchain {
+			sb.WriteString("GOTOOLCHAIN=local\n")
+			sb.WriteString("export GOROOT PATH GOTOOLCHAIN\n")
+		} else {
+			sb.WriteString("export GOROOT PATH\n")
+		}
+	}
+	fmt.Fprintf(&sb, "exec %s \"$@\"\n", shQuote(shim.Target))
+	return sb.String()
+}
+
+// windowsShimScript returns a batch script that runs shim.Target, with versionDir activated when it is not empty.
+// pinToolchain also sets GOTOOLCHAIN=local there.
+func windowsShimScript(shim Shim, versionDir string, pinToolchain bool) string {
+	var sb strings.Builder
+	sb.WriteString("@echo off\r\n")
+	fmt.Fprintf(&sb, "rem govman shim for %s; regenerate with 'govman shim --rehash'\r\n", shim.Name)
+	sb.WriteString("setlocal\r\n")
+	if versionDir != "" {
+		fmt.Fprintf(&sb, "set \"GOROOT=%s\"\r\n", versionDir)
+		fmt.Fprintf(&sb, "set \"PATH=%s;%%PATH%%\"\r\n", filepath.Join(versionDir, "bin"))
+		if pinToolchain {
+			sb.WriteString("set \"GOTOOLCHAIN=local\"\r\n")
+		}
+	}
+	fmt.Fprintf(&sb, "\"%s\" %%*\r\n", shim.Target)
+	sb.WriteString("exit /b %ERRORLEVEL%\r\n")
+	return sb.String()
+}
+
+// shQuote quotes s as a single word for a POSIX shell.
+func shQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// saveShims records shims in the bin directory, removing the record when there are none.
+// Returns an error if the record cannot be written.
+func (m *Manager) saveShims(shims []Shim) error {
+	path := filepath.Join(m.config.GetBinPath(), shimManifestFile)
+	if len(shims) == 0 {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to remove shim list: %w", err)
+		}
+		return nil
+	}
+
+	data, err := json.MarshalIndent(shims, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode shim list: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return _util.WithPermissionHint(fmt.Errorf("failed to save shim list: %w", err), filepath.Dir(path))
+	}
+	return nil
+}
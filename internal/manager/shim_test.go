@@ -0,0 +1,243 @@
+package manager
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+	"testing"
+
+	_config "github.com/justjundana/govman/internal/config"
+)
+
+// setupShimTools installs a fake Go 1.21.0 that is active on PATH and whose GOPATH/bin holds tools, and returns that
+// tool directory. The tools are a shell script "hello", a copy of the test binary "built" (which carries build
+// information for runtime.Version()), a non-executable file, and a "gofmt" that collides with the toolchain.
+func setupShimTools(t *testing.T, config *_config.Config) string {
+	t.Helper()
+
+	gopath := filepath.Join(t.TempDir(), "gopath")
+	versionBin := filepath.Join(config.GetVersionDir("1.21.0"), "bin")
+	if err := os.MkdirAll(versionBin, 0755); err != nil {
+		t.Fatal(err)
+	}
+	goScript := "#!/bin/sh\ncase \"$1\" in\nversion) echo 'go version go1.21.0 linux/amd64' ;;\nenv) echo ''; echo '" + gopath + "' ;;\nesac\n"
+	if err := os.WriteFile(filepath.Join(versionBin, "go"), []byte(goScript), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(versionBin, "gofmt"), []byte("#!/bin/sh\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", versionBin+string(os.PathListSeparator)+"/usr/bin"+string(os.PathListSeparator)+"/bin")
+
+	toolDir := filepath.Join(gopath, "bin")
+	if err := os.MkdirAll(toolDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(toolDir, "hello"), []byte("#!/bin/sh\necho \"hello $*|$GOROOT\"\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(toolDir, "gofmt"), []byte("#!/bin/sh\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(toolDir, "README"), []byte("not a tool\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	self, err := os.Executable()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := copyFile(self, filepath.Join(toolDir, "built"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(config.GetVersionDir(strings.TrimPrefix(runtime.Version(), "go")), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	return toolDir
+}
+
+func shimNames(shims []Shim) []string {
+	names := make([]string, 0, len(shims))
+	for _, shim := range shims {
+		names = append(names, shim.Name)
+	}
+	return names
+}
+
+func TestManager_Rehash(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses shell scripts as fake go binaries")
+	}
+	if !strings.HasPrefix(runtime.Version(), "go1.") {
+		t.Skip("needs a released Go toolchain to read build information from")
+	}
+
+	config := createTestConfig(t)
+	config.Shims.Version = _config.ShimVersionInstalled
+	manager := createTestManager(t, config)
+	toolDir := setupShimTools(t, config)
+	binDir := config.GetBinPath()
+
+	// A file govman did not create keeps its name, even when a tool has the same one
+	if err := os.WriteFile(filepath.Join(toolDir, "mine"), []byte("#!/bin/sh\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(binDir, "mine"), []byte("user file\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	shims, removed, err := manager.Rehash(context.Background())
+	if err != nil {
+		t.Fatalf("Rehash() error = %v", err)
+	}
+	if got, want := shimNames(shims), []string{"built", "hello"}; !slices.Equal(got, want) {
+		t.Fatalf("Rehash() shims = %v, want %v", got, want)
+	}
+	if len(removed) != 0 {
+		t.Errorf("Rehash() removed = %v, want none", removed)
+	}
+
+	builtVersion := strings.TrimPrefix(runtime.Version(), "go")
+	if shims[0].Version != builtVersion || shims[0].Target != filepath.Join(toolDir, "built") {
+		t.Errorf("built shim = %+v, want version %s and target in %s", shims[0], builtVersion, toolDir)
+	}
+	if shims[1].Version != "" {
+		t.Errorf("hello shim version = %q, want empty for a binary without build information", shims[1].Version)
+	}
+	if data, _ := os.ReadFile(filepath.Join(binDir, "mine")); string(data) != "user file\n" {
+		t.Errorf("Rehash() overwrote a file it did not create: %q", data)
+	}
+
+	t.Run("shim runs the tool", func(t *testing.T) {
+		output, err := exec.Command(filepath.Join(binDir, "hello"), "a b", "c").Output()
+		if err != nil {
+			t.Fatalf("running shim: %v", err)
+		}
+		if got := strings.TrimSpace(string(output)); got != "hello a b c|" {
+			t.Errorf("shim output = %q, want %q", got, "hello a b c|")
+		}
+	})
+
+	t.Run("shim activates the version the tool was built with", func(t *testing.T) {
+		data, err := os.ReadFile(filepath.Join(binDir, "built"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !strings.Contains(string(data), "GOROOT="+shQuote(config.GetVersionDir(builtVersion))) {
+			t.Errorf("built shim does not set GOROOT to Go %s:\n%s", builtVersion, data)
+		}
+	})
+
+	t.Run("active version mode", func(t *testing.T) {
+		config.Shims.Version = _config.ShimVersionActive
+		defer func() { config.Shims.Version = _config.ShimVersionInstalled }()
+
+		if _, _, err := manager.Rehash(context.Background()); err != nil {
+			t.Fatalf("Rehash() error = %v", err)
+		}
+		data, err := os.ReadFile(filepath.Join(binDir, "built"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if strings.Contains(string(data), "GOROOT") {
+			t.Errorf("built shim should leave the active version alone:\n%s", data)
+		}
+	})
+
+	t.Run("removes shims of removed tools", func(t *testing.T) {
+		if err := os.Remove(filepath.Join(toolDir, "hello")); err != nil {
+			t.Fatal(err)
+		}
+
+		shims, removed, err := manager.Rehash(context.Background())
+		if err != nil {
+			t.Fatalf("Rehash() error = %v", err)
+		}
+		if !slices.Equal(removed, []string{"hello"}) {
+			t.Errorf("Rehash() removed = %v, want [hello]", removed)
+		}
+		if got := shimNames(shims); !slices.Equal(got, []string{"built"}) {
+			t.Errorf("Rehash() shims = %v, want [built]", got)
+		}
+		if _, err := os.Lstat(filepath.Join(binDir, "hello")); !os.IsNotExist(err) {
+			t.Errorf("hello shim still exists: %v", err)
+		}
+
+		listed, err := manager.Shims()
+		if err != nil {
+			t.Fatalf("Shims() error = %v", err)
+		}
+		if got := shimNames(listed); !slices.Equal(got, []string{"built"}) {
+			t.Errorf("Shims() = %v, want [built]", got)
+		}
+	})
+}
+
+func TestManager_Rehash_KeepsShimsOfOtherToolDirs(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses shell scripts as fake go binaries")
+	}
+
+	config := createTestConfig(t)
+	manager := createTestManager(t, config)
+	setupShimTools(t, config)
+
+	// A shim made while another GOBIN was in use survives as long as its tool exists
+	otherTool := filepath.Join(t.TempDir(), "other")
+	if err := os.WriteFile(otherTool, []byte("#!/bin/sh\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := manager.saveShims([]Shim{{Name: "other", Target: otherTool}, {Name: "gone", Target: filepath.Join(t.TempDir(), "gone")}}); err != nil {
+		t.Fatal(err)
+	}
+
+	shims, removed, err := manager.Rehash(context.Background())
+	if err != nil {
+		t.Fatalf("Rehash() error = %v", err)
+	}
+	if got, want := shimNames(shims), []string{"built", "hello", "other"}; !slices.Equal(got, want) {
+		t.Errorf("Rehash() shims = %v, want %v", got, want)
+	}
+	if !slices.Equal(removed, []string{"gone"}) {
+		t.Errorf("Rehash() removed = %v, want [gone]", removed)
+	}
+}
+
+func TestManager_Rehash_NoActiveVersion(t *testing.T) {
+	config := createTestConfig(t)
+	manager := createTestManager(t, config)
+	t.Setenv("PATH", t.TempDir())
+
+	if _, _, err := manager.Rehash(context.Background()); err == nil {
+		t.Error("Rehash() should fail when no Go version is active")
+	}
+}
+
+func TestUnixShimScript_Quoting(t *testing.T) {
+	script := unixShimScript(Shim{Name: "tool", Target: "/home/o'neil/go/bin/tool"}, "", true)
+	if !strings.Contains(script, `exec '/home/o'\''neil/go/bin/tool' "$@"`) {
+		t.Errorf("unixShimScript() does not quote the target:\n%s", script)
+	}
+}
+
+func TestShimScript_PinToolchain(t *testing.T) {
+	shim := Shim{Name: "gopls", Target: "/go/bin/gopls"}
+	for _, pin := range []bool{true, false} {
+		unix := unixShimScript(shim, "/govman/go1.25.1", pin)
+		windows := windowsShimScript(shim, `C:\govman\go1.25.1`, pin)
+		if got := strings.Contains(unix, "GOTOOLCHAIN=local"); got != pin {
+			t.Errorf("unixShimScript(pinToolchain=%v) sets GOTOOLCHAIN = %v:\n%s", pin, got, unix)
+		}
+		if got := strings.Contains(windows, "GOTOOLCHAIN=local"); got != pin {
+			t.Errorf("windowsShimScript(pinToolchain=%v) sets GOTOOLCHAIN = %v:\n%s", pin, got, windows)
+		}
+		if !strings.Contains(unix, "export GOROOT PATH") {
+			t.Errorf("unixShimScript(pinToolchain=%v) does not export GOROOT and PATH:\n%s", pin, unix)
+		}
+	}
+}
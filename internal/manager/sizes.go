@@ -0,0 +1,167 @@
+package manager
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	_golang "github.com/justjundana/govman/internal/golang"
+	_logger "github.com/justjundana/govman/internal/logger"
+)
+
+// sizeCacheFile is the name of the version size cache inside the cache directory.
+const sizeCacheFile = "sizes.json"
+
+// maxSizeWorkers bounds how many version directories are walked concurrently.
+const maxSizeWorkers = 4
+
+// sizeCacheEntry records the computed size of a version directory at a given modtime.
+type sizeCacheEntry struct {
+	ModTime time.Time `json:"mod_time"`
+	Size    int64     `json:"size"`
+}
+
+// VersionSizes returns the on-disk size of each given version, keyed by version.
+// Sizes are computed concurrently and cached by directory modtime; versions that cannot be read are omitted.
+func (m *Manager) VersionSizes(versions []string) map[string]int64 {
+	cachePath := filepath.Join(m.config.CacheDir, sizeCacheFile)
+	cache := loadSizeCache(cachePath)
+
+	sizes := make(map[string]int64, len(versions))
+	var pending []string
+	for _, version := range versions {
+		stat, err := os.Stat(m.config.GetVersionDir(version))
+		if err != nil {
+			continue
+		}
+		if entry, ok := cache[version]; ok && entry.ModTime.Equal(stat.ModTime()) {
+			sizes[version] = entry.Size
+			continue
+		}
+		pending = append(pending, version)
+	}
+
+	if len(pending) == 0 {
+		return sizes
+	}
+
+	var (
+		mu sync.Mutex
+		wg sync.WaitGroup
+	)
+	jobs := make(chan string)
+	workers := min(len(pending), maxSizeWorkers)
+	for range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for version := range jobs {
+				dir := m.config.GetVersionDir(version)
+				stat, err := os.Stat(dir)
+				if err != nil {
+					continue
+				}
+				size, err := _golang.DirSize(dir)
+				if err != nil {
+					_logger.Verbose("Failed to compute size of Go %s: %v", version, err)
+					continue
+				}
+
+				mu.Lock()
+				sizes[version] = size
+				cache[version] = sizeCacheEntry{ModTime: stat.ModTime(), Size: size}
+				mu.Unlock()
+			}
+		}()
+	}
+	for _, version := range pending {
+		jobs <- version
+	}
+	close(jobs)
+	wg.Wait()
+
+	if err := saveSizeCache(cachePath, cache); err != nil {
+		_logger.Verbose("Failed to save size cache: %v", err)
+	}
+
+	return sizes
+}
+
+// VersionSizer computes the on-disk size of versions one at a time, for callers that report each as soon as it is
+// known. It reads the size cache once; call Save when done to write back the sizes it computed.
+type VersionSizer struct {
+	m     *Manager
+	path  string
+	cache map[string]sizeCacheEntry
+	dirty bool
+}
+
+// NewVersionSizer returns a VersionSizer backed by the size cache that VersionSizes uses.
+func (m *Manager) NewVersionSizer() *VersionSizer {
+	path := filepath.Join(m.config.CacheDir, sizeCacheFile)
+	return &VersionSizer{m: m, path: path, cache: loadSizeCache(path)}
+}
+
+// Size returns the on-disk size of version, from the cache when its directory is unchanged.
+// Returns false if the version cannot be read.
+func (s *VersionSizer) Size(version string) (int64, bool) {
+	dir := s.m.config.GetVersionDir(version)
+	stat, err := os.Stat(dir)
+	if err != nil {
+		return 0, false
+	}
+	if entry, ok := s.cache[version]; ok && entry.ModTime.Equal(stat.ModTime()) {
+		return entry.Size, true
+	}
+
+	size, err := _golang.DirSize(dir)
+	if err != nil {
+		_logger.Verbose("Failed to compute size of Go %s: %v", version, err)
+		return 0, false
+	}
+	s.cache[version] = sizeCacheEntry{ModTime: stat.ModTime(), Size: size}
+	s.dirty = true
+	return size, true
+}
+
+// Save writes the sizes computed since NewVersionSizer to the size cache. Failures are logged verbosely.
+func (s *VersionSizer) Save() {
+	if !s.dirty {
+		return
+	}
+	if err := saveSizeCache(s.path, s.cache); err != nil {
+		_logger.Verbose("Failed to save size cache: %v", err)
+	}
+}
+
+// loadSizeCache reads the version size cache from path.
+// A missing or unreadable cache yields an empty map.
+func loadSizeCache(path string) map[string]sizeCacheEntry {
+	cache := make(map[string]sizeCacheEntry)
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return cache
+	}
+	if err := json.Unmarshal(data, &cache); err != nil {
+		return make(map[string]sizeCacheEntry)
+	}
+
+	return cache
+}
+
+// saveSizeCache writes the version size cache to path, creating its directory if needed.
+// Returns an error if the cache cannot be written.
+func saveSizeCache(path string, cache map[string]sizeCacheEntry) error {
+	data, err := json.Marshal(cache)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, data, 0644)
+}
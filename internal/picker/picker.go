@@ -0,0 +1,321 @@
+package picker
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"runtime"
+	"strconv"
+	"strings"
+)
+
+// Key is a decoded keypress that the picker reacts to.
+type Key int
+
+const (
+	KeyOther Key = iota
+	KeyUp
+	KeyDown
+	KeyHome
+	KeyEnd
+	KeyEnter
+	KeyEscape
+)
+
+const (
+	defaultHeight = 10
+	hideCursor    = "\x1b[?25l"
+	showCursor    = "\x1b[?25h"
+	clearToEnd    = "\r\x1b[J"
+	reverseVideo  = "\x1b[7m"
+	resetStyle    = "\x1b[0m"
+)
+
+// ErrCancelled is returned when the user dismisses the picker without choosing an item.
+var ErrCancelled = errors.New("selection cancelled")
+
+// ParseKey decodes the bytes of a single read from a raw-mode terminal into a Key.
+// Arrow keys, vi-style j/k, Home/End, Enter, Esc, q, and Ctrl-C are recognized; anything else is KeyOther.
+func ParseKey(b []byte) Key {
+	switch string(b) {
+	case "\x1b[A", "\x1bOA", "k":
+		return KeyUp
+	case "\x1b[B", "\x1bOB", "j":
+		return KeyDown
+	case "\x1b[H", "\x1bOH", "\x1b[1~", "g":
+		return KeyHome
+	case "\x1b[F", "\x1bOF", "\x1b[4~", "G":
+		return KeyEnd
+	case "\r", "\n", "\r\n":
+		return KeyEnter
+	case "\x1b", "q", "\x03":
+		return KeyEscape
+	default:
+		return KeyOther
+	}
+}
+
+// State tracks the cursor and scroll window of a picker over a list of items.
+type State struct {
+	Items  []string
+	Cursor int
+	Offset int
+	Height int
+}
+
+// NewState creates a State showing at most height items, with the cursor on initial when it is present.
+// A non-positive height shows up to the default of 10 items.
+func NewState(items []string, initial string, height int) *State {
+	if height <= 0 {
+		height = defaultHeight
+	}
+
+	s := &State{Items: items, Height: height}
+	for i, item := range items {
+		if item == initial {
+			s.Cursor = i
+			break
+		}
+	}
+	s.scroll()
+
+	return s
+}
+
+// Apply moves the cursor according to key, keeping it inside the scroll window.
+// Returns done when the picker should close and chosen when it closed on a selection.
+func (s *State) Apply(key Key) (done, chosen bool) {
+	switch key {
+	case KeyUp:
+		if s.Cursor > 0 {
+			s.Cursor--
+		}
+	case KeyDown:
+		if s.Cursor < len(s.Items)-1 {
+			s.Cursor++
+		}
+	case KeyHome:
+		s.Cursor = 0
+	case KeyEnd:
+		s.Cursor = max(len(s.Items)-1, 0)
+	case KeyEnter:
+		return true, len(s.Items) > 0
+	case KeyEscape:
+		return true, false
+	}
+
+	s.scroll()
+	return false, false
+}
+
+// Selected returns the item under the cursor, or an empty string if there are no items.
+func (s *State) Selected() string {
+	if s.Cursor < 0 || s.Cursor >= len(s.Items) {
+		return ""
+	}
+	return s.Items[s.Cursor]
+}
+
+// Visible returns the items inside the current scroll window.
+func (s *State) Visible() []string {
+	end := min(s.Offset+s.Height, len(s.Items))
+	return s.Items[s.Offset:end]
+}
+
+// scroll adjusts Offset so the cursor stays inside the scroll window.
+func (s *State) scroll() {
+	if s.Cursor < s.Offset {
+		s.Offset = s.Cursor
+	}
+	if s.Cursor >= s.Offset+s.Height {
+		s.Offset = s.Cursor - s.Height + 1
+	}
+}
+
+// Select shows title and items in a scrollable picker on the controlling terminal, marking active.
+// Falls back to a numbered prompt when raw mode is unavailable (no TTY, or on Windows).
+// Returns the chosen item, ErrCancelled if the user backs out, or an error if the terminal cannot be read.
+func Select(title string, items []string, active string) (string, error) {
+	if len(items) == 0 {
+		return "", fmt.Errorf("nothing to select")
+	}
+
+	in, out, err := openConsole()
+	if err != nil {
+		return Prompt(os.Stdin, os.Stderr, title, items, active)
+	}
+	defer in.Close()
+	if out != in {
+		defer out.Close()
+	}
+
+	restore, err := makeRaw(in)
+	if err != nil {
+		return Prompt(in, out, title, items, active)
+	}
+	defer restore()
+
+	return run(in, out, title, NewState(items, active, defaultHeight), active)
+}
+
+// run drives the picker loop: render, read one key, apply it, and repeat until the picker closes.
+// The rendered lines are cleared before returning. Returns the chosen item or ErrCancelled.
+func run(r io.Reader, w io.Writer, title string, s *State, active string) (string, error) {
+	fmt.Fprint(w, hideCursor)
+	defer fmt.Fprint(w, showCursor)
+
+	buf := make([]byte, 8)
+	for {
+		lines := render(w, title, s, active)
+
+		n, err := r.Read(buf)
+		if err != nil && n == 0 {
+			erase(w, lines)
+			if errors.Is(err, io.EOF) {
+				return "", ErrCancelled
+			}
+			return "", fmt.Errorf("failed to read key: %w", err)
+		}
+
+		done, chosen := s.Apply(ParseKey(buf[:n]))
+		erase(w, lines)
+		if !done {
+			continue
+		}
+		if !chosen {
+			return "", ErrCancelled
+		}
+		return s.Selected(), nil
+	}
+}
+
+// render writes the title, the visible items, and a key hint, highlighting the cursor row.
+// Returns the number of lines written so the next frame can overwrite them.
+func render(w io.Writer, title string, s *State, active string) int {
+	lines := 0
+	writeLine := func(format string, args ...any) {
+		fmt.Fprintf(w, format+"\r\n", args...)
+		lines++
+	}
+
+	writeLine("%s", title)
+	for i, item := range s.Visible() {
+		label := item
+		if item == active {
+			label += " (active)"
+		}
+		if s.Offset+i == s.Cursor {
+			writeLine("%s> %s%s", reverseVideo, label, resetStyle)
+		} else {
+			writeLine("  %s", label)
+		}
+	}
+
+	hint := "↑/↓ move • Enter select • Esc cancel"
+	if len(s.Items) > s.Height {
+		hint = fmt.Sprintf("%d-%d of %d • %s", s.Offset+1, s.Offset+len(s.Visible()), len(s.Items), hint)
+	}
+	writeLine("%s", hint)
+
+	return lines
+}
+
+// erase moves the cursor up over the given number of rendered lines and erases them.
+func erase(w io.Writer, lines int) {
+	if lines > 0 {
+		fmt.Fprintf(w, "\x1b[%dA", lines)
+	}
+	fmt.Fprint(w, clearToEnd)
+}
+
+// Prompt lists items with numbers and reads the chosen number from r, marking active.
+// An empty answer or "q" cancels. Returns the chosen item, ErrCancelled, or an error for invalid input.
+func Prompt(r io.Reader, w io.Writer, title string, items []string, active string) (string, error) {
+	if len(items) == 0 {
+		return "", fmt.Errorf("nothing to select")
+	}
+
+	fmt.Fprintln(w, title)
+	for i, item := range items {
+		if item == active {
+			fmt.Fprintf(w, "  %d) %s (active)\n", i+1, item)
+		} else {
+			fmt.Fprintf(w, "  %d) %s\n", i+1, item)
+		}
+	}
+	fmt.Fprintf(w, "Enter a number [1-%d] (blank to cancel): ", len(items))
+
+	answer, err := bufio.NewReader(r).ReadString('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return "", fmt.Errorf("failed to read selection: %w", err)
+	}
+
+	answer = strings.TrimSpace(answer)
+	if answer == "" || strings.EqualFold(answer, "q") {
+		return "", ErrCancelled
+	}
+
+	n, err := strconv.Atoi(answer)
+	if err != nil || n < 1 || n > len(items) {
+		return "", fmt.Errorf("invalid selection %q: enter a number between 1 and %d", answer, len(items))
+	}
+
+	return items[n-1], nil
+}
+
+// openConsole opens the controlling terminal directly, so the picker works even when stdout and stderr
+// are captured by a shell wrapper. Returns the input and output files (the same file on Unix).
+func openConsole() (*os.File, *os.File, error) {
+	if runtime.GOOS == "windows" {
+		in, err := os.OpenFile("CONIN$", os.O_RDWR, 0)
+		if err != nil {
+			return nil, nil, err
+		}
+		out, err := os.OpenFile("CONOUT$", os.O_RDWR, 0)
+		if err != nil {
+			in.Close()
+			return nil, nil, err
+		}
+		return in, out, nil
+	}
+
+	tty, err := os.OpenFile("/dev/tty", os.O_RDWR, 0)
+	if err != nil {
+		return nil, nil, err
+	}
+	return tty, tty, nil
+}
+
+// makeRaw switches the terminal to raw, no-echo mode using stty.
+// Returns a function restoring the previous settings, or an error if raw mode is unsupported.
+func makeRaw(tty *os.File) (func(), error) {
+	if runtime.GOOS == "windows" {
+		return nil, fmt.Errorf("raw terminal mode is not supported on windows")
+	}
+
+	saved, err := stty(tty, "-g")
+	if err != nil {
+		return nil, err
+	}
+	if _, err := stty(tty, "raw", "-echo"); err != nil {
+		return nil, err
+	}
+
+	return func() {
+		stty(tty, strings.TrimSpace(saved))
+	}, nil
+}
+
+// stty runs stty against tty with the given arguments. Returns its output or an error if it fails.
+func stty(tty *os.File, args ...string) (string, error) {
+	cmd := exec.Command("stty", args...)
+	cmd.Stdin = tty
+	output, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("stty %s failed: %w", strings.Join(args, " "), err)
+	}
+	return string(output), nil
+}
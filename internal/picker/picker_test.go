@@ -0,0 +1,282 @@
+package picker
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+// keyReader returns one queued keypress per Read, like a raw-mode terminal does.
+type keyReader struct {
+	keys []string
+}
+
+func (r *keyReader) Read(p []byte) (int, error) {
+	if len(r.keys) == 0 {
+		return 0, io.EOF
+	}
+	n := copy(p, r.keys[0])
+	r.keys = r.keys[1:]
+	return n, nil
+}
+
+func TestParseKey(t *testing.T) {
+	testCases := []struct {
+		name     string
+		input    string
+		expected Key
+	}{
+		{"Arrow up", "\x1b[A", KeyUp},
+		{"Arrow up application mode", "\x1bOA", KeyUp},
+		{"Vi up", "k", KeyUp},
+		{"Arrow down", "\x1b[B", KeyDown},
+		{"Arrow down application mode", "\x1bOB", KeyDown},
+		{"Vi down", "j", KeyDown},
+		{"Home", "\x1b[H", KeyHome},
+		{"Home tilde", "\x1b[1~", KeyHome},
+		{"End", "\x1b[F", KeyEnd},
+		{"End tilde", "\x1b[4~", KeyEnd},
+		{"Carriage return", "\r", KeyEnter},
+		{"Line feed", "\n", KeyEnter},
+		{"Escape", "\x1b", KeyEscape},
+		{"Quit", "q", KeyEscape},
+		{"Ctrl-C", "\x03", KeyEscape},
+		{"Arrow right", "\x1b[C", KeyOther},
+		{"Letter", "x", KeyOther},
+		{"Empty", "", KeyOther},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := ParseKey([]byte(tc.input)); got != tc.expected {
+				t.Errorf("ParseKey(%q) = %v, expected %v", tc.input, got, tc.expected)
+			}
+		})
+	}
+}
+
+func TestNewState(t *testing.T) {
+	items := []string{"1.25.1", "1.24.5", "1.23.4", "1.22.0", "1.21.3"}
+
+	testCases := []struct {
+		name           string
+		initial        string
+		height         int
+		expectedCursor int
+		expectedOffset int
+		expectedHeight int
+	}{
+		{"Initial at top", "1.25.1", 3, 0, 0, 3},
+		{"Initial inside window", "1.23.4", 3, 2, 0, 3},
+		{"Initial below window scrolls", "1.21.3", 3, 4, 2, 3},
+		{"Unknown initial starts at top", "1.19.0", 3, 0, 0, 3},
+		{"Zero height uses default", "1.22.0", 0, 3, 0, defaultHeight},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			s := NewState(items, tc.initial, tc.height)
+			if s.Cursor != tc.expectedCursor {
+				t.Errorf("Expected cursor %d, got %d", tc.expectedCursor, s.Cursor)
+			}
+			if s.Offset != tc.expectedOffset {
+				t.Errorf("Expected offset %d, got %d", tc.expectedOffset, s.Offset)
+			}
+			if s.Height != tc.expectedHeight {
+				t.Errorf("Expected height %d, got %d", tc.expectedHeight, s.Height)
+			}
+		})
+	}
+}
+
+func TestState_Apply(t *testing.T) {
+	items := []string{"1.25.1", "1.24.5", "1.23.4", "1.22.0", "1.21.3"}
+
+	testCases := []struct {
+		name             string
+		initial          string
+		keys             []Key
+		expectedCursor   int
+		expectedOffset   int
+		expectedDone     bool
+		expectedChosen   bool
+		expectedSelected string
+	}{
+		{"Down moves cursor", "1.25.1", []Key{KeyDown}, 1, 0, false, false, "1.24.5"},
+		{"Up at top stays", "1.25.1", []Key{KeyUp}, 0, 0, false, false, "1.25.1"},
+		{"Down at bottom stays", "1.21.3", []Key{KeyDown}, 4, 2, false, false, "1.21.3"},
+		{"Down past window scrolls", "1.25.1", []Key{KeyDown, KeyDown, KeyDown}, 3, 1, false, false, "1.22.0"},
+		{"Up past window scrolls back", "1.21.3", []Key{KeyUp, KeyUp, KeyUp}, 1, 1, false, false, "1.24.5"},
+		{"End jumps to last", "1.25.1", []Key{KeyEnd}, 4, 2, false, false, "1.21.3"},
+		{"Home jumps to first", "1.21.3", []Key{KeyHome}, 0, 0, false, false, "1.25.1"},
+		{"Other key is ignored", "1.24.5", []Key{KeyOther}, 1, 0, false, false, "1.24.5"},
+		{"Enter chooses", "1.24.5", []Key{KeyDown, KeyEnter}, 2, 0, true, true, "1.23.4"},
+		{"Escape cancels", "1.24.5", []Key{KeyDown, KeyEscape}, 2, 0, true, false, "1.23.4"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			s := NewState(items, tc.initial, 3)
+
+			var done, chosen bool
+			for _, key := range tc.keys {
+				done, chosen = s.Apply(key)
+			}
+
+			if s.Cursor != tc.expectedCursor {
+				t.Errorf("Expected cursor %d, got %d", tc.expectedCursor, s.Cursor)
+			}
+			if s.Offset != tc.expectedOffset {
+				t.Errorf("Expected offset %d, got %d", tc.expectedOffset, s.Offset)
+			}
+			if done != tc.expectedDone || chosen != tc.expectedChosen {
+				t.Errorf("Expected done=%v chosen=%v, got done=%v chosen=%v", tc.expectedDone, tc.expectedChosen, done, chosen)
+			}
+			if got := s.Selected(); got != tc.expectedSelected {
+				t.Errorf("Expected selected %s, got %s", tc.expectedSelected, got)
+			}
+		})
+	}
+}
+
+func TestState_Visible(t *testing.T) {
+	s := NewState([]string{"a", "b", "c", "d"}, "d", 2)
+	visible := s.Visible()
+	if len(visible) != 2 || visible[0] != "c" || visible[1] != "d" {
+		t.Errorf("Expected [c d], got %v", visible)
+	}
+
+	s = NewState([]string{"a"}, "", 5)
+	if visible := s.Visible(); len(visible) != 1 {
+		t.Errorf("Expected 1 visible item, got %v", visible)
+	}
+}
+
+func TestState_ApplyEmpty(t *testing.T) {
+	s := NewState(nil, "", 3)
+
+	s.Apply(KeyDown)
+	s.Apply(KeyEnd)
+	if done, chosen := s.Apply(KeyEnter); !done || chosen {
+		t.Errorf("Expected Enter on empty list to close without choosing, got done=%v chosen=%v", done, chosen)
+	}
+	if s.Selected() != "" {
+		t.Errorf("Expected empty selection, got %q", s.Selected())
+	}
+}
+
+func TestRun(t *testing.T) {
+	items := []string{"1.25.1", "1.24.5", "1.23.4"}
+
+	testCases := []struct {
+		name        string
+		keys        []string
+		expected    string
+		expectedErr error
+	}{
+		{"Enter keeps active", []string{"\r"}, "1.24.5", nil},
+		{"Arrow down then enter", []string{"\x1b[B", "\r"}, "1.23.4", nil},
+		{"Arrow up then enter", []string{"\x1b[A", "\r"}, "1.25.1", nil},
+		{"Unknown keys are ignored", []string{"x", "\x1b[C", "\r"}, "1.24.5", nil},
+		{"Escape cancels", []string{"\x1b[B", "\x1b"}, "", ErrCancelled},
+		{"Ctrl-C cancels", []string{"\x03"}, "", ErrCancelled},
+		{"EOF cancels", nil, "", ErrCancelled},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var out bytes.Buffer
+			s := NewState(items, "1.24.5", 10)
+
+			got, err := run(&keyReader{keys: tc.keys}, &out, "Select a Go version", s, "1.24.5")
+			if !errors.Is(err, tc.expectedErr) {
+				t.Fatalf("Expected error %v, got %v", tc.expectedErr, err)
+			}
+			if got != tc.expected {
+				t.Errorf("Expected %q, got %q", tc.expected, got)
+			}
+
+			output := out.String()
+			if !strings.HasPrefix(output, hideCursor) || !strings.HasSuffix(output, showCursor) {
+				t.Error("Expected cursor to be hidden while running and restored afterwards")
+			}
+			if !strings.Contains(output, "1.24.5 (active)") {
+				t.Error("Expected active version to be marked")
+			}
+		})
+	}
+}
+
+func TestRender(t *testing.T) {
+	var out bytes.Buffer
+	s := NewState([]string{"1.25.1", "1.24.5", "1.23.4", "1.22.0"}, "1.23.4", 2)
+
+	lines := render(&out, "Select a Go version", s, "1.25.1")
+	if lines != 4 {
+		t.Errorf("Expected 4 lines (title, 2 items, hint), got %d", lines)
+	}
+
+	output := out.String()
+	if !strings.Contains(output, reverseVideo+"> 1.23.4"+resetStyle) {
+		t.Errorf("Expected cursor row to be highlighted, got %q", output)
+	}
+	if strings.Contains(output, "1.25.1") {
+		t.Errorf("Expected scrolled-out items to be hidden, got %q", output)
+	}
+	if !strings.Contains(output, "2-3 of 4") {
+		t.Errorf("Expected scroll position in hint, got %q", output)
+	}
+}
+
+func TestPrompt(t *testing.T) {
+	items := []string{"1.25.1", "1.24.5", "1.23.4"}
+
+	testCases := []struct {
+		name        string
+		input       string
+		expected    string
+		expectedErr error
+		wantErr     bool
+	}{
+		{"First item", "1\n", "1.25.1", nil, false},
+		{"Last item without newline", "3", "1.23.4", nil, false},
+		{"Surrounding whitespace", "  2  \n", "1.24.5", nil, false},
+		{"Blank cancels", "\n", "", ErrCancelled, true},
+		{"EOF cancels", "", "", ErrCancelled, true},
+		{"q cancels", "q\n", "", ErrCancelled, true},
+		{"Out of range", "4\n", "", nil, true},
+		{"Zero", "0\n", "", nil, true},
+		{"Not a number", "abc\n", "", nil, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var out bytes.Buffer
+			got, err := Prompt(strings.NewReader(tc.input), &out, "Select a Go version", items, "1.24.5")
+
+			if tc.wantErr && err == nil {
+				t.Fatal("Expected error but got none")
+			}
+			if !tc.wantErr && err != nil {
+				t.Fatalf("Expected no error but got: %v", err)
+			}
+			if tc.expectedErr != nil && !errors.Is(err, tc.expectedErr) {
+				t.Errorf("Expected error %v, got %v", tc.expectedErr, err)
+			}
+			if got != tc.expected {
+				t.Errorf("Expected %q, got %q", tc.expected, got)
+			}
+			if !strings.Contains(out.String(), "2) 1.24.5 (active)") {
+				t.Errorf("Expected numbered list with active marker, got %q", out.String())
+			}
+		})
+	}
+}
+
+func TestPrompt_NoItems(t *testing.T) {
+	if _, err := Prompt(strings.NewReader("1\n"), io.Discard, "Select", nil, ""); err == nil {
+		t.Error("Expected error for empty item list")
+	}
+}
@@ -2,45 +2,83 @@ package progress
 
 import (
 	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"runtime"
+	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
+	"unicode/utf8"
 
+	_theme "github.com/justjundana/govman/internal/theme"
 	_util "github.com/justjundana/govman/internal/util"
 )
 
 // Pre-allocated buffers to reduce allocations
 const (
-	defaultBarWidth = 50
-	fillChar        = "█"
-	emptyChar       = "░"
+	defaultBarWidth = 50                     // Bar width when the terminal width is unknown, e.g. when output is not a terminal
+	minBarWidth     = 10                     // Narrower bars are dropped so the status text keeps its room
+	maxBarWidth     = 60                     // Wider bars add nothing on wide terminals
+	statusReserve   = 48                     // Columns kept for percentage, sizes, speed, and ETA so the bar does not jitter as they change
+	columnsRefresh  = time.Second            // How often the terminal width is re-measured to pick up resizes
 	updateThreshold = 100 * time.Millisecond // Throttle interval for render updates
+
+	// speedWindow is how far back the displayed speed and ETA look, so they follow bandwidth changes quickly
+	speedWindow = 5 * time.Second
+	// sampleInterval bounds how often a progress sample is kept, capping the window at speedWindow/sampleInterval entries
+	sampleInterval = 250 * time.Millisecond
+	// minSpeedSpan is the shortest sample span trusted for a rolling speed; shorter spans fall back to the average
+	minSpeedSpan = time.Second
 )
 
+// rateSample records the progress position at a point in time.
+type rateSample struct {
+	at      time.Time
+	current int64
+}
+
+// ProgressBar renders transfer progress. Add and Write only update an atomic counter and take the mutex when a
+// throttled render is due, so a download writing many small chunks is not serialized on the lock.
 type ProgressBar struct {
 	total         int64
-	current       int64
+	current       atomic.Int64 // bytes so far, clamped to total when it is known
 	width         int
 	description   string
 	startTime     time.Time
-	lastUpdate    time.Time
-	mutex         sync.Mutex
+	lastUpdate    atomic.Int64 // UnixNano of the last throttled render
+	mutex         sync.Mutex   // guards rendering and every field below it
 	finished      bool
 	lastRenderLen int
+	out           io.Writer
+	samples       []rateSample        // oldest first; the first sample is at or before the start of speedWindow
+	now           func() time.Time    // replaced in tests to simulate transfer rates
+	spinnerFrame  int                 // next frame of the theme's spinner in indeterminate mode
+	theme         _theme.Theme        // glyphs for the bar and spinner
+	columns       int                 // terminal width, or 0 when unknown
+	columnsAt     time.Time           // when columns was last measured
+	measure       func(io.Writer) int // reports the width of the terminal out writes to; replaced in tests
 }
 
-// New constructs a new ProgressBar with a total byte count and a description.
-// Parameters: total is the total size to track; description is a label shown with the bar.
-// Returns a *ProgressBar initialized with default width and timestamps.
+// New constructs a ProgressBar for total bytes, or a spinner when total <= 0, labeled with description.
+// It renders to stderr with the current theme, keeping stdout free for command output.
 func New(total int64, description string) *ProgressBar {
-	return &ProgressBar{
+	now := time.Now()
+	pb := &ProgressBar{
 		total:       total,
-		current:     0,
 		width:       defaultBarWidth,
 		description: description,
-		startTime:   time.Now(),
-		lastUpdate:  time.Now(),
+		startTime:   now,
+		out:         os.Stderr,
+		samples:     []rateSample{{at: now}},
+		now:         time.Now,
+		theme:       _theme.Current(),
+		measure:     terminalColumns,
 	}
+	pb.lastUpdate.Store(now.UnixNano())
+	return pb
 }
 
 // Write implements io.Writer for ProgressBar by adding the number of bytes written to progress.
@@ -52,37 +90,120 @@ func (pb *ProgressBar) Write(p []byte) (n int, err error) {
 }
 
 // Add increases the current progress by n bytes and throttles rendering for performance.
-// Parameter n is the increment amount. No return value.
+// Only a due render takes the mutex. Parameter n is the increment amount. No return value.
 func (pb *ProgressBar) Add(n int64) {
+	current := pb.add(n)
+
+	now := pb.now()
+	complete := pb.determinate() && current == pb.total
+	if !complete && !pb.renderDue(now) {
+		return
+	}
+
 	pb.mutex.Lock()
 	defer pb.mutex.Unlock()
 
-	pb.current += n
-	if pb.current > pb.total {
-		pb.current = pb.total
+	// Another writer may have rendered while this one waited for the lock
+	if !complete && !pb.renderDue(now) {
+		return
 	}
+	pb.record(now)
+	pb.render()
+	pb.lastUpdate.Store(now.UnixNano())
+}
 
-	now := time.Now()
-	if now.Sub(pb.lastUpdate) > updateThreshold || pb.current == pb.total {
-		pb.render()
-		pb.lastUpdate = now
+// add atomically increases the progress by n, clamped to the total when it is known. Returns the new progress.
+func (pb *ProgressBar) add(n int64) int64 {
+	for {
+		old := pb.current.Load()
+		next := old + n
+		if pb.determinate() && next > pb.total {
+			next = pb.total
+		}
+		if pb.current.CompareAndSwap(old, next) {
+			return next
+		}
 	}
 }
 
+// renderDue reports whether more than updateThreshold has passed since the last throttled render.
+func (pb *ProgressBar) renderDue(now time.Time) bool {
+	return now.UnixNano()-pb.lastUpdate.Load() > int64(updateThreshold)
+}
+
 // Set updates the current progress to a specific value and triggers a render.
 // Parameter current is the new progress position. No return value.
 func (pb *ProgressBar) Set(current int64) {
 	pb.mutex.Lock()
 	defer pb.mutex.Unlock()
 
-	pb.current = current
-	if pb.current > pb.total {
-		pb.current = pb.total
+	if pb.determinate() && current > pb.total {
+		current = pb.total
 	}
+	pb.current.Store(current)
+	// A jump in position is not throughput, so measure speed from here on
+	pb.samples = []rateSample{{at: pb.now(), current: current}}
 	pb.render()
 }
 
+// determinate reports whether the total size is known, as opposed to indeterminate (spinner) mode.
+func (pb *ProgressBar) determinate() bool {
+	return pb.total > 0
+}
+
+// AverageSpeed returns the average transfer rate in bytes per second since the bar was created,
+// as opposed to the rolling rate shown while the transfer is in progress.
+func (pb *ProgressBar) AverageSpeed() float64 {
+	pb.mutex.Lock()
+	defer pb.mutex.Unlock()
+
+	return pb.averageSpeed(pb.now())
+}
+
+// record keeps a progress sample at most every sampleInterval and drops samples that fell out of speedWindow,
+// always keeping one at or before the window start so the rolling speed covers the whole window. Add only records
+// when it renders, which is at least as often as sampleInterval during a transfer. Callers hold the mutex.
+func (pb *ProgressBar) record(now time.Time) {
+	if len(pb.samples) == 0 || now.Sub(pb.samples[len(pb.samples)-1].at) >= sampleInterval {
+		pb.samples = append(pb.samples, rateSample{at: now, current: pb.current.Load()})
+	}
+
+	cutoff := now.Add(-speedWindow)
+	drop := 0
+	for drop+1 < len(pb.samples) && !pb.samples[drop+1].at.After(cutoff) {
+		drop++
+	}
+	pb.samples = pb.samples[drop:]
+}
+
+// rollingSpeed returns the transfer rate in bytes per second over roughly the last speedWindow.
+// Falls back to averageSpeed until the samples span at least minSpeedSpan. Callers hold the mutex.
+func (pb *ProgressBar) rollingSpeed(now time.Time) float64 {
+	if len(pb.samples) == 0 {
+		return pb.averageSpeed(now)
+	}
+
+	oldest := pb.samples[0]
+	span := now.Sub(oldest.at)
+	if span < minSpeedSpan {
+		return pb.averageSpeed(now)
+	}
+
+	return float64(pb.current.Load()-oldest.current) / span.Seconds()
+}
+
+// averageSpeed returns the cumulative transfer rate in bytes per second since startTime. Callers hold the mutex.
+func (pb *ProgressBar) averageSpeed(now time.Time) float64 {
+	elapsed := now.Sub(pb.startTime)
+	if elapsed <= 0 {
+		return 0
+	}
+
+	return float64(pb.current.Load()) / elapsed.Seconds()
+}
+
 // Finish marks the progress as complete, renders the final state, and prints a newline.
+// In indeterminate mode the spinner is replaced by the final byte count and average speed.
 // No parameters. No return value.
 func (pb *ProgressBar) Finish() {
 	pb.mutex.Lock()
@@ -92,85 +213,255 @@ func (pb *ProgressBar) Finish() {
 		return
 	}
 
-	pb.current = pb.total
+	if pb.determinate() {
+		pb.current.Store(pb.total)
+	}
 	pb.finished = true
 	pb.render()
-	fmt.Println()
+	fmt.Fprintln(pb.out)
+}
+
+// Abort stops the bar where it is and ends its line, so output after an interrupted transfer starts on a fresh line.
+// Has no effect on a bar that is already finished or aborted.
+func (pb *ProgressBar) Abort() {
+	pb.mutex.Lock()
+	defer pb.mutex.Unlock()
+
+	if pb.finished {
+		return
+	}
+
+	pb.finished = true
+	fmt.Fprintln(pb.out)
 }
 
 // render draws the progress bar with percentage, speed, and ETA.
-// Internal helper; respects total <= 0 and throttling logic from Add/Set. No return value.
+// While in progress, speed and ETA use the rolling rate; a finished bar shows the average rate instead.
+// Without a known total it draws the spinner instead. Internal helper; respects throttling logic from Add/Set. No return value.
 func (pb *ProgressBar) render() {
-	if pb.total <= 0 {
+	if !pb.determinate() {
+		pb.renderSpinner()
 		return
 	}
 
-	percentage := float64(pb.current) / float64(pb.total) * 100
+	// Writers keep adding while the line is drawn, so draw one consistent snapshot
+	current := pb.current.Load()
+	percentage := float64(current) / float64(pb.total) * 100
 	if percentage > 100 {
 		percentage = 100
 	}
-	filledWidth := int(float64(pb.width) * float64(pb.current) / float64(pb.total))
-	if filledWidth > pb.width {
-		filledWidth = pb.width
-	}
-
-	// String building using Builder with pre-allocated capacity
-	var bar strings.Builder
-	bar.Grow(pb.width * 3) // Pre-allocate for UTF-8 characters
-
-	// Use more efficient string building
-	for i := 0; i < filledWidth; i++ {
-		bar.WriteString(fillChar)
-	}
 
-	for i := filledWidth; i < pb.width; i++ {
-		bar.WriteString(emptyChar)
-	}
-
-	elapsed := time.Since(pb.startTime)
+	now := pb.now()
+	elapsed := now.Sub(pb.startTime)
 	var speedStr, etaStr string
 
 	if elapsed.Seconds() > 1 {
-		speed := float64(pb.current) / elapsed.Seconds()
+		speed := pb.rollingSpeed(now)
+		if pb.finished {
+			speed = pb.averageSpeed(now)
+		}
 		speedStr = _util.FormatBytes(int64(speed)) + "/s"
 
-		if speed > 0 && pb.current < pb.total {
-			remaining := pb.total - pb.current
+		if speed > 0 && current < pb.total {
+			remaining := pb.total - current
 			eta := time.Duration(float64(remaining)/speed) * time.Second
 			etaStr = _util.FormatDuration(eta)
 		}
 	}
 
-	currentStr := _util.FormatBytes(pb.current)
+	currentStr := _util.FormatBytes(current)
 	totalStr := _util.FormatBytes(pb.total)
 
+	var suffix strings.Builder
+	suffix.WriteString(fmt.Sprintf(" %.1f%% (%s/%s)", percentage, currentStr, totalStr))
+
+	if speedStr != "" {
+		suffix.WriteString(" ")
+		suffix.WriteString(speedStr)
+	}
+
+	if etaStr != "" {
+		suffix.WriteString(" ETA: ")
+		suffix.WriteString(etaStr)
+	}
+
+	width, description := pb.layout(pb.terminalColumns(now), utf8.RuneCountInString(suffix.String()), true)
+
 	// Build status string more efficiently
 	var status strings.Builder
-	status.Grow(120) // Pre-allocate typical status line length
+	status.Grow(120 + width*3) // Pre-allocate typical status line length, with room for UTF-8 bar characters
 
 	status.WriteString("\r")
-	status.WriteString(pb.description)
-	status.WriteString(" [")
-	status.WriteString(bar.String())
-	status.WriteString(fmt.Sprintf("] %.1f%% (%s/%s)", percentage, currentStr, totalStr))
+	status.WriteString(description)
+	if width > 0 {
+		filledWidth := int(float64(width) * float64(current) / float64(pb.total))
+		filledWidth = min(max(filledWidth, 0), width)
 
-	if speedStr != "" {
+		status.WriteString(" [")
+		status.WriteString(_theme.Paint(pb.out, _theme.Accent, strings.Repeat(pb.theme.Fill, filledWidth)))
+		status.WriteString(strings.Repeat(pb.theme.Empty, width-filledWidth))
+		status.WriteString("]")
+	}
+	status.WriteString(suffix.String())
+
+	pb.print(status.String())
+}
+
+// renderSpinner draws the indeterminate mode: a spinner frame, the bytes transferred so far, and the recent speed.
+// A finished spinner shows the final byte count and average speed without a frame. No return value.
+func (pb *ProgressBar) renderSpinner() {
+	now := pb.now()
+
+	var frame string
+	if !pb.finished {
+		frames := pb.theme.Spinner
+		frame = frames[pb.spinnerFrame%len(frames)]
+		pb.spinnerFrame++
+	}
+
+	var suffix strings.Builder
+	suffix.WriteString(" ")
+	suffix.WriteString(_util.FormatBytes(max(pb.current.Load(), 0)))
+
+	if now.Sub(pb.startTime) > time.Second {
+		speed := pb.rollingSpeed(now)
+		if pb.finished {
+			speed = pb.averageSpeed(now)
+		}
+		suffix.WriteString(" ")
+		suffix.WriteString(_util.FormatBytes(int64(speed)) + "/s")
+	}
+
+	suffixLen := utf8.RuneCountInString(suffix.String())
+	if frame != "" {
+		suffixLen += 1 + utf8.RuneCountInString(frame)
+	}
+	_, description := pb.layout(pb.terminalColumns(now), suffixLen, false)
+
+	var status strings.Builder
+	status.WriteString("\r")
+	status.WriteString(description)
+	if frame != "" {
 		status.WriteString(" ")
-		status.WriteString(speedStr)
+		status.WriteString(_theme.Paint(pb.out, _theme.Accent, frame))
 	}
+	status.WriteString(suffix.String())
 
-	if etaStr != "" {
-		status.WriteString(" ETA: ")
-		status.WriteString(etaStr)
+	pb.print(status.String())
+}
+
+// layout fits the line to cols columns around suffixLen columns of status text, sizing the bar and shortening the
+// description so the line never wraps. Returns the bar width (0 for no bar) and the description.
+func (pb *ProgressBar) layout(cols, suffixLen int, withBar bool) (int, string) {
+	if cols <= 0 {
+		if !withBar {
+			return 0, pb.description
+		}
+		return pb.width, pb.description
+	}
+
+	// Leave the last column free so the cursor never moves to a new line
+	avail := cols - 1 - suffixLen
+	descLen := utf8.RuneCountInString(pb.description)
+
+	if withBar {
+		width := min(cols-1-max(suffixLen, statusReserve)-descLen-len(" []"), maxBarWidth)
+		if width >= minBarWidth {
+			return width, pb.description
+		}
+	}
+
+	return 0, truncate(pb.description, avail)
+}
+
+// terminalColumns returns the terminal width, measured at most once per columnsRefresh so resizes are picked up
+// without running stty on every render. Returns 0 when the width is unknown.
+func (pb *ProgressBar) terminalColumns(now time.Time) int {
+	if pb.measure == nil {
+		return 0
+	}
+	if pb.columnsAt.IsZero() || now.Sub(pb.columnsAt) >= columnsRefresh {
+		pb.columns = pb.measure(pb.out)
+		pb.columnsAt = now
+	}
+	return pb.columns
+}
+
+// terminalColumns returns the width of the terminal w writes to, from stty or else the COLUMNS variable.
+// Returns 0 when w is not a terminal or its width cannot be determined.
+func terminalColumns(w io.Writer) int {
+	f, ok := w.(*os.File)
+	if !ok || !_theme.IsTerminal(f) {
+		return 0
+	}
+
+	if runtime.GOOS != "windows" {
+		cmd := exec.Command("stty", "size")
+		cmd.Stdin = f
+		if output, err := cmd.Output(); err == nil {
+			fields := strings.Fields(string(output))
+			if len(fields) == 2 {
+				if cols, err := strconv.Atoi(fields[1]); err == nil && cols > 0 {
+					return cols
+				}
+			}
+		}
+	}
+
+	if cols, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && cols > 0 {
+		return cols
+	}
+	return 0
+}
+
+// truncate shortens s to at most n runes, marking the cut with "...". Returns "" when n <= 0.
+func truncate(s string, n int) string {
+	runes := []rune(s)
+	if len(runes) <= n {
+		return s
+	}
+	if n <= 3 {
+		return string(runes[:max(n, 0)])
+	}
+	return string(runes[:n-3]) + "..."
+}
+
+// visibleLen returns the number of terminal columns s occupies, ignoring carriage returns and ANSI color codes.
+func visibleLen(s string) int {
+	n := 0
+	inEscape := false
+	for _, r := range s {
+		switch {
+		case inEscape:
+			// Color codes end with a letter, e.g. \033[36m
+			if (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') {
+				inEscape = false
+			}
+		case r == '\033':
+			inEscape = true
+		case r != '\r':
+			n++
+		}
+	}
+	return n
+}
+
+// print writes a status line, padding it with spaces to cover a longer previous line,
+// but never past the last terminal column. No return value.
+func (pb *ProgressBar) print(statusStr string) {
+	length := visibleLen(statusStr)
+	target := pb.lastRenderLen
+	if pb.columns > 0 {
+		target = min(target, pb.columns-1)
 	}
 
-	statusStr := status.String()
 	// Dynamically pad if new line is shorter than the previous one
-	if len(statusStr) < pb.lastRenderLen {
-		statusStr += strings.Repeat(" ", pb.lastRenderLen-len(statusStr))
+	if length < target {
+		statusStr += strings.Repeat(" ", target-length)
+		length = target
 	}
 
-	pb.lastRenderLen = len(statusStr)
+	pb.lastRenderLen = length
 
-	fmt.Print(statusStr)
+	fmt.Fprint(pb.out, statusStr)
 }
@@ -1,9 +1,15 @@
 package progress
 
 import (
+	"bytes"
+	"io"
+	"os"
+	"strings"
 	"sync"
 	"testing"
 	"time"
+
+	_theme "github.com/justjundana/govman/internal/theme"
 )
 
 func TestNew(t *testing.T) {
@@ -36,8 +42,8 @@ func TestNew(t *testing.T) {
 			if pb.total != tc.total {
 				t.Errorf("Expected total %d, got %d", tc.total, pb.total)
 			}
-			if pb.current != 0 {
-				t.Errorf("Expected current 0, got %d", pb.current)
+			if pb.current.Load() != 0 {
+				t.Errorf("Expected current 0, got %d", pb.current.Load())
 			}
 			if pb.width != defaultBarWidth {
 				t.Errorf("Expected width %d, got %d", defaultBarWidth, pb.width)
@@ -87,8 +93,8 @@ func TestProgressBar_Write(t *testing.T) {
 			if n != len(tc.data) {
 				t.Errorf("Expected to write %d bytes, got %d", len(tc.data), n)
 			}
-			if pb.current != tc.expected {
-				t.Errorf("Expected current %d, got %d", tc.expected, pb.current)
+			if pb.current.Load() != tc.expected {
+				t.Errorf("Expected current %d, got %d", tc.expected, pb.current.Load())
 			}
 		})
 	}
@@ -135,12 +141,12 @@ func TestProgressBar_Add(t *testing.T) {
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
 			pb := New(tc.total, "Test add")
-			pb.current = tc.initial
+			pb.current.Store(tc.initial)
 
 			pb.Add(tc.add)
 
-			if pb.current != tc.expected {
-				t.Errorf("Expected current %d, got %d", tc.expected, pb.current)
+			if pb.current.Load() != tc.expected {
+				t.Errorf("Expected current %d, got %d", tc.expected, pb.current.Load())
 			}
 		})
 	}
@@ -185,8 +191,8 @@ func TestProgressBar_Set(t *testing.T) {
 
 			pb.Set(tc.set)
 
-			if pb.current != tc.expected {
-				t.Errorf("Expected current %d, got %d", tc.expected, pb.current)
+			if pb.current.Load() != tc.expected {
+				t.Errorf("Expected current %d, got %d", tc.expected, pb.current.Load())
 			}
 		})
 	}
@@ -222,12 +228,12 @@ func TestProgressBar_Finish(t *testing.T) {
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
 			pb := New(tc.total, "Test finish")
-			pb.current = tc.current
+			pb.current.Store(tc.current)
 
 			pb.Finish()
 
-			if pb.current != tc.total {
-				t.Errorf("Expected current %d, got %d", tc.total, pb.current)
+			if pb.current.Load() != tc.total {
+				t.Errorf("Expected current %d, got %d", tc.total, pb.current.Load())
 			}
 			if !pb.finished {
 				t.Error("Expected finished true")
@@ -235,17 +241,43 @@ func TestProgressBar_Finish(t *testing.T) {
 
 			if tc.callTwice {
 				// Second call should not change anything
-				originalCurrent := pb.current
+				originalCurrent := pb.current.Load()
 				pb.Finish()
 
-				if pb.current != originalCurrent {
-					t.Errorf("Second finish call changed current from %d to %d", originalCurrent, pb.current)
+				if pb.current.Load() != originalCurrent {
+					t.Errorf("Second finish call changed current from %d to %d", originalCurrent, pb.current.Load())
 				}
 			}
 		})
 	}
 }
 
+func TestProgressBar_Abort(t *testing.T) {
+	var buf bytes.Buffer
+	pb := New(100, "Test abort")
+	pb.out = &buf
+	pb.Set(40)
+	buf.Reset()
+
+	pb.Abort()
+	if !pb.finished {
+		t.Error("Expected finished true after Abort")
+	}
+	if pb.current.Load() != 40 {
+		t.Errorf("Abort changed current to %d, want 40", pb.current.Load())
+	}
+	if buf.String() != "\n" {
+		t.Errorf("Abort wrote %q, want a single newline", buf.String())
+	}
+
+	buf.Reset()
+	pb.Abort()
+	pb.Finish()
+	if buf.Len() != 0 {
+		t.Errorf("Abort or Finish after Abort wrote %q, want nothing", buf.String())
+	}
+}
+
 func TestProgressBar_ConcurrentAccess(t *testing.T) {
 	pb := New(1000, "Concurrent test")
 
@@ -268,8 +300,8 @@ func TestProgressBar_ConcurrentAccess(t *testing.T) {
 	wg.Wait()
 
 	expected := int64(numGoroutines * numOperations)
-	if pb.current != expected {
-		t.Errorf("Expected current %d, got %d", expected, pb.current)
+	if pb.current.Load() != expected {
+		t.Errorf("Expected current %d, got %d", expected, pb.current.Load())
 	}
 }
 
@@ -334,7 +366,7 @@ func TestProgressBar_Render(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			// Create progress bar
 			pb := New(tc.total, tc.description)
-			pb.current = tc.current
+			pb.current.Store(tc.current)
 
 			// Simulate elapsed time for speed/ETA calculations
 			if tc.elapsed > 0 {
@@ -345,8 +377,8 @@ func TestProgressBar_Render(t *testing.T) {
 			pb.render()
 
 			// Test basic rendering properties
-			if pb.current != tc.current {
-				t.Errorf("Render changed current from %d to %d", tc.current, pb.current)
+			if pb.current.Load() != tc.current {
+				t.Errorf("Render changed current from %d to %d", tc.current, pb.current.Load())
 			}
 			if pb.total != tc.total {
 				t.Errorf("Render changed total from %d to %d", tc.total, pb.total)
@@ -364,7 +396,7 @@ func TestProgressBar_Render(t *testing.T) {
 func TestProgressBar_RenderEdgeCases(t *testing.T) {
 	// Test edge case: very fast completion
 	pb := New(100, "Fast completion")
-	pb.current = 100
+	pb.current.Store(100)
 	pb.startTime = time.Now().Add(-10 * time.Millisecond) // Very fast
 
 	// Should not panic
@@ -372,20 +404,20 @@ func TestProgressBar_RenderEdgeCases(t *testing.T) {
 
 	// Test edge case: zero elapsed time (should not divide by zero)
 	pb2 := New(100, "Zero elapsed")
-	pb2.current = 50
+	pb2.current.Store(50)
 	pb2.startTime = time.Now()
 
 	pb2.render()
 
 	// Test edge case: current > total (should be clamped in render)
 	pb3 := New(100, "Over progress")
-	pb3.current = 150
+	pb3.current.Store(150)
 
 	pb3.render()
 	// Note: render() doesn't clamp current, only Add() and Set() do
 	// So this test should expect the value to remain 150
-	if pb3.current != 150 {
-		t.Errorf("Expected current to remain 150, got %d", pb3.current)
+	if pb3.current.Load() != 150 {
+		t.Errorf("Expected current to remain 150, got %d", pb3.current.Load())
 	}
 }
 
@@ -413,7 +445,7 @@ func TestProgressBar_RenderSpeedAndETA(t *testing.T) {
 	pb := New(1000, "Speed test")
 
 	// Set progress and time to trigger speed calculation
-	pb.current = 500
+	pb.current.Store(500)
 	pb.startTime = time.Now().Add(-5 * time.Second) // 5 seconds elapsed
 
 	// This should trigger speed calculation since elapsed > 1 second
@@ -421,7 +453,7 @@ func TestProgressBar_RenderSpeedAndETA(t *testing.T) {
 
 	// Test with progress that would result in ETA calculation
 	pb2 := New(1000, "ETA test")
-	pb2.current = 10
+	pb2.current.Store(10)
 	pb2.startTime = time.Now().Add(-10 * time.Second) // 10 seconds for 100 units = 10 units/sec
 
 	// This should trigger both speed and ETA calculation since
@@ -430,7 +462,7 @@ func TestProgressBar_RenderSpeedAndETA(t *testing.T) {
 
 	// Test edge case where current equals total (no ETA should be calculated)
 	pb3 := New(1000, "No ETA test")
-	pb3.current = 1000 // complete
+	pb3.current.Store(1000) // complete
 	pb3.startTime = time.Now().Add(-5 * time.Second)
 
 	// This should not calculate ETA since current == total
@@ -438,7 +470,7 @@ func TestProgressBar_RenderSpeedAndETA(t *testing.T) {
 
 	// Test edge case where speed is 0 (no ETA should be calculated)
 	pb4 := New(100, "Zero speed test")
-	pb4.current = 0
+	pb4.current.Store(0)
 	pb4.startTime = time.Now().Add(-5 * time.Second) // elapsed > 1s but current is still 0
 
 	// This should calculate speed (0/s) but no ETA since speed is 0
@@ -450,7 +482,7 @@ func TestProgressBar_RenderPadding(t *testing.T) {
 	pb := New(100, "Short desc") // Create a short description to ensure padding is needed
 
 	// Set progress to trigger full render with padding
-	pb.current = 50
+	pb.current.Store(50)
 	pb.startTime = time.Now().Add(-2 * time.Second) // Ensure elapsed > 1s for speed calc
 
 	// Call render to trigger the padding logic
@@ -460,21 +492,21 @@ func TestProgressBar_RenderPadding(t *testing.T) {
 func TestProgressBar_RenderWithNegativeCurrent(t *testing.T) {
 	// Test render when current is negative (edge case)
 	pb := New(100, "Negative test")
-	pb.current = -10 // Set negative value
+	pb.current.Store(-10) // Set negative value
 
 	// This should handle the negative value gracefully
 	pb.render()
 
 	// Test with negative total (should return early)
 	pb2 := New(-100, "Negative total")
-	pb2.current = 50
+	pb2.current.Store(50)
 	pb2.render() // Should return early due to total <= 0
 }
 
 func TestProgressBar_RenderETACalculation(t *testing.T) {
 	// Test the specific path for ETA calculation: speed > 0 && pb.current < pb.total
 	pb := New(1000, "ETA calc test")
-	pb.current = 100                                 // Less than total
+	pb.current.Store(100)                            // Less than total
 	pb.startTime = time.Now().Add(-10 * time.Second) // Elapsed > 1s, current < total, speed > 0
 
 	// This should trigger both speed and ETA calculation
@@ -485,7 +517,7 @@ func TestProgressBar_RenderShortStatusString(t *testing.T) {
 	// Test the padding logic where status string is less than 80 characters
 	// This creates a very short description to ensure padding is needed
 	pb := New(1, "X") // Very short description and small numbers to keep status short
-	pb.current = 1
+	pb.current.Store(1)
 	pb.startTime = time.Now().Add(-2 * time.Second) // Ensure speed calculation happens
 
 	// This should trigger rendering with padding since the status string will be short
@@ -495,7 +527,7 @@ func TestProgressBar_RenderShortStatusString(t *testing.T) {
 func TestProgressBar_RenderNegativeCurrentPositiveSpeed(t *testing.T) {
 	// Test when current is negative but speed is positive and current < total
 	pb := New(100, "Neg curr test")
-	pb.current = -50                                // Negative but still < total
+	pb.current.Store(-50)                           // Negative but still < total
 	pb.startTime = time.Now().Add(-5 * time.Second) // Elapsed > 1s
 
 	// This should handle negative current properly
@@ -506,7 +538,7 @@ func TestProgressBar_RenderETACalculationPath(t *testing.T) {
 	// Test the specific path for ETA calculation: speed > 0 && pb.current < pb.total
 	// with proper elapsed time > 1s
 	pb := New(1000, "ETA calc path")
-	pb.current = 100                                // Less than total and positive
+	pb.current.Store(100)                           // Less than total and positive
 	pb.startTime = time.Now().Add(-5 * time.Second) // Elapsed > 1s to trigger speed calc
 
 	// This should trigger the full path: elapsed > 1s, speed > 0, current < total
@@ -514,7 +546,7 @@ func TestProgressBar_RenderETACalculationPath(t *testing.T) {
 
 	// Additional test case with different values
 	pb2 := New(500, "ETA calc path 2")
-	pb2.current = 250                                // Less than total and positive
+	pb2.current.Store(250)                           // Less than total and positive
 	pb2.startTime = time.Now().Add(-2 * time.Second) // Elapsed > 1s to trigger speed calc
 
 	// This should also trigger the ETA calculation path
@@ -525,7 +557,7 @@ func TestProgressBar_RenderStringPadding(t *testing.T) {
 	// Test specifically the padding code path: len(statusStr) < 80
 	// Create a progress bar with minimal content to ensure short status string
 	pb := New(1, "A") // Very minimal values
-	pb.current = 0
+	pb.current.Store(0)
 	pb.startTime = time.Now().Add(-1 * time.Second) // Elapsed time to trigger speed calc
 
 	// Render and check that it doesn't panic (the padding logic is executed)
@@ -533,7 +565,7 @@ func TestProgressBar_RenderStringPadding(t *testing.T) {
 
 	// Try with different values that would create a short status string
 	pb2 := New(999, "S") // Small description and 3-digit numbers
-	pb2.current = 100
+	pb2.current.Store(100)
 	pb2.startTime = time.Now().Add(-2 * time.Second)
 
 	pb2.render()
@@ -542,7 +574,7 @@ func TestProgressBar_RenderStringPadding(t *testing.T) {
 func TestProgressBar_RenderZeroCurrentPositiveTotal(t *testing.T) {
 	// Test when current is 0 but total is positive - this should calculate filledWidth as 0
 	pb := New(100, "Zero current")
-	pb.current = 0
+	pb.current.Store(0)
 	pb.startTime = time.Now().Add(-2 * time.Second) // Ensure elapsed > 1s to trigger speed calc
 
 	// This should handle the case where current=0 but total > 0
@@ -555,7 +587,7 @@ func TestProgressBar_RenderComprehensive(t *testing.T) {
 
 	// Create a progress bar with values that will trigger all calculation paths
 	pb := New(100, "Comprehensive test")
-	pb.current = 50                                 // Some progress but not complete
+	pb.current.Store(50)                            // Some progress but not complete
 	pb.startTime = time.Now().Add(-3 * time.Second) // Ensure elapsed > 1s for speed calc
 
 	// This should trigger:
@@ -569,7 +601,7 @@ func TestProgressBar_RenderComprehensive(t *testing.T) {
 
 	// Additional test to make sure we cover the case where ETA is calculated
 	pb2 := New(1000, "ETA test")
-	pb2.current = 20                                 // Progress made, but still has progress to go
+	pb2.current.Store(20)                            // Progress made, but still has progress to go
 	pb2.startTime = time.Now().Add(-5 * time.Second) // Elapsed time to calculate speed
 
 	pb2.render()
@@ -579,7 +611,7 @@ func TestProgressBar_RenderCurrentEqualsTotal(t *testing.T) {
 	// Test when current equals total exactly - this should result in 100% completion
 	// which may affect the ETA calculation (since pb.current < pb.total will be false)
 	pb := New(100, "100% test")
-	pb.current = 100                                // exactly equal to total
+	pb.current.Store(100)                           // exactly equal to total
 	pb.startTime = time.Now().Add(-2 * time.Second) // Elapsed > 1s to calculate speed
 
 	// This should not calculate ETA since current == total
@@ -590,7 +622,7 @@ func TestProgressBar_RenderETACalculationSpecific(t *testing.T) {
 	// Test the exact scenario that would trigger the ETA calculation line:
 	// remaining / speed calculation
 	pb := New(100, "ETA specific")                   // total = 100
-	pb.current = 50                                  // less than total, positive, so speed > 0 and current < total
+	pb.current.Store(50)                             // less than total, positive, so speed > 0 and current < total
 	pb.startTime = time.Now().Add(-10 * time.Second) // elapsed > 1s to trigger speed calc
 
 	// With current=50 and elapsed=10s, speed = 50/10 = 5.0 bytes/s
@@ -604,7 +636,7 @@ func TestProgressBar_RenderAllStringOperations(t *testing.T) {
 	// Test to ensure all string operations in render are covered
 	// This includes all the string building, formatting, and concatenation operations
 	pb := New(1000000, "Long description to test string operations") // Larger total to see bigger numbers
-	pb.current = 500000                                              // Halfway through
+	pb.current.Store(500000)                                         // Halfway through
 	pb.startTime = time.Now().Add(-10 * time.Second)                 // To trigger speed and ETA calculation
 
 	// This should execute all string operations in render:
@@ -618,7 +650,7 @@ func TestProgressBar_RenderAllStringOperations(t *testing.T) {
 func TestProgressBar_RenderMultipleCalls(t *testing.T) {
 	// Test multiple render calls in succession
 	pb := New(1000, "Multiple render test")
-	pb.current = 10
+	pb.current.Store(10)
 	pb.startTime = time.Now().Add(-5 * time.Second)
 
 	// Call render multiple times
@@ -626,7 +658,7 @@ func TestProgressBar_RenderMultipleCalls(t *testing.T) {
 	pb.render()
 
 	// Update progress and render again
-	pb.current = 500
+	pb.current.Store(500)
 	pb.render()
 	pb.render()
 }
@@ -635,7 +667,7 @@ func TestProgressBar_RenderAlmostComplete(t *testing.T) {
 	// Test when current is very close to total (but not equal)
 	// This might test a different code path than exactly equal
 	pb := New(1000, "Almost complete")
-	pb.current = 999 // very close to total but not quite
+	pb.current.Store(999) // very close to total but not quite
 	pb.startTime = time.Now().Add(-5 * time.Second)
 
 	// This should calculate speed but not ETA (since current is very close to total)
@@ -646,7 +678,7 @@ func TestProgressBar_RenderNegativeSpeed(t *testing.T) {
 	// Test when current is negative, resulting in negative speed
 	// This could affect the ETA calculation condition: if speed > 0 && pb.current < pb.total
 	pb := New(100, "Neg speed test")
-	pb.current = -10                                // This will result in negative speed
+	pb.current.Store(-10)                           // This will result in negative speed
 	pb.startTime = time.Now().Add(-2 * time.Second) // Elapsed > 1s
 
 	// This should handle negative current properly and not calculate ETA (since speed < 0)
@@ -657,7 +689,7 @@ func TestProgressBar_RenderSmallRemaining(t *testing.T) {
 	// Test with a scenario that will result in small remaining calculation
 	// This specifically tests the line: eta := time.Duration(float64(remaining)/speed) * time.Second
 	pb := New(100, "Small remaining")
-	pb.current = 90                                  // Close to total
+	pb.current.Store(90)                             // Close to total
 	pb.startTime = time.Now().Add(-10 * time.Second) // This gives speed of 90/10 = 9.0 bytes/s
 	// remaining = 100 - 90 = 10
 	// eta = 10 / 9.0 = ~1.1 seconds
@@ -669,7 +701,7 @@ func TestProgressBar_RenderETAPrecise(t *testing.T) {
 	// Test precise ETA calculation with values that ensure all conditions are met
 	// Specifically: elapsed.Seconds() > 1 && speed > 0 && pb.current < pb.total
 	pb := New(100, "ETA Precise")                   // total = 100
-	pb.current = 10                                 // less than total and positive
+	pb.current.Store(10)                            // less than total and positive
 	pb.startTime = time.Now().Add(-2 * time.Second) // elapsed > 1s
 
 	// This should trigger: speed = 10/2 = 5.0 bytes/s, then remaining = 90, eta = 90/5.0 = 18s
@@ -679,7 +711,7 @@ func TestProgressBar_RenderETAPrecise(t *testing.T) {
 func TestProgressBar_RenderZeroFilledWidth(t *testing.T) {
 	// Test when filledWidth is 0 (current = 0, so 0*width/total = 0)
 	pb := New(100, "Zero filled")
-	pb.current = 0
+	pb.current.Store(0)
 	pb.startTime = time.Now().Add(-2 * time.Second)
 
 	// This should result in filledWidth = 0, testing the first loop with 0 iterations
@@ -689,7 +721,7 @@ func TestProgressBar_RenderZeroFilledWidth(t *testing.T) {
 func TestProgressBar_RenderFullFilledWidth(t *testing.T) {
 	// Test when filledWidth equals the full width (current = total)
 	pb := New(100, "Full filled")
-	pb.current = 100 // equal to total
+	pb.current.Store(100) // equal to total
 	pb.startTime = time.Now().Add(-2 * time.Second)
 
 	// This should result in filledWidth = pb.width, testing the second loop with 0 iterations
@@ -699,7 +731,7 @@ func TestProgressBar_RenderFullFilledWidth(t *testing.T) {
 func TestProgressBar_RenderFractionalCalculations(t *testing.T) {
 	// Test with values that result in fractional calculations that might round differently
 	pb := New(3, "Fractional") // Small total to create interesting fractions
-	pb.current = 1             // Results in 1/3 which is 0.333...
+	pb.current.Store(1)        // Results in 1/3 which is 0.333...
 	pb.startTime = time.Now().Add(-3 * time.Second)
 
 	// This will result in filledWidth = int(50 * 1 / 3) = int(16.666) = 16
@@ -709,7 +741,7 @@ func TestProgressBar_RenderFractionalCalculations(t *testing.T) {
 func TestProgressBar_RenderCurrentGreaterThanTotal(t *testing.T) {
 	// Test when current is greater than total - this should result in filledWidth > pb.width
 	pb := New(100, "Over total")
-	pb.current = 150 // greater than total
+	pb.current.Store(150) // greater than total
 	pb.startTime = time.Now().Add(-3 * time.Second)
 
 	// This will result in filledWidth = int(50 * 150 / 100) = int(50 * 1.5) = 75
@@ -722,7 +754,7 @@ func TestProgressBar_RenderStatusStringBoundary(t *testing.T) {
 	// Specifically test when len(statusStr) == 80 (so no padding is added)
 	// or very close to 80 to ensure the padding logic is tested
 	pb := New(10000000, "A very long description that might make the status string approach 80 chars")
-	pb.current = 500000 // Large current value
+	pb.current.Store(500000) // Large current value
 	pb.startTime = time.Now().Add(-10 * time.Second)
 
 	// This should trigger all formatting operations
@@ -772,7 +804,7 @@ func TestProgressBar_RenderComprehensiveEdgeCases(t *testing.T) {
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
 			pb := New(tc.total, tc.name)
-			pb.current = tc.current
+			pb.current.Store(tc.current)
 			if tc.elapsed > 0 {
 				pb.startTime = time.Now().Add(-tc.elapsed)
 			} else {
@@ -783,3 +815,449 @@ func TestProgressBar_RenderComprehensiveEdgeCases(t *testing.T) {
 		})
 	}
 }
+
+func TestProgressBar_Output(t *testing.T) {
+	t.Run("Renders to the configured writer", func(t *testing.T) {
+		var buf bytes.Buffer
+		pb := New(100, "Downloading go.tar.gz")
+		pb.out = &buf
+
+		pb.Set(50)
+		pb.Finish()
+
+		output := buf.String()
+		if !strings.Contains(output, "Downloading go.tar.gz") || !strings.Contains(output, "100.0%") {
+			t.Errorf("Expected rendered progress bar, got %q", output)
+		}
+		if !strings.HasSuffix(output, "\n") {
+			t.Error("Expected Finish to end the line")
+		}
+	})
+
+	t.Run("Defaults to stderr, leaving stdout clean", func(t *testing.T) {
+		oldStdout, oldStderr := os.Stdout, os.Stderr
+		rOut, wOut, _ := os.Pipe()
+		rErr, wErr, _ := os.Pipe()
+		os.Stdout, os.Stderr = wOut, wErr
+
+		pb := New(100, "Downloading go.tar.gz")
+		pb.Set(100)
+		pb.Finish()
+
+		wOut.Close()
+		wErr.Close()
+		os.Stdout, os.Stderr = oldStdout, oldStderr
+
+		stdout, _ := io.ReadAll(rOut)
+		stderr, _ := io.ReadAll(rErr)
+		if len(stdout) != 0 {
+			t.Errorf("Expected nothing on stdout, got %q", stdout)
+		}
+		if !strings.Contains(string(stderr), "Downloading go.tar.gz") {
+			t.Errorf("Expected progress bar on stderr, got %q", stderr)
+		}
+	})
+}
+
+// fakeClock is a manually advanced clock for simulating transfer rates.
+type fakeClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+// newFakeClockBar returns a bar that renders to a buffer and reads time from a fake clock starting at its creation.
+func newFakeClockBar(total int64) (*ProgressBar, *fakeClock, *bytes.Buffer) {
+	clock := &fakeClock{now: time.Unix(1_700_000_000, 0)}
+	var buf bytes.Buffer
+	pb := New(total, "Downloading")
+	pb.out = &buf
+	pb.now = clock.Now
+	pb.startTime = clock.Now()
+	pb.lastUpdate.Store(clock.Now().UnixNano())
+	pb.samples = []rateSample{{at: clock.Now()}}
+	return pb, clock, &buf
+}
+
+// feed adds bytesPerSecond in 100ms steps for the given duration.
+func feed(pb *ProgressBar, clock *fakeClock, bytesPerSecond int64, duration time.Duration) {
+	step := 100 * time.Millisecond
+	for elapsed := time.Duration(0); elapsed < duration; elapsed += step {
+		clock.Advance(step)
+		pb.Add(bytesPerSecond / 10)
+	}
+}
+
+func TestProgressBar_RollingSpeed(t *testing.T) {
+	const mb = 1 << 20
+
+	t.Run("Speed follows a bandwidth drop", func(t *testing.T) {
+		pb, clock, _ := newFakeClockBar(1 << 40)
+
+		feed(pb, clock, 10*mb, 20*time.Second)
+		feed(pb, clock, 1*mb, 10*time.Second)
+
+		pb.mutex.Lock()
+		rolling := pb.rollingSpeed(clock.Now())
+		average := pb.averageSpeed(clock.Now())
+		pb.mutex.Unlock()
+
+		if rolling < 0.9*mb || rolling > 1.1*mb {
+			t.Errorf("rolling speed = %.0f B/s, want about 1 MB/s after the drop", rolling)
+		}
+		if average < 6.5*mb || average > 7.5*mb {
+			t.Errorf("average speed = %.0f B/s, want about 7 MB/s over the whole transfer", average)
+		}
+		if got := pb.AverageSpeed(); got != average {
+			t.Errorf("AverageSpeed() = %.0f, want %.0f", got, average)
+		}
+	})
+
+	t.Run("Speed follows a bandwidth increase", func(t *testing.T) {
+		pb, clock, _ := newFakeClockBar(1 << 40)
+
+		feed(pb, clock, 1*mb, 20*time.Second)
+		feed(pb, clock, 8*mb, 10*time.Second)
+
+		pb.mutex.Lock()
+		rolling := pb.rollingSpeed(clock.Now())
+		pb.mutex.Unlock()
+
+		if rolling < 7.5*mb || rolling > 8.5*mb {
+			t.Errorf("rolling speed = %.0f B/s, want about 8 MB/s after the increase", rolling)
+		}
+	})
+
+	t.Run("ETA is derived from recent throughput", func(t *testing.T) {
+		pb, clock, buf := newFakeClockBar(200 * mb)
+
+		feed(pb, clock, 10*mb, 10*time.Second) // 100 MB done
+		feed(pb, clock, 1*mb, 10*time.Second)  // 110 MB done, 90 MB left at 1 MB/s
+		buf.Reset()
+		pb.mutex.Lock()
+		pb.render()
+		pb.mutex.Unlock()
+
+		output := buf.String()
+		if !strings.Contains(output, "1024 KB/s") {
+			t.Errorf("Expected the recent 1024 KB/s rate in %q", output)
+		}
+		if !strings.Contains(output, "ETA: 1m30s") {
+			t.Errorf("Expected an ETA of 1m30s from the recent rate in %q", output)
+		}
+	})
+
+	t.Run("Set does not count as throughput", func(t *testing.T) {
+		pb, clock, _ := newFakeClockBar(1 << 40)
+
+		pb.Set(500 * mb) // resumed download
+		feed(pb, clock, 2*mb, 5*time.Second)
+
+		pb.mutex.Lock()
+		rolling := pb.rollingSpeed(clock.Now())
+		pb.mutex.Unlock()
+
+		if rolling < 1.9*mb || rolling > 2.1*mb {
+			t.Errorf("rolling speed = %.0f B/s, want about 2 MB/s excluding the resumed bytes", rolling)
+		}
+	})
+
+	t.Run("Short spans fall back to the average", func(t *testing.T) {
+		pb, clock, _ := newFakeClockBar(1 << 40)
+
+		pb.Set(0)
+		clock.Advance(500 * time.Millisecond)
+		pb.Add(mb)
+
+		pb.mutex.Lock()
+		rolling := pb.rollingSpeed(clock.Now())
+		average := pb.averageSpeed(clock.Now())
+		pb.mutex.Unlock()
+
+		if rolling != average {
+			t.Errorf("rolling speed = %.0f, want the average %.0f for a span under %v", rolling, average, minSpeedSpan)
+		}
+	})
+
+	t.Run("Samples stay bounded", func(t *testing.T) {
+		pb, clock, _ := newFakeClockBar(1 << 40)
+
+		feed(pb, clock, mb, time.Minute)
+
+		if max := int(speedWindow/sampleInterval) + 2; len(pb.samples) > max {
+			t.Errorf("kept %d samples, want at most %d", len(pb.samples), max)
+		}
+	})
+
+	t.Run("Concurrent adds", func(t *testing.T) {
+		pb, clock, _ := newFakeClockBar(1 << 40)
+
+		var wg sync.WaitGroup
+		for range 8 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for range 100 {
+					clock.Advance(time.Millisecond)
+					pb.Add(1024)
+				}
+			}()
+		}
+		wg.Wait()
+
+		if pb.current.Load() != 8*100*1024 {
+			t.Errorf("current = %d, want %d", pb.current.Load(), 8*100*1024)
+		}
+	})
+}
+
+func TestProgressBar_Indeterminate(t *testing.T) {
+	t.Run("Unknown total shows a spinner and bytes so far", func(t *testing.T) {
+		for _, total := range []int64{0, -1} {
+			pb, clock, buf := newFakeClockBar(total)
+
+			clock.Advance(200 * time.Millisecond)
+			pb.Add(3 << 20)
+
+			output := buf.String()
+			if pb.current.Load() != 3<<20 {
+				t.Errorf("total %d: current = %d, want bytes added without clamping", total, pb.current.Load())
+			}
+			if !strings.Contains(output, "Downloading "+_theme.Default.Spinner[0]+" 3 MB") {
+				t.Errorf("total %d: expected spinner and byte count, got %q", total, output)
+			}
+			if strings.Contains(output, "%") || strings.Contains(output, _theme.Default.Fill) {
+				t.Errorf("total %d: indeterminate mode should not draw a bar, got %q", total, output)
+			}
+		}
+	})
+
+	t.Run("Spinner advances at the render throttle", func(t *testing.T) {
+		pb, clock, buf := newFakeClockBar(0)
+
+		for range 10 {
+			clock.Advance(50 * time.Millisecond)
+			pb.Add(1024)
+		}
+
+		// 500ms of updates every 50ms renders about every other update, not all ten
+		renders := strings.Count(buf.String(), "\r")
+		if renders < 3 || renders > 5 {
+			t.Errorf("rendered %d times in 500ms, want the 100ms throttle to apply", renders)
+		}
+		if pb.spinnerFrame != renders {
+			t.Errorf("spinner frame = %d, want one frame per render (%d)", pb.spinnerFrame, renders)
+		}
+	})
+
+	t.Run("Finish replaces the spinner with the final size", func(t *testing.T) {
+		pb, clock, buf := newFakeClockBar(0)
+
+		feed(pb, clock, 1000*1024, 4*time.Second)
+		buf.Reset()
+		pb.Finish()
+
+		output := buf.String()
+		if pb.current.Load() != 4000*1024 {
+			t.Errorf("current = %d, Finish should keep the bytes transferred", pb.current.Load())
+		}
+		if !strings.HasPrefix(output, "\rDownloading 4 MB 1000 KB/s") || !strings.HasSuffix(output, "\n") {
+			t.Errorf("expected final size, average speed, and a newline without a spinner frame, got %q", output)
+		}
+		for _, frame := range _theme.Default.Spinner {
+			if strings.Contains(output, frame) {
+				t.Errorf("finished output still has spinner frame %q: %q", frame, output)
+			}
+		}
+	})
+}
+
+func TestProgressBar_Theme(t *testing.T) {
+	t.Cleanup(func() { _theme.Set(_theme.Default) })
+	_theme.Set(_theme.ASCII)
+
+	t.Run("Bar uses the theme's fill and empty characters", func(t *testing.T) {
+		pb, _, buf := newFakeClockBar(100)
+		pb.width = 10
+		pb.Set(50)
+
+		output := buf.String()
+		if !strings.Contains(output, "[#####-----]") {
+			t.Errorf("expected an ASCII bar, got %q", output)
+		}
+		if strings.Contains(output, _theme.Default.Fill) || strings.Contains(output, _theme.Default.Empty) {
+			t.Errorf("ASCII theme should not draw block characters, got %q", output)
+		}
+	})
+
+	t.Run("Spinner uses the theme's frames", func(t *testing.T) {
+		pb, clock, buf := newFakeClockBar(0)
+		clock.Advance(200 * time.Millisecond)
+		pb.Add(1024)
+
+		if !strings.Contains(buf.String(), "Downloading "+_theme.ASCII.Spinner[0]+" ") {
+			t.Errorf("expected an ASCII spinner frame, got %q", buf.String())
+		}
+	})
+}
+
+func TestProgressBar_TerminalWidth(t *testing.T) {
+	// lastLine returns the most recent redraw, without the leading carriage return
+	lastLine := func(buf *bytes.Buffer) string {
+		lines := strings.Split(buf.String(), "\r")
+		return lines[len(lines)-1]
+	}
+
+	testCases := []struct {
+		name        string
+		columns     int
+		description string
+		wantWidth   int // expected bar width; 0 means no bar
+		wantDesc    string
+	}{
+		{name: "Unknown width keeps the default", columns: 0, description: "Downloading", wantWidth: defaultBarWidth, wantDesc: "Downloading"},
+		{name: "Wide terminal caps the bar", columns: 300, description: "Downloading", wantWidth: maxBarWidth, wantDesc: "Downloading"},
+		{name: "Medium terminal fits the bar", columns: 100, description: "Downloading", wantWidth: 100 - 1 - statusReserve - len("Downloading") - len(" []"), wantDesc: "Downloading"},
+		{name: "Narrow terminal drops the bar", columns: 60, description: "Downloading", wantWidth: 0, wantDesc: "Downloading"},
+		{name: "Tiny terminal shortens the description", columns: 30, description: "Downloading go1.25.1.linux-amd64.tar.gz", wantWidth: 0, wantDesc: "Downl..."},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			pb, _, buf := newFakeClockBar(1000)
+			pb.description = tc.description
+			pb.measure = func(io.Writer) int { return tc.columns }
+
+			pb.Set(500)
+			line := lastLine(buf)
+
+			if !strings.HasPrefix(line, tc.wantDesc) {
+				t.Errorf("expected line to start with %q, got %q", tc.wantDesc, line)
+			}
+			if !strings.Contains(line, "50.0% (500 B/1000 B)") {
+				t.Errorf("status text should always be shown, got %q", line)
+			}
+			if gotWidth := strings.Count(line, _theme.Default.Fill) + strings.Count(line, _theme.Default.Empty); gotWidth != tc.wantWidth {
+				t.Errorf("bar width = %d, want %d (line %q)", gotWidth, tc.wantWidth, line)
+			}
+			if tc.wantWidth == 0 && strings.Contains(line, "[") {
+				t.Errorf("expected no bar brackets, got %q", line)
+			}
+			if tc.columns > 0 && visibleLen(line) >= tc.columns {
+				t.Errorf("line is %d columns wide, want less than %d so it does not wrap: %q", visibleLen(line), tc.columns, line)
+			}
+		})
+	}
+
+	t.Run("Resizing is picked up and padding stays within the terminal", func(t *testing.T) {
+		pb, clock, buf := newFakeClockBar(1000)
+		columns := 200
+		pb.measure = func(io.Writer) int { return columns }
+
+		pb.Set(100)
+		wide := visibleLen(lastLine(buf))
+
+		columns = 40
+		clock.Advance(columnsRefresh)
+		pb.Set(200)
+		narrow := lastLine(buf)
+
+		if visibleLen(narrow) >= 40 {
+			t.Errorf("after shrinking to 40 columns the line is %d columns wide (was %d): %q", visibleLen(narrow), wide, narrow)
+		}
+	})
+
+	t.Run("Width is measured at most once per refresh interval", func(t *testing.T) {
+		pb, clock, _ := newFakeClockBar(1 << 30)
+		calls := 0
+		pb.measure = func(io.Writer) int { calls++; return 120 }
+
+		feed(pb, clock, 1<<20, 3*time.Second)
+
+		if calls < 2 || calls > 4 {
+			t.Errorf("measured the terminal %d times in 3s, want about once per %v", calls, columnsRefresh)
+		}
+	})
+
+	t.Run("Spinner shortens the description to fit", func(t *testing.T) {
+		pb, clock, buf := newFakeClockBar(0)
+		pb.description = "Downloading go1.25.1.linux-amd64.tar.gz"
+		pb.measure = func(io.Writer) int { return 30 }
+
+		clock.Advance(200 * time.Millisecond)
+		pb.Add(3 << 20)
+		line := lastLine(buf)
+
+		if !strings.HasSuffix(strings.TrimRight(line, " "), "3 MB") || visibleLen(line) >= 30 {
+			t.Errorf("expected the byte count to fit within 30 columns, got %q", line)
+		}
+	})
+}
+
+func TestVisibleLen(t *testing.T) {
+	testCases := []struct {
+		name  string
+		input string
+		want  int
+	}{
+		{name: "Plain text", input: "abc", want: 3},
+		{name: "Carriage return is not counted", input: "\rabc", want: 3},
+		{name: "Color codes are not counted", input: "\033[36m###\033[0m--", want: 5},
+		{name: "Multi-byte characters count once", input: "█░⠋", want: 3},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := visibleLen(tc.input); got != tc.want {
+				t.Errorf("visibleLen(%q) = %d, want %d", tc.input, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestProgressBar_ConcurrentAddClamps(t *testing.T) {
+	pb := New(1000, "Concurrent clamp")
+	pb.out = io.Discard
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 1000; j++ {
+				pb.Write(make([]byte, 3))
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := pb.current.Load(); got != 1000 {
+		t.Errorf("current = %d after writing past the total, want it clamped to 1000", got)
+	}
+}
+
+// BenchmarkProgressBar_WriteParallel measures the download writer path with many goroutines writing small chunks.
+// Only throttled renders take the mutex, so adding bytes does not contend on it.
+func BenchmarkProgressBar_WriteParallel(b *testing.B) {
+	pb := New(1<<62, "Benchmark")
+	pb.out = io.Discard
+	chunk := make([]byte, 512)
+
+	b.ReportAllocs()
+	b.RunParallel(func(p *testing.PB) {
+		for p.Next() {
+			pb.Write(chunk)
+		}
+	})
+}
@@ -0,0 +1,160 @@
+package shell
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// HookCommands returns shell code, evaluated from the startup file, that exports SessionPIDEnv and applies the PATH
+// printed by govmanPath's '_autoswitch' on each directory change. Returns an error for shells without one (cmd).
+func HookCommands(s Shell, govmanPath string) ([]string, error) {
+	switch s.(type) {
+	case *BashShell:
+		escapedBin := escapeBashPath(govmanPath)
+		return []string{
+			"# govman auto-switch hook for bash",
+			"export GOVMAN_SESSION_PID=$$",
+			"__govman_autoswitch() {",
+			"    local cmd",
+			fmt.Sprintf(`    cmd="$("%s" _autoswitch bash)" || return 0`, escapedBin),
+			`    [[ -n "$cmd" ]] && eval "$cmd"`,
+			"    return 0",
+			"}",
+			`__govman_hook_pwd=""`,
+			"__govman_hook() {",
+			`    if [[ "$PWD" != "$__govman_hook_pwd" ]]; then`,
+			`        __govman_hook_pwd="$PWD"`,
+			"        __govman_autoswitch",
+			"    fi",
+			"}",
+			`if [[ ";${PROMPT_COMMAND:-};" != *";__govman_hook;"* ]]; then`,
+			`    PROMPT_COMMAND="__govman_hook${PROMPT_COMMAND:+;$PROMPT_COMMAND}"`,
+			"fi",
+		}, nil
+
+	case *ZshShell:
+		escapedBin := escapeBashPath(govmanPath)
+		return []string{
+			"# govman auto-switch hook for zsh",
+			"export GOVMAN_SESSION_PID=$$",
+			"__govman_autoswitch() {",
+			"    local cmd",
+			fmt.Sprintf(`    cmd="$("%s" _autoswitch zsh)" || return 0`, escapedBin),
+			`    [[ -n "$cmd" ]] && eval "$cmd"`,
+			"    return 0",
+			"}",
+			"autoload -Uz add-zsh-hook",
+			"add-zsh-hook chpwd __govman_autoswitch",
+			"__govman_autoswitch",
+		}, nil
+
+	case *FishShell:
+		escapedBin := escapeFishPath(govmanPath)
+		return []string{
+			"# govman auto-switch hook for fish",
+			"set -gx GOVMAN_SESSION_PID $fish_pid",
+			"function __govman_autoswitch --on-variable PWD",
+			fmt.Sprintf(`    "%s" _autoswitch fish | source`, escapedBin),
+			"end",
+			"__govman_autoswitch",
+		}, nil
+
+	case *PowerShell:
+		escapedBin := escapePowerShellPath(govmanPath)
+		return []string{
+			"# govman auto-switch hook for PowerShell",
+			`$env:GOVMAN_SESSION_PID = "$PID"`,
+			"if (-not $global:__GovmanOriginalPrompt) {",
+			"    $global:__GovmanOriginalPrompt = $function:prompt",
+			"    $global:__GovmanHookPwd = ''",
+			"    function global:prompt {",
+			"        if ($PWD.Path -ne $global:__GovmanHookPwd) {",
+			"            $global:__GovmanHookPwd = $PWD.Path",
+			fmt.Sprintf(`            $cmd = & "%s" _autoswitch powershell`, escapedBin),
+			`            if ($LASTEXITCODE -eq 0 -and $cmd) { Invoke-Expression ($cmd -join "` + "`" + `n") }`,
+			"        }",
+			"        & $global:__GovmanOriginalPrompt",
+			"    }",
+			"}",
+		}, nil
+
+	case *NushellShell:
+		return []string{
+			"# govman auto-switch hook for Nushell",
+			"$env.GOVMAN_SESSION_PID = ($nu.pid | into string)",
+			"$env.config.hooks.env_change.PWD = ($env.config.hooks.env_change.PWD? | default [] | append {|before, after|",
+			fmt.Sprintf(`    let govman_bin = %s`, quoteNushellPath(govmanPath)),
+			"    let result = (do { ^$govman_bin _autoswitch nushell } | complete)",
+			"    if $result.stderr != \"\" { print -e ($result.stderr | str trim) }",
+			"    if $result.exit_code == 0 and ($result.stdout | str trim) != \"\" {",
+			"        $env.PATH = ($result.stdout | from json)",
+			"    }",
+			"})",
+		}, nil
+
+	default:
+		return nil, fmt.Errorf("auto-switch hooks are not supported for %s", s.DisplayName())
+	}
+}
+
+// SetPathCommand returns a command that replaces PATH with entries, for use by the auto-switch hook.
+// Nushell has no eval, so for it the entries are returned as a JSON list that the hook parses.
+// Returns an error for shells the hook does not support.
+func SetPathCommand(s Shell, entries []string) (string, error) {
+	switch s.(type) {
+	case *BashShell, *ZshShell:
+		escaped := make([]string, len(entries))
+		for i, entry := range entries {
+			escaped[i] = escapeBashPath(entry)
+		}
+		return fmt.Sprintf(`export PATH="%s"`, strings.Join(escaped, ":")), nil
+
+	case *FishShell:
+		quoted := make([]string, len(entries))
+		for i, entry := range entries {
+			quoted[i] = fmt.Sprintf(`"%s"`, escapeFishPath(entry))
+		}
+		return "set -gx PATH " + strings.Join(quoted, " "), nil
+
+	case *PowerShell:
+		escaped := make([]string, len(entries))
+		for i, entry := range entries {
+			escaped[i] = escapePowerShellPath(entry)
+		}
+		// pwsh on Linux and macOS uses ':' like the host, so join with the separator PATH was split on
+		return fmt.Sprintf(`$env:PATH = "%s"`, strings.Join(escaped, string(os.PathListSeparator))), nil
+
+	case *NushellShell:
+		data, err := json.Marshal(entries)
+		if err != nil {
+			return "", fmt.Errorf("failed to encode PATH: %w", err)
+		}
+		return string(data), nil
+
+	default:
+		return "", fmt.Errorf("auto-switch hooks are not supported for %s", s.DisplayName())
+	}
+}
+
+// SwitchPath builds the PATH entries that activate binDir: binDir first, followed by pathEnv with any other
+// directories under installDir removed so repeated switches do not accumulate. Returns false when binDir already leads PATH.
+func SwitchPath(pathEnv, binDir, installDir string) ([]string, bool) {
+	current := filepath.SplitList(pathEnv)
+	if len(current) > 0 && filepath.Clean(current[0]) == filepath.Clean(binDir) {
+		return nil, false
+	}
+
+	installPrefix := filepath.Clean(installDir) + string(filepath.Separator)
+	entries := []string{binDir}
+	for _, entry := range current {
+		if entry == "" || strings.HasPrefix(filepath.Clean(entry)+string(filepath.Separator), installPrefix) {
+			continue
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries, true
+}
@@ -0,0 +1,137 @@
+package shell
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestHookCommands(t *testing.T) {
+	govmanPath := "/opt/go man/govman"
+
+	testCases := []struct {
+		name     string
+		shell    Shell
+		expected []string
+	}{
+		{"Bash", &BashShell{}, []string{`"/opt/go man/govman" _autoswitch bash`, "PROMPT_COMMAND", `eval "$cmd"`}},
+		{"Zsh", &ZshShell{}, []string{`"/opt/go man/govman" _autoswitch zsh`, "add-zsh-hook chpwd __govman_autoswitch"}},
+		{"Fish", &FishShell{}, []string{"--on-variable PWD", `"/opt/go man/govman" _autoswitch fish | source`}},
+		{"PowerShell", &PowerShell{}, []string{`& "/opt/go man/govman" _autoswitch powershell`, "function global:prompt"}},
+		{"Nushell", &NushellShell{}, []string{"env_change.PWD", "r#'/opt/go man/govman'#", "_autoswitch nushell", "from json"}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			lines, err := HookCommands(tc.shell, govmanPath)
+			if err != nil {
+				t.Fatalf("HookCommands() error = %v", err)
+			}
+
+			script := strings.Join(lines, "\n")
+			for _, expected := range tc.expected {
+				if !strings.Contains(script, expected) {
+					t.Errorf("Expected hook to contain %q, got:\n%s", expected, script)
+				}
+			}
+			if containsGovmanConfig(script) {
+				t.Error("Hook output must not be mistaken for 'govman init' configuration")
+			}
+		})
+	}
+
+	t.Run("Cmd is unsupported", func(t *testing.T) {
+		if _, err := HookCommands(&CmdShell{}, govmanPath); err == nil {
+			t.Error("Expected error for cmd")
+		}
+	})
+}
+
+func TestSetPathCommand(t *testing.T) {
+	entries := []string{"/home/u/.govman/versions/go1.22.0/bin", "/usr/bin", `/tmp/a"b$c`}
+	sep := string(os.PathListSeparator)
+
+	testCases := []struct {
+		name     string
+		shell    Shell
+		expected string
+	}{
+		{"Bash", &BashShell{}, `export PATH="/home/u/.govman/versions/go1.22.0/bin:/usr/bin:/tmp/a\"b\$c"`},
+		{"Zsh", &ZshShell{}, `export PATH="/home/u/.govman/versions/go1.22.0/bin:/usr/bin:/tmp/a\"b\$c"`},
+		{"Fish", &FishShell{}, `set -gx PATH "/home/u/.govman/versions/go1.22.0/bin" "/usr/bin" "/tmp/a\"b\$c"`},
+		{"PowerShell", &PowerShell{}, `$env:PATH = "/home/u/.govman/versions/go1.22.0/bin` + sep + `/usr/bin` + sep + "/tmp/a`\"b`$c\""},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := SetPathCommand(tc.shell, entries)
+			if err != nil {
+				t.Fatalf("SetPathCommand() error = %v", err)
+			}
+			if got != tc.expected {
+				t.Errorf("SetPathCommand() = %s, want %s", got, tc.expected)
+			}
+		})
+	}
+
+	t.Run("Nushell emits a JSON list", func(t *testing.T) {
+		got, err := SetPathCommand(&NushellShell{}, entries)
+		if err != nil {
+			t.Fatalf("SetPathCommand() error = %v", err)
+		}
+		var decoded []string
+		if err := json.Unmarshal([]byte(got), &decoded); err != nil {
+			t.Fatalf("Expected JSON list, got %q: %v", got, err)
+		}
+		if !slices.Equal(decoded, entries) {
+			t.Errorf("Decoded %v, want %v", decoded, entries)
+		}
+	})
+
+	t.Run("Cmd is unsupported", func(t *testing.T) {
+		if _, err := SetPathCommand(&CmdShell{}, entries); err == nil {
+			t.Error("Expected error for cmd")
+		}
+	})
+}
+
+func TestSwitchPath(t *testing.T) {
+	installDir := filepath.FromSlash("/home/u/.govman/versions")
+	binDir := filepath.Join(installDir, "go1.22.0", "bin")
+	otherBin := filepath.Join(installDir, "go1.21.0", "bin")
+	usrBin := filepath.FromSlash("/usr/bin")
+	similar := filepath.FromSlash("/home/u/.govman/versions-old/bin")
+	join := func(entries ...string) string {
+		return strings.Join(entries, string(os.PathListSeparator))
+	}
+
+	testCases := []struct {
+		name            string
+		pathEnv         string
+		expected        []string
+		expectedChanged bool
+	}{
+		{"Prepends to plain PATH", join(usrBin), []string{binDir, usrBin}, true},
+		{"Replaces another govman version", join(otherBin, usrBin), []string{binDir, usrBin}, true},
+		{"Removes duplicates further down", join(usrBin, binDir), []string{binDir, usrBin}, true},
+		{"Keeps directories that only share a prefix", join(similar), []string{binDir, similar}, true},
+		{"Drops empty entries", join(usrBin, "", usrBin), []string{binDir, usrBin, usrBin}, true},
+		{"Already active", join(binDir, usrBin), nil, false},
+		{"Empty PATH", "", []string{binDir}, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, changed := SwitchPath(tc.pathEnv, binDir, installDir)
+			if changed != tc.expectedChanged {
+				t.Errorf("SwitchPath() changed = %v, want %v", changed, tc.expectedChanged)
+			}
+			if !slices.Equal(got, tc.expected) {
+				t.Errorf("SwitchPath() = %v, want %v", got, tc.expected)
+			}
+		})
+	}
+}
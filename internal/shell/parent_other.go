@@ -0,0 +1,8 @@
+//go:build !windows
+
+package shell
+
+// parentProcessName is only needed to tell Windows shells apart; elsewhere it returns an empty string.
+func parentProcessName() string {
+	return ""
+}
@@ -0,0 +1,27 @@
+package shell
+
+import (
+	"os"
+	"syscall"
+	"unsafe"
+)
+
+// parentProcessName returns the executable name of govman's parent process, e.g. "cmd.exe".
+// Returns an empty string if the process list cannot be read.
+func parentProcessName() string {
+	snapshot, err := syscall.CreateToolhelp32Snapshot(syscall.TH32CS_SNAPPROCESS, 0)
+	if err != nil {
+		return ""
+	}
+	defer syscall.CloseHandle(snapshot)
+
+	ppid := uint32(os.Getppid())
+	var entry syscall.ProcessEntry32
+	entry.Size = uint32(unsafe.Sizeof(entry))
+	for err = syscall.Process32First(snapshot, &entry); err == nil; err = syscall.Process32Next(snapshot, &entry) {
+		if entry.ProcessID == ppid {
+			return syscall.UTF16ToString(entry.ExeFile[:])
+		}
+	}
+	return ""
+}
@@ -5,24 +5,43 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
-	"regexp"
 	"runtime"
+	"slices"
 	"strings"
 	"text/template"
+
+	_config "github.com/justjundana/govman/internal/config"
 )
 
 var (
-	currentGOOS        = runtime.GOOS
-	execLookPath       = exec.LookPath
-	userHomeDir        = os.UserHomeDir
-	newlineRegex       = regexp.MustCompile(`\n{3,}`)
-	configRemovalRegex = regexp.MustCompile(`(?ms)^[#\s]*(REM\s+)?GOVMAN - Go Version Manager.*?^[#\s]*(REM\s+)?END GOVMAN.*?$\n?`)
+	currentGOOS   = runtime.GOOS
+	execLookPath  = exec.LookPath
+	userHomeDir   = os.UserHomeDir
+	parentProcess = parentProcessName
+)
+
+// The integration block written by SetupCommands starts and ends with these sentinel comments, so it can be
+// found and removed wherever it ends up in the file. Blocks from older releases use the legacy pair instead.
+const (
+	blockStart       = ">>> govman >>>"
+	blockEnd         = "<<< govman <<<"
+	legacyBlockStart = "GOVMAN - Go Version Manager"
+	legacyBlockEnd   = "END GOVMAN"
 )
 
+// SubshellVersionEnv is set to the active version inside a 'govman shell' subshell. While it is set, the
+// integration block keeps that version first on PATH and auto-switching leaves it alone.
+const SubshellVersionEnv = "GOVMAN_SHELL_VERSION"
+
+// SessionPIDEnv holds the process ID of the interactive shell, exported by the integration block and the hook so that
+// 'govman use --temp' records its version for that shell and auto-switching leaves it alone there.
+const SessionPIDEnv = "GOVMAN_SESSION_PID"
+
 // configMarkers are strings used to detect existing govman configuration.
 // These must be kept in sync with the output of SetupCommands functions.
 var configMarkers = []string{
-	"GOVMAN - Go Version Manager",
+	blockStart,
+	legacyBlockStart,
 	"govman_auto_switch",
 	"Invoke-GovmanAutoSwitch",
 	"__govman_cd_hook",
@@ -43,6 +62,87 @@ type ZshShell struct{}
 type FishShell struct{}
 type PowerShell struct{}
 type CmdShell struct{}
+type NushellShell struct{}
+
+// pinToolchain controls whether activation sets GOTOOLCHAIN=local; change it with SetPinToolchain.
+var pinToolchain = true
+
+// SetPinToolchain sets whether shell setup, 'use', and the auto-switch hook set GOTOOLCHAIN=local,
+// which stops a go.mod from making the activated go binary download a different toolchain.
+func SetPinToolchain(enabled bool) {
+	pinToolchain = enabled
+}
+
+// configFile is the govman config file the auto-switch hooks read; change it with SetConfigFile.
+var configFile string
+
+// SetConfigFile sets the config file path embedded in the auto-switch hooks written by shell setup,
+// so they read the same file as govman itself (GOVMAN_HOME, the XDG layout, or --config).
+func SetConfigFile(path string) {
+	if abs, err := filepath.Abs(path); err == nil {
+		path = abs
+	}
+	configFile = path
+}
+
+// hookConfigFile returns the config path for the auto-switch hooks, defaulting to ~/.govman/config.yaml when unset.
+func hookConfigFile() string {
+	if configFile != "" {
+		return configFile
+	}
+
+	home, err := userHomeDir()
+	if err != nil {
+		home = "~"
+	}
+	return filepath.Join(home, ".govman", "config.yaml")
+}
+
+// sessionFilePrefix returns the path of the session files in the govman home holding binPath, up to the shell's process ID.
+func sessionFilePrefix(binPath string) string {
+	return filepath.Join(filepath.Dir(binPath), _config.SessionFilePrefix)
+}
+
+// ToolchainCommand returns the command that sets GOTOOLCHAIN=local in s, or "" when pinning is disabled.
+func ToolchainCommand(s Shell) string {
+	if !pinToolchain {
+		return ""
+	}
+
+	switch s.(type) {
+	case *BashShell, *ZshShell:
+		return "export GOTOOLCHAIN=local"
+	case *FishShell:
+		return "set -gx GOTOOLCHAIN local"
+	case *PowerShell:
+		return "$env:GOTOOLCHAIN = 'local'"
+	case *NushellShell:
+		return `$env.GOTOOLCHAIN = "local"`
+	case *CmdShell:
+		return "set GOTOOLCHAIN=local"
+	default:
+		return ""
+	}
+}
+
+// withoutToolchain drops the GOTOOLCHAIN line from setup commands when pinning is disabled.
+func withoutToolchain(commands []string) []string {
+	if pinToolchain {
+		return commands
+	}
+
+	return slices.DeleteFunc(commands, func(line string) bool {
+		return strings.Contains(line, "GOTOOLCHAIN")
+	})
+}
+
+// printActivation prints the GOTOOLCHAIN command (when pinning) followed by pathCmd for the caller to eval.
+func printActivation(s Shell, pathCmd string) {
+	if toolchainCmd := ToolchainCommand(s); toolchainCmd != "" {
+		fmt.Println(toolchainCmd)
+	}
+	fmt.Println(pathCmd)
+}
 
 // validateBinPath ensures the binary path is safe and exists
 func validateBinPath(binPath string) error {
@@ -115,6 +215,16 @@ func escapeFishPath(path string) string {
 	return replacer.Replace(path)
 }
 
+// quoteNushellPath wraps a path in a Nushell raw string (r#'...'#), which needs no escaping.
+// Extra # characters are added when the path itself contains the closing delimiter.
+func quoteNushellPath(path string) string {
+	hashes := "#"
+	for strings.Contains(path, "'"+hashes) {
+		hashes += "#"
+	}
+	return fmt.Sprintf("r%s'%s'%s", hashes, path, hashes)
+}
+
 // escapePowerShellPath properly escapes a path for use in PowerShell
 func escapePowerShellPath(path string) string {
 	// PowerShell escaping: backtick is the escape character
@@ -137,6 +247,14 @@ func escapeCmdPath(path string) string {
 // falling back to an available default when detection is inconclusive.
 func Detect() Shell {
 	if currentGOOS == "windows" {
+		// Prefer the shell govman is actually running in
+		if isPowerShellSession() && (isCommandAvailable("pwsh") || isCommandAvailable("powershell")) {
+			return &PowerShell{}
+		}
+		if isCmdSession() {
+			return &CmdShell{}
+		}
+
 		// Check for PowerShell Core first (preferred)
 		if isCommandAvailable("pwsh") {
 			return &PowerShell{}
@@ -149,6 +267,14 @@ func Detect() Shell {
 		return &CmdShell{}
 	}
 
+	// Fish sets FISH_VERSION, which catches fish running on top of a different login shell
+	if os.Getenv("FISH_VERSION") != "" && isCommandAvailable("fish") {
+		return &FishShell{}
+	}
+	if os.Getenv("NU_VERSION") != "" && isCommandAvailable("nu") {
+		return &NushellShell{}
+	}
+
 	// For Unix-like systems, check SHELL environment variable
 	shellPath := os.Getenv("SHELL")
 	if shellPath == "" {
@@ -165,6 +291,10 @@ func Detect() Shell {
 		if isCommandAvailable("fish") {
 			return &FishShell{}
 		}
+	case "nu":
+		if isCommandAvailable("nu") {
+			return &NushellShell{}
+		}
 	case "bash", "sh":
 		if isCommandAvailable("bash") {
 			return &BashShell{}
@@ -175,12 +305,35 @@ func Detect() Shell {
 	return detectAvailableShell()
 }
 
+// isPowerShellSession reports whether govman was started from PowerShell.
+// Windows defines PSModulePath system-wide, but only PowerShell adds the per-user
+// Documents\PowerShell or Documents\WindowsPowerShell modules directory to it.
+func isPowerShellSession() bool {
+	for _, dir := range strings.Split(os.Getenv("PSModulePath"), ";") {
+		dir = strings.ToLower(strings.ReplaceAll(dir, "/", `\`))
+		if strings.Contains(dir, `documents\powershell\modules`) || strings.Contains(dir, `documents\windowspowershell\modules`) {
+			return true
+		}
+	}
+	return false
+}
+
+// isCmdSession reports whether govman was started from Command Prompt: its parent process is cmd.exe or, when the
+// parent is unknown, PROMPT is set, which cmd exports to its children. ComSpec names cmd.exe in every session.
+func isCmdSession() bool {
+	if parent := parentProcess(); parent != "" {
+		return strings.EqualFold(parent, "cmd.exe")
+	}
+	return os.Getenv("PROMPT") != ""
+}
+
 // detectAvailableShell returns the first available shell from a prioritized list.
 func detectAvailableShell() Shell {
 	shells := []Shell{
 		&BashShell{},
 		&ZshShell{},
 		&FishShell{},
+		&NushellShell{},
 	}
 
 	for _, shell := range shells {
@@ -192,6 +345,40 @@ func detectAvailableShell() Shell {
 	return &BashShell{}
 }
 
+// Executable returns the program that starts an interactive sh: $SHELL when it names the same shell, so a
+// specific install is kept, otherwise the shell's executable found on PATH (cmd uses %ComSpec%).
+// Returns an error if no executable for sh can be found.
+func Executable(sh Shell) (string, error) {
+	var names []string
+	switch sh.Name() {
+	case "nushell":
+		names = []string{"nu"}
+	case "powershell":
+		names = []string{"pwsh", "powershell"}
+	case "cmd":
+		if comSpec := os.Getenv("ComSpec"); comSpec != "" {
+			return comSpec, nil
+		}
+		names = []string{"cmd"}
+	default:
+		names = []string{sh.Name()}
+	}
+
+	if shellPath := os.Getenv("SHELL"); shellPath != "" {
+		base := strings.TrimSuffix(filepath.Base(shellPath), filepath.Ext(shellPath))
+		if slices.Contains(names, base) {
+			return shellPath, nil
+		}
+	}
+
+	for _, name := range names {
+		if path, err := execLookPath(name); err == nil {
+			return path, nil
+		}
+	}
+	return "", fmt.Errorf("%s is not installed or not on PATH", sh.DisplayName())
+}
+
 // isCommandAvailable reports whether a command exists in the system PATH.
 func isCommandAvailable(command string) bool {
 	_, err := execLookPath(command)
@@ -256,18 +443,23 @@ func (s *BashShell) SetupCommands(binPath string) []string {
 	escapedPath := escapeBashPath(binPath)
 
 	commands := []string{
+		"# >>> govman >>>",
 		"# GOVMAN - Go Version Manager",
 		fmt.Sprintf(`export PATH="%s:$PATH"`, escapedPath),
 		"# Ensure GOBIN and GOPATH/bin are available",
 		`if [ -n "$GOBIN" ]; then export PATH="$GOBIN:$PATH"; fi`,
 		`if command -v go >/dev/null 2>&1; then export PATH="$(go env GOPATH)/bin:$PATH"; fi`,
 		`export PATH="$HOME/go/bin:$PATH"`,
+		"# Keep the version of a 'govman shell' subshell ahead of the default",
+		`if [ -n "$GOVMAN_SHELL_VERSION" ] && [ -n "$GOROOT" ]; then export PATH="$GOROOT/bin:$PATH"; fi`,
+		"# Identify this terminal to 'govman use --temp'; a new shell starts without a session version",
+		fmt.Sprintf(`export GOVMAN_SESSION_PID=$$; rm -f "%s$$"`, escapeBashPath(sessionFilePrefix(binPath))),
 		"export GOTOOLCHAIN=local",
 		"",
 		"# Wrapper function for automatic PATH execution",
 		"govman() {",
 		fmt.Sprintf(`    local govman_bin="%s/govman"`, escapedPath),
-		`    if [[ ("$1" == "use" && "$#" -ge 2 && "$2" != "--help" && "$2" != "-h") || "$1" == "refresh" ]]; then`,
+		`    if [[ ("$1" == "use" && "$2" != "--help" && "$2" != "-h") || ("$1" == "local" && "$#" -ge 2 && "$2" != -*) || "$1" == "refresh" ]]; then`,
 		"        local output",
 		`        output="$("$govman_bin" "$@" 2>&1)"`,
 		"        local exit_code=$?",
@@ -278,6 +470,8 @@ func (s *BashShell) SetupCommands(binPath string) []string {
 		`                echo "✓ Go version switched successfully"`,
 		"                return 0",
 		"            fi",
+		`            printf '%s\n' "$output"`,
+		"            return 0",
 		"        else",
 		`            echo "$output" >&2`,
 		"            return $exit_code",
@@ -289,7 +483,7 @@ func (s *BashShell) SetupCommands(binPath string) []string {
 		"# Auto-switch Go versions based on .govman-goversion file",
 		"govman_auto_switch() {",
 		"    # Check if auto-switch is enabled in config",
-		`    local config_file="$HOME/.govman/config.yaml"`,
+		fmt.Sprintf(`    local config_file="%s"`, escapeBashPath(hookConfigFile())),
 		`    local auto_switch_enabled="true"`,
 		`    if [[ -f "$config_file" ]]; then`,
 		`        auto_switch_enabled=$(awk '/^auto_switch:/,/^[^ ]/ {if (/^[[:space:]]*enabled:/) {print $2; exit}}' "$config_file" 2>/dev/null | tr -d '[:space:]')`,
@@ -298,6 +492,10 @@ func (s *BashShell) SetupCommands(binPath string) []string {
 		`    if [[ "$auto_switch_enabled" != "true" ]]; then`,
 		"        return 0",
 		"    fi",
+		"    # A 'govman shell' subshell keeps its version until it exits",
+		`    [[ -n "$GOVMAN_SHELL_VERSION" ]] && return 0`,
+		"    # So does a version chosen with 'govman use --temp' in this terminal",
+		fmt.Sprintf(`    [[ -n "$GOVMAN_SESSION_PID" && -f "%s$GOVMAN_SESSION_PID" ]] && return 0`, escapeBashPath(sessionFilePrefix(binPath))),
 		"",
 		"    # Check file exists and is non-empty (-s), handle permission errors",
 		"    if [[ -s .govman-goversion ]]; then",
@@ -361,10 +559,10 @@ func (s *BashShell) SetupCommands(binPath string) []string {
 		"",
 		"# Run auto-switch on shell startup",
 		"govman_auto_switch",
-		"# END GOVMAN",
+		"# <<< govman <<<",
 	}
 
-	return commands
+	return withoutToolchain(commands)
 }
 
 // ExecutePathCommand outputs the PATH command for automatic execution via eval.
@@ -376,7 +574,7 @@ func (s *BashShell) ExecutePathCommand(path string) error {
 	pathCmd := s.PathCommand(path)
 
 	// Output the command for eval
-	fmt.Println(pathCmd)
+	printActivation(s, pathCmd)
 
 	// Instructions to stderr so they don't interfere with eval
 	fmt.Fprintf(os.Stderr, "# To apply to current session, run:\n")
@@ -420,18 +618,23 @@ func (s *ZshShell) SetupCommands(binPath string) []string {
 	escapedPath := escapeBashPath(binPath)
 
 	commands := []string{
+		"# >>> govman >>>",
 		"# GOVMAN - Go Version Manager",
 		fmt.Sprintf(`export PATH="%s:$PATH"`, escapedPath),
 		"# Ensure GOBIN and GOPATH/bin are available",
 		`if [ -n "$GOBIN" ]; then export PATH="$GOBIN:$PATH"; fi`,
 		`if command -v go >/dev/null 2>&1; then export PATH="$(go env GOPATH)/bin:$PATH"; fi`,
 		`export PATH="$HOME/go/bin:$PATH"`,
+		"# Keep the version of a 'govman shell' subshell ahead of the default",
+		`if [ -n "$GOVMAN_SHELL_VERSION" ] && [ -n "$GOROOT" ]; then export PATH="$GOROOT/bin:$PATH"; fi`,
+		"# Identify this terminal to 'govman use --temp'; a new shell starts without a session version",
+		fmt.Sprintf(`export GOVMAN_SESSION_PID=$$; rm -f "%s$$"`, escapeBashPath(sessionFilePrefix(binPath))),
 		"export GOTOOLCHAIN=local",
 		"",
 		"# Wrapper function for automatic PATH execution",
 		"govman() {",
 		fmt.Sprintf(`    local govman_bin="%s/govman"`, escapedPath),
-		`    if [[ ("$1" == "use" && "$#" -ge 2 && "$2" != "--help" && "$2" != "-h") || "$1" == "refresh" ]]; then`,
+		`    if [[ ("$1" == "use" && "$2" != "--help" && "$2" != "-h") || ("$1" == "local" && "$#" -ge 2 && "$2" != -*) || "$1" == "refresh" ]]; then`,
 		"        local output",
 		`        output="$("$govman_bin" "$@" 2>&1)"`,
 		"        local exit_code=$?",
@@ -442,6 +645,8 @@ func (s *ZshShell) SetupCommands(binPath string) []string {
 		`                echo "✓ Go version switched successfully"`,
 		"                return 0",
 		"            fi",
+		`            printf '%s\n' "$output"`,
+		"            return 0",
 		"        else",
 		`            echo "$output" >&2`,
 		"            return $exit_code",
@@ -453,7 +658,7 @@ func (s *ZshShell) SetupCommands(binPath string) []string {
 		"# Auto-switch Go versions based on .govman-goversion file",
 		"govman_auto_switch() {",
 		"    # Check if auto-switch is enabled in config",
-		`    local config_file="$HOME/.govman/config.yaml"`,
+		fmt.Sprintf(`    local config_file="%s"`, escapeBashPath(hookConfigFile())),
 		`    local auto_switch_enabled="true"`,
 		`    if [[ -f "$config_file" ]]; then`,
 		`        auto_switch_enabled=$(awk '/^auto_switch:/,/^[^ ]/ {if (/^[[:space:]]*enabled:/) {print $2; exit}}' "$config_file" 2>/dev/null | tr -d '[:space:]')`,
@@ -462,6 +667,10 @@ func (s *ZshShell) SetupCommands(binPath string) []string {
 		`    if [[ "$auto_switch_enabled" != "true" ]]; then`,
 		"        return 0",
 		"    fi",
+		"    # A 'govman shell' subshell keeps its version until it exits",
+		`    [[ -n "$GOVMAN_SHELL_VERSION" ]] && return 0`,
+		"    # So does a version chosen with 'govman use --temp' in this terminal",
+		fmt.Sprintf(`    [[ -n "$GOVMAN_SESSION_PID" && -f "%s$GOVMAN_SESSION_PID" ]] && return 0`, escapeBashPath(sessionFilePrefix(binPath))),
 		"",
 		"    # Check file exists and is non-empty (-s), handle permission errors",
 		"    if [[ -s .govman-goversion ]]; then",
@@ -513,10 +722,10 @@ func (s *ZshShell) SetupCommands(binPath string) []string {
 		"",
 		"# Run auto-switch on shell startup",
 		"govman_auto_switch",
-		"# END GOVMAN",
+		"# <<< govman <<<",
 	}
 
-	return commands
+	return withoutToolchain(commands)
 }
 
 // ExecutePathCommand outputs the PATH command for automatic execution via eval.
@@ -526,7 +735,7 @@ func (s *ZshShell) ExecutePathCommand(path string) error {
 	}
 
 	pathCmd := s.PathCommand(path)
-	fmt.Println(pathCmd)
+	printActivation(s, pathCmd)
 
 	fmt.Fprintf(os.Stderr, "# To apply to current session, run:\n")
 	fmt.Fprintf(os.Stderr, "# eval \"$(govman use <version>)\"\n")
@@ -558,10 +767,11 @@ func (s *FishShell) ConfigFile() string {
 	return filepath.Join(home, ".config", "fish", "config.fish")
 }
 
-// PathCommand returns a Fish-compatible command to prepend binPath to PATH.
+// PathCommand returns a Fish-compatible command to prepend binPath to PATH for this session.
+// --global avoids persisting the entry in fish_user_paths; --move brings an existing entry back to the front.
 func (s *FishShell) PathCommand(path string) string {
 	escapedPath := escapeFishPath(path)
-	return fmt.Sprintf(`fish_add_path -p "%s"`, escapedPath)
+	return fmt.Sprintf(`fish_add_path --global --move --prepend "%s"`, escapedPath)
 }
 
 // SetupCommands returns the Fish configuration lines to integrate govman.
@@ -569,6 +779,7 @@ func (s *FishShell) SetupCommands(binPath string) []string {
 	escapedPath := escapeFishPath(binPath)
 
 	commands := []string{
+		"# >>> govman >>>",
 		"# GOVMAN - Go Version Manager",
 		fmt.Sprintf(`fish_add_path -p "%s"`, escapedPath),
 		"set -gx GOTOOLCHAIN local",
@@ -577,11 +788,15 @@ func (s *FishShell) SetupCommands(binPath string) []string {
 		`if test -n "$GOBIN"; and test -d "$GOBIN"; fish_add_path -p "$GOBIN"; end`,
 		`if type -q go; set -l gopath (go env GOPATH 2>/dev/null); if test -n "$gopath"; and test -d "$gopath/bin"; fish_add_path -p "$gopath/bin"; end; end`,
 		`set -l homegobin "$HOME/go/bin"; if test -d "$homegobin"; fish_add_path -p "$homegobin"; end`,
+		"# Keep the version of a 'govman shell' subshell ahead of the default",
+		`if set -q GOVMAN_SHELL_VERSION; and set -q GOROOT; fish_add_path -gmp "$GOROOT/bin"; end`,
+		"# Identify this terminal to 'govman use --temp'; a new shell starts without a session version",
+		fmt.Sprintf(`set -gx GOVMAN_SESSION_PID $fish_pid; rm -f "%s$fish_pid"`, escapeFishPath(sessionFilePrefix(binPath))),
 		"",
 		"# Wrapper function for automatic PATH execution",
 		"function govman",
 		fmt.Sprintf(`    set govman_bin "%s/govman"`, escapedPath),
-		`    if test "$argv[1]" = "refresh"; or begin; test "$argv[1]" = "use"; and test (count $argv) -ge 2; and test "$argv[2]" != "--help"; and test "$argv[2]" != "-h"; end`,
+		`    if test "$argv[1]" = "refresh"; or begin; test "$argv[1]" = "use"; and test "$argv[2]" != "--help"; and test "$argv[2]" != "-h"; end; or begin; test "$argv[1]" = "local"; and test (count $argv) -ge 2; and not string match -q -- '-*' $argv[2]; end`,
 		"        set output ($govman_bin $argv 2>&1)",
 		"        set exit_code $status",
 		"        if test $exit_code -eq 0",
@@ -592,6 +807,8 @@ func (s *FishShell) SetupCommands(binPath string) []string {
 		"                    return 0",
 		"                end",
 		"            end",
+		"            printf '%s\n' $output",
+		"            return 0",
 		"        else",
 		"            for line in $output",
 		"                echo $line >&2",
@@ -604,7 +821,7 @@ func (s *FishShell) SetupCommands(binPath string) []string {
 		"",
 		"# Auto-switch Go versions based on .govman-goversion file",
 		"function govman_auto_switch",
-		`    set config_file "$HOME/.govman/config.yaml"`,
+		fmt.Sprintf(`    set config_file "%s"`, escapeFishPath(hookConfigFile())),
 		`    set auto_switch_enabled "true"`,
 		`    if test -f "$config_file"`,
 		`        set auto_switch_enabled (awk '/^auto_switch:/,/^[^ ]/ {if (/^[[:space:]]*enabled:/) {print $2; exit}}' "$config_file" 2>/dev/null | tr -d '[:space:]')`,
@@ -613,6 +830,10 @@ func (s *FishShell) SetupCommands(binPath string) []string {
 		`    if test "$auto_switch_enabled" != "true"`,
 		"        return 0",
 		"    end",
+		"    # A 'govman shell' subshell keeps its version until it exits",
+		"    set -q GOVMAN_SHELL_VERSION; and return 0",
+		"    # So does a version chosen with 'govman use --temp' in this terminal",
+		fmt.Sprintf(`    test -n "$GOVMAN_SESSION_PID"; and test -f "%s$GOVMAN_SESSION_PID"; and return 0`, escapeFishPath(sessionFilePrefix(binPath))),
 		"",
 		"    # Check file exists and is non-empty (-s), handle permission/empty errors",
 		"    if test -s .govman-goversion",
@@ -662,10 +883,10 @@ func (s *FishShell) SetupCommands(binPath string) []string {
 		"",
 		"# Run auto-switch on shell startup",
 		"govman_auto_switch",
-		"# END GOVMAN",
+		"# <<< govman <<<",
 	}
 
-	return commands
+	return withoutToolchain(commands)
 }
 
 // ExecutePathCommand outputs the PATH command for automatic execution via eval.
@@ -675,7 +896,7 @@ func (s *FishShell) ExecutePathCommand(path string) error {
 	}
 
 	pathCmd := s.PathCommand(path)
-	fmt.Println(pathCmd)
+	printActivation(s, pathCmd)
 
 	fmt.Fprintf(os.Stderr, "# To apply to current session, run:\n")
 	fmt.Fprintf(os.Stderr, "# eval (govman use <version>)\n")
@@ -683,6 +904,147 @@ func (s *FishShell) ExecutePathCommand(path string) error {
 	return nil
 }
 
+// ==================== NUSHELL ====================
+func (s *NushellShell) Name() string {
+	return "nushell"
+}
+
+// DisplayName returns the human-friendly name for Nushell.
+func (s *NushellShell) DisplayName() string {
+	return "Nushell"
+}
+
+// IsAvailable reports whether Nushell is present in the system PATH.
+func (s *NushellShell) IsAvailable() bool {
+	return isCommandAvailable("nu")
+}
+
+// ConfigFile returns the path to the Nushell configuration file.
+func (s *NushellShell) ConfigFile() string {
+	home, err := userHomeDir()
+	if err != nil {
+		return "config.nu"
+	}
+	return filepath.Join(home, ".config", "nushell", "config.nu")
+}
+
+// PathCommand returns a Nushell command to prepend binPath to PATH.
+func (s *NushellShell) PathCommand(path string) string {
+	return fmt.Sprintf(`$env.PATH = ($env.PATH | prepend %s)`, quoteNushellPath(path))
+}
+
+// SetupCommands returns the Nushell configuration lines to integrate govman.
+// Nushell has no eval, so the wrapper parses the directory out of PathCommand's output instead.
+func (s *NushellShell) SetupCommands(binPath string) []string {
+	quotedPath := quoteNushellPath(binPath)
+	quotedBin := quoteNushellPath(filepath.Join(binPath, "govman"))
+
+	commands := []string{
+		"# >>> govman >>>",
+		"# GOVMAN - Go Version Manager",
+		fmt.Sprintf(`$env.PATH = ($env.PATH | prepend %s)`, quotedPath),
+		`$env.GOTOOLCHAIN = "local"`,
+		"",
+		"# Ensure GOBIN and GOPATH/bin are available",
+		`if ($env.GOBIN? | default "" | path exists) { $env.PATH = ($env.PATH | prepend $env.GOBIN) }`,
+		`if (which go | is-not-empty) { let gopath_bin = (^go env GOPATH | str trim | path join "bin"); if ($gopath_bin | path exists) { $env.PATH = ($env.PATH | prepend $gopath_bin) } }`,
+		`if ($env.HOME | path join "go" "bin" | path exists) { $env.PATH = ($env.PATH | prepend ($env.HOME | path join "go" "bin")) }`,
+		"# Keep the version of a 'govman shell' subshell ahead of the default",
+		`if ($env.GOVMAN_SHELL_VERSION? | is-not-empty) and ($env.GOROOT? | is-not-empty) { $env.PATH = ($env.PATH | prepend ($env.GOROOT | path join "bin")) }`,
+		"# Identify this terminal to 'govman use --temp'; a new shell starts without a session version",
+		fmt.Sprintf(`$env.GOVMAN_SESSION_PID = ($nu.pid | into string); rm -f (%s + $env.GOVMAN_SESSION_PID)`, quoteNushellPath(sessionFilePrefix(binPath))),
+		"",
+		"# Wrapper command for automatic PATH execution",
+		"def --env --wrapped govman [...args] {",
+		fmt.Sprintf(`    let govman_bin = %s`, quotedBin),
+		`    let count = ($args | length)`,
+		`    let switching = (($count >= 1 and $args.0 == "refresh") or ($count >= 1 and $args.0 == "use" and $args.1? not-in ["--help", "-h"]) or ($count >= 2 and $args.0 == "local" and not ($args.1 | str starts-with "-")))`,
+		"    if not $switching {",
+		"        ^$govman_bin ...$args",
+		"        return",
+		"    }",
+		"    let result = (do { ^$govman_bin ...$args } | complete)",
+		"    let output = ($result.stdout + $result.stderr)",
+		"    if $result.exit_code != 0 {",
+		"        print -e $output",
+		"        return",
+		"    }",
+		`    let dirs = ($output | lines | parse --regex r##'^\$env\.PATH = \(\$env\.PATH \| prepend r#+'(?<dir>.*)'#+\)$'##)`,
+		"    if ($dirs | is-empty) {",
+		"        print $output",
+		"        return",
+		"    }",
+		"    $env.PATH = ($env.PATH | prepend ($dirs | last | get dir))",
+		`    print "✓ Go version switched successfully"`,
+		"}",
+		"",
+		"# Auto-switch Go versions based on .govman-goversion file",
+		"def --env govman_auto_switch [] {",
+		fmt.Sprintf(`    let config_file = %s`, quoteNushellPath(hookConfigFile())),
+		`    if ($config_file | path exists) and (((open $config_file).auto_switch?.enabled? | default true) != true) {`,
+		"        return",
+		"    }",
+		"    # A 'govman shell' subshell keeps its version until it exits",
+		"    if ($env.GOVMAN_SHELL_VERSION? | is-not-empty) {",
+		"        return",
+		"    }",
+		"    # So does a version chosen with 'govman use --temp' in this terminal",
+		fmt.Sprintf(`    if ($env.GOVMAN_SESSION_PID? | is-not-empty) and ((%s + $env.GOVMAN_SESSION_PID) | path exists) {`, quoteNushellPath(sessionFilePrefix(binPath))),
+		"        return",
+		"    }",
+		"",
+		`    if not (".govman-goversion" | path exists) {`,
+		"        return",
+		"    }",
+		"    let required_version = (open --raw .govman-goversion | str trim)",
+		"    if ($required_version | is-empty) {",
+		"        return",
+		"    }",
+		"",
+		"    # Validate version format (e.g., 1.25, 1.25.1, 1.25rc1)",
+		`    if not ($required_version =~ '^[0-9]+\.[0-9]+(\.?[0-9]*)(-?(rc|beta|alpha)[0-9]*)?$') {`,
+		`        print -e $"Warning: Invalid version format in .govman-goversion: ($required_version)"`,
+		"        return",
+		"    }",
+		"",
+		"    # Skip go version call if we already matched this version",
+		"    if ($env.__GOVMAN_LAST_VERSION? == $required_version) {",
+		"        return",
+		"    }",
+		"",
+		`    let current_version = if (which go | is-empty) { "" } else { ^go version | parse --regex 'go(?<v>[0-9]+\.[0-9]+(\.[0-9]+)?)' | get 0?.v? | default "" }`,
+		"    if $current_version != $required_version {",
+		`        print $"Auto-switching to Go ($required_version) \(required by .govman-goversion\)"`,
+		"        govman use $required_version",
+		"    }",
+		"    $env.__GOVMAN_LAST_VERSION = $required_version",
+		"}",
+		"",
+		"# Nushell-specific: Hook into directory changes",
+		"$env.config.hooks.env_change.PWD = ($env.config.hooks.env_change.PWD? | default [] | append {|before, after| govman_auto_switch })",
+		"",
+		"# Run auto-switch on shell startup",
+		"govman_auto_switch",
+		"# <<< govman <<<",
+	}
+
+	return withoutToolchain(commands)
+}
+
+// ExecutePathCommand outputs the PATH command for the govman wrapper to apply.
+func (s *NushellShell) ExecutePathCommand(path string) error {
+	if err := validateBinPath(path); err != nil {
+		return err
+	}
+
+	pathCmd := s.PathCommand(path)
+	fmt.Println(pathCmd)
+
+	fmt.Fprintf(os.Stderr, "# To apply to current session, run 'govman init --shell nushell' so the govman command applies it\n")
+
+	return nil
+}
+
 // ==================== POWER SHELL ====================
 func (s *PowerShell) Name() string {
 	return "powershell"
@@ -727,6 +1089,7 @@ func (s *PowerShell) SetupCommands(binPath string) []string {
 	escapedPath := escapePowerShellPath(binPath)
 
 	commands := []string{
+		"# >>> govman >>>",
 		"# GOVMAN - Go Version Manager",
 		fmt.Sprintf(`$env:PATH = "%s;" + $env:PATH`, escapedPath),
 		"$env:GOTOOLCHAIN = 'local'",
@@ -735,11 +1098,15 @@ func (s *PowerShell) SetupCommands(binPath string) []string {
 		`if ($env:GOBIN) { $env:PATH = "$env:GOBIN;" + $env:PATH }`,
 		`$goCmd = Get-Command go -ErrorAction SilentlyContinue; if ($goCmd) { $gopath = (& go env GOPATH 2>$null); if ($gopath) { $env:PATH = "$gopath\bin;" + $env:PATH } }`,
 		`$homeGoBin = Join-Path $env:USERPROFILE "go\bin"; if (Test-Path $homeGoBin) { $env:PATH = "$homeGoBin;" + $env:PATH }`,
+		"# Keep the version of a 'govman shell' subshell ahead of the default",
+		`if ($env:GOVMAN_SHELL_VERSION -and $env:GOROOT) { $env:PATH = "$env:GOROOT\bin;" + $env:PATH }`,
+		"# Identify this terminal to 'govman use --temp'; a new shell starts without a session version",
+		fmt.Sprintf(`$env:GOVMAN_SESSION_PID = "$PID"; Remove-Item -LiteralPath ("%s" + $PID) -ErrorAction SilentlyContinue`, escapePowerShellPath(sessionFilePrefix(binPath))),
 		"",
 		"# Wrapper function for automatic PATH execution",
 		"function govman {",
 		fmt.Sprintf(`    $govman_bin = "%s\govman.exe"`, escapedPath),
-		"    if (($args[0] -eq 'refresh') -or ($args.Count -ge 2 -and $args[0] -eq 'use' -and $args[1] -ne '--help' -and $args[1] -ne '-h')) {",
+		"    if (($args[0] -eq 'refresh') -or ($args[0] -eq 'use' -and $args[1] -ne '--help' -and $args[1] -ne '-h') -or ($args.Count -ge 2 -and $args[0] -eq 'local' -and -not ([string]$args[1]).StartsWith('-'))) {",
 		"        try {",
 		"            $output = & $govman_bin @args 2>&1",
 		"            if ($LASTEXITCODE -eq 0) {",
@@ -749,6 +1116,8 @@ func (s *PowerShell) SetupCommands(binPath string) []string {
 		"                    Write-Host '✓ Go version switched successfully' -ForegroundColor Green",
 		"                    return",
 		"                }",
+		"                $output | ForEach-Object { Write-Host $_ }",
+		"                return",
 		"            } else {",
 		"                $output | ForEach-Object { Write-Error $_ }",
 		"                return",
@@ -763,7 +1132,15 @@ func (s *PowerShell) SetupCommands(binPath string) []string {
 		"",
 		"# Auto-switch Go versions based on .govman-goversion file",
 		"function Invoke-GovmanAutoSwitch {",
-		"    $configFile = \"$env:USERPROFILE\\.govman\\config.yaml\"",
+		"    # A 'govman shell' subshell keeps its version until it exits",
+		"    if ($env:GOVMAN_SHELL_VERSION) {",
+		"        return",
+		"    }",
+		"    # So does a version chosen with 'govman use --temp' in this terminal",
+		fmt.Sprintf(`    if ($env:GOVMAN_SESSION_PID -and (Test-Path -LiteralPath ("%s" + $env:GOVMAN_SESSION_PID))) {`, escapePowerShellPath(sessionFilePrefix(binPath))),
+		"        return",
+		"    }",
+		fmt.Sprintf("    $configFile = \"%s\"", escapePowerShellPath(hookConfigFile())),
 		"    if (Test-Path $configFile) {",
 		"        try {",
 		"            $autoSwitchEnabled = $true",
@@ -856,10 +1233,10 @@ func (s *PowerShell) SetupCommands(binPath string) []string {
 		"",
 		"# Run auto-switch on shell startup",
 		"Invoke-GovmanAutoSwitch",
-		"# END GOVMAN",
+		"# <<< govman <<<",
 	}
 
-	return commands
+	return withoutToolchain(commands)
 }
 
 // ExecutePathCommand outputs the PATH command for automatic execution.
@@ -869,7 +1246,7 @@ func (s *PowerShell) ExecutePathCommand(path string) error {
 	}
 
 	pathCmd := s.PathCommand(path)
-	fmt.Println(pathCmd)
+	printActivation(s, pathCmd)
 
 	fmt.Fprintf(os.Stderr, "# To apply to current session, run:\n")
 	fmt.Fprintf(os.Stderr, "# govman use <version> | Invoke-Expression\n")
@@ -909,6 +1286,7 @@ func (s *CmdShell) SetupCommands(binPath string) []string {
 
 	commands := []string{
 		"@echo off",
+		"REM >>> govman >>>",
 		"REM GOVMAN - Go Version Manager",
 		fmt.Sprintf(`set "PATH=%s;%%PATH%%"`, escapedPath),
 		"set GOTOOLCHAIN=local",
@@ -926,13 +1304,16 @@ func (s *CmdShell) SetupCommands(binPath string) []string {
 		"REM Add Go's default bin directory",
 		`if exist "%USERPROFILE%\go\bin" set "PATH=%USERPROFILE%\go\bin;%PATH%"`,
 		"",
+		"REM Keep the version of a 'govman shell' subshell ahead of the default",
+		`if defined GOVMAN_SHELL_VERSION if defined GOROOT set "PATH=%GOROOT%\bin;%PATH%"`,
+		"",
 		"REM Note: Auto-switching (.govman-goversion) is not available in Command Prompt",
 		"REM Use 'govman use <version>' to switch versions manually",
 		"",
-		"REM END GOVMAN",
+		"REM <<< govman <<<",
 	}
 
-	return commands
+	return withoutToolchain(commands)
 }
 
 // ExecutePathCommand outputs the PATH command for Command Prompt.
@@ -942,7 +1323,7 @@ func (s *CmdShell) ExecutePathCommand(path string) error {
 	}
 
 	pathCmd := s.PathCommand(path)
-	fmt.Println(pathCmd)
+	printActivation(s, pathCmd)
 
 	fmt.Fprintln(os.Stderr, "REM To apply to current session, copy and run:")
 	fmt.Fprintf(os.Stderr, "REM %s\n", pathCmd)
@@ -997,24 +1378,24 @@ func initializeUnixShell(shell Shell, binPath string, force bool) error {
 		if !force {
 			return fmt.Errorf("govman is already configured in %s (use --force to override)", configFile)
 		}
-		existingContent = removeExistingConfig(existingContent)
+		cleaned, _, err := removeExistingConfig(existingContent)
+		if err != nil {
+			return fmt.Errorf("%s: %w", configFile, err)
+		}
+		existingContent = cleaned
 	}
 
-	// Prepare new configuration
-	setupCommands := shell.SetupCommands(binPath)
-	newConfig := "\n" + strings.Join(setupCommands, "\n") + "\n"
-
-	// Combine content
-	finalContent := strings.TrimSpace(existingContent) + newConfig
+	// Append the new block, leaving the rest of the file untouched
+	finalContent := appendConfig(existingContent, shell.SetupCommands(binPath), "\n")
 
 	// Write to file with proper permissions
 	if err := os.WriteFile(configFile, []byte(finalContent), 0644); err != nil {
 		return fmt.Errorf("failed to write config to %s: %w", configFile, err)
 	}
 
-	fmt.Printf("✅ Successfully configured %s\n", shell.DisplayName())
-	fmt.Printf("📝 Configuration added to: %s\n", configFile)
-	fmt.Printf("🔄 Reload your shell or run: source %s\n", configFile)
+	fmt.Fprintf(os.Stderr, "✅ Successfully configured %s\n", shell.DisplayName())
+	fmt.Fprintf(os.Stderr, "📝 Configuration added to: %s\n", configFile)
+	fmt.Fprintf(os.Stderr, "🔄 Reload your shell or run: source %s\n", configFile)
 
 	return nil
 }
@@ -1049,24 +1430,24 @@ func initializePowerShell(shell Shell, binPath string, force bool) error {
 		if !force {
 			return fmt.Errorf("govman is already configured in PowerShell profile (use --force to override)")
 		}
-		existingContent = removeExistingConfig(existingContent)
+		cleaned, _, err := removeExistingConfig(existingContent)
+		if err != nil {
+			return fmt.Errorf("%s: %w", profilePath, err)
+		}
+		existingContent = cleaned
 	}
 
-	// Prepare new configuration
-	setupCommands := shell.SetupCommands(binPath)
-	newConfig := "\r\n" + strings.Join(setupCommands, "\r\n") + "\r\n"
-
-	// Combine content
-	finalContent := strings.TrimSpace(existingContent) + newConfig
+	// Append the new block, leaving the rest of the profile untouched
+	finalContent := appendConfig(existingContent, shell.SetupCommands(binPath), "\r\n")
 
 	// Write to file
 	if err := os.WriteFile(profilePath, []byte(finalContent), 0644); err != nil {
 		return fmt.Errorf("failed to write PowerShell profile: %w", err)
 	}
 
-	fmt.Printf("✅ Successfully configured PowerShell\n")
-	fmt.Printf("📝 Configuration added to: %s\n", profilePath)
-	fmt.Printf("🔄 Reload PowerShell or run: . $PROFILE\n")
+	fmt.Fprintf(os.Stderr, "✅ Successfully configured PowerShell\n")
+	fmt.Fprintf(os.Stderr, "📝 Configuration added to: %s\n", profilePath)
+	fmt.Fprintf(os.Stderr, "🔄 Reload PowerShell or run: . $PROFILE\n")
 
 	return nil
 }
@@ -1172,44 +1553,44 @@ exit /b %errorlevel%
 	}
 
 	// Print setup instructions (inline, no separate function)
-	fmt.Printf("✅ Created govman wrapper: %s\n\n", wrapperPath)
-	fmt.Println("📝 SETUP INSTRUCTIONS")
-	fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
-	fmt.Println()
-	fmt.Println("Step 1: Add govman to your PATH")
-	fmt.Println()
-	fmt.Println("   Option A - Permanent (Recommended):")
-	fmt.Printf("   setx PATH \"%%PATH%%;%s\"\n", binPath)
-	fmt.Println()
-	fmt.Println("   Option B - Current session only:")
-	fmt.Printf("   set PATH=%%PATH%%;%s\n", binPath)
-	fmt.Println()
-	fmt.Println("Step 2: Restart Command Prompt (if using Option A)")
-	fmt.Println()
-	fmt.Println("Step 3: Verify installation")
-	fmt.Println("   govman --version")
-	fmt.Println()
-	fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
-	fmt.Println()
-	fmt.Println("⚠️  COMMAND PROMPT LIMITATIONS")
-	fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
-	fmt.Println("• No automatic version switching (.govman-goversion not supported)")
-	fmt.Println("• Must manually run 'govman use <version>' in each session")
-	fmt.Println("• PATH changes only affect current Command Prompt window")
-	fmt.Println()
-	fmt.Println("💡 FOR BETTER EXPERIENCE")
-	fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
-	fmt.Println("Consider using one of these shells for auto-switching:")
-	fmt.Println()
-	fmt.Println("• PowerShell (Recommended for Windows):")
-	fmt.Println("  powershell -Command \"govman init\"")
-	fmt.Println()
-	fmt.Println("• Git Bash (if installed):")
-	fmt.Println("  bash -c 'govman init'")
-	fmt.Println()
-	fmt.Println("• WSL (Windows Subsystem for Linux):")
-	fmt.Println("  wsl -e govman init")
-	fmt.Println()
+	fmt.Fprintf(os.Stderr, "✅ Created govman wrapper: %s\n\n", wrapperPath)
+	fmt.Fprintln(os.Stderr, "📝 SETUP INSTRUCTIONS")
+	fmt.Fprintln(os.Stderr, "━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+	fmt.Fprintln(os.Stderr)
+	fmt.Fprintln(os.Stderr, "Step 1: Add govman to your PATH")
+	fmt.Fprintln(os.Stderr)
+	fmt.Fprintln(os.Stderr, "   Option A - Permanent (Recommended):")
+	fmt.Fprintf(os.Stderr, "   setx PATH \"%%PATH%%;%s\"\n", binPath)
+	fmt.Fprintln(os.Stderr)
+	fmt.Fprintln(os.Stderr, "   Option B - Current session only:")
+	fmt.Fprintf(os.Stderr, "   set PATH=%%PATH%%;%s\n", binPath)
+	fmt.Fprintln(os.Stderr)
+	fmt.Fprintln(os.Stderr, "Step 2: Restart Command Prompt (if using Option A)")
+	fmt.Fprintln(os.Stderr)
+	fmt.Fprintln(os.Stderr, "Step 3: Verify installation")
+	fmt.Fprintln(os.Stderr, "   govman --version")
+	fmt.Fprintln(os.Stderr)
+	fmt.Fprintln(os.Stderr, "━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+	fmt.Fprintln(os.Stderr)
+	fmt.Fprintln(os.Stderr, "⚠️  COMMAND PROMPT LIMITATIONS")
+	fmt.Fprintln(os.Stderr, "━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+	fmt.Fprintln(os.Stderr, "• No automatic version switching (.govman-goversion not supported)")
+	fmt.Fprintln(os.Stderr, "• Must manually run 'govman use <version>' in each session")
+	fmt.Fprintln(os.Stderr, "• PATH changes only affect current Command Prompt window")
+	fmt.Fprintln(os.Stderr)
+	fmt.Fprintln(os.Stderr, "💡 FOR BETTER EXPERIENCE")
+	fmt.Fprintln(os.Stderr, "━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+	fmt.Fprintln(os.Stderr, "Consider using one of these shells for auto-switching:")
+	fmt.Fprintln(os.Stderr)
+	fmt.Fprintln(os.Stderr, "• PowerShell (Recommended for Windows):")
+	fmt.Fprintln(os.Stderr, "  powershell -Command \"govman init\"")
+	fmt.Fprintln(os.Stderr)
+	fmt.Fprintln(os.Stderr, "• Git Bash (if installed):")
+	fmt.Fprintln(os.Stderr, "  bash -c 'govman init'")
+	fmt.Fprintln(os.Stderr)
+	fmt.Fprintln(os.Stderr, "• WSL (Windows Subsystem for Linux):")
+	fmt.Fprintln(os.Stderr, "  wsl -e govman init")
+	fmt.Fprintln(os.Stderr)
 
 	return nil
 }
@@ -1225,15 +1606,131 @@ func containsGovmanConfig(content string) bool {
 	return false
 }
 
-// removeExistingConfig removes existing govman configuration from content.
-func removeExistingConfig(content string) string {
-	// Use the pre-compiled regex for better performance
-	cleanedContent := configRemovalRegex.ReplaceAllString(content, "")
+// IsInitialized reports whether govman integration is present in the shell's configuration file.
+// Returns false with a nil error when the file does not exist.
+func IsInitialized(shell Shell) (bool, error) {
+	content, err := os.ReadFile(shell.ConfigFile())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	return containsGovmanConfig(string(content)), nil
+}
+
+// RemoveIntegration strips the govman block from the shell's configuration file, leaving the rest of the file as is.
+// Returns true if a block was removed, false with a nil error if the file has none or does not exist,
+// or an error if the file cannot be read or written or a block is missing its end sentinel.
+func RemoveIntegration(shell Shell) (bool, error) {
+	if shell.Name() == "cmd" {
+		return false, fmt.Errorf("%s has no configuration file", shell.DisplayName())
+	}
+
+	configFile := shell.ConfigFile()
+	info, err := os.Stat(configFile)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	content, err := os.ReadFile(configFile)
+	if err != nil {
+		return false, fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	cleaned, removed, err := removeExistingConfig(string(content))
+	if err != nil {
+		return false, fmt.Errorf("%s: %w", configFile, err)
+	}
+	if !removed {
+		return false, nil
+	}
+
+	if err := os.WriteFile(configFile, []byte(cleaned), info.Mode().Perm()); err != nil {
+		return false, fmt.Errorf("failed to write config to %s: %w", configFile, err)
+	}
 
-	// Clean up excessive newlines
-	cleanedContent = newlineRegex.ReplaceAllString(cleanedContent, "\n\n")
+	return true, nil
+}
+
+// removeExistingConfig strips every current or legacy govman block from content, keeping other lines byte for byte.
+// Returns the new content, whether a block was removed, and an error if a block has no end sentinel.
+func removeExistingConfig(content string) (string, bool, error) {
+	lines := strings.SplitAfter(content, "\n")
+	kept := make([]string, 0, len(lines))
+	removed := false
+
+	for i := 0; i < len(lines); i++ {
+		if !isSentinel(lines[i], blockStart, legacyBlockStart) {
+			kept = append(kept, lines[i])
+			continue
+		}
+
+		end := -1
+		for j := i + 1; j < len(lines); j++ {
+			if isSentinel(lines[j], blockEnd, legacyBlockEnd) {
+				end = j
+				break
+			}
+		}
+		if end < 0 {
+			return content, false, fmt.Errorf("govman block starting on line %d has no end marker", i+1)
+		}
+
+		// Drop the separator written before a trailing block; blank lines elsewhere belong to the user
+		if isBlankLines(lines[end+1:]) && len(kept) > 0 && isBlankLines(kept[len(kept)-1:]) {
+			kept = kept[:len(kept)-1]
+		}
+
+		removed = true
+		i = end
+	}
+
+	return strings.Join(kept, ""), removed, nil
+}
 
-	return strings.TrimSpace(cleanedContent)
+// isSentinel reports whether line is a comment (# or REM) whose text is exactly one of the given markers.
+func isSentinel(line string, markers ...string) bool {
+	text := strings.TrimSpace(line)
+	switch {
+	case strings.HasPrefix(text, "#"):
+		text = strings.TrimSpace(strings.TrimLeft(text, "#"))
+	case len(text) >= 3 && strings.EqualFold(text[:3], "REM"):
+		text = strings.TrimSpace(text[3:])
+	default:
+		return false
+	}
+	return slices.Contains(markers, text)
+}
+
+// isBlankLines reports whether every line is empty or whitespace.
+func isBlankLines(lines []string) bool {
+	for _, line := range lines {
+		if strings.TrimSpace(line) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// appendConfig adds block to the end of content, separated by a blank line, without altering the existing content.
+// newline is the line ending to use. Returns the combined content.
+func appendConfig(content string, block []string, newline string) string {
+	var sb strings.Builder
+	sb.WriteString(content)
+	if content != "" {
+		if !strings.HasSuffix(content, "\n") {
+			sb.WriteString(newline)
+		}
+		sb.WriteString(newline)
+	}
+	sb.WriteString(strings.Join(block, newline))
+	sb.WriteString(newline)
+	return sb.String()
 }
 
 // GetShellInstructions returns manual setup instructions for a shell.
@@ -1254,6 +1751,8 @@ func GetShellInstructions(shell Shell, binPath string) string {
 	switch shell.Name() {
 	case "fish":
 		instructions.WriteString("   source ~/.config/fish/config.fish\n")
+	case "nushell":
+		instructions.WriteString("   (Restart Nushell or run: exec nu)\n")
 	case "powershell":
 		instructions.WriteString("   . $PROFILE\n")
 	case "cmd":
@@ -6,6 +6,7 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"slices"
 	"strings"
 	"testing"
 )
@@ -15,6 +16,12 @@ func TestDetect(t *testing.T) {
 		name         string
 		goos         string
 		shellEnv     string
+		fishVersion  string
+		nuVersion    string
+		psModulePath string
+		comSpec      string
+		prompt       string
+		parent       string
 		mockCommands map[string]bool
 		expectedType interface{}
 	}{
@@ -36,6 +43,54 @@ func TestDetect(t *testing.T) {
 			mockCommands: map[string]bool{},
 			expectedType: &CmdShell{},
 		},
+		{
+			name:         "Windows PowerShell session",
+			goos:         "windows",
+			psModulePath: `C:\Users\me\Documents\WindowsPowerShell\Modules;C:\Program Files\WindowsPowerShell\Modules`,
+			comSpec:      `C:\Windows\system32\cmd.exe`,
+			mockCommands: map[string]bool{"powershell": true},
+			expectedType: &PowerShell{},
+		},
+		{
+			name:         "Windows PowerShell Core session",
+			goos:         "windows",
+			psModulePath: `C:\Users\me\Documents\PowerShell\Modules;C:\Program Files\PowerShell\7\Modules`,
+			comSpec:      `C:\Windows\system32\cmd.exe`,
+			mockCommands: map[string]bool{"pwsh": true},
+			expectedType: &PowerShell{},
+		},
+		{
+			name:         "Windows cmd session with pwsh installed",
+			goos:         "windows",
+			psModulePath: `C:\Program Files\WindowsPowerShell\Modules;C:\Windows\system32\WindowsPowerShell\v1.0\Modules`,
+			comSpec:      `C:\Windows\system32\cmd.exe`,
+			prompt:       "$P$G",
+			mockCommands: map[string]bool{"pwsh": true},
+			expectedType: &CmdShell{},
+		},
+		{
+			name:         "Windows cmd parent process",
+			goos:         "windows",
+			parent:       "CMD.EXE",
+			mockCommands: map[string]bool{"pwsh": true},
+			expectedType: &CmdShell{},
+		},
+		{
+			name:         "Windows ComSpec alone is not a cmd session",
+			goos:         "windows",
+			comSpec:      `C:\Windows\system32\cmd.exe`,
+			mockCommands: map[string]bool{"pwsh": true},
+			expectedType: &PowerShell{},
+		},
+		{
+			name:         "Windows parent process outweighs PROMPT",
+			goos:         "windows",
+			comSpec:      `C:\Windows\system32\cmd.exe`,
+			prompt:       "$P$G",
+			parent:       "WindowsTerminal.exe",
+			mockCommands: map[string]bool{"pwsh": true},
+			expectedType: &PowerShell{},
+		},
 		{
 			name:         "Unix with zsh in SHELL",
 			goos:         "linux",
@@ -50,6 +105,37 @@ func TestDetect(t *testing.T) {
 			mockCommands: map[string]bool{"fish": true},
 			expectedType: &FishShell{},
 		},
+		{
+			name:         "Unix with FISH_VERSION under bash login shell",
+			goos:         "linux",
+			shellEnv:     "/bin/bash",
+			fishVersion:  "3.7.1",
+			mockCommands: map[string]bool{"bash": true, "fish": true},
+			expectedType: &FishShell{},
+		},
+		{
+			name:         "Unix with FISH_VERSION but fish not available",
+			goos:         "linux",
+			shellEnv:     "/bin/bash",
+			fishVersion:  "3.7.1",
+			mockCommands: map[string]bool{"bash": true},
+			expectedType: &BashShell{},
+		},
+		{
+			name:         "Unix with nu in SHELL",
+			goos:         "linux",
+			shellEnv:     "/usr/bin/nu",
+			mockCommands: map[string]bool{"nu": true},
+			expectedType: &NushellShell{},
+		},
+		{
+			name:         "Unix with NU_VERSION under bash login shell",
+			goos:         "linux",
+			shellEnv:     "/bin/bash",
+			nuVersion:    "0.98.0",
+			mockCommands: map[string]bool{"bash": true, "nu": true},
+			expectedType: &NushellShell{},
+		},
 		{
 			name:         "Unix with bash in SHELL",
 			goos:         "linux",
@@ -85,6 +171,15 @@ func TestDetect(t *testing.T) {
 				defer func() { os.Setenv("SHELL", originalShell) }()
 				os.Setenv("SHELL", tc.shellEnv)
 			}
+			t.Setenv("FISH_VERSION", tc.fishVersion)
+			t.Setenv("NU_VERSION", tc.nuVersion)
+			t.Setenv("PSModulePath", tc.psModulePath)
+			t.Setenv("ComSpec", tc.comSpec)
+			t.Setenv("PROMPT", tc.prompt)
+
+			originalParent := parentProcess
+			defer func() { parentProcess = originalParent }()
+			parentProcess = func() string { return tc.parent }
 
 			// Mock exec.LookPath
 			originalLookPath := execLookPath
@@ -116,6 +211,10 @@ func TestDetect(t *testing.T) {
 				if _, ok := shell.(*FishShell); !ok {
 					t.Errorf("Expected FishShell, got %T", shell)
 				}
+			case *NushellShell:
+				if _, ok := shell.(*NushellShell); !ok {
+					t.Errorf("Expected NushellShell, got %T", shell)
+				}
 			case *PowerShell:
 				if _, ok := shell.(*PowerShell); !ok {
 					t.Errorf("Expected PowerShell, got %T", shell)
@@ -272,8 +371,8 @@ func TestBashShell(t *testing.T) {
 	if len(commands) == 0 {
 		t.Error("SetupCommands should return commands")
 	}
-	if !strings.Contains(commands[0], "GOVMAN - Go Version Manager") {
-		t.Error("SetupCommands should contain GOVMAN header")
+	if commands[0] != "# "+blockStart || commands[len(commands)-1] != "# "+blockEnd {
+		t.Error("SetupCommands should be wrapped in the govman sentinel comments")
 	}
 
 	// Test ExecutePathCommand
@@ -355,8 +454,8 @@ func TestZshShell(t *testing.T) {
 	if len(commands) == 0 {
 		t.Error("SetupCommands should return commands")
 	}
-	if !strings.Contains(commands[0], "GOVMAN - Go Version Manager") {
-		t.Error("SetupCommands should contain GOVMAN header")
+	if commands[0] != "# "+blockStart || commands[len(commands)-1] != "# "+blockEnd {
+		t.Error("SetupCommands should be wrapped in the govman sentinel comments")
 	}
 
 	// Test ExecutePathCommand
@@ -430,8 +529,8 @@ func TestFishShell(t *testing.T) {
 	}
 
 	// Test PathCommand
-	if shell.PathCommand("/usr/local/bin") != `fish_add_path -p "/usr/local/bin"` {
-		t.Errorf("PathCommand output incorrect")
+	if shell.PathCommand("/usr/local/bin") != `fish_add_path --global --move --prepend "/usr/local/bin"` {
+		t.Errorf("PathCommand output incorrect: %s", shell.PathCommand("/usr/local/bin"))
 	}
 
 	// Test SetupCommands
@@ -439,8 +538,8 @@ func TestFishShell(t *testing.T) {
 	if len(commands) == 0 {
 		t.Error("SetupCommands should return commands")
 	}
-	if !strings.Contains(commands[0], "GOVMAN - Go Version Manager") {
-		t.Error("SetupCommands should contain GOVMAN header")
+	if commands[0] != "# "+blockStart || commands[len(commands)-1] != "# "+blockEnd {
+		t.Error("SetupCommands should be wrapped in the govman sentinel comments")
 	}
 
 	// Test ExecutePathCommand
@@ -472,6 +571,157 @@ func TestFishShell(t *testing.T) {
 	os.Stderr = oldStderr
 }
 
+func TestFishShellSyntax(t *testing.T) {
+	shell := &FishShell{}
+	commands := strings.Join(shell.SetupCommands("/home/user/.govman/bin"), "\n")
+
+	// POSIX constructs that fish cannot parse
+	for _, posix := range []string{"export ", "$(", "then", "PROMPT_COMMAND"} {
+		if strings.Contains(commands, posix) {
+			t.Errorf("SetupCommands should not contain POSIX syntax %q", posix)
+		}
+	}
+
+	expected := []string{
+		`fish_add_path -p "/home/user/.govman/bin"`,
+		"set -gx GOTOOLCHAIN local",
+		"function govman",
+		"function govman_auto_switch",
+		"function __govman_cd_hook --on-variable PWD",
+		"# <<< govman <<<",
+	}
+	for _, want := range expected {
+		if !strings.Contains(commands, want) {
+			t.Errorf("SetupCommands should contain %q", want)
+		}
+	}
+
+	// The wrapper evals lines starting with fish_add_path, so PathCommand must produce one
+	if !strings.Contains(commands, "string match -qr '^fish_add_path'") {
+		t.Error("Wrapper should eval fish_add_path lines")
+	}
+	if !strings.HasPrefix(shell.PathCommand("/x"), "fish_add_path ") {
+		t.Errorf("PathCommand should start with fish_add_path, got %q", shell.PathCommand("/x"))
+	}
+
+	// Fish escaping of special characters
+	if got := shell.PathCommand(`/tmp/a"b$c`); got != `fish_add_path --global --move --prepend "/tmp/a\"b\$c"` {
+		t.Errorf("PathCommand did not escape path: %s", got)
+	}
+}
+
+func TestNushellShell(t *testing.T) {
+	shell := &NushellShell{}
+
+	// Test Name
+	if shell.Name() != "nushell" {
+		t.Errorf("Expected 'nushell', got %s", shell.Name())
+	}
+
+	// Test DisplayName
+	if shell.DisplayName() != "Nushell" {
+		t.Errorf("Expected 'Nushell', got %s", shell.DisplayName())
+	}
+
+	// Test IsAvailable
+	originalLookPath := execLookPath
+	defer func() { execLookPath = originalLookPath }()
+	execLookPath = func(cmd string) (string, error) {
+		if cmd == "nu" {
+			return "/usr/bin/nu", nil
+		}
+		return "", exec.ErrNotFound
+	}
+
+	if !shell.IsAvailable() {
+		t.Error("Expected nu to be available")
+	}
+
+	// Test ConfigFile
+	originalUserHomeDir := userHomeDir
+	defer func() { userHomeDir = originalUserHomeDir }()
+
+	testHome := t.TempDir()
+	userHomeDir = func() (string, error) {
+		return testHome, nil
+	}
+
+	expected := filepath.Join(testHome, ".config", "nushell", "config.nu")
+	if shell.ConfigFile() != expected {
+		t.Errorf("Expected %s, got %s", expected, shell.ConfigFile())
+	}
+
+	// Test PathCommand
+	pathCommands := map[string]string{
+		"/usr/local/bin":  `$env.PATH = ($env.PATH | prepend r#'/usr/local/bin'#)`,
+		`/tmp/a"b$c`:      `$env.PATH = ($env.PATH | prepend r#'/tmp/a"b$c'#)`,
+		`/tmp/it's'#odd`:  `$env.PATH = ($env.PATH | prepend r##'/tmp/it's'#odd'##)`,
+		`C:\Users\me\bin`: `$env.PATH = ($env.PATH | prepend r#'C:\Users\me\bin'#)`,
+	}
+	for path, want := range pathCommands {
+		if got := shell.PathCommand(path); got != want {
+			t.Errorf("PathCommand(%q) = %s, expected %s", path, got, want)
+		}
+	}
+
+	// Test SetupCommands
+	commands := strings.Join(shell.SetupCommands("/home/user/.govman/bin"), "\n")
+	expectedLines := []string{
+		"# GOVMAN - Go Version Manager",
+		`$env.PATH = ($env.PATH | prepend r#'/home/user/.govman/bin'#)`,
+		`$env.GOTOOLCHAIN = "local"`,
+		"def --env --wrapped govman [...args] {",
+		`    let govman_bin = r#'/home/user/.govman/bin/govman'#`,
+		"def --env govman_auto_switch [] {",
+		"$env.config.hooks.env_change.PWD = ($env.config.hooks.env_change.PWD? | default [] | append {|before, after| govman_auto_switch })",
+		"# <<< govman <<<",
+	}
+	for _, want := range expectedLines {
+		if !strings.Contains(commands, want) {
+			t.Errorf("SetupCommands should contain %q", want)
+		}
+	}
+	for _, posix := range []string{"export ", "$(", "then", "fish_add_path"} {
+		if strings.Contains(commands, posix) {
+			t.Errorf("SetupCommands should not contain non-nu syntax %q", posix)
+		}
+	}
+	if !containsGovmanConfig(commands) {
+		t.Error("SetupCommands output should be detected as govman config")
+	}
+	if cleaned, _, _ := removeExistingConfig("# user config\n" + commands + "\n"); cleaned != "# user config\n" {
+		t.Errorf("removeExistingConfig should strip the nu block, got %q", cleaned)
+	}
+
+	// Test ExecutePathCommand
+	oldStdout := os.Stdout
+	oldStderr := os.Stderr
+	rOut, wOut, _ := os.Pipe()
+	rErr, wErr, _ := os.Pipe()
+	os.Stdout = wOut
+	os.Stderr = wErr
+
+	// Use current directory which exists
+	err := shell.ExecutePathCommand(".")
+	wOut.Close()
+	wErr.Close()
+	os.Stdout = oldStdout
+	os.Stderr = oldStderr
+
+	if err != nil {
+		t.Errorf("ExecutePathCommand failed: %v", err)
+	}
+
+	outBytes, _ := io.ReadAll(rOut)
+	errBytes, _ := io.ReadAll(rErr)
+	if got := strings.TrimSpace(string(outBytes)); got != shell.PathCommand(".") {
+		t.Errorf("ExecutePathCommand should print PathCommand to stdout, got %q", got)
+	}
+	if !strings.Contains(string(errBytes), "govman init --shell nushell") {
+		t.Error("ExecutePathCommand should explain how to apply the command")
+	}
+}
+
 func TestPowerShell(t *testing.T) {
 	shell := &PowerShell{}
 
@@ -523,8 +773,8 @@ func TestPowerShell(t *testing.T) {
 	if len(commands) == 0 {
 		t.Error("SetupCommands should return commands")
 	}
-	if !strings.Contains(commands[0], "GOVMAN - Go Version Manager") {
-		t.Error("SetupCommands should contain GOVMAN header")
+	if commands[0] != "# "+blockStart || commands[len(commands)-1] != "# "+blockEnd {
+		t.Error("SetupCommands should be wrapped in the govman sentinel comments")
 	}
 
 	// Test ExecutePathCommand
@@ -599,15 +849,8 @@ func TestCmdShell(t *testing.T) {
 		t.Error("SetupCommands should return commands")
 	}
 	// CmdShell uses REM for comments
-	found := false
-	for _, cmd := range commands {
-		if strings.Contains(cmd, "GOVMAN - Go Version Manager") {
-			found = true
-			break
-		}
-	}
-	if !found {
-		t.Error("SetupCommands should contain GOVMAN header")
+	if !slices.Contains(commands, "REM "+blockStart) || commands[len(commands)-1] != "REM "+blockEnd {
+		t.Error("SetupCommands should be wrapped in the govman sentinel comments")
 	}
 
 	// Test ExecutePathCommand
@@ -711,6 +954,82 @@ func TestInitializeShell(t *testing.T) {
 	}
 }
 
+// captureStreams runs fn with os.Stdout and os.Stderr redirected and returns what was written to each.
+func captureStreams(t *testing.T, fn func()) (string, string) {
+	t.Helper()
+
+	oldStdout, oldStderr := os.Stdout, os.Stderr
+	rOut, wOut, _ := os.Pipe()
+	rErr, wErr, _ := os.Pipe()
+	os.Stdout, os.Stderr = wOut, wErr
+	defer func() { os.Stdout, os.Stderr = oldStdout, oldStderr }()
+
+	fn()
+	wOut.Close()
+	wErr.Close()
+
+	outBytes, _ := io.ReadAll(rOut)
+	errBytes, _ := io.ReadAll(rErr)
+	return string(outBytes), string(errBytes)
+}
+
+func TestOutputStreams(t *testing.T) {
+	t.Run("ExecutePathCommand writes only the activation commands to stdout", func(t *testing.T) {
+		shell := &BashShell{}
+		binDir := t.TempDir()
+		stdout, stderr := captureStreams(t, func() {
+			if err := shell.ExecutePathCommand(binDir); err != nil {
+				t.Errorf("ExecutePathCommand failed: %v", err)
+			}
+		})
+
+		if want := "export GOTOOLCHAIN=local\n" + shell.PathCommand(binDir) + "\n"; stdout != want {
+			t.Errorf("Expected stdout to contain only the activation commands %q, got %q", want, stdout)
+		}
+		if !strings.Contains(stderr, "# To apply to current session") {
+			t.Errorf("Expected usage hint on stderr, got %q", stderr)
+		}
+	})
+
+	t.Run("InitializeShell writes status messages to stderr", func(t *testing.T) {
+		tempDir := t.TempDir()
+		t.Setenv("HOME", tempDir)
+
+		stdout, stderr := captureStreams(t, func() {
+			if err := InitializeShell(&BashShell{}, tempDir, false); err != nil {
+				t.Errorf("InitializeShell failed: %v", err)
+			}
+		})
+
+		if stdout != "" {
+			t.Errorf("Expected nothing on stdout, got %q", stdout)
+		}
+		if !strings.Contains(stderr, "Successfully configured Bash") {
+			t.Errorf("Expected status message on stderr, got %q", stderr)
+		}
+	})
+
+	t.Run("Cmd setup instructions go to stderr", func(t *testing.T) {
+		originalGOOS := currentGOOS
+		defer func() { currentGOOS = originalGOOS }()
+		currentGOOS = "windows"
+
+		tempDir := t.TempDir()
+		stdout, stderr := captureStreams(t, func() {
+			if err := InitializeShell(&CmdShell{}, tempDir, false); err != nil {
+				t.Errorf("InitializeShell failed: %v", err)
+			}
+		})
+
+		if stdout != "" {
+			t.Errorf("Expected nothing on stdout, got %q", stdout)
+		}
+		if !strings.Contains(stderr, "SETUP INSTRUCTIONS") {
+			t.Errorf("Expected setup instructions on stderr, got %q", stderr)
+		}
+	})
+}
+
 func TestInitializeShellCmd(t *testing.T) {
 	// Test InitializeShell with cmd shell (Windows)
 	originalGOOS := currentGOOS
@@ -782,12 +1101,14 @@ func TestContainsGovmanConfig(t *testing.T) {
 
 func TestRemoveExistingConfig(t *testing.T) {
 	testCases := []struct {
-		name     string
-		input    string
-		expected string
+		name        string
+		input       string
+		expected    string
+		removed     bool
+		expectError bool
 	}{
 		{
-			name: "Remove complete govman block",
+			name: "Remove legacy govman block",
 			input: `export PATH=/usr/bin:$PATH
 # GOVMAN - Go Version Manager
 export PATH="/usr/local/bin:$PATH"
@@ -796,37 +1117,312 @@ govman() {
   echo "test"
 }
 # END GOVMAN
-export PS1="\$ "`,
+export PS1="\$ "
+`,
 			expected: `export PATH=/usr/bin:$PATH
-export PS1="\$ "`,
+export PS1="\$ "
+`,
+			removed: true,
 		},
 		{
-			name:     "No govman config to remove",
-			input:    "export PATH=/usr/bin:$PATH",
-			expected: "export PATH=/usr/bin:$PATH",
+			name: "Remove sentinel block and its separator at end of file",
+			input: `export PATH=/usr/bin:$PATH
+
+# >>> govman >>>
+# GOVMAN - Go Version Manager
+export PATH="/usr/local/bin:$PATH"
+# <<< govman <<<
+`,
+			expected: "export PATH=/usr/bin:$PATH\n",
+			removed:  true,
 		},
 		{
-			name: "Multiple consecutive newlines collapsed",
+			name: "Block moved between user lines keeps them intact",
 			input: `export PATH=/usr/bin:$PATH
 
 
-# GOVMAN - Go Version Manager
+# >>> govman >>>
 export PATH="/usr/local/bin:$PATH"
-# END GOVMAN
+# <<< govman <<<
 
 
-export PS1="\$ "`,
+export PS1="\$ "   # trailing edits stay
+`,
 			expected: `export PATH=/usr/bin:$PATH
 
-export PS1="\$ "`,
+
+
+
+export PS1="\$ "   # trailing edits stay
+`,
+			removed: true,
+		},
+		{
+			name:     "No govman config to remove",
+			input:    "export PATH=/usr/bin:$PATH",
+			expected: "export PATH=/usr/bin:$PATH",
+		},
+		{
+			name:        "Block without end marker",
+			input:       "export A=1\n# >>> govman >>>\nexport PATH=/x\nexport B=2\n",
+			expectError: true,
 		},
 	}
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			result := removeExistingConfig(tc.input)
+			result, removed, err := removeExistingConfig(tc.input)
+			if tc.expectError {
+				if err == nil {
+					t.Fatal("Expected an error for an unterminated block")
+				}
+				if result != tc.input {
+					t.Errorf("Content should be unchanged on error, got %q", result)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if removed != tc.removed {
+				t.Errorf("removed = %v, expected %v", removed, tc.removed)
+			}
 			if result != tc.expected {
-				t.Errorf("Expected:\n%s\nGot:\n%s", tc.expected, result)
+				t.Errorf("Expected:\n%q\nGot:\n%q", tc.expected, result)
+			}
+		})
+	}
+}
+
+func TestIntegrationBlockRoundTrip(t *testing.T) {
+	tempDir := t.TempDir()
+
+	originalUserHomeDir := userHomeDir
+	defer func() { userHomeDir = originalUserHomeDir }()
+	userHomeDir = func() (string, error) {
+		return tempDir, nil
+	}
+
+	shell := &BashShell{}
+	original := "# ~/.bashrc\nexport EDITOR=vim\nalias ll='ls -l'" // no trailing newline
+	if err := os.WriteFile(shell.ConfigFile(), []byte(original), 0600); err != nil {
+		t.Fatalf("Failed to write config file: %v", err)
+	}
+
+	if err := InitializeShell(shell, tempDir, false); err != nil {
+		t.Fatalf("InitializeShell failed: %v", err)
+	}
+	applied, _ := os.ReadFile(shell.ConfigFile())
+	if !strings.HasPrefix(string(applied), original+"\n") {
+		t.Errorf("Existing content should be kept as is, got %q", applied)
+	}
+	if strings.Count(string(applied), blockStart) != 1 {
+		t.Fatalf("Expected exactly one govman block, got:\n%s", applied)
+	}
+
+	// Re-applying must not add a second block
+	if err := InitializeShell(shell, tempDir, false); err == nil || !strings.Contains(err.Error(), "already configured") {
+		t.Errorf("Expected an already configured error, got %v", err)
+	}
+	if err := InitializeShell(shell, tempDir, true); err != nil {
+		t.Fatalf("InitializeShell with force failed: %v", err)
+	}
+	reapplied, _ := os.ReadFile(shell.ConfigFile())
+	if string(reapplied) != string(applied) {
+		t.Errorf("Forced re-apply should produce the same file.\nFirst:\n%q\nSecond:\n%q", applied, reapplied)
+	}
+
+	// User edits after the block survive removal
+	edited := string(reapplied) + "export PAGER=less\n"
+	if err := os.WriteFile(shell.ConfigFile(), []byte(edited), 0600); err != nil {
+		t.Fatalf("Failed to write config file: %v", err)
+	}
+
+	removed, err := RemoveIntegration(shell)
+	if err != nil || !removed {
+		t.Fatalf("RemoveIntegration = %v, %v; expected true, nil", removed, err)
+	}
+	cleaned, _ := os.ReadFile(shell.ConfigFile())
+	if expected := original + "\n\nexport PAGER=less\n"; string(cleaned) != expected {
+		t.Errorf("Expected:\n%q\nGot:\n%q", expected, cleaned)
+	}
+	if info, _ := os.Stat(shell.ConfigFile()); info.Mode().Perm() != 0600 {
+		t.Errorf("File mode should be preserved, got %v", info.Mode().Perm())
+	}
+
+	removed, err = RemoveIntegration(shell)
+	if err != nil || removed {
+		t.Errorf("Second RemoveIntegration = %v, %v; expected false, nil", removed, err)
+	}
+}
+
+func TestRemoveIntegrationMissingFile(t *testing.T) {
+	tempDir := t.TempDir()
+
+	originalUserHomeDir := userHomeDir
+	defer func() { userHomeDir = originalUserHomeDir }()
+	userHomeDir = func() (string, error) {
+		return tempDir, nil
+	}
+
+	removed, err := RemoveIntegration(&ZshShell{})
+	if err != nil || removed {
+		t.Errorf("RemoveIntegration = %v, %v; expected false, nil for a missing file", removed, err)
+	}
+	if _, err := RemoveIntegration(&CmdShell{}); err == nil {
+		t.Error("Expected an error for Command Prompt")
+	}
+}
+
+func TestSubshellGuard(t *testing.T) {
+	shells := []Shell{&BashShell{}, &ZshShell{}, &FishShell{}, &NushellShell{}, &PowerShell{}, &CmdShell{}}
+
+	for _, shell := range shells {
+		t.Run(shell.Name(), func(t *testing.T) {
+			var guards int
+			for _, line := range shell.SetupCommands("/opt/govman/bin") {
+				if strings.Contains(line, SubshellVersionEnv) {
+					guards++
+				}
+			}
+			// The PATH guard, plus the auto-switch guard where auto-switching exists
+			want := 2
+			if shell.Name() == "cmd" {
+				want = 1
+			}
+			if guards != want {
+				t.Errorf("SetupCommands has %d lines checking %s, want %d", guards, SubshellVersionEnv, want)
+			}
+		})
+	}
+
+	t.Run("bash keeps the subshell version first", func(t *testing.T) {
+		bash, err := exec.LookPath("bash")
+		if err != nil {
+			t.Skip("bash is not installed")
+		}
+
+		script := filepath.Join(t.TempDir(), "govman.sh")
+		commands := (&BashShell{}).SetupCommands("/opt/govman/bin")
+		if err := os.WriteFile(script, []byte(strings.Join(commands, "\n")+"\n"), 0644); err != nil {
+			t.Fatalf("Failed to write script: %v", err)
+		}
+
+		run := func(env ...string) string {
+			cmd := exec.Command(bash, "-c", `source "$1"; printf '%s' "$PATH"`, "bash", script)
+			cmd.Env = append([]string{"PATH=/usr/bin:/bin", "HOME=" + t.TempDir(), "GOROOT=/opt/go1.22.0"}, env...)
+			output, err := cmd.Output()
+			if err != nil {
+				t.Fatalf("bash failed: %v", err)
+			}
+			return string(output)
+		}
+
+		if path := run(SubshellVersionEnv + "=1.22.0"); !strings.HasPrefix(path, "/opt/go1.22.0/bin:") {
+			t.Errorf("PATH in a subshell = %q, want the subshell version first", path)
+		}
+		if path := run(); strings.Contains(path, "/opt/go1.22.0/bin") {
+			t.Errorf("PATH outside a subshell = %q, should not use GOROOT", path)
+		}
+	})
+}
+
+func TestSessionGuard(t *testing.T) {
+	shells := []Shell{&BashShell{}, &ZshShell{}, &FishShell{}, &NushellShell{}, &PowerShell{}}
+
+	for _, shell := range shells {
+		t.Run(shell.Name(), func(t *testing.T) {
+			var lines int
+			for _, line := range shell.SetupCommands("/opt/govman/bin") {
+				if strings.Contains(line, SessionPIDEnv) {
+					lines++
+				}
+			}
+			// The export, plus the auto-switch guard
+			if lines != 2 {
+				t.Errorf("SetupCommands has %d lines using %s, want 2", lines, SessionPIDEnv)
+			}
+		})
+	}
+
+	t.Run("bash leaves a --temp version alone", func(t *testing.T) {
+		bash, err := exec.LookPath("bash")
+		if err != nil {
+			t.Skip("bash is not installed")
+		}
+
+		home := t.TempDir()
+		project := t.TempDir()
+		if err := os.WriteFile(filepath.Join(project, ".govman-goversion"), []byte("1.22.0\n"), 0644); err != nil {
+			t.Fatalf("Failed to write project file: %v", err)
+		}
+		script := filepath.Join(home, "govman.sh")
+		commands := (&BashShell{}).SetupCommands(filepath.Join(home, "bin"))
+		if err := os.WriteFile(script, []byte(strings.Join(commands, "\n")+"\n"), 0644); err != nil {
+			t.Fatalf("Failed to write script: %v", err)
+		}
+		prefix := filepath.Join(home, "session-")
+
+		run := func(body string) string {
+			cmd := exec.Command(bash, "-c", body, "bash", script, prefix)
+			cmd.Dir = project
+			cmd.Env = []string{"PATH=/usr/bin:/bin", "HOME=" + home}
+			output, err := cmd.Output()
+			if err != nil {
+				t.Fatalf("bash failed: %v", err)
+			}
+			return string(output)
+		}
+
+		if out := run(`source "$1" >/dev/null 2>&1; govman_auto_switch 2>/dev/null`); !strings.Contains(out, "Switching to Go 1.22.0") {
+			t.Errorf("auto-switch without a session version printed %q, want a switch", out)
+		}
+		if out := run(`source "$1" >/dev/null 2>&1; echo 1.25.1 > "$2$GOVMAN_SESSION_PID"; govman_auto_switch 2>&1`); out != "" {
+			t.Errorf("auto-switch with a session version printed %q, want nothing", out)
+		}
+		if out := run(`echo 1.25.1 > "$2$$"; source "$1" >/dev/null 2>&1; if [ -e "$2$$" ]; then echo leftover; fi`); out != "" {
+			t.Error("a new shell should remove a session file left by an earlier shell with its process ID")
+		}
+	})
+}
+
+func TestExecutable(t *testing.T) {
+	originalLookPath := execLookPath
+	defer func() { execLookPath = originalLookPath }()
+	execLookPath = func(cmd string) (string, error) {
+		if cmd == "zsh" || cmd == "nu" || cmd == "powershell" {
+			return "/usr/bin/" + cmd, nil
+		}
+		return "", exec.ErrNotFound
+	}
+
+	tests := []struct {
+		name     string
+		shell    Shell
+		shellEnv string
+		comSpec  string
+		want     string
+		wantErr  bool
+	}{
+		{name: "SHELL names the shell", shell: &ZshShell{}, shellEnv: "/opt/homebrew/bin/zsh", want: "/opt/homebrew/bin/zsh"},
+		{name: "SHELL names another shell", shell: &ZshShell{}, shellEnv: "/bin/bash", want: "/usr/bin/zsh"},
+		{name: "Nushell runs nu", shell: &NushellShell{}, want: "/usr/bin/nu"},
+		{name: "Windows PowerShell without pwsh", shell: &PowerShell{}, want: "/usr/bin/powershell"},
+		{name: "Command Prompt uses ComSpec", shell: &CmdShell{}, comSpec: `C:\Windows\system32\cmd.exe`, want: `C:\Windows\system32\cmd.exe`},
+		{name: "Not installed", shell: &FishShell{}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("SHELL", tt.shellEnv)
+			t.Setenv("ComSpec", tt.comSpec)
+
+			got, err := Executable(tt.shell)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Executable() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("Executable() = %q, want %q", got, tt.want)
 			}
 		})
 	}
@@ -1211,6 +1807,29 @@ func TestDetectEdgeCases(t *testing.T) {
 	}
 }
 
+func TestIsPowerShellSession(t *testing.T) {
+	testCases := []struct {
+		name         string
+		psModulePath string
+		expected     bool
+	}{
+		{"Unset", "", false},
+		{"System modules only (cmd)", `C:\Program Files\WindowsPowerShell\Modules;C:\WINDOWS\system32\WindowsPowerShell\v1.0\Modules`, false},
+		{"Windows PowerShell user modules", `C:\Users\me\Documents\WindowsPowerShell\Modules;C:\Program Files\WindowsPowerShell\Modules`, true},
+		{"PowerShell Core user modules", `C:\Users\me\Documents\PowerShell\Modules`, true},
+		{"Mixed case", `c:\users\me\DOCUMENTS\PowerShell\Modules`, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Setenv("PSModulePath", tc.psModulePath)
+			if got := isPowerShellSession(); got != tc.expected {
+				t.Errorf("isPowerShellSession() with %q = %v, expected %v", tc.psModulePath, got, tc.expected)
+			}
+		})
+	}
+}
+
 func TestDetectAvailableShellNoShells(t *testing.T) {
 	// Mock exec.LookPath to return no shells
 	originalLookPath := execLookPath
@@ -1856,3 +2475,110 @@ func TestInitializeShellWithExistingConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestIsInitialized(t *testing.T) {
+	tempDir := t.TempDir()
+
+	originalUserHomeDir := userHomeDir
+	defer func() { userHomeDir = originalUserHomeDir }()
+	userHomeDir = func() (string, error) {
+		return tempDir, nil
+	}
+
+	shell := &ZshShell{}
+
+	initialized, err := IsInitialized(shell)
+	if err != nil {
+		t.Fatalf("Unexpected error for missing config file: %v", err)
+	}
+	if initialized {
+		t.Error("Expected missing config file to report not initialized")
+	}
+
+	if err := os.WriteFile(shell.ConfigFile(), []byte("export EDITOR=vim\n"), 0644); err != nil {
+		t.Fatalf("Failed to write config file: %v", err)
+	}
+	if initialized, _ := IsInitialized(shell); initialized {
+		t.Error("Expected config without govman block to report not initialized")
+	}
+
+	content := "export EDITOR=vim\n" + strings.Join(shell.SetupCommands(tempDir), "\n")
+	if err := os.WriteFile(shell.ConfigFile(), []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write config file: %v", err)
+	}
+	if initialized, _ := IsInitialized(shell); !initialized {
+		t.Error("Expected config with govman block to report initialized")
+	}
+}
+
+func TestSetConfigFile(t *testing.T) {
+	t.Cleanup(func() { configFile = "" })
+
+	configPath := filepath.Join(t.TempDir(), "xdg config", "govman", "config.yaml")
+	SetConfigFile(configPath)
+
+	// CmdShell has no auto-switch hook, so it never reads the config file
+	shells := []Shell{&BashShell{}, &ZshShell{}, &FishShell{}, &PowerShell{}, &NushellShell{}}
+	for _, sh := range shells {
+		t.Run(sh.Name(), func(t *testing.T) {
+			setup := strings.Join(sh.SetupCommands("/home/user/.govman/bin"), "\n")
+			if !strings.Contains(setup, configPath) {
+				t.Errorf("SetupCommands() should read %s in the auto-switch hook", configPath)
+			}
+			if strings.Contains(setup, ".govman/config.yaml") || strings.Contains(setup, `.govman\config.yaml`) {
+				t.Error("SetupCommands() should not fall back to ~/.govman/config.yaml once a config file is set")
+			}
+		})
+	}
+
+	t.Run("relative path is made absolute", func(t *testing.T) {
+		SetConfigFile("custom.yaml")
+		if !filepath.IsAbs(hookConfigFile()) {
+			t.Errorf("hookConfigFile() = %q, want an absolute path", hookConfigFile())
+		}
+	})
+}
+
+func TestPinToolchain(t *testing.T) {
+	t.Cleanup(func() { SetPinToolchain(true) })
+
+	shells := []Shell{&BashShell{}, &ZshShell{}, &FishShell{}, &PowerShell{}, &NushellShell{}, &CmdShell{}}
+	binDir := "/home/user/.govman/bin"
+
+	for _, sh := range shells {
+		t.Run(sh.Name(), func(t *testing.T) {
+			SetPinToolchain(true)
+			toolchainCmd := ToolchainCommand(sh)
+			if !strings.Contains(toolchainCmd, "GOTOOLCHAIN") || !strings.Contains(toolchainCmd, "local") {
+				t.Errorf("ToolchainCommand() = %q, want a GOTOOLCHAIN=local assignment", toolchainCmd)
+			}
+			if !slices.Contains(sh.SetupCommands(binDir), toolchainCmd) {
+				t.Errorf("SetupCommands() should contain %q when pinning", toolchainCmd)
+			}
+
+			SetPinToolchain(false)
+			if got := ToolchainCommand(sh); got != "" {
+				t.Errorf("ToolchainCommand() = %q, want empty when pinning is disabled", got)
+			}
+			for _, line := range sh.SetupCommands(binDir) {
+				if strings.Contains(line, "GOTOOLCHAIN") {
+					t.Errorf("SetupCommands() should not set GOTOOLCHAIN when pinning is disabled, got %q", line)
+				}
+			}
+		})
+	}
+
+	t.Run("ExecutePathCommand without pinning", func(t *testing.T) {
+		SetPinToolchain(false)
+		shell := &BashShell{}
+		dir := t.TempDir()
+		stdout, _ := captureStreams(t, func() {
+			if err := shell.ExecutePathCommand(dir); err != nil {
+				t.Errorf("ExecutePathCommand failed: %v", err)
+			}
+		})
+		if stdout != shell.PathCommand(dir)+"\n" {
+			t.Errorf("Expected only the PATH command, got %q", stdout)
+		}
+	})
+}
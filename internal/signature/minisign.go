@@ -0,0 +1,142 @@
+package signature
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/ed25519"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"golang.org/x/crypto/blake2b"
+)
+
+const (
+	// minisignAlgorithm marks a signature over the file itself, and a key.
+	minisignAlgorithm = "Ed"
+	// minisignHashedAlgorithm marks a signature over the BLAKE2b-512 hash of the file.
+	minisignHashedAlgorithm = "ED"
+
+	minisignKeyIDSize   = 8
+	trustedCommentLabel = "trusted comment: "
+
+	// maxLegacySignedSize bounds the file a legacy "Ed" signature may cover, since it must be held in memory.
+	maxLegacySignedSize = 32 << 20
+)
+
+// minisignKey is a decoded minisign public key.
+type minisignKey struct {
+	id  [minisignKeyIDSize]byte
+	key ed25519.PublicKey
+}
+
+// parseMinisignKey decodes a minisign public key file, skipping its comment line, or a bare base64 key.
+func parseMinisignKey(data []byte) (*minisignKey, error) {
+	var encoded string
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "untrusted comment:") {
+			continue
+		}
+		encoded = line
+		break
+	}
+
+	raw, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("invalid minisign key encoding: %w", err)
+	}
+	if len(raw) != 2+minisignKeyIDSize+ed25519.PublicKeySize || string(raw[:2]) != minisignAlgorithm {
+		return nil, errors.New("invalid minisign key")
+	}
+
+	k := &minisignKey{key: ed25519.PublicKey(raw[2+minisignKeyIDSize:])}
+	copy(k.id[:], raw[2:])
+	return k, nil
+}
+
+// verify checks a minisign signature file sig over the file at filePath, including the signed trusted comment.
+func (k *minisignKey) verify(filePath string, sig []byte) error {
+	lines := make([]string, 0, 4)
+	scanner := bufio.NewScanner(bytes.NewReader(sig))
+	for scanner.Scan() {
+		lines = append(lines, strings.TrimRight(scanner.Text(), "\r"))
+	}
+	if len(lines) < 4 || !strings.HasPrefix(lines[2], trustedCommentLabel) {
+		return fmt.Errorf("%w: malformed minisign signature", ErrInvalidSignature)
+	}
+
+	sigBlock, err := base64.StdEncoding.DecodeString(lines[1])
+	if err != nil || len(sigBlock) != 2+minisignKeyIDSize+ed25519.SignatureSize {
+		return fmt.Errorf("%w: malformed minisign signature", ErrInvalidSignature)
+	}
+	globalSig, err := base64.StdEncoding.DecodeString(lines[3])
+	if err != nil || len(globalSig) != ed25519.SignatureSize {
+		return fmt.Errorf("%w: malformed minisign trusted comment signature", ErrInvalidSignature)
+	}
+
+	algorithm := string(sigBlock[:2])
+	if !bytes.Equal(sigBlock[2:2+minisignKeyIDSize], k.id[:]) {
+		return fmt.Errorf("%w: signed by key %X, expected %X", ErrInvalidSignature,
+			reverse(sigBlock[2:2+minisignKeyIDSize]), reverse(k.id[:]))
+	}
+	fileSig := sigBlock[2+minisignKeyIDSize:]
+
+	if algorithm != minisignAlgorithm && algorithm != minisignHashedAlgorithm {
+		return fmt.Errorf("%w: unsupported minisign algorithm %q", ErrInvalidSignature, algorithm)
+	}
+	message, err := signedMessage(filePath, algorithm)
+	if err != nil {
+		return err
+	}
+
+	if !ed25519.Verify(k.key, message, fileSig) {
+		return fmt.Errorf("%w: file does not match its minisign signature", ErrInvalidSignature)
+	}
+
+	trusted := append(append([]byte{}, fileSig...), strings.TrimPrefix(lines[2], trustedCommentLabel)...)
+	if !ed25519.Verify(k.key, trusted, globalSig) {
+		return fmt.Errorf("%w: minisign trusted comment was altered", ErrInvalidSignature)
+	}
+	return nil
+}
+
+// signedMessage streams the file at path into what a minisign signature of the given algorithm covers: its
+// BLAKE2b-512 hash, or for a legacy signature the contents, up to maxLegacySignedSize.
+func signedMessage(path, algorithm string) ([]byte, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read signed file: %w", err)
+	}
+	defer file.Close()
+
+	if algorithm == minisignHashedAlgorithm {
+		hash, _ := blake2b.New512(nil)
+		if _, err := io.Copy(hash, file); err != nil {
+			return nil, fmt.Errorf("failed to read signed file: %w", err)
+		}
+		return hash.Sum(nil), nil
+	}
+
+	data, err := io.ReadAll(io.LimitReader(file, maxLegacySignedSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read signed file: %w", err)
+	}
+	if len(data) > maxLegacySignedSize {
+		return nil, fmt.Errorf("%w: legacy minisign signatures are limited to %d MiB; sign with a prehashed (-H) signature",
+			ErrInvalidSignature, maxLegacySignedSize>>20)
+	}
+	return data, nil
+}
+
+// reverse returns a reversed copy of b; minisign prints key IDs as little-endian numbers.
+func reverse(b []byte) []byte {
+	out := make([]byte, len(b))
+	for i := range b {
+		out[len(b)-1-i] = b[i]
+	}
+	return out
+}
@@ -0,0 +1,72 @@
+package signature
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// execLookPath is swappable so tests can simulate a system without gpg.
+var execLookPath = exec.LookPath
+
+// verifyOpenPGP checks the detached signature sig of filePath against armoredKey with gpg.
+// The key is imported into a throwaway keyring, so the user's own keyring is neither read nor changed.
+func verifyOpenPGP(armoredKey []byte, filePath string, sig []byte) error {
+	gpg, err := findGPG()
+	if err != nil {
+		return err
+	}
+
+	home, err := os.MkdirTemp("", "govman-gpg-")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary keyring: %w", err)
+	}
+	defer os.RemoveAll(home)
+
+	keyPath := filepath.Join(home, "key.asc")
+	sigPath := filepath.Join(home, "file.sig")
+	if err := os.WriteFile(keyPath, armoredKey, 0600); err != nil {
+		return fmt.Errorf("failed to write temporary key: %w", err)
+	}
+	if err := os.WriteFile(sigPath, sig, 0600); err != nil {
+		return fmt.Errorf("failed to write temporary signature: %w", err)
+	}
+
+	if out, err := runGPG(gpg, home, "--import", keyPath); err != nil {
+		return fmt.Errorf("gpg could not import the public key: %s", out)
+	}
+
+	if out, err := runGPG(gpg, home, "--verify", sigPath, filePath); err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			return fmt.Errorf("%w: %s", ErrInvalidSignature, out)
+		}
+		return fmt.Errorf("failed to run gpg: %w", err)
+	}
+	return nil
+}
+
+// findGPG returns the path of gpg, or gpg2 on systems that only ship that name.
+func findGPG() (string, error) {
+	for _, name := range []string{"gpg", "gpg2"} {
+		if path, err := execLookPath(name); err == nil {
+			return path, nil
+		}
+	}
+	return "", errors.New("gpg is required to verify OpenPGP signatures but was not found in PATH")
+}
+
+// runGPG runs gpg non-interactively against the keyring in home. Returns its trimmed output and the run error.
+func runGPG(gpg, home string, args ...string) (string, error) {
+	args = append([]string{"--batch", "--no-tty", "--quiet", "--homedir", home}, args...)
+	var out bytes.Buffer
+	cmd := exec.Command(gpg, args...)
+	cmd.Stdout = &out
+	cmd.Stderr = &out
+	err := cmd.Run()
+	return strings.TrimSpace(out.String()), err
+}
@@ -0,0 +1,88 @@
+package signature
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+)
+
+// ErrInvalidSignature is returned by Verify when a signature does not match the file or was not made by the key.
+var ErrInvalidSignature = errors.New("signature verification failed")
+
+// Kind identifies the signature scheme of a public key.
+type Kind int
+
+const (
+	// OpenPGP keys verify detached .asc signatures, such as those Google publishes for Go releases, with gpg.
+	OpenPGP Kind = iota + 1
+	// Minisign keys verify detached .minisig signatures in pure Go.
+	Minisign
+)
+
+// String returns the scheme name.
+func (k Kind) String() string {
+	switch k {
+	case OpenPGP:
+		return "OpenPGP"
+	case Minisign:
+		return "minisign"
+	default:
+		return "unknown"
+	}
+}
+
+// Extension returns the suffix added to an archive URL to fetch its detached signature.
+func (k Kind) Extension() string {
+	if k == OpenPGP {
+		return ".asc"
+	}
+	return ".minisig"
+}
+
+// pgpKeyHeader starts every ASCII-armored OpenPGP public key.
+const pgpKeyHeader = "-----BEGIN PGP PUBLIC KEY BLOCK-----"
+
+// PublicKey is a trusted key that detached signatures are checked against.
+type PublicKey struct {
+	kind     Kind
+	armored  []byte       // the OpenPGP key as read, handed to gpg
+	minisign *minisignKey // the parsed minisign key
+}
+
+// LoadPublicKey reads the public key at path: an ASCII-armored OpenPGP key, or a minisign public key file
+// or its bare base64 line. Returns an error if the file cannot be read or holds neither.
+func LoadPublicKey(path string) (*PublicKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read public key: %w", err)
+	}
+	return ParsePublicKey(data)
+}
+
+// ParsePublicKey parses a public key in any format LoadPublicKey accepts.
+func ParsePublicKey(data []byte) (*PublicKey, error) {
+	if bytes.Contains(data, []byte(pgpKeyHeader)) {
+		return &PublicKey{kind: OpenPGP, armored: data}, nil
+	}
+
+	key, err := parseMinisignKey(data)
+	if err != nil {
+		return nil, fmt.Errorf("unrecognized public key: expected an ASCII-armored OpenPGP key or a minisign key: %w", err)
+	}
+	return &PublicKey{kind: Minisign, minisign: key}, nil
+}
+
+// Kind returns the signature scheme of the key.
+func (k *PublicKey) Kind() Kind {
+	return k.kind
+}
+
+// Verify checks that sig is a valid detached signature of the file at filePath made by the key.
+// Returns an error wrapping ErrInvalidSignature on a bad signature, or another error if verification could not run.
+func (k *PublicKey) Verify(filePath string, sig []byte) error {
+	if k.kind == OpenPGP {
+		return verifyOpenPGP(k.armored, filePath, sig)
+	}
+	return k.minisign.verify(filePath, sig)
+}
@@ -0,0 +1,165 @@
+package signature
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"golang.org/x/crypto/blake2b"
+)
+
+// minisignSign returns a minisign public key file and a signature file of data, the way the minisign tool
+// writes them, using the given algorithm.
+func minisignSign(t *testing.T, data []byte, algorithm string) (pubFile, sigFile []byte) {
+	t.Helper()
+
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyID := []byte{1, 2, 3, 4, 5, 6, 7, 8}
+
+	message := data
+	if algorithm == minisignHashedAlgorithm {
+		sum := blake2b.Sum512(data)
+		message = sum[:]
+	}
+	fileSig := ed25519.Sign(priv, message)
+	trustedComment := "timestamp:1700000000\tfile:go.tar.gz"
+	globalSig := ed25519.Sign(priv, append(append([]byte{}, fileSig...), trustedComment...))
+
+	pubRaw := append(append([]byte(minisignAlgorithm), keyID...), pub...)
+	sigRaw := append(append([]byte(algorithm), keyID...), fileSig...)
+
+	pubFile = []byte("untrusted comment: minisign public key 0807060504030201\n" + base64.StdEncoding.EncodeToString(pubRaw) + "\n")
+	sigFile = []byte("untrusted comment: signature from minisign secret key\n" +
+		base64.StdEncoding.EncodeToString(sigRaw) + "\n" +
+		trustedCommentLabel + trustedComment + "\n" +
+		base64.StdEncoding.EncodeToString(globalSig) + "\n")
+	return pubFile, sigFile
+}
+
+func TestMinisignVerify(t *testing.T) {
+	dir := t.TempDir()
+	filePath := filepath.Join(dir, "go.tar.gz")
+	data := []byte(strings.Repeat("archive contents ", 50))
+	os.WriteFile(filePath, data, 0644)
+
+	for _, algorithm := range []string{minisignAlgorithm, minisignHashedAlgorithm} {
+		t.Run(algorithm, func(t *testing.T) {
+			pubFile, sigFile := minisignSign(t, data, algorithm)
+
+			keyPath := filepath.Join(dir, "minisign.pub")
+			os.WriteFile(keyPath, pubFile, 0644)
+			key, err := LoadPublicKey(keyPath)
+			if err != nil {
+				t.Fatalf("LoadPublicKey() error = %v", err)
+			}
+			if key.Kind() != Minisign || key.Kind().Extension() != ".minisig" {
+				t.Fatalf("Kind() = %v (%s), want minisign (.minisig)", key.Kind(), key.Kind().Extension())
+			}
+
+			if err := key.Verify(filePath, sigFile); err != nil {
+				t.Errorf("Verify() error = %v", err)
+			}
+
+			tampered := filepath.Join(dir, "tampered.tar.gz")
+			os.WriteFile(tampered, append(data, '!'), 0644)
+			if err := key.Verify(tampered, sigFile); !errors.Is(err, ErrInvalidSignature) {
+				t.Errorf("Verify() on a changed file error = %v, want ErrInvalidSignature", err)
+			}
+
+			altered := strings.Replace(string(sigFile), "file:go.tar.gz", "file:other.tar.gz", 1)
+			if err := key.Verify(filePath, []byte(altered)); !errors.Is(err, ErrInvalidSignature) {
+				t.Errorf("Verify() with an altered trusted comment error = %v, want ErrInvalidSignature", err)
+			}
+
+			otherPub, _ := minisignSign(t, data, algorithm)
+			otherKey, err := ParsePublicKey(otherPub)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := otherKey.Verify(filePath, sigFile); !errors.Is(err, ErrInvalidSignature) {
+				t.Errorf("Verify() with another key error = %v, want ErrInvalidSignature", err)
+			}
+		})
+	}
+}
+
+func TestParsePublicKey(t *testing.T) {
+	armored := []byte(pgpKeyHeader + "\n\nmQINBF...\n-----END PGP PUBLIC KEY BLOCK-----\n")
+	key, err := ParsePublicKey(armored)
+	if err != nil {
+		t.Fatalf("ParsePublicKey(armored) error = %v", err)
+	}
+	if key.Kind() != OpenPGP || key.Kind().Extension() != ".asc" {
+		t.Errorf("Kind() = %v (%s), want OpenPGP (.asc)", key.Kind(), key.Kind().Extension())
+	}
+
+	pubFile, _ := minisignSign(t, nil, minisignAlgorithm)
+	bare := strings.Split(string(pubFile), "\n")[1]
+	if key, err := ParsePublicKey([]byte(bare)); err != nil || key.Kind() != Minisign {
+		t.Errorf("ParsePublicKey(bare minisign key) = %v, %v; want a minisign key", key, err)
+	}
+
+	for _, bad := range []string{"", "not a key", base64.StdEncoding.EncodeToString([]byte("Ed short"))} {
+		if _, err := ParsePublicKey([]byte(bad)); err == nil {
+			t.Errorf("ParsePublicKey(%q) should fail", bad)
+		}
+	}
+
+	if _, err := LoadPublicKey(filepath.Join(t.TempDir(), "missing.pub")); err == nil {
+		t.Error("LoadPublicKey() of a missing file should fail")
+	}
+}
+
+func TestOpenPGPVerify(t *testing.T) {
+	gpg, err := exec.LookPath("gpg")
+	if err != nil {
+		t.Skip("gpg not available")
+	}
+
+	// Make a signing key and signature in a keyring of its own, separate from the one Verify uses
+	home := t.TempDir()
+	run := func(args ...string) []byte {
+		t.Helper()
+		args = append([]string{"--batch", "--quiet", "--homedir", home, "--pinentry-mode", "loopback", "--passphrase", ""}, args...)
+		out, err := exec.Command(gpg, args...).Output()
+		if err != nil {
+			t.Skipf("gpg %v failed: %v", args, err)
+		}
+		return out
+	}
+	run("--quick-gen-key", "Release Signer <release@example.com>", "ed25519", "sign", "never")
+	armored := run("--armor", "--export", "release@example.com")
+
+	filePath := filepath.Join(t.TempDir(), "go.tar.gz")
+	os.WriteFile(filePath, []byte("archive contents"), 0644)
+	sig := run("--armor", "--detach-sign", "--output", "-", filePath)
+
+	key, err := ParsePublicKey(armored)
+	if err != nil {
+		t.Fatalf("ParsePublicKey() error = %v", err)
+	}
+	if err := key.Verify(filePath, sig); err != nil {
+		t.Errorf("Verify() error = %v", err)
+	}
+
+	os.WriteFile(filePath, []byte("tampered contents"), 0644)
+	if err := key.Verify(filePath, sig); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("Verify() on a changed file error = %v, want ErrInvalidSignature", err)
+	}
+
+	original := execLookPath
+	execLookPath = func(string) (string, error) { return "", exec.ErrNotFound }
+	t.Cleanup(func() { execLookPath = original })
+	if err := key.Verify(filePath, sig); err == nil || errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("Verify() without gpg error = %v, want a missing gpg error", err)
+	}
+}
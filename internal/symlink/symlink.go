@@ -3,13 +3,37 @@ package symlink
 import (
 	"fmt"
 	"os"
+	"os/exec"
 	"path/filepath"
+	"runtime"
+	"strings"
 )
 
-// Create creates a symlink at symlinkPath pointing to target.
-// Uses atomic replacement pattern: creates a temp symlink and renames it.
-// This avoids TOCTOU race conditions between check and create operations.
-func Create(target, symlinkPath string) error {
+// Strategy describes how a link was materialized on disk.
+type Strategy string
+
+const (
+	// StrategySymlink is a regular symbolic link.
+	StrategySymlink Strategy = "symlink"
+	// StrategyJunction is a Windows directory junction, used for directory targets when symlinks are unavailable.
+	StrategyJunction Strategy = "junction"
+	// StrategyShim is a .cmd script next to the link path that runs the target, used for file targets when symlinks
+	// are unavailable. A copy would not do: go.exe finds its GOROOT from its own location.
+	StrategyShim Strategy = "shim"
+)
+
+var (
+	currentGOOS  = runtime.GOOS
+	symlinkFunc  = os.Symlink
+	junctionFunc = func(target, linkPath string) error {
+		return exec.Command("cmd", "/c", "mklink", "/J", linkPath, target).Run()
+	}
+)
+
+// Create atomically creates a symlink at symlinkPath pointing to target, relative to symlinkPath's directory with
+// relative. On Windows without symlink privileges it falls back to a junction or a .cmd shim at ShimPath.
+// Returns the strategy that was used.
+func Create(target, symlinkPath string, relative bool) (Strategy, error) {
 	// Create a temporary symlink in the same directory
 	dir := filepath.Dir(symlinkPath)
 	tempLink := filepath.Join(dir, fmt.Sprintf(".govman-symlink-%d", os.Getpid()))
@@ -17,17 +41,129 @@ func Create(target, symlinkPath string) error {
 	// Remove any leftover temp symlink from previous failed attempts
 	os.Remove(tempLink)
 
+	linkTarget := target
+	if relative {
+		linkTarget = relativeTarget(target, dir)
+	}
+
 	// Create the symlink at the temporary location
-	if err := os.Symlink(target, tempLink); err != nil {
-		return fmt.Errorf("failed to create temporary symlink: %w", err)
+	strategy := StrategySymlink
+	if err := symlinkFunc(linkTarget, tempLink); err != nil {
+		if currentGOOS != "windows" {
+			return "", fmt.Errorf("failed to create temporary symlink: %w", err)
+		}
+
+		strategy, err = createFallback(target, tempLink)
+		if err != nil {
+			return "", fmt.Errorf("failed to create temporary symlink, junction, or shim: %w", err)
+		}
+	}
+
+	// The link and its shim replace each other, so only one of them is ever found on PATH
+	finalPath, stalePath := symlinkPath, ShimPath(symlinkPath)
+	if strategy == StrategyShim {
+		finalPath, stalePath = stalePath, finalPath
 	}
 
 	// Atomically rename the temp symlink to the final location
 	// This replaces any existing symlink in a single operation
-	if err := os.Rename(tempLink, symlinkPath); err != nil {
-		os.Remove(tempLink) // Cleanup on failure
-		return fmt.Errorf("failed to rename symlink to final location: %w", err)
+	if err := os.Rename(tempLink, finalPath); err != nil {
+		os.RemoveAll(tempLink) // Cleanup on failure
+		return "", fmt.Errorf("failed to rename symlink to final location: %w", err)
+	}
+	if currentGOOS == "windows" {
+		if err := os.Remove(stalePath); err != nil && !os.IsNotExist(err) {
+			return "", fmt.Errorf("failed to remove %s: %w", stalePath, err)
+		}
+	}
+
+	return strategy, nil
+}
+
+// ShimPath returns the path of the .cmd shim Create writes in place of a file link at linkPath,
+// e.g. go.cmd for go.exe.
+func ShimPath(linkPath string) string {
+	return strings.TrimSuffix(linkPath, filepath.Ext(linkPath)) + ".cmd"
+}
+
+// Lstat is like os.Lstat for a link made by Create, describing its shim on Windows when that was used instead.
+func Lstat(linkPath string) (os.FileInfo, error) {
+	info, err := os.Lstat(linkPath)
+	if os.IsNotExist(err) && currentGOOS == "windows" {
+		if shimInfo, shimErr := os.Lstat(ShimPath(linkPath)); shimErr == nil {
+			return shimInfo, nil
+		}
+	}
+	return info, err
+}
+
+// Stat is like os.Stat for a link made by Create, describing its shim on Windows when that was used instead.
+func Stat(linkPath string) (os.FileInfo, error) {
+	info, err := os.Stat(linkPath)
+	if os.IsNotExist(err) && currentGOOS == "windows" {
+		if shimInfo, shimErr := os.Stat(ShimPath(linkPath)); shimErr == nil {
+			return shimInfo, nil
+		}
 	}
+	return info, err
+}
 
+// Remove deletes a link made by Create and, on Windows, its shim. A missing link is not an error.
+func Remove(linkPath string) error {
+	if err := os.Remove(linkPath); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	if currentGOOS == "windows" {
+		if err := os.Remove(ShimPath(linkPath)); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
 	return nil
 }
+
+// relativeTarget returns target relative to dir, or target unchanged if no relative path exists
+// (e.g. different Windows volumes).
+func relativeTarget(target, dir string) string {
+	absTarget, err := filepath.Abs(target)
+	if err != nil {
+		return target
+	}
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		return target
+	}
+
+	rel, err := filepath.Rel(absDir, absTarget)
+	if err != nil {
+		return target
+	}
+	return rel
+}
+
+// createFallback materializes linkPath without a symlink: a junction for directories, a .cmd script running the
+// target for files. Returns the strategy used or an error if neither works.
+func createFallback(target, linkPath string) (Strategy, error) {
+	info, err := os.Stat(target)
+	if err != nil {
+		return "", err
+	}
+
+	if info.IsDir() {
+		if err := junctionFunc(target, linkPath); err != nil {
+			return "", fmt.Errorf("failed to create junction: %w", err)
+		}
+		return StrategyJunction, nil
+	}
+
+	absTarget, err := filepath.Abs(target)
+	if err != nil {
+		return "", err
+	}
+	// cmd expands %VAR% even inside quotes, so a literal % is doubled
+	script := "@\"" + strings.ReplaceAll(absTarget, "%", "%%") + "\" %*\r\n"
+	if err := os.WriteFile(linkPath, []byte(script), 0755); err != nil {
+		os.Remove(linkPath)
+		return "", fmt.Errorf("failed to write shim for %s: %w", target, err)
+	}
+	return StrategyShim, nil
+}
@@ -1,6 +1,7 @@
 package symlink
 
 import (
+	"errors"
 	"os"
 	"path/filepath"
 	"runtime"
@@ -14,10 +15,13 @@ func TestCreate_NewSymlink(t *testing.T) {
 
 	os.WriteFile(target, []byte("ok"), 0644)
 
-	err := Create(target, symlink)
+	strategy, err := Create(target, symlink, false)
 	if err != nil {
 		t.Fatalf("Create failed: %v", err)
 	}
+	if strategy != StrategySymlink {
+		t.Errorf("expected strategy %q, got %q", StrategySymlink, strategy)
+	}
 
 	resolved, err := os.Readlink(symlink)
 	if err != nil {
@@ -35,13 +39,13 @@ func TestCreate_OverwriteExisting(t *testing.T) {
 	symlink := filepath.Join(tempDir, "mylink")
 
 	os.WriteFile(target1, []byte("v1"), 0644)
-	if err := Create(target1, symlink); err != nil {
+	if _, err := Create(target1, symlink, false); err != nil {
 		t.Fatalf("first Create failed: %v", err)
 	}
 
 	target2 := filepath.Join(tempDir, "file2.txt")
 	os.WriteFile(target2, []byte("v2"), 0644)
-	if err := Create(target2, symlink); err != nil {
+	if _, err := Create(target2, symlink, false); err != nil {
 		t.Fatalf("overwrite Create failed: %v", err)
 	}
 
@@ -66,7 +70,7 @@ func TestCreate_Error_ReadOnlyDir(t *testing.T) {
 
 	symlink := filepath.Join(readonlyDir, "link")
 
-	err := Create(target, symlink)
+	_, err := Create(target, symlink, false)
 	if err == nil {
 		t.Error("expected error when creating symlink in read-only dir, got nil")
 	}
@@ -88,8 +92,261 @@ func TestCreate_ErrorOnRemove(t *testing.T) {
 		t.Fatalf("failed to create file inside blockDir: %v", err)
 	}
 
-	err := Create(target, blockDir)
+	_, err := Create(target, blockDir, false)
 	if err == nil {
 		t.Error("expected error when os.Remove fails on non-empty directory, got nil")
 	}
 }
+
+func TestCreate_RelativeTarget(t *testing.T) {
+	tempDir := t.TempDir()
+	target := filepath.Join(tempDir, "versions", "go1.22.0", "bin", "go")
+	binDir := filepath.Join(tempDir, ".govman", "bin")
+	os.MkdirAll(filepath.Dir(target), 0755)
+	os.MkdirAll(binDir, 0755)
+	os.WriteFile(target, []byte("v1.22.0"), 0755)
+
+	link := filepath.Join(binDir, "go")
+	if _, err := Create(target, link, true); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+
+	resolved, err := os.Readlink(link)
+	if err != nil {
+		t.Fatalf("os.Readlink failed: %v", err)
+	}
+	expected := filepath.Join("..", "..", "versions", "go1.22.0", "bin", "go")
+	if resolved != expected {
+		t.Errorf("expected relative target %q, got %q", expected, resolved)
+	}
+
+	data, err := os.ReadFile(link)
+	if err != nil || string(data) != "v1.22.0" {
+		t.Errorf("expected link to resolve to target contents, got %q (err %v)", data, err)
+	}
+}
+
+func TestCreate_RelativeSurvivesRelocation(t *testing.T) {
+	parent := t.TempDir()
+	oldHome := filepath.Join(parent, "old")
+	target := filepath.Join(oldHome, "versions", "go1.22.0", "bin", "go")
+	os.MkdirAll(filepath.Dir(target), 0755)
+	os.MkdirAll(filepath.Join(oldHome, "bin"), 0755)
+	os.WriteFile(target, []byte("v1.22.0"), 0755)
+
+	if _, err := Create(target, filepath.Join(oldHome, "bin", "go"), true); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+
+	newHome := filepath.Join(parent, "new")
+	if err := os.Rename(oldHome, newHome); err != nil {
+		t.Fatalf("failed to move home: %v", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(newHome, "bin", "go"))
+	if err != nil {
+		t.Fatalf("relative link broke after relocation: %v", err)
+	}
+	if string(data) != "v1.22.0" {
+		t.Errorf("expected %q, got %q", "v1.22.0", data)
+	}
+}
+
+func TestCreate_AbsoluteBreaksOnRelocation(t *testing.T) {
+	parent := t.TempDir()
+	oldHome := filepath.Join(parent, "old")
+	target := filepath.Join(oldHome, "versions", "go")
+	os.MkdirAll(filepath.Dir(target), 0755)
+	os.MkdirAll(filepath.Join(oldHome, "bin"), 0755)
+	os.WriteFile(target, []byte("data"), 0755)
+
+	if _, err := Create(target, filepath.Join(oldHome, "bin", "go"), false); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+
+	newHome := filepath.Join(parent, "new")
+	os.Rename(oldHome, newHome)
+
+	resolved, _ := os.Readlink(filepath.Join(newHome, "bin", "go"))
+	if resolved != target {
+		t.Errorf("expected absolute target %q, got %q", target, resolved)
+	}
+	if _, err := os.Stat(filepath.Join(newHome, "bin", "go")); err == nil {
+		t.Error("expected absolute link to dangle after relocation")
+	}
+}
+
+func TestRelativeTarget(t *testing.T) {
+	tempDir := t.TempDir()
+
+	testCases := []struct {
+		name     string
+		target   string
+		dir      string
+		expected string
+	}{
+		{"Sibling tree", filepath.Join(tempDir, "versions", "go1.22.0", "bin", "go"), filepath.Join(tempDir, "bin"), filepath.Join("..", "versions", "go1.22.0", "bin", "go")},
+		{"Same directory", filepath.Join(tempDir, "go"), tempDir, "go"},
+		{"Nested below", filepath.Join(tempDir, "a", "b"), tempDir, filepath.Join("a", "b")},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := relativeTarget(tc.target, tc.dir); got != tc.expected {
+				t.Errorf("relativeTarget(%q, %q) = %q, expected %q", tc.target, tc.dir, got, tc.expected)
+			}
+		})
+	}
+}
+
+// failSymlinks makes os.Symlink fail as it does on Windows without developer mode or admin rights.
+func failSymlinks(t *testing.T, goos string) {
+	t.Helper()
+
+	originalGOOS := currentGOOS
+	originalSymlink := symlinkFunc
+	t.Cleanup(func() {
+		currentGOOS = originalGOOS
+		symlinkFunc = originalSymlink
+	})
+
+	currentGOOS = goos
+	symlinkFunc = func(oldname, newname string) error {
+		return &os.LinkError{Op: "symlink", Old: oldname, New: newname, Err: errors.New("a required privilege is not held by the client")}
+	}
+}
+
+func TestCreate_FallbackShimsFileOnWindows(t *testing.T) {
+	failSymlinks(t, "windows")
+
+	tempDir := t.TempDir()
+	target := filepath.Join(tempDir, "100%", "go.exe")
+	link := filepath.Join(tempDir, "bin-go.exe")
+	os.MkdirAll(filepath.Dir(target), 0755)
+	os.WriteFile(target, []byte("binary"), 0755)
+	os.WriteFile(link, []byte("old copy"), 0755)
+
+	strategy, err := Create(target, link, false)
+	if err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	if strategy != StrategyShim {
+		t.Errorf("expected strategy %q, got %q", StrategyShim, strategy)
+	}
+
+	shim := filepath.Join(tempDir, "bin-go.cmd")
+	if ShimPath(link) != shim {
+		t.Errorf("ShimPath(%q) = %q, expected %q", link, ShimPath(link), shim)
+	}
+	data, err := os.ReadFile(shim)
+	if err != nil {
+		t.Fatalf("failed to read shim: %v", err)
+	}
+	want := "@\"" + filepath.Join(tempDir, "100%%", "go.exe") + "\" %*\r\n"
+	if string(data) != want {
+		t.Errorf("expected shim %q, got %q", want, data)
+	}
+	if _, err := os.Lstat(link); !os.IsNotExist(err) {
+		t.Errorf("expected the stale copy at the link path to be removed, got %v", err)
+	}
+	if _, err := Lstat(link); err != nil {
+		t.Errorf("Lstat should find the shim: %v", err)
+	}
+
+	if err := Remove(link); err != nil {
+		t.Fatalf("Remove failed: %v", err)
+	}
+	if _, err := Lstat(link); !os.IsNotExist(err) {
+		t.Errorf("expected no link or shim after Remove, got %v", err)
+	}
+}
+
+func TestCreate_FallbackJunctionForDirectoryOnWindows(t *testing.T) {
+	failSymlinks(t, "windows")
+
+	var junctionTarget string
+	originalJunction := junctionFunc
+	t.Cleanup(func() { junctionFunc = originalJunction })
+	junctionFunc = func(target, linkPath string) error {
+		junctionTarget = target
+		return os.Mkdir(linkPath, 0755)
+	}
+
+	tempDir := t.TempDir()
+	target := filepath.Join(tempDir, "go1.22.0")
+	os.Mkdir(target, 0755)
+	link := filepath.Join(tempDir, "current")
+
+	strategy, err := Create(target, link, false)
+	if err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	if strategy != StrategyJunction {
+		t.Errorf("expected strategy %q, got %q", StrategyJunction, strategy)
+	}
+	if junctionTarget != target {
+		t.Errorf("expected junction to %q, got %q", target, junctionTarget)
+	}
+}
+
+func TestCreate_NoFallbackOutsideWindows(t *testing.T) {
+	failSymlinks(t, "linux")
+
+	tempDir := t.TempDir()
+	target := filepath.Join(tempDir, "go")
+	os.WriteFile(target, []byte("binary"), 0755)
+	link := filepath.Join(tempDir, "link")
+
+	if _, err := Create(target, link, false); err == nil {
+		t.Fatal("expected error when symlinks fail outside Windows, got nil")
+	}
+	if _, err := os.Lstat(link); !os.IsNotExist(err) {
+		t.Error("expected no file at link path after failure")
+	}
+}
+
+func TestCreate_FallbackMissingTarget(t *testing.T) {
+	failSymlinks(t, "windows")
+
+	tempDir := t.TempDir()
+	if _, err := Create(filepath.Join(tempDir, "missing"), filepath.Join(tempDir, "link"), false); err == nil {
+		t.Error("expected error when target does not exist, got nil")
+	}
+}
+
+func TestCreate_WindowsRealFallback(t *testing.T) {
+	if runtime.GOOS != "windows" {
+		t.Skip("Windows-only: exercises real mklink /J and .cmd shims")
+	}
+
+	originalSymlink := symlinkFunc
+	t.Cleanup(func() { symlinkFunc = originalSymlink })
+	symlinkFunc = func(oldname, newname string) error {
+		return &os.LinkError{Op: "symlink", Old: oldname, New: newname, Err: errors.New("a required privilege is not held by the client")}
+	}
+
+	tempDir := t.TempDir()
+
+	targetDir := filepath.Join(tempDir, "go1.22.0")
+	os.MkdirAll(filepath.Join(targetDir, "bin"), 0755)
+	os.WriteFile(filepath.Join(targetDir, "bin", "go.exe"), []byte("binary"), 0755)
+
+	strategy, err := Create(targetDir, filepath.Join(tempDir, "current"), false)
+	if err != nil {
+		t.Fatalf("Create (directory) failed: %v", err)
+	}
+	if strategy != StrategyJunction {
+		t.Errorf("expected strategy %q, got %q", StrategyJunction, strategy)
+	}
+	if _, err := os.Stat(filepath.Join(tempDir, "current", "bin", "go.exe")); err != nil {
+		t.Errorf("expected junction to expose target contents: %v", err)
+	}
+
+	strategy, err = Create(filepath.Join(targetDir, "bin", "go.exe"), filepath.Join(tempDir, "go.exe"), false)
+	if err != nil {
+		t.Fatalf("Create (file) failed: %v", err)
+	}
+	if strategy != StrategyShim {
+		t.Errorf("expected strategy %q, got %q", StrategyShim, strategy)
+	}
+}
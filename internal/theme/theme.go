@@ -0,0 +1,159 @@
+package theme
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+)
+
+// Style names the role of a piece of output; each theme maps styles to ANSI color codes.
+type Style int
+
+const (
+	Plain Style = iota
+	Error
+	Warning
+	Success
+	Muted
+	Accent
+)
+
+const reset = "\033[0m"
+
+// Theme is a named set of glyphs and colors used to draw progress bars and log prefixes.
+type Theme struct {
+	Name    string
+	Fill    string // filled part of a progress bar
+	Empty   string // unfilled part of a progress bar
+	Spinner []string
+	Colors  map[Style]string
+}
+
+// defaultColors is the palette shared by the built-in themes; only their glyphs differ.
+var defaultColors = map[Style]string{
+	Error:   "\033[31m",
+	Warning: "\033[33m",
+	Success: "\033[32m",
+	Muted:   "\033[2m",
+	Accent:  "\033[36m",
+}
+
+var (
+	// Default draws with Unicode block and braille characters.
+	Default = Theme{
+		Name:    "default",
+		Fill:    "█",
+		Empty:   "░",
+		Spinner: []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"},
+		Colors:  defaultColors,
+	}
+
+	// ASCII draws with plain ASCII characters for consoles without UTF-8, such as some Windows consoles.
+	ASCII = Theme{
+		Name:    "ascii",
+		Fill:    "#",
+		Empty:   "-",
+		Spinner: []string{"|", "/", "-", "\\"},
+		Colors:  defaultColors,
+	}
+)
+
+var builtin = []Theme{Default, ASCII}
+
+var (
+	mutex   sync.RWMutex
+	current = Default
+	noColor bool
+)
+
+// isTerminal reports whether w is a terminal rather than a pipe, file, or buffer. Replaced in tests.
+var isTerminal = func(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
+
+// IsTerminal reports whether w is a terminal rather than a pipe, file, or buffer.
+func IsTerminal(w io.Writer) bool {
+	return isTerminal(w)
+}
+
+// Names returns the names of the built-in themes, default first.
+func Names() []string {
+	names := make([]string, len(builtin))
+	for i, t := range builtin {
+		names[i] = t.Name
+	}
+	return names
+}
+
+// Lookup returns the built-in theme with the given name; an empty name selects Default.
+// Returns an error listing the valid names when no theme matches.
+func Lookup(name string) (Theme, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if name == "" {
+		return Default, nil
+	}
+	for _, t := range builtin {
+		if t.Name == name {
+			return t, nil
+		}
+	}
+	return Default, fmt.Errorf("unknown theme %q: must be one of %s", name, strings.Join(Names(), ", "))
+}
+
+// Set makes t the theme used by subsequent output.
+func Set(t Theme) {
+	mutex.Lock()
+	defer mutex.Unlock()
+	current = t
+}
+
+// Current returns the theme in use.
+func Current() Theme {
+	mutex.RLock()
+	defer mutex.RUnlock()
+	return current
+}
+
+// SetColor turns color on or off for all output, as set by --no-color.
+// Even when on, color is only used for terminals and when NO_COLOR is unset.
+func SetColor(enabled bool) {
+	mutex.Lock()
+	defer mutex.Unlock()
+	noColor = !enabled
+}
+
+// ColorEnabled reports whether output written to w should be colored: color has not been turned off
+// with SetColor, NO_COLOR is unset or empty, TERM is not "dumb", and w is a terminal.
+func ColorEnabled(w io.Writer) bool {
+	mutex.RLock()
+	disabled := noColor
+	mutex.RUnlock()
+
+	if disabled || os.Getenv("NO_COLOR") != "" || os.Getenv("TERM") == "dumb" {
+		return false
+	}
+	return isTerminal(w)
+}
+
+// Paint wraps s in the current theme's color for style when output to w is colored.
+// Returns s unchanged for Plain, an empty s, or when color is disabled.
+func Paint(w io.Writer, style Style, s string) string {
+	if style == Plain || s == "" || !ColorEnabled(w) {
+		return s
+	}
+	code := Current().Colors[style]
+	if code == "" {
+		return s
+	}
+	return code + s + reset
+}
@@ -0,0 +1,131 @@
+package theme
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+)
+
+// withTerminal makes every writer look like a terminal for the duration of the test.
+func withTerminal(t *testing.T, terminal bool) {
+	t.Helper()
+	original := isTerminal
+	isTerminal = func(io.Writer) bool { return terminal }
+	t.Cleanup(func() { isTerminal = original })
+}
+
+func TestLookup(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    string
+		wantErr bool
+	}{
+		{name: "Empty selects default", input: "", want: "default"},
+		{name: "Default", input: "default", want: "default"},
+		{name: "ASCII", input: "ascii", want: "ascii"},
+		{name: "Case and whitespace are ignored", input: " ASCII ", want: "ascii"},
+		{name: "Unknown theme", input: "neon", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Lookup(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Lookup(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if tt.wantErr {
+				if !strings.Contains(err.Error(), "default, ascii") {
+					t.Errorf("error should list the valid themes, got %q", err)
+				}
+				return
+			}
+			if got.Name != tt.want {
+				t.Errorf("Lookup(%q) = %q, want %q", tt.input, got.Name, tt.want)
+			}
+		})
+	}
+}
+
+func TestASCIIThemeIsASCII(t *testing.T) {
+	glyphs := append([]string{ASCII.Fill, ASCII.Empty}, ASCII.Spinner...)
+	for _, glyph := range glyphs {
+		for _, r := range glyph {
+			if r > 127 {
+				t.Errorf("ASCII theme glyph %q contains non-ASCII rune %q", glyph, r)
+			}
+		}
+	}
+}
+
+func TestColorEnabled(t *testing.T) {
+	tests := []struct {
+		name     string
+		terminal bool
+		color    bool
+		noColor  string
+		term     string
+		want     bool
+	}{
+		{name: "Terminal", terminal: true, color: true, term: "xterm-256color", want: true},
+		{name: "Not a terminal", terminal: false, color: true, term: "xterm-256color", want: false},
+		{name: "No color flag", terminal: true, color: false, term: "xterm-256color", want: false},
+		{name: "NO_COLOR set", terminal: true, color: true, noColor: "1", term: "xterm-256color", want: false},
+		{name: "Dumb terminal", terminal: true, color: true, term: "dumb", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			withTerminal(t, tt.terminal)
+			t.Setenv("NO_COLOR", tt.noColor)
+			t.Setenv("TERM", tt.term)
+			SetColor(tt.color)
+			t.Cleanup(func() { SetColor(true) })
+
+			if got := ColorEnabled(io.Discard); got != tt.want {
+				t.Errorf("ColorEnabled() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPaint(t *testing.T) {
+	t.Setenv("NO_COLOR", "")
+	t.Setenv("TERM", "xterm-256color")
+	var buf bytes.Buffer
+
+	t.Run("Colors a styled string on a terminal", func(t *testing.T) {
+		withTerminal(t, true)
+		got := Paint(&buf, Error, "Error: ")
+		if got != Default.Colors[Error]+"Error: "+reset {
+			t.Errorf("Paint() = %q, want the error color around the text", got)
+		}
+	})
+
+	t.Run("Plain and empty strings are unchanged", func(t *testing.T) {
+		withTerminal(t, true)
+		if got := Paint(&buf, Plain, "text"); got != "text" {
+			t.Errorf("Paint(Plain) = %q, want %q", got, "text")
+		}
+		if got := Paint(&buf, Error, ""); got != "" {
+			t.Errorf("Paint(\"\") = %q, want an empty string", got)
+		}
+	})
+
+	t.Run("No escape codes without a terminal", func(t *testing.T) {
+		withTerminal(t, false)
+		if got := Paint(&buf, Success, "Success: "); got != "Success: " {
+			t.Errorf("Paint() = %q, want the text unchanged", got)
+		}
+	})
+}
+
+func TestSetCurrent(t *testing.T) {
+	t.Cleanup(func() { Set(Default) })
+
+	Set(ASCII)
+	if got := Current().Name; got != "ascii" {
+		t.Errorf("Current() = %q after Set(ASCII), want %q", got, "ascii")
+	}
+}
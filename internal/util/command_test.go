@@ -0,0 +1,79 @@
+package util
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestSplitCommand(t *testing.T) {
+	testCases := []struct {
+		name        string
+		command     string
+		expected    []string
+		expectError bool
+	}{
+		{
+			name:     "Program and arguments",
+			command:  "go install golang.org/x/tools/gopls@latest",
+			expected: []string{"go", "install", "golang.org/x/tools/gopls@latest"},
+		},
+		{
+			name:     "Extra whitespace",
+			command:  "  go\tversion  ",
+			expected: []string{"go", "version"},
+		},
+		{
+			name:     "Double quotes keep spaces",
+			command:  `go env -w "GOFLAGS=-mod=mod -trimpath"`,
+			expected: []string{"go", "env", "-w", "GOFLAGS=-mod=mod -trimpath"},
+		},
+		{
+			name:     "Single quotes are literal",
+			command:  `echo '$HOME; rm -rf /'`,
+			expected: []string{"echo", "$HOME; rm -rf /"},
+		},
+		{
+			name:     "Escapes inside double quotes",
+			command:  `echo "say \"hi\" C:\temp"`,
+			expected: []string{"echo", `say "hi" C:\temp`},
+		},
+		{
+			name:     "Empty quoted argument",
+			command:  `printf ""`,
+			expected: []string{"printf", ""},
+		},
+		{
+			name:     "Shell operators are plain arguments",
+			command:  "go version && touch pwned",
+			expected: []string{"go", "version", "&&", "touch", "pwned"},
+		},
+		{
+			name:        "Empty command",
+			command:     "   ",
+			expectError: true,
+		},
+		{
+			name:        "Unterminated quote",
+			command:     `go install "golang.org/x/tools/gopls@latest`,
+			expectError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := SplitCommand(tc.command)
+			if tc.expectError {
+				if err == nil {
+					t.Errorf("Expected error for %q but got %q", tc.command, result)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if !slices.Equal(result, tc.expected) {
+				t.Errorf("Expected %q, got %q", tc.expected, result)
+			}
+		})
+	}
+}
@@ -1,8 +1,11 @@
 package util
 
 import (
+	"errors"
 	"fmt"
 	"math"
+	"strconv"
+	"strings"
 	"time"
 )
 
@@ -53,3 +56,127 @@ func FormatDuration(d time.Duration) string {
 	minutes := int(d.Minutes()) % 60
 	return fmt.Sprintf("%dh%dm", hours, minutes)
 }
+
+// ParseBytes parses a human-readable byte size such as 512, 500k, 2 MB, or 1.5GB, the inverse of FormatBytes.
+// Units are B, K/KB, M/MB, G/GB, T/TB, P/PB, and E/EB in base 1024, case-insensitive, with or without a space.
+// A plain integer is a count of bytes. Returns the number of bytes or an error for empty, negative, or malformed input.
+func ParseBytes(s string) (int64, error) {
+	trimmed := strings.TrimSpace(s)
+	if trimmed == "" {
+		return 0, fmt.Errorf("empty byte size")
+	}
+
+	end := 0
+	for end < len(trimmed) && (trimmed[end] >= '0' && trimmed[end] <= '9' || trimmed[end] == '.') {
+		end++
+	}
+	number, unit := trimmed[:end], strings.ToUpper(strings.TrimSpace(trimmed[end:]))
+	if number == "" {
+		return 0, fmt.Errorf("invalid byte size %q: expected a number, optionally followed by a unit", s)
+	}
+
+	multiplier, ok := byteUnitMultiplier(unit)
+	if !ok {
+		return 0, fmt.Errorf("invalid byte size %q: unknown unit %q (use B, KB, MB, GB, TB, PB, or EB)", s, unit)
+	}
+
+	if multiplier == 1 || !strings.Contains(number, ".") {
+		count, err := strconv.ParseInt(number, 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid byte size %q: %s", s, byteSizeError(number, err))
+		}
+		if count > math.MaxInt64/multiplier {
+			return 0, fmt.Errorf("invalid byte size %q: value out of range", s)
+		}
+		return count * multiplier, nil
+	}
+
+	value, err := strconv.ParseFloat(number, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid byte size %q: malformed number %q", s, number)
+	}
+	bytes := value * float64(multiplier)
+	if bytes >= math.MaxInt64 {
+		return 0, fmt.Errorf("invalid byte size %q: value out of range", s)
+	}
+	return int64(bytes), nil
+}
+
+// byteUnitMultiplier returns the size of an upper-case unit accepted by ParseBytes, with or without its
+// trailing B, matching the byteSizeUnits of FormatBytes. Returns false for an unknown unit.
+func byteUnitMultiplier(unit string) (int64, bool) {
+	if unit == "" || unit == "B" {
+		return 1, true
+	}
+
+	prefix := strings.TrimSuffix(unit, "B")
+	multiplier := int64(1)
+	for _, known := range byteSizeUnits {
+		multiplier *= 1024
+		if prefix == known[:1] {
+			return multiplier, true
+		}
+	}
+	return 0, false
+}
+
+// byteSizeError describes why number could not be parsed as a whole byte count.
+func byteSizeError(number string, err error) string {
+	if errors.Is(err, strconv.ErrRange) {
+		return "value out of range"
+	}
+	if strings.Contains(number, ".") {
+		return "fractional bytes are not allowed"
+	}
+	return fmt.Sprintf("malformed number %q", number)
+}
+
+// durationUnits maps the unit suffixes accepted by ParseDuration to their length.
+var durationUnits = map[byte]time.Duration{
+	's': time.Second,
+	'm': time.Minute,
+	'h': time.Hour,
+	'd': 24 * time.Hour,
+	'w': 7 * 24 * time.Hour,
+}
+
+// ParseDuration parses durations written like FormatDuration output (45s, 3m12s, 2h05m), also accepting days and weeks (30d, 1w3d).
+// Units are s, m (minutes), h, d, and w, each used at most once from largest to smallest, so 5m1h and 1d2d are rejected.
+// Parameter s is the duration string. Returns the duration or an error for empty, negative, or malformed input.
+func ParseDuration(s string) (time.Duration, error) {
+	if s == "" {
+		return 0, fmt.Errorf("empty duration")
+	}
+
+	var total time.Duration
+	previous := time.Duration(math.MaxInt64)
+	rest := s
+	for rest != "" {
+		i := 0
+		for i < len(rest) && rest[i] >= '0' && rest[i] <= '9' {
+			i++
+		}
+		if i == 0 || i == len(rest) {
+			return 0, fmt.Errorf("invalid duration %q: expected a number followed by s, m, h, d, or w", s)
+		}
+
+		unit, ok := durationUnits[rest[i]]
+		if !ok {
+			return 0, fmt.Errorf("invalid duration %q: unknown unit %q", s, rest[i])
+		}
+		if unit >= previous {
+			return 0, fmt.Errorf("invalid duration %q: units must appear once each, largest first (w, d, h, m, s)", s)
+		}
+		previous = unit
+
+		n, err := strconv.ParseInt(rest[:i], 10, 64)
+		if err != nil || time.Duration(n) > (math.MaxInt64-total)/unit {
+			return 0, fmt.Errorf("invalid duration %q: value out of range", s)
+		}
+
+		total += time.Duration(n) * unit
+		rest = rest[i+1:]
+	}
+
+	return total, nil
+}
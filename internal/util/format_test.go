@@ -180,3 +180,123 @@ func TestFormatDuration(t *testing.T) {
 		})
 	}
 }
+
+func TestParseDuration(t *testing.T) {
+	testCases := []struct {
+		name     string
+		input    string
+		expected time.Duration
+		wantErr  bool
+	}{
+		{name: "Seconds", input: "45s", expected: 45 * time.Second},
+		{name: "Minutes and seconds", input: "3m12s", expected: 3*time.Minute + 12*time.Second},
+		{name: "Hours with padded minutes", input: "2h05m", expected: 2*time.Hour + 5*time.Minute},
+		{name: "Days", input: "30d", expected: 30 * 24 * time.Hour},
+		{name: "Weeks and days", input: "1w2d", expected: 9 * 24 * time.Hour},
+		{name: "Weeks, days, and hours", input: "1w3d12h", expected: 10*24*time.Hour + 12*time.Hour},
+		{name: "Zero", input: "0s", expected: 0},
+		{name: "Empty", input: "", wantErr: true},
+		{name: "Number without unit", input: "30", wantErr: true},
+		{name: "Unit without number", input: "d", wantErr: true},
+		{name: "Unknown unit", input: "3y", wantErr: true},
+		{name: "Negative", input: "-5m", wantErr: true},
+		{name: "Fractional", input: "1.5h", wantErr: true},
+		{name: "Overflow", input: "99999999999w", wantErr: true},
+		{name: "Combined overflow", input: "15250w100000d", wantErr: true},
+		{name: "Repeated unit", input: "1d2d", wantErr: true},
+		{name: "Units out of order", input: "5m1h", wantErr: true},
+		{name: "Trailing number", input: "1w3", wantErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := ParseDuration(tc.input)
+			if tc.wantErr {
+				if err == nil {
+					t.Errorf("ParseDuration(%q) expected error, got %v", tc.input, result)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseDuration(%q) unexpected error: %v", tc.input, err)
+			}
+			if result != tc.expected {
+				t.Errorf("ParseDuration(%q) = %v; want %v", tc.input, result, tc.expected)
+			}
+		})
+	}
+}
+
+func TestParseBytes(t *testing.T) {
+	testCases := []struct {
+		name     string
+		input    string
+		expected int64
+		wantErr  bool
+	}{
+		{name: "Plain bytes", input: "512", expected: 512},
+		{name: "Kilobytes", input: "500k", expected: 500 * 1024},
+		{name: "Megabytes upper case", input: "2M", expected: 2 * 1024 * 1024},
+		{name: "Gigabytes", input: "1g", expected: 1 << 30},
+		{name: "Fractional", input: "1.5m", expected: 3 * 512 * 1024},
+		{name: "Bytes unit", input: "100B", expected: 100},
+		{name: "Two-letter unit", input: "10KB", expected: 10 * 1024},
+		{name: "Unit after space", input: "5 MB", expected: 5 * 1024 * 1024},
+		{name: "Lower-case unit", input: "3 gb", expected: 3 << 30},
+		{name: "Terabytes", input: "2TB", expected: 2 << 40},
+		{name: "Surrounding spaces", input: "  7 k ", expected: 7 * 1024},
+		{name: "Zero", input: "0", expected: 0},
+		{name: "Empty", input: "", wantErr: true},
+		{name: "Only spaces", input: "   ", wantErr: true},
+		{name: "Unit without number", input: "k", wantErr: true},
+		{name: "Unknown unit", input: "5x", wantErr: true},
+		{name: "Unit with extra letters", input: "5 kbytes", wantErr: true},
+		{name: "Negative", input: "-5k", wantErr: true},
+		{name: "Fractional bytes", input: "1.5", wantErr: true},
+		{name: "Malformed number", input: "1.2.3mb", wantErr: true},
+		{name: "Overflow", input: "99999999999g", wantErr: true},
+		{name: "Overflow without unit", input: "99999999999999999999", wantErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := ParseBytes(tc.input)
+			if tc.wantErr {
+				if err == nil {
+					t.Errorf("ParseBytes(%q) expected error, got %v", tc.input, result)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseBytes(%q) unexpected error: %v", tc.input, err)
+			}
+			if result != tc.expected {
+				t.Errorf("ParseBytes(%q) = %d; want %d", tc.input, result, tc.expected)
+			}
+		})
+	}
+}
+
+func TestParseBytes_RoundTrip(t *testing.T) {
+	for _, size := range []int64{0, 512, 1024, 5 * 1024 * 1024, 3 << 30, 2 << 40, 3 << 50} {
+		parsed, err := ParseBytes(FormatBytes(size))
+		if err != nil {
+			t.Fatalf("ParseBytes(FormatBytes(%d)) error: %v", size, err)
+		}
+		if parsed != size {
+			t.Errorf("Round trip of %d via %q gave %d", size, FormatBytes(size), parsed)
+		}
+	}
+}
+
+func TestParseDuration_RoundTrip(t *testing.T) {
+	for _, d := range []time.Duration{30 * time.Second, 2*time.Minute + 30*time.Second, 3*time.Hour + 45*time.Minute} {
+		parsed, err := ParseDuration(FormatDuration(d))
+		if err != nil {
+			t.Fatalf("ParseDuration(FormatDuration(%v)) error: %v", d, err)
+		}
+		if parsed != d {
+			t.Errorf("Round trip of %v gave %v", d, parsed)
+		}
+	}
+}
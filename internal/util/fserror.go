@@ -0,0 +1,48 @@
+package util
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+)
+
+// PermissionError is a filesystem permission denial, reported with advice on where to look.
+type PermissionError struct {
+	Dir string // directory whose ownership and permissions to check
+	Err error
+}
+
+// Error returns the underlying error followed by the advice.
+func (e *PermissionError) Error() string {
+	return fmt.Sprintf("%v (check the ownership and permissions of %s, or run govman as the user that owns it)", e.Err, e.Dir)
+}
+
+// Unwrap returns the underlying error, so errors.Is(err, fs.ErrPermission) still holds.
+func (e *PermissionError) Unwrap() error {
+	return e.Err
+}
+
+// IsPermissionError reports whether err is, or wraps, a filesystem permission denial.
+func IsPermissionError(err error) bool {
+	return errors.Is(err, fs.ErrPermission)
+}
+
+// WithPermissionHint wraps err in a *PermissionError when it is a permission denial. The advice names the
+// directory holding the denied path, or dir when err does not say which path was denied.
+// Any other error, nil, or an error that already carries the advice is returned unchanged.
+func WithPermissionHint(err error, dir string) error {
+	var permErr *PermissionError
+	if !IsPermissionError(err) || errors.As(err, &permErr) {
+		return err
+	}
+
+	var pathErr *fs.PathError
+	if errors.As(err, &pathErr) && pathErr.Path != "" {
+		dir = filepath.Dir(pathErr.Path)
+		if abs, absErr := filepath.Abs(dir); absErr == nil {
+			dir = abs
+		}
+	}
+	return &PermissionError{Dir: dir, Err: err}
+}
@@ -0,0 +1,85 @@
+package util
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestWithPermissionHint(t *testing.T) {
+	denied := &fs.PathError{Op: "open", Path: "/srv/govman/cache/go.tar.gz", Err: fs.ErrPermission}
+
+	testCases := []struct {
+		name     string
+		err      error
+		dir      string
+		wantHint bool
+		wantDir  string
+	}{
+		{
+			name: "Nil error",
+			err:  nil,
+		},
+		{
+			name: "Other error",
+			err:  fmt.Errorf("failed to open: %w", fs.ErrNotExist),
+		},
+		{
+			name:     "Denied path names its directory",
+			err:      fmt.Errorf("failed to download: %w", denied),
+			dir:      "/srv/govman",
+			wantHint: true,
+			wantDir:  "/srv/govman/cache",
+		},
+		{
+			name:     "Denial without a path falls back to dir",
+			err:      &os.LinkError{Op: "symlink", Old: "a", New: "b", Err: fs.ErrPermission},
+			dir:      "/srv/govman/bin",
+			wantHint: true,
+			wantDir:  "/srv/govman/bin",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := WithPermissionHint(tc.err, tc.dir)
+
+			var permErr *PermissionError
+			if errors.As(got, &permErr) != tc.wantHint {
+				t.Fatalf("WithPermissionHint() = %v, want hint %v", got, tc.wantHint)
+			}
+			if !tc.wantHint {
+				if got != tc.err {
+					t.Errorf("WithPermissionHint() = %v, want the error unchanged", got)
+				}
+				return
+			}
+
+			if permErr.Dir != tc.wantDir {
+				t.Errorf("Dir = %q, want %q", permErr.Dir, tc.wantDir)
+			}
+			if !strings.Contains(got.Error(), tc.err.Error()) || !strings.Contains(got.Error(), "check the ownership and permissions of "+tc.wantDir) {
+				t.Errorf("Error() = %q, want the original message and advice naming %s", got, tc.wantDir)
+			}
+			if !IsPermissionError(got) {
+				t.Error("the hinted error should still be a permission error")
+			}
+		})
+	}
+}
+
+func TestWithPermissionHintOnce(t *testing.T) {
+	err := WithPermissionHint(&fs.PathError{Op: "mkdir", Path: "x", Err: fs.ErrPermission}, "")
+	twice := WithPermissionHint(fmt.Errorf("outer: %w", err), "")
+
+	if strings.Count(twice.Error(), "check the ownership") != 1 {
+		t.Errorf("advice should appear once, got %q", twice)
+	}
+	if want, _ := filepath.Abs("."); !strings.Contains(err.Error(), want) {
+		t.Errorf("relative paths should be reported as absolute directories, got %q", err)
+	}
+}
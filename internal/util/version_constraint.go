@@ -0,0 +1,170 @@
+package util
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	_golang "github.com/justjundana/govman/internal/golang"
+)
+
+// constraintTermRegex matches a single constraint term such as "^1.22", "~1.22.3", or ">=1.21".
+var constraintTermRegex = regexp.MustCompile(`^(\^|~|>=|<=|>|<|=)?(\d+)(?:\.(\d+))?(?:\.(\d+))?$`)
+
+// VersionConstraint is a set of comparators that a version must all satisfy.
+type VersionConstraint struct {
+	raw         string
+	comparators []versionComparator
+}
+
+// versionComparator is a single bound, e.g. ">= 1.22.0".
+type versionComparator struct {
+	op      string
+	version string
+}
+
+// IsVersionConstraint reports whether input uses constraint syntax (^, ~, >, <, or =)
+// rather than an exact version, alias, or wildcard pattern.
+func IsVersionConstraint(input string) bool {
+	return strings.ContainsAny(input, "^~<>=")
+}
+
+// ParseVersionConstraint parses npm-style constraints:
+//   - "^1.22" matches >=1.22.0 <2.0.0
+//   - "~1.22.3" matches >=1.22.3 <1.23.0
+//   - ">=1.21 <1.23" matches every term, separated by spaces or commas
+//
+// Returns an error describing the first invalid term.
+func ParseVersionConstraint(constraint string) (*VersionConstraint, error) {
+	terms := strings.FieldsFunc(constraint, func(r rune) bool {
+		return r == ' ' || r == ','
+	})
+	if len(terms) == 0 {
+		return nil, fmt.Errorf("empty version constraint")
+	}
+
+	c := &VersionConstraint{raw: constraint}
+	for _, term := range terms {
+		comparators, err := parseConstraintTerm(term)
+		if err != nil {
+			return nil, fmt.Errorf("invalid version constraint %q: %w", constraint, err)
+		}
+		c.comparators = append(c.comparators, comparators...)
+	}
+
+	return c, nil
+}
+
+// parseConstraintTerm expands a single term into one or two comparators.
+func parseConstraintTerm(term string) ([]versionComparator, error) {
+	matches := constraintTermRegex.FindStringSubmatch(term)
+	if matches == nil {
+		return nil, fmt.Errorf("%q is not a valid term (expected e.g. ^1.22, ~1.22.3, >=1.21)", term)
+	}
+
+	op := matches[1]
+	major, _ := strconv.Atoi(matches[2])
+	minor, _ := strconv.Atoi(matches[3])
+	patch, _ := strconv.Atoi(matches[4])
+	hasMinor := matches[3] != ""
+	hasPatch := matches[4] != ""
+	lower := fmt.Sprintf("%d.%d.%d", major, minor, patch)
+
+	switch op {
+	case "^":
+		var upper string
+		switch {
+		case major > 0 || !hasMinor:
+			upper = fmt.Sprintf("%d.0.0", major+1)
+		case minor > 0 || !hasPatch:
+			upper = fmt.Sprintf("0.%d.0", minor+1)
+		default:
+			upper = fmt.Sprintf("0.0.%d", patch+1)
+		}
+		return []versionComparator{{">=", lower}, {"<", upper}}, nil
+
+	case "~":
+		upper := fmt.Sprintf("%d.%d.0", major, minor+1)
+		if !hasMinor {
+			upper = fmt.Sprintf("%d.0.0", major+1)
+		}
+		return []versionComparator{{">=", lower}, {"<", upper}}, nil
+
+	case "", "=":
+		// A bare partial version like "=1.22" matches every 1.22.x release
+		switch {
+		case !hasMinor:
+			return []versionComparator{{">=", lower}, {"<", fmt.Sprintf("%d.0.0", major+1)}}, nil
+		case !hasPatch:
+			return []versionComparator{{">=", lower}, {"<", fmt.Sprintf("%d.%d.0", major, minor+1)}}, nil
+		}
+		return []versionComparator{{"=", lower}}, nil
+	}
+
+	return []versionComparator{{op, lower}}, nil
+}
+
+// Matches reports whether version satisfies every comparator in the constraint.
+// Pre-release versions (beta, rc, alpha) never match.
+func (c *VersionConstraint) Matches(version string) bool {
+	if IsPrereleaseVersion(version) {
+		return false
+	}
+
+	for _, comparator := range c.comparators {
+		cmp := _golang.CompareVersions(version, comparator.version)
+		var ok bool
+		switch comparator.op {
+		case ">=":
+			ok = cmp >= 0
+		case ">":
+			ok = cmp > 0
+		case "<=":
+			ok = cmp <= 0
+		case "<":
+			ok = cmp < 0
+		case "=":
+			ok = cmp == 0
+		}
+		if !ok {
+			return false
+		}
+	}
+
+	return true
+}
+
+// String returns the constraint as originally written.
+func (c *VersionConstraint) String() string {
+	return c.raw
+}
+
+// ResolveVersionConstraint returns the highest stable version in versions that satisfies constraint.
+// Returns an error if the constraint is invalid or no version matches.
+func ResolveVersionConstraint(constraint string, versions []string) (string, error) {
+	c, err := ParseVersionConstraint(constraint)
+	if err != nil {
+		return "", err
+	}
+
+	best := ""
+	for _, v := range versions {
+		if c.Matches(v) && (best == "" || _golang.CompareVersions(v, best) > 0) {
+			best = v
+		}
+	}
+
+	if best == "" {
+		return "", fmt.Errorf("no stable version satisfies %q", constraint)
+	}
+
+	return best, nil
+}
+
+// IsPrereleaseVersion reports whether version is a beta, rc, or alpha release.
+func IsPrereleaseVersion(version string) bool {
+	return strings.Contains(version, "rc") ||
+		strings.Contains(version, "beta") ||
+		strings.Contains(version, "alpha")
+}
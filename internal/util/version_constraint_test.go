@@ -0,0 +1,166 @@
+package util
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestIsVersionConstraint(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected bool
+	}{
+		{"^1.22", true},
+		{"~1.22.3", true},
+		{">=1.21 <1.23", true},
+		{"=1.22.1", true},
+		{"1.22.1", false},
+		{"1.22", false},
+		{"latest", false},
+		{"1.22.*", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			if got := IsVersionConstraint(tt.input); got != tt.expected {
+				t.Errorf("IsVersionConstraint(%q) = %v, want %v", tt.input, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestParseVersionConstraint_Errors(t *testing.T) {
+	tests := []struct {
+		name       string
+		constraint string
+		errContain string
+	}{
+		{"empty", "", "empty version constraint"},
+		{"only separators", " , ", "empty version constraint"},
+		{"operator without version", ">=", "not a valid term"},
+		{"garbage", "^abc", "not a valid term"},
+		{"prerelease bound", "^1.22rc1", "not a valid term"},
+		{"too many components", "~1.22.3.4", "not a valid term"},
+		{"double operator", ">>1.21", "not a valid term"},
+		{"shell metacharacters", "^1.22;rm", "not a valid term"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseVersionConstraint(tt.constraint)
+			if err == nil {
+				t.Fatalf("ParseVersionConstraint(%q) expected error", tt.constraint)
+			}
+			if !strings.Contains(err.Error(), tt.errContain) {
+				t.Errorf("ParseVersionConstraint(%q) error = %v, want containing %q", tt.constraint, err, tt.errContain)
+			}
+		})
+	}
+}
+
+func TestVersionConstraint_Matches(t *testing.T) {
+	tests := []struct {
+		constraint string
+		matches    []string
+		rejects    []string
+	}{
+		{
+			constraint: "^1.22",
+			matches:    []string{"1.22", "1.22.0", "1.22.5", "1.25.1"},
+			rejects:    []string{"1.21.9", "2.0.0", "1.23rc1"},
+		},
+		{
+			constraint: "^0.2.3",
+			matches:    []string{"0.2.3", "0.2.9"},
+			rejects:    []string{"0.3.0", "0.2.2"},
+		},
+		{
+			constraint: "~1.22.3",
+			matches:    []string{"1.22.3", "1.22.10"},
+			rejects:    []string{"1.22.2", "1.23.0"},
+		},
+		{
+			constraint: "~1.22",
+			matches:    []string{"1.22", "1.22.8"},
+			rejects:    []string{"1.21.5", "1.23.0"},
+		},
+		{
+			constraint: "~1",
+			matches:    []string{"1.0.0", "1.25.1"},
+			rejects:    []string{"2.0.0"},
+		},
+		{
+			constraint: ">=1.21 <1.23",
+			matches:    []string{"1.21", "1.21.13", "1.22.8"},
+			rejects:    []string{"1.20.14", "1.23.0", "1.22rc2"},
+		},
+		{
+			constraint: ">1.21.0,<=1.22.2",
+			matches:    []string{"1.21.1", "1.22.2"},
+			rejects:    []string{"1.21.0", "1.22.3"},
+		},
+		{
+			constraint: "=1.22.1",
+			matches:    []string{"1.22.1"},
+			rejects:    []string{"1.22.0", "1.22.2"},
+		},
+		{
+			constraint: "=1.22",
+			matches:    []string{"1.22.0", "1.22.9"},
+			rejects:    []string{"1.21.9", "1.23.0"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.constraint, func(t *testing.T) {
+			c, err := ParseVersionConstraint(tt.constraint)
+			if err != nil {
+				t.Fatalf("ParseVersionConstraint(%q) error = %v", tt.constraint, err)
+			}
+			if c.String() != tt.constraint {
+				t.Errorf("String() = %q, want %q", c.String(), tt.constraint)
+			}
+			for _, v := range tt.matches {
+				if !c.Matches(v) {
+					t.Errorf("%q should match %s", tt.constraint, v)
+				}
+			}
+			for _, v := range tt.rejects {
+				if c.Matches(v) {
+					t.Errorf("%q should not match %s", tt.constraint, v)
+				}
+			}
+		})
+	}
+}
+
+func TestResolveVersionConstraint(t *testing.T) {
+	available := []string{"1.25.1", "1.25rc1", "1.24.7", "1.23.12", "1.22.10", "1.22.3", "1.21.13", "1.20.14"}
+
+	tests := []struct {
+		name       string
+		constraint string
+		expected   string
+		wantErr    bool
+	}{
+		{"caret picks highest in major", "^1.22", "1.25.1", false},
+		{"tilde stays within minor", "~1.22.3", "1.22.10", false},
+		{"range", ">=1.21 <1.23", "1.22.10", false},
+		{"upper bound only", "<1.22", "1.21.13", false},
+		{"exact", "=1.22.3", "1.22.3", false},
+		{"no match", "^2.0", "", true},
+		{"invalid", "^one", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ResolveVersionConstraint(tt.constraint, available)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ResolveVersionConstraint(%q) error = %v, wantErr %v", tt.constraint, err, tt.wantErr)
+			}
+			if got != tt.expected {
+				t.Errorf("ResolveVersionConstraint(%q) = %q, want %q", tt.constraint, got, tt.expected)
+			}
+		})
+	}
+}
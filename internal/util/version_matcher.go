@@ -3,6 +3,7 @@ package util
 import (
 	"fmt"
 	"sort"
+	"strconv"
 	"strings"
 
 	_golang "github.com/justjundana/govman/internal/golang"
@@ -22,8 +23,9 @@ func ExtractMajorMinor(version string) string {
 }
 
 // FindBestMatchingVersion finds the best matching installed version for a requested version.
-// It matches based on major.minor version (e.g., "1.25" matches "1.25.1", "1.25.4", etc.).
-// If multiple versions match, it returns the highest (latest patch) version.
+// It matches based on major.minor version (e.g., "1.25" matches "1.25.1", "1.25.4", "1.25rc1", etc.).
+// Stable releases are preferred; the newest prerelease is returned only when no stable release matches,
+// or when the requested version is itself a prerelease.
 //
 // Parameters:
 //   - requestedVersion: The version requested (can be partial like "1.25" or full like "1.25.4")
@@ -35,35 +37,59 @@ func ExtractMajorMinor(version string) string {
 // Examples:
 //   - requestedVersion="1.25", installedVersions=["1.25.1", "1.25.4", "1.26.0"] -> "1.25.4"
 //   - requestedVersion="1.25.4", installedVersions=["1.25.1", "1.24.3"] -> "1.25.1"
+//   - requestedVersion="1.25", installedVersions=["1.25rc1", "1.25.0"] -> "1.25.0"
 //   - requestedVersion="1.25", installedVersions=["1.24.5", "1.26.0"] -> error
 func FindBestMatchingVersion(requestedVersion string, installedVersions []string) (string, error) {
+	return findBestMatchingVersion(requestedVersion, installedVersions, IsPrereleaseVersion(requestedVersion))
+}
+
+// FindBestMatchingVersionPreferPrerelease works like FindBestMatchingVersion but opts in to prereleases:
+// the newest beta/rc on the requested major.minor line wins, falling back to the highest stable release.
+//
+// Examples:
+//   - requestedVersion="1.25", installedVersions=["1.25rc1", "1.25rc2", "1.24.5"] -> "1.25rc2"
+//   - requestedVersion="1.25", installedVersions=["1.25.1", "1.24.5"] -> "1.25.1"
+func FindBestMatchingVersionPreferPrerelease(requestedVersion string, installedVersions []string) (string, error) {
+	return findBestMatchingVersion(requestedVersion, installedVersions, true)
+}
+
+// findBestMatchingVersion returns the highest installed version on the requested major.minor line,
+// taken from prereleases first when preferPrerelease is set and from stable releases first otherwise.
+func findBestMatchingVersion(requestedVersion string, installedVersions []string, preferPrerelease bool) (string, error) {
 	if len(installedVersions) == 0 {
 		return "", fmt.Errorf("no versions installed")
 	}
 
-	requestedMajorMinor := ExtractMajorMinor(requestedVersion)
+	requestedMajorMinor := ReleaseLine(requestedVersion)
 
-	// Find all versions that match the major.minor
-	var matchingVersions []string
+	// Find all versions that match the major.minor, split by stability
+	var stable, prerelease []string
 	for _, installed := range installedVersions {
-		installedMajorMinor := ExtractMajorMinor(installed)
-		if installedMajorMinor == requestedMajorMinor {
-			matchingVersions = append(matchingVersions, installed)
+		if ReleaseLine(installed) != requestedMajorMinor {
+			continue
+		}
+		if IsPrereleaseVersion(installed) {
+			prerelease = append(prerelease, installed)
+		} else {
+			stable = append(stable, installed)
 		}
 	}
 
-	if len(matchingVersions) == 0 {
+	if len(stable) == 0 && len(prerelease) == 0 {
 		return "", fmt.Errorf("no installed version matches %s (major.minor: %s)", requestedVersion, requestedMajorMinor)
 	}
 
-	// If there's only one match, return it
-	if len(matchingVersions) == 1 {
-		return matchingVersions[0], nil
+	candidates, fallback := stable, prerelease
+	if preferPrerelease {
+		candidates, fallback = prerelease, stable
+	}
+	if len(candidates) == 0 {
+		candidates = fallback
 	}
 
 	// If multiple matches, return the highest version
-	bestVersion := matchingVersions[0]
-	for _, v := range matchingVersions[1:] {
+	bestVersion := candidates[0]
+	for _, v := range candidates[1:] {
 		if _golang.CompareVersions(v, bestVersion) > 0 {
 			bestVersion = v
 		}
@@ -72,6 +98,85 @@ func FindBestMatchingVersion(requestedVersion string, installedVersions []string
 	return bestVersion, nil
 }
 
+// ReleaseLine returns the major.minor line of a version, ignoring any prerelease suffix
+// (e.g., "1.25rc1" -> "1.25", "1.25-rc1" -> "1.25").
+func ReleaseLine(version string) string {
+	line := ExtractMajorMinor(version)
+	for i := 0; i < len(line); i++ {
+		if !isDigit(line[i]) && line[i] != '.' {
+			return line[:i]
+		}
+	}
+	return line
+}
+
+// LatestPerMinor keeps only the newest version of each major.minor line, so prereleases only
+// survive for lines without a stable release. The result is sorted in descending order.
+// Example: ["1.25.1", "1.25.0", "1.26rc1", "1.24.7"] -> ["1.26rc1", "1.25.1", "1.24.7"]
+func LatestPerMinor(versions []string) []string {
+	latest := make(map[string]string)
+	for _, v := range versions {
+		line := ReleaseLine(v)
+		if current, ok := latest[line]; !ok || _golang.CompareVersions(v, current) > 0 {
+			latest[line] = v
+		}
+	}
+
+	result := make([]string, 0, len(latest))
+	for _, v := range latest {
+		result = append(result, v)
+	}
+
+	sortVersionsDescending(result)
+	return result
+}
+
+// ReleaseLineGroup is one major.minor line and its versions, as returned by GroupByReleaseLine.
+type ReleaseLineGroup struct {
+	Line     string   // major.minor, e.g. "1.25"
+	Versions []string // newest first
+}
+
+// GroupByReleaseLine groups versions by their major.minor line, with prereleases under the line they precede.
+// Lines and the versions within each line are sorted in descending order.
+// Example: ["1.24.7", "1.25.1", "1.25rc1"] -> [{1.25 [1.25.1 1.25rc1]} {1.24 [1.24.7]}]
+func GroupByReleaseLine(versions []string) []ReleaseLineGroup {
+	sorted := make([]string, len(versions))
+	copy(sorted, versions)
+	sortVersionsDescending(sorted)
+
+	// Newest first, so each line starts at its newest version and lines come out in descending order
+	var groups []ReleaseLineGroup
+	index := make(map[string]int)
+	for _, v := range sorted {
+		line := ReleaseLine(v)
+		i, ok := index[line]
+		if !ok {
+			i = len(groups)
+			index[line] = i
+			groups = append(groups, ReleaseLineGroup{Line: line})
+		}
+		groups[i].Versions = append(groups[i].Versions, v)
+	}
+
+	return groups
+}
+
+// FilterByMajor returns the versions whose major component equals major, preserving order.
+// Example: major=1 keeps "1.25.1" and "1.26rc1" but drops "2.0.0".
+func FilterByMajor(versions []string, major int) []string {
+	prefix := strconv.Itoa(major)
+
+	var filtered []string
+	for _, v := range versions {
+		if v == prefix || strings.HasPrefix(v, prefix+".") {
+			filtered = append(filtered, v)
+		}
+	}
+
+	return filtered
+}
+
 // IsWildcardPattern checks if a version string contains a wildcard pattern.
 // Supports patterns like "1.14.*" where * matches any suffix.
 func IsWildcardPattern(version string) bool {
@@ -1,6 +1,7 @@
 package util
 
 import (
+	"reflect"
 	"testing"
 )
 
@@ -104,6 +105,34 @@ func TestFindBestMatchingVersion(t *testing.T) {
 			expectedVersion:   "1.25.9",
 			expectError:       false,
 		},
+		{
+			name:              "stable preferred over prerelease",
+			requestedVersion:  "1.22",
+			installedVersions: []string{"1.22rc1", "1.22.0", "1.22rc2"},
+			expectedVersion:   "1.22.0",
+			expectError:       false,
+		},
+		{
+			name:              "stable preferred over prerelease in any order",
+			requestedVersion:  "1.22",
+			installedVersions: []string{"1.22.0", "1.22rc2", "1.21.13"},
+			expectedVersion:   "1.22.0",
+			expectError:       false,
+		},
+		{
+			name:              "falls back to newest prerelease when no stable",
+			requestedVersion:  "1.23",
+			installedVersions: []string{"1.23rc1", "1.23beta2", "1.23rc2", "1.22.5"},
+			expectedVersion:   "1.23rc2",
+			expectError:       false,
+		},
+		{
+			name:              "prerelease request prefers prereleases",
+			requestedVersion:  "1.22rc1",
+			installedVersions: []string{"1.22.0", "1.22rc2"},
+			expectedVersion:   "1.22rc2",
+			expectError:       false,
+		},
 	}
 
 	for _, tt := range tests {
@@ -129,6 +158,74 @@ func TestFindBestMatchingVersion(t *testing.T) {
 	}
 }
 
+func TestFindBestMatchingVersionPreferPrerelease(t *testing.T) {
+	tests := []struct {
+		name              string
+		requestedVersion  string
+		installedVersions []string
+		expectedVersion   string
+		expectError       bool
+	}{
+		{
+			name:              "newest prerelease preferred over stable",
+			requestedVersion:  "1.22",
+			installedVersions: []string{"1.22.0", "1.22rc1", "1.22rc2"},
+			expectedVersion:   "1.22rc2",
+			expectError:       false,
+		},
+		{
+			name:              "rc preferred over beta",
+			requestedVersion:  "1.23.0",
+			installedVersions: []string{"1.23beta1", "1.23rc1", "1.23alpha1"},
+			expectedVersion:   "1.23rc1",
+			expectError:       false,
+		},
+		{
+			name:              "falls back to highest stable when no prerelease",
+			requestedVersion:  "1.22",
+			installedVersions: []string{"1.22.1", "1.22.3", "1.23rc1"},
+			expectedVersion:   "1.22.3",
+			expectError:       false,
+		},
+		{
+			name:              "no matching version",
+			requestedVersion:  "1.24",
+			installedVersions: []string{"1.23rc1", "1.22.3"},
+			expectedVersion:   "",
+			expectError:       true,
+		},
+		{
+			name:              "empty installed versions",
+			requestedVersion:  "1.24",
+			installedVersions: []string{},
+			expectedVersion:   "",
+			expectError:       true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := FindBestMatchingVersionPreferPrerelease(tt.requestedVersion, tt.installedVersions)
+
+			if tt.expectError {
+				if err == nil {
+					t.Errorf("FindBestMatchingVersionPreferPrerelease(%q, %v) expected error but got none", tt.requestedVersion, tt.installedVersions)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Errorf("FindBestMatchingVersionPreferPrerelease(%q, %v) unexpected error: %v", tt.requestedVersion, tt.installedVersions, err)
+				return
+			}
+
+			if result != tt.expectedVersion {
+				t.Errorf("FindBestMatchingVersionPreferPrerelease(%q, %v) = %q, want %q", tt.requestedVersion, tt.installedVersions, result, tt.expectedVersion)
+			}
+		})
+	}
+}
+
 func TestIsWildcardPattern(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -446,3 +543,123 @@ func TestSortVersionsDescending(t *testing.T) {
 		})
 	}
 }
+
+func TestLatestPerMinor(t *testing.T) {
+	tests := []struct {
+		name     string
+		versions []string
+		expected []string
+	}{
+		{
+			name:     "keeps newest patch per minor",
+			versions: []string{"1.25.1", "1.25.0", "1.24.7", "1.24.6", "1.23.12"},
+			expected: []string{"1.25.1", "1.24.7", "1.23.12"},
+		},
+		{
+			name:     "stable release wins over its prereleases",
+			versions: []string{"1.25rc2", "1.25.0", "1.25rc1", "1.24.7"},
+			expected: []string{"1.25.0", "1.24.7"},
+		},
+		{
+			name:     "prerelease kept for line without stable",
+			versions: []string{"1.26rc1", "1.26beta1", "1.25.1", "1.25.0"},
+			expected: []string{"1.26rc1", "1.25.1"},
+		},
+		{
+			name:     "unsorted input",
+			versions: []string{"1.23.1", "1.25.0", "1.23.4", "1.25.2"},
+			expected: []string{"1.25.2", "1.23.4"},
+		},
+		{
+			name:     "empty input",
+			versions: []string{},
+			expected: []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := LatestPerMinor(tt.versions)
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("LatestPerMinor(%v) = %v, want %v", tt.versions, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestGroupByReleaseLine(t *testing.T) {
+	tests := []struct {
+		name     string
+		versions []string
+		expected []ReleaseLineGroup
+	}{
+		{
+			name:     "patches nested under their line",
+			versions: []string{"1.24.6", "1.25.1", "1.24.7", "1.25.0"},
+			expected: []ReleaseLineGroup{
+				{Line: "1.25", Versions: []string{"1.25.1", "1.25.0"}},
+				{Line: "1.24", Versions: []string{"1.24.7", "1.24.6"}},
+			},
+		},
+		{
+			name:     "prereleases join the line they precede",
+			versions: []string{"1.26rc1", "1.25.0", "1.25rc2", "1.25-beta1"},
+			expected: []ReleaseLineGroup{
+				{Line: "1.26", Versions: []string{"1.26rc1"}},
+				{Line: "1.25", Versions: []string{"1.25.0", "1.25rc2", "1.25-beta1"}},
+			},
+		},
+		{
+			name:     "minor lines ordered numerically",
+			versions: []string{"1.9.7", "1.10.8", "2.0.0"},
+			expected: []ReleaseLineGroup{
+				{Line: "2.0", Versions: []string{"2.0.0"}},
+				{Line: "1.10", Versions: []string{"1.10.8"}},
+				{Line: "1.9", Versions: []string{"1.9.7"}},
+			},
+		},
+		{
+			name:     "empty input",
+			versions: []string{},
+			expected: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			input := make([]string, len(tt.versions))
+			copy(input, tt.versions)
+			result := GroupByReleaseLine(input)
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("GroupByReleaseLine(%v) = %v, want %v", tt.versions, result, tt.expected)
+			}
+			if !reflect.DeepEqual(input, tt.versions) {
+				t.Errorf("GroupByReleaseLine() reordered its input to %v", input)
+			}
+		})
+	}
+}
+
+func TestFilterByMajor(t *testing.T) {
+	versions := []string{"2.0.0", "1.25.1", "1.26rc1", "10.1.0", "1"}
+
+	tests := []struct {
+		name     string
+		major    int
+		expected []string
+	}{
+		{"major 1", 1, []string{"1.25.1", "1.26rc1", "1"}},
+		{"major 2", 2, []string{"2.0.0"}},
+		{"major 10 does not match 1", 10, []string{"10.1.0"}},
+		{"no matches", 3, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := FilterByMajor(versions, tt.major)
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("FilterByMajor(%v, %d) = %v, want %v", versions, tt.major, result, tt.expected)
+			}
+		})
+	}
+}
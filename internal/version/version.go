@@ -3,6 +3,7 @@ package version
 import (
 	"fmt"
 	"runtime"
+	"strings"
 )
 
 var (
@@ -18,11 +19,13 @@ type Info struct {
 	Date      string `json:"date"`
 	BuildBy   string `json:"buildBy"`
 	GoVersion string `json:"goVersion"`
+	OS        string `json:"os"`
+	Arch      string `json:"arch"`
 	Platform  string `json:"platform"`
 }
 
 // Get aggregates build-time and runtime information into an Info struct.
-// No parameters. Returns Info containing version, commit, date, builder, Go version, and platform.
+// No parameters. Returns Info containing version, commit, date, builder, Go version, OS, arch, and platform.
 func Get() Info {
 	return Info{
 		Version:   Version,
@@ -30,6 +33,8 @@ func Get() Info {
 		Date:      Date,
 		BuildBy:   BuildBy,
 		GoVersion: runtime.Version(),
+		OS:        runtime.GOOS,
+		Arch:      runtime.GOARCH,
 		Platform:  fmt.Sprintf("%s/%s", runtime.GOOS, runtime.GOARCH),
 	}
 }
@@ -44,3 +49,20 @@ func BuildVersion() string {
 
 	return info.Version
 }
+
+// String formats Info as a human-readable, multi-line report suitable for bug reports.
+func (i Info) String() string {
+	version := i.Version
+	if version == "dev" {
+		version = fmt.Sprintf("%s-%s", version, i.Commit)
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "govman %s\n", version)
+	fmt.Fprintf(&sb, "  Commit:     %s\n", i.Commit)
+	fmt.Fprintf(&sb, "  Built:      %s\n", i.Date)
+	fmt.Fprintf(&sb, "  Built by:   %s\n", i.BuildBy)
+	fmt.Fprintf(&sb, "  Go version: %s\n", i.GoVersion)
+	fmt.Fprintf(&sb, "  OS/Arch:    %s", i.Platform)
+	return sb.String()
+}
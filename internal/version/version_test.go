@@ -1,6 +1,9 @@
 package version
 
 import (
+	"encoding/json"
+	"runtime"
+	"strings"
 	"testing"
 )
 
@@ -59,6 +62,9 @@ func TestGet(t *testing.T) {
 			if info.Platform == "" {
 				t.Errorf("expected Platform to be non-empty")
 			}
+			if info.OS != runtime.GOOS || info.Arch != runtime.GOARCH {
+				t.Errorf("expected OS/Arch: %s/%s, got: %s/%s", runtime.GOOS, runtime.GOARCH, info.OS, info.Arch)
+			}
 		})
 	}
 }
@@ -95,3 +101,65 @@ func TestBuildVersion(t *testing.T) {
 		})
 	}
 }
+
+func TestInfo_String(t *testing.T) {
+	testCases := []struct {
+		name     string
+		version  string
+		commit   string
+		expected []string
+	}{
+		{
+			name:     "Release build",
+			version:  "v1.2.3",
+			commit:   "abc123",
+			expected: []string{"govman v1.2.3\n", "Commit:     abc123", "Built:      2025-09-13", "Built by:   tester", "Go version: " + runtime.Version(), "OS/Arch:    " + runtime.GOOS + "/" + runtime.GOARCH},
+		},
+		{
+			name:     "Dev build",
+			version:  "dev",
+			commit:   "abc123",
+			expected: []string{"govman dev-abc123\n"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			setTestValues(tc.version, tc.commit, "2025-09-13", "tester")
+
+			got := Get().String()
+			for _, want := range tc.expected {
+				if !strings.Contains(got, want) {
+					t.Errorf("expected String() to contain %q, got:\n%s", want, got)
+				}
+			}
+		})
+	}
+}
+
+func TestInfo_JSON(t *testing.T) {
+	setTestValues("v1.2.3", "abc123", "2025-09-13", "tester")
+
+	data, err := json.Marshal(Get())
+	if err != nil {
+		t.Fatalf("failed to marshal Info: %v", err)
+	}
+
+	var decoded map[string]string
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("failed to unmarshal Info: %v", err)
+	}
+
+	for key, want := range map[string]string{
+		"version":   "v1.2.3",
+		"commit":    "abc123",
+		"date":      "2025-09-13",
+		"goVersion": runtime.Version(),
+		"os":        runtime.GOOS,
+		"arch":      runtime.GOARCH,
+	} {
+		if decoded[key] != want {
+			t.Errorf("expected %s: %s, got: %s", key, want, decoded[key])
+		}
+	}
+}
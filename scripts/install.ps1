@@ -1,5 +1,5 @@
 # govman installation script for Windows
-# This script installs govman to $env:USERPROFILE\.govman\bin and adds it to PATH
+# This script installs govman to $env:USERPROFILE\.govman\bin (or $env:GOVMAN_HOME\bin) and adds it to PATH
 
 param(
     [switch]$Quiet,
@@ -370,10 +370,18 @@ function Show-Completion {
     Write-Host ""
 }
 
+# Resolve the govman home directory, honoring GOVMAN_HOME
+function Get-GovmanHome {
+    if ($env:GOVMAN_HOME) {
+        return $env:GOVMAN_HOME
+    }
+    return Join-Path $env:USERPROFILE ".govman"
+}
+
 # Check if govman is already installed
 function Test-ExistingInstallation {
-    $installDir = Join-Path $env:USERPROFILE ".govman\bin"
-    $govmanDir = Join-Path $env:USERPROFILE ".govman"
+    $installDir = Join-Path (Get-GovmanHome) "bin"
+    $govmanDir = Get-GovmanHome
     $binaryFound = Test-Path (Join-Path $installDir "govman.exe")
     $commandFound = $null -ne (Get-Command govman -ErrorAction SilentlyContinue)
 
@@ -456,7 +464,7 @@ function Main {
     Write-Host ""
 
     # Set installation directory
-    $installDir = Join-Path $env:USERPROFILE ".govman\bin"
+    $installDir = Join-Path (Get-GovmanHome) "bin"
     Print-Info "Installation directory: $($Colors.Bold)$installDir$($Colors.Reset)"
     Write-Host ""
 
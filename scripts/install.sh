@@ -1,6 +1,6 @@
 #!/usr/bin/env bash
  # govman installation script
-# This script installs govman to $HOME/.govman/bin and adds it to PATH
+# This script installs govman to $HOME/.govman/bin ($GOVMAN_HOME/bin, or $XDG_DATA_HOME/govman/bin on new Linux installs) and adds it to PATH
  set -e
  # Global flags
 QUIET_MODE=false
@@ -120,6 +120,16 @@ print_info() {
  print_install() {
     [[ "$QUIET_MODE" == "true" ]] && return
     echo -e "${CYAN}${BOLD} ${INSTALL}  INSTALLING${NC} ${GRAY}│${NC} $1"
+}
+ # Resolve the govman data directory: GOVMAN_HOME, an existing ~/.govman, XDG on Linux, then ~/.govman
+govman_data_dir() {
+    if [[ -n "$GOVMAN_HOME" ]]; then
+        echo "$GOVMAN_HOME"
+    elif [[ "$(uname -s)" == "Linux" && ! -d "$HOME/.govman" ]]; then
+        echo "${XDG_DATA_HOME:-$HOME/.local/share}/govman"
+    else
+        echo "$HOME/.govman"
+    fi
 }
  # Check if running on Windows (Git Bash)
 is_windows() {
@@ -446,8 +456,8 @@ show_completion() {
 }
  # Check if govman is already installed
 check_existing_installation() {
-    local install_dir="$HOME/.govman/bin"
-    local govman_dir="$HOME/.govman"
+    local install_dir="$(govman_data_dir)/bin"
+    local govman_dir="$(govman_data_dir)"
     local shell_configs_str
     shell_configs_str=$(get_shell_configs)
     local shell_configs=($shell_configs_str)
@@ -535,7 +545,7 @@ main() {
     print_success "Latest version: ${BOLD}$version${NC}"
     echo
          # Set installation directory
-    local install_dir="$HOME/.govman/bin"
+    local install_dir="$(govman_data_dir)/bin"
     print_info "Installation directory: ${BOLD}$install_dir${NC}"
     echo
          # Show system info
@@ -1,5 +1,5 @@
 # govman uninstallation script for Windows
-# This script removes govman from $env:USERPROFILE\.govman\bin and removes it from PATH
+# This script removes govman from $env:USERPROFILE\.govman\bin (or $env:GOVMAN_HOME\bin) and removes it from PATH
 
 param(
     [switch]$Help
@@ -122,10 +122,18 @@ function Show-Help {
     Write-Host "  .\uninstall.ps1 -Help   # Show help"
 }
 
+# Resolve the govman home directory, honoring GOVMAN_HOME
+function Get-GovmanHome {
+    if ($env:GOVMAN_HOME) {
+        return $env:GOVMAN_HOME
+    }
+    return Join-Path $env:USERPROFILE ".govman"
+}
+
 # Check if govman is installed
 function Test-GovmanInstallation {
-    $installDir = Join-Path $env:USERPROFILE ".govman\bin"
-    $govmanDir = Join-Path $env:USERPROFILE ".govman"
+    $installDir = Join-Path (Get-GovmanHome) "bin"
+    $govmanDir = Get-GovmanHome
     $binaryFound = Test-Path (Join-Path $installDir "govman.exe")
     $commandFound = $null -ne (Get-Command govman -ErrorAction SilentlyContinue)
     $dataFound = Test-Path $govmanDir
@@ -185,8 +193,8 @@ function Show-RemovalPreview {
     Write-Host "$($Colors.Bold)$($Colors.White)Removal Preview:$($Colors.Reset)"
     Print-Separator "┄"
 
-    $installDir = Join-Path $env:USERPROFILE ".govman\bin"
-    $govmanDir = Join-Path $env:USERPROFILE ".govman"
+    $installDir = Join-Path (Get-GovmanHome) "bin"
+    $govmanDir = Get-GovmanHome
 
     # Check binary
     if (Test-Path (Join-Path $installDir "govman.exe")) {
@@ -236,7 +244,7 @@ function Show-RemovalProgress {
 
 # Remove binary with feedback
 function Remove-Binary {
-    $installDir = Join-Path $env:USERPROFILE ".govman\bin"
+    $installDir = Join-Path (Get-GovmanHome) "bin"
 
     Print-Step "Removing govman binary..."
 
@@ -256,7 +264,7 @@ function Remove-Binary {
 
 # Remove from PATH with feedback
 function Remove-FromPath {
-    $installDir = Join-Path $env:USERPROFILE ".govman\bin"
+    $installDir = Join-Path (Get-GovmanHome) "bin"
 
     Print-Step "Cleaning PATH configuration..."
 
@@ -284,7 +292,7 @@ function Remove-FromPath {
 
 # Remove entire govman directory with feedback
 function Remove-GovmanDir {
-    $govmanDir = Join-Path $env:USERPROFILE ".govman"
+    $govmanDir = Get-GovmanHome
 
     Print-Step "Removing govman data directory..."
 
@@ -1,6 +1,6 @@
 #!/usr/bin/env bash
  # govman uninstallation script
-# This script removes govman from $HOME/.govman/bin and removes it from PATH
+# This script removes govman from $HOME/.govman/bin ($GOVMAN_HOME/bin, or $XDG_DATA_HOME/govman/bin on Linux) and removes it from PATH
  set -e
  # Colors and styles
 RED='\033[0;31m'
@@ -89,6 +89,26 @@ get_user_input() {
         read -r -p "$(echo -e "$prompt")" response
     fi
      echo "$response"
+}
+ # Resolve the govman data directory: GOVMAN_HOME, an existing ~/.govman, XDG on Linux, then ~/.govman
+govman_data_dir() {
+    if [[ -n "$GOVMAN_HOME" ]]; then
+        echo "$GOVMAN_HOME"
+    elif [[ "$(uname -s)" == "Linux" && ! -d "$HOME/.govman" ]]; then
+        echo "${XDG_DATA_HOME:-$HOME/.local/share}/govman"
+    else
+        echo "$HOME/.govman"
+    fi
+}
+ # Resolve the directory holding config.yaml, following the same rules as govman_data_dir
+govman_config_dir() {
+    if [[ -n "$GOVMAN_HOME" ]]; then
+        echo "$GOVMAN_HOME"
+    elif [[ "$(uname -s)" == "Linux" && ! -d "$HOME/.govman" ]]; then
+        echo "${XDG_CONFIG_HOME:-$HOME/.config}/govman"
+    else
+        echo "$HOME/.govman"
+    fi
 }
  # Get shell configuration files
 get_shell_configs() {
@@ -98,8 +118,8 @@ get_shell_configs() {
 }
  # Check if govman is installed
 check_govman_installation() {
-    local install_dir="$HOME/.govman/bin"
-    local govman_dir="$HOME/.govman"
+    local install_dir="$(govman_data_dir)/bin"
+    local govman_dir="$(govman_data_dir)"
     local shell_configs_str
     shell_configs_str=$(get_shell_configs)
     local shell_configs=($shell_configs_str)
@@ -167,8 +187,8 @@ show_removal_preview() {
     local option="$1"
      echo -e "${BOLD}${WHITE}Removal Preview:${NC}"
     print_separator "┄"
-     local install_dir="$HOME/.govman/bin"
-    local govman_dir="$HOME/.govman"
+     local install_dir="$(govman_data_dir)/bin"
+    local govman_dir="$(govman_data_dir)"
     local shell_configs_str
     shell_configs_str=$(get_shell_configs)
     local shell_configs=($shell_configs_str)
@@ -220,7 +240,7 @@ show_removal_progress() {
 }
  # Remove binary with feedback
 remove_binary() {
-    local install_dir="$HOME/.govman/bin"
+    local install_dir="$(govman_data_dir)/bin"
          print_step "Removing govman binary..."
          if [[ -d "$install_dir" ]]; then
         show_removal_progress "binary directory"
@@ -243,7 +263,8 @@ remove_from_path() {
             if grep -q "# GOVMAN - Go Version Manager" "$shell_config" 2>/dev/null; then
                 show_removal_progress "$(basename "$shell_config") configuration"
                  # More portable sed operation
-                sed '/# GOVMAN - Go Version Manager/,/# END GOVMAN/d' "$shell_config" > "${shell_config}.tmp"
+                # Current blocks are wrapped in sentinels; older installs used GOVMAN / END GOVMAN
+                sed -e '/# >>> govman >>>/,/# <<< govman <<</d' -e '/# GOVMAN - Go Version Manager/,/# END GOVMAN/d' "$shell_config" > "${shell_config}.tmp"
                 mv "${shell_config}.tmp" "$shell_config"
                  # Clean up extra blank lines that might be left
                 awk 'NF || prev_blank {print} {prev_blank = !NF}' "$shell_config" > "${shell_config}.tmp" && mv "${shell_config}.tmp" "$shell_config"
@@ -260,7 +281,8 @@ remove_from_path() {
 }
  # Remove entire govman directory with feedback
 remove_govman_dir() {
-    local govman_dir="$HOME/.govman"
+    local govman_dir="$(govman_data_dir)"
+    local config_dir="$(govman_config_dir)"
          print_step "Removing govman data directory..."
          if [[ -d "$govman_dir" ]]; then
         # Show what's being removed
@@ -272,6 +294,10 @@ remove_govman_dir() {
     else
         print_warning "govman directory not found at $govman_dir"
     fi
+    if [[ "$config_dir" != "$govman_dir" && -d "$config_dir" ]]; then
+        rm -rf "$config_dir"
+        print_success "Removed govman config directory: $config_dir"
+    fi
 }
  # Show uninstall options
 show_uninstall_options() {